@@ -63,6 +63,8 @@ func (m *UserRegisterReq) validate(all bool) error {
 
 	// no validation rules for CheckPassword
 
+	// no validation rules for Email
+
 	if len(errors) > 0 {
 		return UserRegisterReqMultiError(errors)
 	}
@@ -298,6 +300,8 @@ func (m *UserLoginReq) validate(all bool) error {
 
 	// no validation rules for UserPassword
 
+	// no validation rules for VerifyCode
+
 	if len(errors) > 0 {
 		return UserLoginReqMultiError(errors)
 	}
@@ -528,6 +532,8 @@ func (m *SearchUsersReq) validate(all bool) error {
 
 	// no validation rules for UserName
 
+	// no validation rules for IncludeServiceAccounts
+
 	if len(errors) > 0 {
 		return SearchUsersReqMultiError(errors)
 	}
@@ -842,71 +848,46 @@ var _ interface {
 	ErrorName() string
 } = DeleteUserReqValidationError{}
 
-// Validate checks the field values on GetCurrentReply with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetCurrentReply) Validate() error {
+// Validate checks the field values on ListPendingUsersReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListPendingUsersReq) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetCurrentReply with the rules
+// ValidateAll checks the field values on ListPendingUsersReq with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetCurrentReplyMultiError, or nil if none found.
-func (m *GetCurrentReply) ValidateAll() error {
+// ListPendingUsersReqMultiError, or nil if none found.
+func (m *ListPendingUsersReq) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetCurrentReply) validate(all bool) error {
+func (m *ListPendingUsersReq) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetData()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetCurrentReplyValidationError{
-					field:  "Data",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetCurrentReplyValidationError{
-					field:  "Data",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetCurrentReplyValidationError{
-				field:  "Data",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Page
+
+	// no validation rules for PageSize
 
 	if len(errors) > 0 {
-		return GetCurrentReplyMultiError(errors)
+		return ListPendingUsersReqMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetCurrentReplyMultiError is an error wrapping multiple validation errors
-// returned by GetCurrentReply.ValidateAll() if the designated constraints
-// aren't met.
-type GetCurrentReplyMultiError []error
+// ListPendingUsersReqMultiError is an error wrapping multiple validation
+// errors returned by ListPendingUsersReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListPendingUsersReqMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetCurrentReplyMultiError) Error() string {
+func (m ListPendingUsersReqMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -915,11 +896,11 @@ func (m GetCurrentReplyMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetCurrentReplyMultiError) AllErrors() []error { return m }
+func (m ListPendingUsersReqMultiError) AllErrors() []error { return m }
 
-// GetCurrentReplyValidationError is the validation error returned by
-// GetCurrentReply.Validate if the designated constraints aren't met.
-type GetCurrentReplyValidationError struct {
+// ListPendingUsersReqValidationError is the validation error returned by
+// ListPendingUsersReq.Validate if the designated constraints aren't met.
+type ListPendingUsersReqValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -927,22 +908,24 @@ type GetCurrentReplyValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetCurrentReplyValidationError) Field() string { return e.field }
+func (e ListPendingUsersReqValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetCurrentReplyValidationError) Reason() string { return e.reason }
+func (e ListPendingUsersReqValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetCurrentReplyValidationError) Cause() error { return e.cause }
+func (e ListPendingUsersReqValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetCurrentReplyValidationError) Key() bool { return e.key }
+func (e ListPendingUsersReqValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetCurrentReplyValidationError) ErrorName() string { return "GetCurrentReplyValidationError" }
+func (e ListPendingUsersReqValidationError) ErrorName() string {
+	return "ListPendingUsersReqValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetCurrentReplyValidationError) Error() string {
+func (e ListPendingUsersReqValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -954,14 +937,14 @@ func (e GetCurrentReplyValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetCurrentReply.%s: %s%s",
+		"invalid %sListPendingUsersReq.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetCurrentReplyValidationError{}
+var _ error = ListPendingUsersReqValidationError{}
 
 var _ interface {
 	Field() string
@@ -969,64 +952,184 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetCurrentReplyValidationError{}
+} = ListPendingUsersReqValidationError{}
 
-// Validate checks the field values on User with the rules defined in the proto
-// definition for this message. If any rules are violated, the first error
-// encountered is returned, or nil if there are no violations.
-func (m *User) Validate() error {
+// Validate checks the field values on ListPendingUsersReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListPendingUsersReply) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on User with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in UserMultiError, or nil if none found.
-func (m *User) ValidateAll() error {
+// ValidateAll checks the field values on ListPendingUsersReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListPendingUsersReplyMultiError, or nil if none found.
+func (m *ListPendingUsersReply) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *User) validate(all bool) error {
+func (m *ListPendingUsersReply) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
 
-	// no validation rules for UserName
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListPendingUsersReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListPendingUsersReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListPendingUsersReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
 
-	// no validation rules for UserAccount
+	}
 
-	// no validation rules for AvatarUrl
+	// no validation rules for Total
 
-	// no validation rules for Phone
+	if len(errors) > 0 {
+		return ListPendingUsersReplyMultiError(errors)
+	}
 
-	// no validation rules for Email
+	return nil
+}
 
-	// no validation rules for UserStatus
+// ListPendingUsersReplyMultiError is an error wrapping multiple validation
+// errors returned by ListPendingUsersReply.ValidateAll() if the designated
+// constraints aren't met.
+type ListPendingUsersReplyMultiError []error
 
-	// no validation rules for Gender
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListPendingUsersReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
 
-	// no validation rules for Empty
+// AllErrors returns a list of validation violation errors.
+func (m ListPendingUsersReplyMultiError) AllErrors() []error { return m }
 
-	// no validation rules for UserRole
+// ListPendingUsersReplyValidationError is the validation error returned by
+// ListPendingUsersReply.Validate if the designated constraints aren't met.
+type ListPendingUsersReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
 
-	// no validation rules for CreateTime
+// Field function returns field value.
+func (e ListPendingUsersReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListPendingUsersReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListPendingUsersReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListPendingUsersReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListPendingUsersReplyValidationError) ErrorName() string {
+	return "ListPendingUsersReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListPendingUsersReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListPendingUsersReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListPendingUsersReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListPendingUsersReplyValidationError{}
+
+// Validate checks the field values on ApproveUserReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ApproveUserReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ApproveUserReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ApproveUserReqMultiError,
+// or nil if none found.
+func (m *ApproveUserReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ApproveUserReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
 
 	if len(errors) > 0 {
-		return UserMultiError(errors)
+		return ApproveUserReqMultiError(errors)
 	}
 
 	return nil
 }
 
-// UserMultiError is an error wrapping multiple validation errors returned by
-// User.ValidateAll() if the designated constraints aren't met.
-type UserMultiError []error
+// ApproveUserReqMultiError is an error wrapping multiple validation errors
+// returned by ApproveUserReq.ValidateAll() if the designated constraints
+// aren't met.
+type ApproveUserReqMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UserMultiError) Error() string {
+func (m ApproveUserReqMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1035,11 +1138,11 @@ func (m UserMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UserMultiError) AllErrors() []error { return m }
+func (m ApproveUserReqMultiError) AllErrors() []error { return m }
 
-// UserValidationError is the validation error returned by User.Validate if the
-// designated constraints aren't met.
-type UserValidationError struct {
+// ApproveUserReqValidationError is the validation error returned by
+// ApproveUserReq.Validate if the designated constraints aren't met.
+type ApproveUserReqValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1047,22 +1150,22 @@ type UserValidationError struct {
 }
 
 // Field function returns field value.
-func (e UserValidationError) Field() string { return e.field }
+func (e ApproveUserReqValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UserValidationError) Reason() string { return e.reason }
+func (e ApproveUserReqValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UserValidationError) Cause() error { return e.cause }
+func (e ApproveUserReqValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UserValidationError) Key() bool { return e.key }
+func (e ApproveUserReqValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UserValidationError) ErrorName() string { return "UserValidationError" }
+func (e ApproveUserReqValidationError) ErrorName() string { return "ApproveUserReqValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UserValidationError) Error() string {
+func (e ApproveUserReqValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1074,14 +1177,14 @@ func (e UserValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUser.%s: %s%s",
+		"invalid %sApproveUserReq.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UserValidationError{}
+var _ error = ApproveUserReqValidationError{}
 
 var _ interface {
 	Field() string
@@ -1089,4 +1192,7745 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UserValidationError{}
+} = ApproveUserReqValidationError{}
+
+// Validate checks the field values on RejectUserReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RejectUserReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RejectUserReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RejectUserReqMultiError, or
+// nil if none found.
+func (m *RejectUserReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RejectUserReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Reason
+
+	if len(errors) > 0 {
+		return RejectUserReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// RejectUserReqMultiError is an error wrapping multiple validation errors
+// returned by RejectUserReq.ValidateAll() if the designated constraints
+// aren't met.
+type RejectUserReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RejectUserReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RejectUserReqMultiError) AllErrors() []error { return m }
+
+// RejectUserReqValidationError is the validation error returned by
+// RejectUserReq.Validate if the designated constraints aren't met.
+type RejectUserReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RejectUserReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RejectUserReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RejectUserReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RejectUserReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RejectUserReqValidationError) ErrorName() string { return "RejectUserReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RejectUserReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRejectUserReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RejectUserReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RejectUserReqValidationError{}
+
+// Validate checks the field values on ListAuditLogsReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListAuditLogsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAuditLogsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAuditLogsReqMultiError, or nil if none found.
+func (m *ListAuditLogsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAuditLogsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ActorId
+
+	// no validation rules for TargetId
+
+	// no validation rules for Action
+
+	// no validation rules for Result
+
+	// no validation rules for StartTime
+
+	// no validation rules for EndTime
+
+	// no validation rules for Page
+
+	// no validation rules for PageSize
+
+	if len(errors) > 0 {
+		return ListAuditLogsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAuditLogsReqMultiError is an error wrapping multiple validation errors
+// returned by ListAuditLogsReq.ValidateAll() if the designated constraints
+// aren't met.
+type ListAuditLogsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAuditLogsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAuditLogsReqMultiError) AllErrors() []error { return m }
+
+// ListAuditLogsReqValidationError is the validation error returned by
+// ListAuditLogsReq.Validate if the designated constraints aren't met.
+type ListAuditLogsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAuditLogsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAuditLogsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAuditLogsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAuditLogsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAuditLogsReqValidationError) ErrorName() string { return "ListAuditLogsReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListAuditLogsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAuditLogsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAuditLogsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAuditLogsReqValidationError{}
+
+// Validate checks the field values on ListAuditLogsReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAuditLogsReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAuditLogsReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAuditLogsReplyMultiError, or nil if none found.
+func (m *ListAuditLogsReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAuditLogsReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAuditLogsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAuditLogsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAuditLogsReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListAuditLogsReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAuditLogsReplyMultiError is an error wrapping multiple validation errors
+// returned by ListAuditLogsReply.ValidateAll() if the designated constraints
+// aren't met.
+type ListAuditLogsReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAuditLogsReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAuditLogsReplyMultiError) AllErrors() []error { return m }
+
+// ListAuditLogsReplyValidationError is the validation error returned by
+// ListAuditLogsReply.Validate if the designated constraints aren't met.
+type ListAuditLogsReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAuditLogsReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAuditLogsReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAuditLogsReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAuditLogsReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAuditLogsReplyValidationError) ErrorName() string {
+	return "ListAuditLogsReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAuditLogsReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAuditLogsReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAuditLogsReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAuditLogsReplyValidationError{}
+
+// Validate checks the field values on ListMyLoginHistoryReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListMyLoginHistoryReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListMyLoginHistoryReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListMyLoginHistoryReqMultiError, or nil if none found.
+func (m *ListMyLoginHistoryReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListMyLoginHistoryReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Page
+
+	// no validation rules for PageSize
+
+	if len(errors) > 0 {
+		return ListMyLoginHistoryReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListMyLoginHistoryReqMultiError is an error wrapping multiple validation
+// errors returned by ListMyLoginHistoryReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListMyLoginHistoryReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListMyLoginHistoryReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListMyLoginHistoryReqMultiError) AllErrors() []error { return m }
+
+// ListMyLoginHistoryReqValidationError is the validation error returned by
+// ListMyLoginHistoryReq.Validate if the designated constraints aren't met.
+type ListMyLoginHistoryReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListMyLoginHistoryReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListMyLoginHistoryReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListMyLoginHistoryReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListMyLoginHistoryReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListMyLoginHistoryReqValidationError) ErrorName() string {
+	return "ListMyLoginHistoryReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListMyLoginHistoryReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListMyLoginHistoryReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListMyLoginHistoryReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListMyLoginHistoryReqValidationError{}
+
+// Validate checks the field values on ListLoginHistoryReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListLoginHistoryReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLoginHistoryReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListLoginHistoryReqMultiError, or nil if none found.
+func (m *ListLoginHistoryReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLoginHistoryReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Page
+
+	// no validation rules for PageSize
+
+	if len(errors) > 0 {
+		return ListLoginHistoryReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLoginHistoryReqMultiError is an error wrapping multiple validation
+// errors returned by ListLoginHistoryReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListLoginHistoryReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLoginHistoryReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLoginHistoryReqMultiError) AllErrors() []error { return m }
+
+// ListLoginHistoryReqValidationError is the validation error returned by
+// ListLoginHistoryReq.Validate if the designated constraints aren't met.
+type ListLoginHistoryReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLoginHistoryReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLoginHistoryReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLoginHistoryReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLoginHistoryReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLoginHistoryReqValidationError) ErrorName() string {
+	return "ListLoginHistoryReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListLoginHistoryReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLoginHistoryReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLoginHistoryReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLoginHistoryReqValidationError{}
+
+// Validate checks the field values on ListLoginHistoryReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListLoginHistoryReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLoginHistoryReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListLoginHistoryReplyMultiError, or nil if none found.
+func (m *ListLoginHistoryReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLoginHistoryReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListLoginHistoryReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListLoginHistoryReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListLoginHistoryReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListLoginHistoryReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLoginHistoryReplyMultiError is an error wrapping multiple validation
+// errors returned by ListLoginHistoryReply.ValidateAll() if the designated
+// constraints aren't met.
+type ListLoginHistoryReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLoginHistoryReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLoginHistoryReplyMultiError) AllErrors() []error { return m }
+
+// ListLoginHistoryReplyValidationError is the validation error returned by
+// ListLoginHistoryReply.Validate if the designated constraints aren't met.
+type ListLoginHistoryReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLoginHistoryReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLoginHistoryReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLoginHistoryReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLoginHistoryReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLoginHistoryReplyValidationError) ErrorName() string {
+	return "ListLoginHistoryReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListLoginHistoryReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLoginHistoryReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLoginHistoryReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLoginHistoryReplyValidationError{}
+
+// Validate checks the field values on ListSessionsReply with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListSessionsReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSessionsReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSessionsReplyMultiError, or nil if none found.
+func (m *ListSessionsReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSessionsReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListSessionsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListSessionsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListSessionsReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListSessionsReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSessionsReplyMultiError is an error wrapping multiple validation errors
+// returned by ListSessionsReply.ValidateAll() if the designated constraints
+// aren't met.
+type ListSessionsReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSessionsReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSessionsReplyMultiError) AllErrors() []error { return m }
+
+// ListSessionsReplyValidationError is the validation error returned by
+// ListSessionsReply.Validate if the designated constraints aren't met.
+type ListSessionsReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSessionsReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSessionsReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSessionsReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSessionsReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSessionsReplyValidationError) ErrorName() string {
+	return "ListSessionsReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSessionsReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSessionsReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSessionsReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSessionsReplyValidationError{}
+
+// Validate checks the field values on CreateWebhookReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateWebhookReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateWebhookReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateWebhookReqMultiError, or nil if none found.
+func (m *CreateWebhookReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateWebhookReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Url
+
+	// no validation rules for Secret
+
+	if len(errors) > 0 {
+		return CreateWebhookReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateWebhookReqMultiError is an error wrapping multiple validation errors
+// returned by CreateWebhookReq.ValidateAll() if the designated constraints
+// aren't met.
+type CreateWebhookReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateWebhookReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateWebhookReqMultiError) AllErrors() []error { return m }
+
+// CreateWebhookReqValidationError is the validation error returned by
+// CreateWebhookReq.Validate if the designated constraints aren't met.
+type CreateWebhookReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateWebhookReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateWebhookReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateWebhookReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateWebhookReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateWebhookReqValidationError) ErrorName() string { return "CreateWebhookReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CreateWebhookReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateWebhookReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateWebhookReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateWebhookReqValidationError{}
+
+// Validate checks the field values on CreateWebhookReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateWebhookReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateWebhookReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateWebhookReplyMultiError, or nil if none found.
+func (m *CreateWebhookReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateWebhookReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateWebhookReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateWebhookReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateWebhookReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateWebhookReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateWebhookReplyMultiError is an error wrapping multiple validation errors
+// returned by CreateWebhookReply.ValidateAll() if the designated constraints
+// aren't met.
+type CreateWebhookReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateWebhookReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateWebhookReplyMultiError) AllErrors() []error { return m }
+
+// CreateWebhookReplyValidationError is the validation error returned by
+// CreateWebhookReply.Validate if the designated constraints aren't met.
+type CreateWebhookReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateWebhookReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateWebhookReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateWebhookReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateWebhookReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateWebhookReplyValidationError) ErrorName() string {
+	return "CreateWebhookReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateWebhookReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateWebhookReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateWebhookReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateWebhookReplyValidationError{}
+
+// Validate checks the field values on ListWebhooksReply with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListWebhooksReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListWebhooksReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListWebhooksReplyMultiError, or nil if none found.
+func (m *ListWebhooksReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListWebhooksReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListWebhooksReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListWebhooksReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListWebhooksReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListWebhooksReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListWebhooksReplyMultiError is an error wrapping multiple validation errors
+// returned by ListWebhooksReply.ValidateAll() if the designated constraints
+// aren't met.
+type ListWebhooksReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListWebhooksReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListWebhooksReplyMultiError) AllErrors() []error { return m }
+
+// ListWebhooksReplyValidationError is the validation error returned by
+// ListWebhooksReply.Validate if the designated constraints aren't met.
+type ListWebhooksReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListWebhooksReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListWebhooksReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListWebhooksReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListWebhooksReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListWebhooksReplyValidationError) ErrorName() string {
+	return "ListWebhooksReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListWebhooksReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListWebhooksReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListWebhooksReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListWebhooksReplyValidationError{}
+
+// Validate checks the field values on DeleteWebhookReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteWebhookReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteWebhookReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteWebhookReqMultiError, or nil if none found.
+func (m *DeleteWebhookReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteWebhookReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return DeleteWebhookReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteWebhookReqMultiError is an error wrapping multiple validation errors
+// returned by DeleteWebhookReq.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteWebhookReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteWebhookReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteWebhookReqMultiError) AllErrors() []error { return m }
+
+// DeleteWebhookReqValidationError is the validation error returned by
+// DeleteWebhookReq.Validate if the designated constraints aren't met.
+type DeleteWebhookReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteWebhookReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteWebhookReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteWebhookReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteWebhookReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteWebhookReqValidationError) ErrorName() string { return "DeleteWebhookReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DeleteWebhookReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteWebhookReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteWebhookReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteWebhookReqValidationError{}
+
+// Validate checks the field values on ListWebhookDeliveriesReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListWebhookDeliveriesReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListWebhookDeliveriesReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListWebhookDeliveriesReqMultiError, or nil if none found.
+func (m *ListWebhookDeliveriesReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListWebhookDeliveriesReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for WebhookId
+
+	// no validation rules for Status
+
+	// no validation rules for Page
+
+	// no validation rules for PageSize
+
+	if len(errors) > 0 {
+		return ListWebhookDeliveriesReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveriesReqMultiError is an error wrapping multiple validation
+// errors returned by ListWebhookDeliveriesReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListWebhookDeliveriesReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListWebhookDeliveriesReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListWebhookDeliveriesReqMultiError) AllErrors() []error { return m }
+
+// ListWebhookDeliveriesReqValidationError is the validation error returned by
+// ListWebhookDeliveriesReq.Validate if the designated constraints aren't met.
+type ListWebhookDeliveriesReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListWebhookDeliveriesReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListWebhookDeliveriesReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListWebhookDeliveriesReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListWebhookDeliveriesReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListWebhookDeliveriesReqValidationError) ErrorName() string {
+	return "ListWebhookDeliveriesReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListWebhookDeliveriesReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListWebhookDeliveriesReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListWebhookDeliveriesReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListWebhookDeliveriesReqValidationError{}
+
+// Validate checks the field values on ListWebhookDeliveriesReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListWebhookDeliveriesReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListWebhookDeliveriesReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListWebhookDeliveriesReplyMultiError, or nil if none found.
+func (m *ListWebhookDeliveriesReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListWebhookDeliveriesReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListWebhookDeliveriesReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListWebhookDeliveriesReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListWebhookDeliveriesReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListWebhookDeliveriesReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveriesReplyMultiError is an error wrapping multiple
+// validation errors returned by ListWebhookDeliveriesReply.ValidateAll() if
+// the designated constraints aren't met.
+type ListWebhookDeliveriesReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListWebhookDeliveriesReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListWebhookDeliveriesReplyMultiError) AllErrors() []error { return m }
+
+// ListWebhookDeliveriesReplyValidationError is the validation error returned
+// by ListWebhookDeliveriesReply.Validate if the designated constraints aren't met.
+type ListWebhookDeliveriesReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListWebhookDeliveriesReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListWebhookDeliveriesReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListWebhookDeliveriesReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListWebhookDeliveriesReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListWebhookDeliveriesReplyValidationError) ErrorName() string {
+	return "ListWebhookDeliveriesReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListWebhookDeliveriesReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListWebhookDeliveriesReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListWebhookDeliveriesReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListWebhookDeliveriesReplyValidationError{}
+
+// Validate checks the field values on RedeliverWebhookReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RedeliverWebhookReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RedeliverWebhookReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RedeliverWebhookReqMultiError, or nil if none found.
+func (m *RedeliverWebhookReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RedeliverWebhookReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for DeliveryId
+
+	if len(errors) > 0 {
+		return RedeliverWebhookReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// RedeliverWebhookReqMultiError is an error wrapping multiple validation
+// errors returned by RedeliverWebhookReq.ValidateAll() if the designated
+// constraints aren't met.
+type RedeliverWebhookReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RedeliverWebhookReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RedeliverWebhookReqMultiError) AllErrors() []error { return m }
+
+// RedeliverWebhookReqValidationError is the validation error returned by
+// RedeliverWebhookReq.Validate if the designated constraints aren't met.
+type RedeliverWebhookReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RedeliverWebhookReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RedeliverWebhookReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RedeliverWebhookReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RedeliverWebhookReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RedeliverWebhookReqValidationError) ErrorName() string {
+	return "RedeliverWebhookReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RedeliverWebhookReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRedeliverWebhookReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RedeliverWebhookReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RedeliverWebhookReqValidationError{}
+
+// Validate checks the field values on RedeliverWebhookReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RedeliverWebhookReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RedeliverWebhookReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RedeliverWebhookReplyMultiError, or nil if none found.
+func (m *RedeliverWebhookReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RedeliverWebhookReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RedeliverWebhookReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RedeliverWebhookReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RedeliverWebhookReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RedeliverWebhookReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// RedeliverWebhookReplyMultiError is an error wrapping multiple validation
+// errors returned by RedeliverWebhookReply.ValidateAll() if the designated
+// constraints aren't met.
+type RedeliverWebhookReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RedeliverWebhookReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RedeliverWebhookReplyMultiError) AllErrors() []error { return m }
+
+// RedeliverWebhookReplyValidationError is the validation error returned by
+// RedeliverWebhookReply.Validate if the designated constraints aren't met.
+type RedeliverWebhookReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RedeliverWebhookReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RedeliverWebhookReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RedeliverWebhookReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RedeliverWebhookReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RedeliverWebhookReplyValidationError) ErrorName() string {
+	return "RedeliverWebhookReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RedeliverWebhookReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRedeliverWebhookReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RedeliverWebhookReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RedeliverWebhookReplyValidationError{}
+
+// Validate checks the field values on WatchUsersReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchUsersReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchUsersReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchUsersReqMultiError, or
+// nil if none found.
+func (m *WatchUsersReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchUsersReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for FromSeq
+
+	if len(errors) > 0 {
+		return WatchUsersReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// WatchUsersReqMultiError is an error wrapping multiple validation errors
+// returned by WatchUsersReq.ValidateAll() if the designated constraints
+// aren't met.
+type WatchUsersReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchUsersReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchUsersReqMultiError) AllErrors() []error { return m }
+
+// WatchUsersReqValidationError is the validation error returned by
+// WatchUsersReq.Validate if the designated constraints aren't met.
+type WatchUsersReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchUsersReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchUsersReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchUsersReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchUsersReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchUsersReqValidationError) ErrorName() string { return "WatchUsersReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WatchUsersReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchUsersReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchUsersReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchUsersReqValidationError{}
+
+// Validate checks the field values on CreateOAuthClientReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateOAuthClientReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateOAuthClientReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateOAuthClientReqMultiError, or nil if none found.
+func (m *CreateOAuthClientReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateOAuthClientReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Public
+
+	if len(errors) > 0 {
+		return CreateOAuthClientReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateOAuthClientReqMultiError is an error wrapping multiple validation
+// errors returned by CreateOAuthClientReq.ValidateAll() if the designated
+// constraints aren't met.
+type CreateOAuthClientReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateOAuthClientReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateOAuthClientReqMultiError) AllErrors() []error { return m }
+
+// CreateOAuthClientReqValidationError is the validation error returned by
+// CreateOAuthClientReq.Validate if the designated constraints aren't met.
+type CreateOAuthClientReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateOAuthClientReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateOAuthClientReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateOAuthClientReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateOAuthClientReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateOAuthClientReqValidationError) ErrorName() string {
+	return "CreateOAuthClientReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateOAuthClientReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateOAuthClientReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateOAuthClientReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateOAuthClientReqValidationError{}
+
+// Validate checks the field values on CreateOAuthClientReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateOAuthClientReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateOAuthClientReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateOAuthClientReplyMultiError, or nil if none found.
+func (m *CreateOAuthClientReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateOAuthClientReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateOAuthClientReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateOAuthClientReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateOAuthClientReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateOAuthClientReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateOAuthClientReplyMultiError is an error wrapping multiple validation
+// errors returned by CreateOAuthClientReply.ValidateAll() if the designated
+// constraints aren't met.
+type CreateOAuthClientReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateOAuthClientReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateOAuthClientReplyMultiError) AllErrors() []error { return m }
+
+// CreateOAuthClientReplyValidationError is the validation error returned by
+// CreateOAuthClientReply.Validate if the designated constraints aren't met.
+type CreateOAuthClientReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateOAuthClientReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateOAuthClientReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateOAuthClientReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateOAuthClientReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateOAuthClientReplyValidationError) ErrorName() string {
+	return "CreateOAuthClientReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateOAuthClientReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateOAuthClientReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateOAuthClientReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateOAuthClientReplyValidationError{}
+
+// Validate checks the field values on ListOAuthClientsReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListOAuthClientsReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListOAuthClientsReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListOAuthClientsReplyMultiError, or nil if none found.
+func (m *ListOAuthClientsReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListOAuthClientsReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListOAuthClientsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListOAuthClientsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListOAuthClientsReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListOAuthClientsReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListOAuthClientsReplyMultiError is an error wrapping multiple validation
+// errors returned by ListOAuthClientsReply.ValidateAll() if the designated
+// constraints aren't met.
+type ListOAuthClientsReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListOAuthClientsReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListOAuthClientsReplyMultiError) AllErrors() []error { return m }
+
+// ListOAuthClientsReplyValidationError is the validation error returned by
+// ListOAuthClientsReply.Validate if the designated constraints aren't met.
+type ListOAuthClientsReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListOAuthClientsReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListOAuthClientsReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListOAuthClientsReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListOAuthClientsReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListOAuthClientsReplyValidationError) ErrorName() string {
+	return "ListOAuthClientsReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListOAuthClientsReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListOAuthClientsReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListOAuthClientsReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListOAuthClientsReplyValidationError{}
+
+// Validate checks the field values on DeleteOAuthClientReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteOAuthClientReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteOAuthClientReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteOAuthClientReqMultiError, or nil if none found.
+func (m *DeleteOAuthClientReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteOAuthClientReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ClientId
+
+	if len(errors) > 0 {
+		return DeleteOAuthClientReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteOAuthClientReqMultiError is an error wrapping multiple validation
+// errors returned by DeleteOAuthClientReq.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteOAuthClientReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteOAuthClientReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteOAuthClientReqMultiError) AllErrors() []error { return m }
+
+// DeleteOAuthClientReqValidationError is the validation error returned by
+// DeleteOAuthClientReq.Validate if the designated constraints aren't met.
+type DeleteOAuthClientReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteOAuthClientReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteOAuthClientReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteOAuthClientReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteOAuthClientReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteOAuthClientReqValidationError) ErrorName() string {
+	return "DeleteOAuthClientReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteOAuthClientReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteOAuthClientReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteOAuthClientReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteOAuthClientReqValidationError{}
+
+// Validate checks the field values on OAuthConsentReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *OAuthConsentReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OAuthConsentReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// OAuthConsentReqMultiError, or nil if none found.
+func (m *OAuthConsentReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OAuthConsentReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ResponseType
+
+	// no validation rules for ClientId
+
+	// no validation rules for RedirectUri
+
+	// no validation rules for Scope
+
+	// no validation rules for State
+
+	// no validation rules for CodeChallenge
+
+	// no validation rules for CodeChallengeMethod
+
+	// no validation rules for Nonce
+
+	if len(errors) > 0 {
+		return OAuthConsentReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// OAuthConsentReqMultiError is an error wrapping multiple validation errors
+// returned by OAuthConsentReq.ValidateAll() if the designated constraints
+// aren't met.
+type OAuthConsentReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m OAuthConsentReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m OAuthConsentReqMultiError) AllErrors() []error { return m }
+
+// OAuthConsentReqValidationError is the validation error returned by
+// OAuthConsentReq.Validate if the designated constraints aren't met.
+type OAuthConsentReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OAuthConsentReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OAuthConsentReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OAuthConsentReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OAuthConsentReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OAuthConsentReqValidationError) ErrorName() string { return "OAuthConsentReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e OAuthConsentReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOAuthConsentReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OAuthConsentReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OAuthConsentReqValidationError{}
+
+// Validate checks the field values on GetOAuthConsentReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOAuthConsentReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOAuthConsentReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOAuthConsentReplyMultiError, or nil if none found.
+func (m *GetOAuthConsentReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOAuthConsentReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetClient()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetOAuthConsentReplyValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetOAuthConsentReplyValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClient()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetOAuthConsentReplyValidationError{
+				field:  "Client",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Granted
+
+	if len(errors) > 0 {
+		return GetOAuthConsentReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetOAuthConsentReplyMultiError is an error wrapping multiple validation
+// errors returned by GetOAuthConsentReply.ValidateAll() if the designated
+// constraints aren't met.
+type GetOAuthConsentReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOAuthConsentReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOAuthConsentReplyMultiError) AllErrors() []error { return m }
+
+// GetOAuthConsentReplyValidationError is the validation error returned by
+// GetOAuthConsentReply.Validate if the designated constraints aren't met.
+type GetOAuthConsentReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOAuthConsentReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOAuthConsentReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOAuthConsentReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOAuthConsentReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOAuthConsentReplyValidationError) ErrorName() string {
+	return "GetOAuthConsentReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOAuthConsentReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOAuthConsentReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOAuthConsentReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOAuthConsentReplyValidationError{}
+
+// Validate checks the field values on SubmitOAuthConsentReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SubmitOAuthConsentReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SubmitOAuthConsentReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SubmitOAuthConsentReqMultiError, or nil if none found.
+func (m *SubmitOAuthConsentReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SubmitOAuthConsentReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetRequest()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SubmitOAuthConsentReqValidationError{
+					field:  "Request",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SubmitOAuthConsentReqValidationError{
+					field:  "Request",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRequest()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SubmitOAuthConsentReqValidationError{
+				field:  "Request",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Approve
+
+	if len(errors) > 0 {
+		return SubmitOAuthConsentReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// SubmitOAuthConsentReqMultiError is an error wrapping multiple validation
+// errors returned by SubmitOAuthConsentReq.ValidateAll() if the designated
+// constraints aren't met.
+type SubmitOAuthConsentReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SubmitOAuthConsentReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SubmitOAuthConsentReqMultiError) AllErrors() []error { return m }
+
+// SubmitOAuthConsentReqValidationError is the validation error returned by
+// SubmitOAuthConsentReq.Validate if the designated constraints aren't met.
+type SubmitOAuthConsentReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SubmitOAuthConsentReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SubmitOAuthConsentReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SubmitOAuthConsentReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SubmitOAuthConsentReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SubmitOAuthConsentReqValidationError) ErrorName() string {
+	return "SubmitOAuthConsentReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SubmitOAuthConsentReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSubmitOAuthConsentReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SubmitOAuthConsentReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SubmitOAuthConsentReqValidationError{}
+
+// Validate checks the field values on SubmitOAuthConsentReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SubmitOAuthConsentReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SubmitOAuthConsentReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SubmitOAuthConsentReplyMultiError, or nil if none found.
+func (m *SubmitOAuthConsentReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SubmitOAuthConsentReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RedirectUri
+
+	if len(errors) > 0 {
+		return SubmitOAuthConsentReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// SubmitOAuthConsentReplyMultiError is an error wrapping multiple validation
+// errors returned by SubmitOAuthConsentReply.ValidateAll() if the designated
+// constraints aren't met.
+type SubmitOAuthConsentReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SubmitOAuthConsentReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SubmitOAuthConsentReplyMultiError) AllErrors() []error { return m }
+
+// SubmitOAuthConsentReplyValidationError is the validation error returned by
+// SubmitOAuthConsentReply.Validate if the designated constraints aren't met.
+type SubmitOAuthConsentReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SubmitOAuthConsentReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SubmitOAuthConsentReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SubmitOAuthConsentReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SubmitOAuthConsentReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SubmitOAuthConsentReplyValidationError) ErrorName() string {
+	return "SubmitOAuthConsentReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SubmitOAuthConsentReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSubmitOAuthConsentReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SubmitOAuthConsentReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SubmitOAuthConsentReplyValidationError{}
+
+// Validate checks the field values on ExternalLoginReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ExternalLoginReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExternalLoginReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExternalLoginReqMultiError, or nil if none found.
+func (m *ExternalLoginReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExternalLoginReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Provider
+
+	// no validation rules for VerifyCode
+
+	if len(errors) > 0 {
+		return ExternalLoginReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExternalLoginReqMultiError is an error wrapping multiple validation errors
+// returned by ExternalLoginReq.ValidateAll() if the designated constraints
+// aren't met.
+type ExternalLoginReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExternalLoginReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExternalLoginReqMultiError) AllErrors() []error { return m }
+
+// ExternalLoginReqValidationError is the validation error returned by
+// ExternalLoginReq.Validate if the designated constraints aren't met.
+type ExternalLoginReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExternalLoginReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExternalLoginReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExternalLoginReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExternalLoginReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExternalLoginReqValidationError) ErrorName() string { return "ExternalLoginReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExternalLoginReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExternalLoginReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExternalLoginReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExternalLoginReqValidationError{}
+
+// Validate checks the field values on ExternalLoginReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExternalLoginReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExternalLoginReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExternalLoginReplyMultiError, or nil if none found.
+func (m *ExternalLoginReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExternalLoginReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for AuthorizeUrl
+
+	if len(errors) > 0 {
+		return ExternalLoginReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExternalLoginReplyMultiError is an error wrapping multiple validation errors
+// returned by ExternalLoginReply.ValidateAll() if the designated constraints
+// aren't met.
+type ExternalLoginReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExternalLoginReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExternalLoginReplyMultiError) AllErrors() []error { return m }
+
+// ExternalLoginReplyValidationError is the validation error returned by
+// ExternalLoginReply.Validate if the designated constraints aren't met.
+type ExternalLoginReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExternalLoginReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExternalLoginReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExternalLoginReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExternalLoginReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExternalLoginReplyValidationError) ErrorName() string {
+	return "ExternalLoginReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExternalLoginReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExternalLoginReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExternalLoginReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExternalLoginReplyValidationError{}
+
+// Validate checks the field values on ExternalLoginCallbackReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExternalLoginCallbackReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExternalLoginCallbackReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExternalLoginCallbackReqMultiError, or nil if none found.
+func (m *ExternalLoginCallbackReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExternalLoginCallbackReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Provider
+
+	// no validation rules for Code
+
+	// no validation rules for State
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return ExternalLoginCallbackReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExternalLoginCallbackReqMultiError is an error wrapping multiple validation
+// errors returned by ExternalLoginCallbackReq.ValidateAll() if the designated
+// constraints aren't met.
+type ExternalLoginCallbackReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExternalLoginCallbackReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExternalLoginCallbackReqMultiError) AllErrors() []error { return m }
+
+// ExternalLoginCallbackReqValidationError is the validation error returned by
+// ExternalLoginCallbackReq.Validate if the designated constraints aren't met.
+type ExternalLoginCallbackReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExternalLoginCallbackReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExternalLoginCallbackReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExternalLoginCallbackReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExternalLoginCallbackReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExternalLoginCallbackReqValidationError) ErrorName() string {
+	return "ExternalLoginCallbackReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExternalLoginCallbackReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExternalLoginCallbackReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExternalLoginCallbackReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExternalLoginCallbackReqValidationError{}
+
+// Validate checks the field values on ListIdentitiesReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListIdentitiesReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListIdentitiesReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListIdentitiesReplyMultiError, or nil if none found.
+func (m *ListIdentitiesReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListIdentitiesReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListIdentitiesReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListIdentitiesReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListIdentitiesReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListIdentitiesReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListIdentitiesReplyMultiError is an error wrapping multiple validation
+// errors returned by ListIdentitiesReply.ValidateAll() if the designated
+// constraints aren't met.
+type ListIdentitiesReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListIdentitiesReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListIdentitiesReplyMultiError) AllErrors() []error { return m }
+
+// ListIdentitiesReplyValidationError is the validation error returned by
+// ListIdentitiesReply.Validate if the designated constraints aren't met.
+type ListIdentitiesReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListIdentitiesReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListIdentitiesReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListIdentitiesReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListIdentitiesReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListIdentitiesReplyValidationError) ErrorName() string {
+	return "ListIdentitiesReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListIdentitiesReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListIdentitiesReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListIdentitiesReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListIdentitiesReplyValidationError{}
+
+// Validate checks the field values on UnlinkIdentityReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UnlinkIdentityReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnlinkIdentityReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnlinkIdentityReqMultiError, or nil if none found.
+func (m *UnlinkIdentityReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnlinkIdentityReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return UnlinkIdentityReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// UnlinkIdentityReqMultiError is an error wrapping multiple validation errors
+// returned by UnlinkIdentityReq.ValidateAll() if the designated constraints
+// aren't met.
+type UnlinkIdentityReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnlinkIdentityReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnlinkIdentityReqMultiError) AllErrors() []error { return m }
+
+// UnlinkIdentityReqValidationError is the validation error returned by
+// UnlinkIdentityReq.Validate if the designated constraints aren't met.
+type UnlinkIdentityReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnlinkIdentityReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnlinkIdentityReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnlinkIdentityReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnlinkIdentityReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnlinkIdentityReqValidationError) ErrorName() string {
+	return "UnlinkIdentityReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnlinkIdentityReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnlinkIdentityReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnlinkIdentityReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnlinkIdentityReqValidationError{}
+
+// Validate checks the field values on SAMLAssertionReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SAMLAssertionReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SAMLAssertionReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SAMLAssertionReqMultiError, or nil if none found.
+func (m *SAMLAssertionReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SAMLAssertionReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Provider
+
+	// no validation rules for SAMLResponse
+
+	// no validation rules for RelayState
+
+	if len(errors) > 0 {
+		return SAMLAssertionReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// SAMLAssertionReqMultiError is an error wrapping multiple validation errors
+// returned by SAMLAssertionReq.ValidateAll() if the designated constraints
+// aren't met.
+type SAMLAssertionReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SAMLAssertionReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SAMLAssertionReqMultiError) AllErrors() []error { return m }
+
+// SAMLAssertionReqValidationError is the validation error returned by
+// SAMLAssertionReq.Validate if the designated constraints aren't met.
+type SAMLAssertionReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SAMLAssertionReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SAMLAssertionReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SAMLAssertionReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SAMLAssertionReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SAMLAssertionReqValidationError) ErrorName() string { return "SAMLAssertionReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SAMLAssertionReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSAMLAssertionReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SAMLAssertionReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SAMLAssertionReqValidationError{}
+
+// Validate checks the field values on GetCurrentReply with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetCurrentReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetCurrentReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetCurrentReplyMultiError, or nil if none found.
+func (m *GetCurrentReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetCurrentReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetCurrentReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetCurrentReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetCurrentReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetCurrentReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetCurrentReplyMultiError is an error wrapping multiple validation errors
+// returned by GetCurrentReply.ValidateAll() if the designated constraints
+// aren't met.
+type GetCurrentReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetCurrentReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetCurrentReplyMultiError) AllErrors() []error { return m }
+
+// GetCurrentReplyValidationError is the validation error returned by
+// GetCurrentReply.Validate if the designated constraints aren't met.
+type GetCurrentReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetCurrentReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetCurrentReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetCurrentReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetCurrentReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetCurrentReplyValidationError) ErrorName() string { return "GetCurrentReplyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetCurrentReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetCurrentReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetCurrentReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetCurrentReplyValidationError{}
+
+// Validate checks the field values on User with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *User) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on User with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UserMultiError, or nil if none found.
+func (m *User) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *User) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserName
+
+	// no validation rules for UserAccount
+
+	// no validation rules for AvatarUrl
+
+	// no validation rules for Phone
+
+	// no validation rules for Email
+
+	// no validation rules for UserStatus
+
+	// no validation rules for Gender
+
+	// no validation rules for Empty
+
+	// no validation rules for UserRole
+
+	// no validation rules for CreateTime
+
+	// no validation rules for UserType
+
+	// no validation rules for ImpersonatorId
+
+	if len(errors) > 0 {
+		return UserMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserMultiError is an error wrapping multiple validation errors returned by
+// User.ValidateAll() if the designated constraints aren't met.
+type UserMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserMultiError) AllErrors() []error { return m }
+
+// UserValidationError is the validation error returned by User.Validate if the
+// designated constraints aren't met.
+type UserValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserValidationError) ErrorName() string { return "UserValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUser.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserValidationError{}
+
+// Validate checks the field values on AuditLog with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AuditLog) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AuditLog with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AuditLogMultiError, or nil
+// if none found.
+func (m *AuditLog) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AuditLog) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for ActorId
+
+	// no validation rules for TargetId
+
+	// no validation rules for Action
+
+	// no validation rules for Ip
+
+	// no validation rules for UserAgent
+
+	// no validation rules for Result
+
+	// no validation rules for Reason
+
+	// no validation rules for Detail
+
+	// no validation rules for CreateTime
+
+	if len(errors) > 0 {
+		return AuditLogMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuditLogMultiError is an error wrapping multiple validation errors returned
+// by AuditLog.ValidateAll() if the designated constraints aren't met.
+type AuditLogMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuditLogMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuditLogMultiError) AllErrors() []error { return m }
+
+// AuditLogValidationError is the validation error returned by
+// AuditLog.Validate if the designated constraints aren't met.
+type AuditLogValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditLogValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditLogValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditLogValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditLogValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditLogValidationError) ErrorName() string { return "AuditLogValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditLogValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditLog.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditLogValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditLogValidationError{}
+
+// Validate checks the field values on LoginHistory with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoginHistory) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginHistory with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoginHistoryMultiError, or
+// nil if none found.
+func (m *LoginHistory) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginHistory) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserId
+
+	// no validation rules for UserAccount
+
+	// no validation rules for Ip
+
+	// no validation rules for UserAgent
+
+	// no validation rules for Method
+
+	// no validation rules for Success
+
+	// no validation rules for Reason
+
+	// no validation rules for CreateTime
+
+	// no validation rules for Unrecognized
+
+	if len(errors) > 0 {
+		return LoginHistoryMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoginHistoryMultiError is an error wrapping multiple validation errors
+// returned by LoginHistory.ValidateAll() if the designated constraints aren't met.
+type LoginHistoryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginHistoryMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginHistoryMultiError) AllErrors() []error { return m }
+
+// LoginHistoryValidationError is the validation error returned by
+// LoginHistory.Validate if the designated constraints aren't met.
+type LoginHistoryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginHistoryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginHistoryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginHistoryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginHistoryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginHistoryValidationError) ErrorName() string { return "LoginHistoryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoginHistoryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginHistory.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginHistoryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginHistoryValidationError{}
+
+// Validate checks the field values on Session with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Session) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Session with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in SessionMultiError, or nil if none found.
+func (m *Session) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Session) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Ip
+
+	// no validation rules for UserAgent
+
+	// no validation rules for Method
+
+	// no validation rules for LoginTime
+
+	// no validation rules for Unrecognized
+
+	if len(errors) > 0 {
+		return SessionMultiError(errors)
+	}
+
+	return nil
+}
+
+// SessionMultiError is an error wrapping multiple validation errors returned
+// by Session.ValidateAll() if the designated constraints aren't met.
+type SessionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SessionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SessionMultiError) AllErrors() []error { return m }
+
+// SessionValidationError is the validation error returned by Session.Validate
+// if the designated constraints aren't met.
+type SessionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SessionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SessionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SessionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SessionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SessionValidationError) ErrorName() string { return "SessionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SessionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSession.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SessionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SessionValidationError{}
+
+// Validate checks the field values on Webhook with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Webhook) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Webhook with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in WebhookMultiError, or nil if none found.
+func (m *Webhook) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Webhook) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Url
+
+	// no validation rules for Secret
+
+	// no validation rules for CreatorId
+
+	// no validation rules for CreateTime
+
+	if len(errors) > 0 {
+		return WebhookMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebhookMultiError is an error wrapping multiple validation errors returned
+// by Webhook.ValidateAll() if the designated constraints aren't met.
+type WebhookMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebhookMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebhookMultiError) AllErrors() []error { return m }
+
+// WebhookValidationError is the validation error returned by Webhook.Validate
+// if the designated constraints aren't met.
+type WebhookValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebhookValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebhookValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebhookValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebhookValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebhookValidationError) ErrorName() string { return "WebhookValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WebhookValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebhook.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebhookValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebhookValidationError{}
+
+// Validate checks the field values on WebhookDelivery with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *WebhookDelivery) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebhookDelivery with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebhookDeliveryMultiError, or nil if none found.
+func (m *WebhookDelivery) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebhookDelivery) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for WebhookId
+
+	// no validation rules for EventId
+
+	// no validation rules for EventType
+
+	// no validation rules for Payload
+
+	// no validation rules for Status
+
+	// no validation rules for Attempts
+
+	// no validation rules for NextAttemptTime
+
+	// no validation rules for LastStatusCode
+
+	// no validation rules for LastError
+
+	// no validation rules for CreateTime
+
+	// no validation rules for UpdateTime
+
+	if len(errors) > 0 {
+		return WebhookDeliveryMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebhookDeliveryMultiError is an error wrapping multiple validation errors
+// returned by WebhookDelivery.ValidateAll() if the designated constraints
+// aren't met.
+type WebhookDeliveryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebhookDeliveryMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebhookDeliveryMultiError) AllErrors() []error { return m }
+
+// WebhookDeliveryValidationError is the validation error returned by
+// WebhookDelivery.Validate if the designated constraints aren't met.
+type WebhookDeliveryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebhookDeliveryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebhookDeliveryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebhookDeliveryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebhookDeliveryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebhookDeliveryValidationError) ErrorName() string { return "WebhookDeliveryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WebhookDeliveryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebhookDelivery.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebhookDeliveryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebhookDeliveryValidationError{}
+
+// Validate checks the field values on UserChangeEvent with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserChangeEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserChangeEvent with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserChangeEventMultiError, or nil if none found.
+func (m *UserChangeEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserChangeEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Seq
+
+	// no validation rules for EventId
+
+	// no validation rules for Type
+
+	// no validation rules for UserId
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserChangeEventValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserChangeEventValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserChangeEventValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for OccurredAt
+
+	if len(errors) > 0 {
+		return UserChangeEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserChangeEventMultiError is an error wrapping multiple validation errors
+// returned by UserChangeEvent.ValidateAll() if the designated constraints
+// aren't met.
+type UserChangeEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserChangeEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserChangeEventMultiError) AllErrors() []error { return m }
+
+// UserChangeEventValidationError is the validation error returned by
+// UserChangeEvent.Validate if the designated constraints aren't met.
+type UserChangeEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserChangeEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserChangeEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserChangeEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserChangeEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserChangeEventValidationError) ErrorName() string { return "UserChangeEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserChangeEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserChangeEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserChangeEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserChangeEventValidationError{}
+
+// Validate checks the field values on OAuthClient with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *OAuthClient) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OAuthClient with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in OAuthClientMultiError, or
+// nil if none found.
+func (m *OAuthClient) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OAuthClient) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ClientId
+
+	// no validation rules for ClientSecret
+
+	// no validation rules for Name
+
+	// no validation rules for Public
+
+	// no validation rules for CreatorId
+
+	// no validation rules for CreateTime
+
+	if len(errors) > 0 {
+		return OAuthClientMultiError(errors)
+	}
+
+	return nil
+}
+
+// OAuthClientMultiError is an error wrapping multiple validation errors
+// returned by OAuthClient.ValidateAll() if the designated constraints aren't met.
+type OAuthClientMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m OAuthClientMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m OAuthClientMultiError) AllErrors() []error { return m }
+
+// OAuthClientValidationError is the validation error returned by
+// OAuthClient.Validate if the designated constraints aren't met.
+type OAuthClientValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OAuthClientValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OAuthClientValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OAuthClientValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OAuthClientValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OAuthClientValidationError) ErrorName() string { return "OAuthClientValidationError" }
+
+// Error satisfies the builtin error interface
+func (e OAuthClientValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOAuthClient.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OAuthClientValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OAuthClientValidationError{}
+
+// Validate checks the field values on Identity with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Identity) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Identity with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in IdentityMultiError, or nil
+// if none found.
+func (m *Identity) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Identity) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Provider
+
+	// no validation rules for Subject
+
+	// no validation rules for Email
+
+	// no validation rules for CreateTime
+
+	// no validation rules for LastLoginTime
+
+	if len(errors) > 0 {
+		return IdentityMultiError(errors)
+	}
+
+	return nil
+}
+
+// IdentityMultiError is an error wrapping multiple validation errors returned
+// by Identity.ValidateAll() if the designated constraints aren't met.
+type IdentityMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IdentityMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IdentityMultiError) AllErrors() []error { return m }
+
+// IdentityValidationError is the validation error returned by
+// Identity.Validate if the designated constraints aren't met.
+type IdentityValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IdentityValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IdentityValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IdentityValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IdentityValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IdentityValidationError) ErrorName() string { return "IdentityValidationError" }
+
+// Error satisfies the builtin error interface
+func (e IdentityValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIdentity.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IdentityValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IdentityValidationError{}
+
+// Validate checks the field values on CreateAccessTokenReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateAccessTokenReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateAccessTokenReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAccessTokenReqMultiError, or nil if none found.
+func (m *CreateAccessTokenReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateAccessTokenReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for ExpireDays
+
+	if len(errors) > 0 {
+		return CreateAccessTokenReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateAccessTokenReqMultiError is an error wrapping multiple validation
+// errors returned by CreateAccessTokenReq.ValidateAll() if the designated
+// constraints aren't met.
+type CreateAccessTokenReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateAccessTokenReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateAccessTokenReqMultiError) AllErrors() []error { return m }
+
+// CreateAccessTokenReqValidationError is the validation error returned by
+// CreateAccessTokenReq.Validate if the designated constraints aren't met.
+type CreateAccessTokenReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateAccessTokenReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateAccessTokenReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateAccessTokenReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateAccessTokenReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateAccessTokenReqValidationError) ErrorName() string {
+	return "CreateAccessTokenReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateAccessTokenReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateAccessTokenReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateAccessTokenReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateAccessTokenReqValidationError{}
+
+// Validate checks the field values on CreateAccessTokenReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateAccessTokenReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateAccessTokenReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAccessTokenReplyMultiError, or nil if none found.
+func (m *CreateAccessTokenReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateAccessTokenReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Token
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateAccessTokenReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateAccessTokenReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateAccessTokenReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateAccessTokenReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateAccessTokenReplyMultiError is an error wrapping multiple validation
+// errors returned by CreateAccessTokenReply.ValidateAll() if the designated
+// constraints aren't met.
+type CreateAccessTokenReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateAccessTokenReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateAccessTokenReplyMultiError) AllErrors() []error { return m }
+
+// CreateAccessTokenReplyValidationError is the validation error returned by
+// CreateAccessTokenReply.Validate if the designated constraints aren't met.
+type CreateAccessTokenReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateAccessTokenReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateAccessTokenReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateAccessTokenReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateAccessTokenReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateAccessTokenReplyValidationError) ErrorName() string {
+	return "CreateAccessTokenReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateAccessTokenReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateAccessTokenReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateAccessTokenReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateAccessTokenReplyValidationError{}
+
+// Validate checks the field values on ListAccessTokensReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAccessTokensReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAccessTokensReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAccessTokensReplyMultiError, or nil if none found.
+func (m *ListAccessTokensReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAccessTokensReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAccessTokensReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAccessTokensReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAccessTokensReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListAccessTokensReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAccessTokensReplyMultiError is an error wrapping multiple validation
+// errors returned by ListAccessTokensReply.ValidateAll() if the designated
+// constraints aren't met.
+type ListAccessTokensReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAccessTokensReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAccessTokensReplyMultiError) AllErrors() []error { return m }
+
+// ListAccessTokensReplyValidationError is the validation error returned by
+// ListAccessTokensReply.Validate if the designated constraints aren't met.
+type ListAccessTokensReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAccessTokensReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAccessTokensReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAccessTokensReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAccessTokensReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAccessTokensReplyValidationError) ErrorName() string {
+	return "ListAccessTokensReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAccessTokensReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAccessTokensReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAccessTokensReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAccessTokensReplyValidationError{}
+
+// Validate checks the field values on RevokeAccessTokenReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeAccessTokenReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeAccessTokenReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeAccessTokenReqMultiError, or nil if none found.
+func (m *RevokeAccessTokenReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeAccessTokenReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return RevokeAccessTokenReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeAccessTokenReqMultiError is an error wrapping multiple validation
+// errors returned by RevokeAccessTokenReq.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeAccessTokenReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeAccessTokenReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeAccessTokenReqMultiError) AllErrors() []error { return m }
+
+// RevokeAccessTokenReqValidationError is the validation error returned by
+// RevokeAccessTokenReq.Validate if the designated constraints aren't met.
+type RevokeAccessTokenReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeAccessTokenReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeAccessTokenReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeAccessTokenReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeAccessTokenReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeAccessTokenReqValidationError) ErrorName() string {
+	return "RevokeAccessTokenReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeAccessTokenReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeAccessTokenReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeAccessTokenReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeAccessTokenReqValidationError{}
+
+// Validate checks the field values on ListUserAccessTokensReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListUserAccessTokensReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUserAccessTokensReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUserAccessTokensReqMultiError, or nil if none found.
+func (m *ListUserAccessTokensReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUserAccessTokensReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Page
+
+	// no validation rules for PageSize
+
+	if len(errors) > 0 {
+		return ListUserAccessTokensReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUserAccessTokensReqMultiError is an error wrapping multiple validation
+// errors returned by ListUserAccessTokensReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListUserAccessTokensReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUserAccessTokensReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUserAccessTokensReqMultiError) AllErrors() []error { return m }
+
+// ListUserAccessTokensReqValidationError is the validation error returned by
+// ListUserAccessTokensReq.Validate if the designated constraints aren't met.
+type ListUserAccessTokensReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUserAccessTokensReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUserAccessTokensReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUserAccessTokensReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUserAccessTokensReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUserAccessTokensReqValidationError) ErrorName() string {
+	return "ListUserAccessTokensReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUserAccessTokensReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUserAccessTokensReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUserAccessTokensReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUserAccessTokensReqValidationError{}
+
+// Validate checks the field values on ListUserAccessTokensReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListUserAccessTokensReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUserAccessTokensReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUserAccessTokensReplyMultiError, or nil if none found.
+func (m *ListUserAccessTokensReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUserAccessTokensReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUserAccessTokensReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUserAccessTokensReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUserAccessTokensReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListUserAccessTokensReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUserAccessTokensReplyMultiError is an error wrapping multiple validation
+// errors returned by ListUserAccessTokensReply.ValidateAll() if the
+// designated constraints aren't met.
+type ListUserAccessTokensReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUserAccessTokensReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUserAccessTokensReplyMultiError) AllErrors() []error { return m }
+
+// ListUserAccessTokensReplyValidationError is the validation error returned by
+// ListUserAccessTokensReply.Validate if the designated constraints aren't met.
+type ListUserAccessTokensReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUserAccessTokensReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUserAccessTokensReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUserAccessTokensReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUserAccessTokensReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUserAccessTokensReplyValidationError) ErrorName() string {
+	return "ListUserAccessTokensReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUserAccessTokensReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUserAccessTokensReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUserAccessTokensReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUserAccessTokensReplyValidationError{}
+
+// Validate checks the field values on AccessToken with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AccessToken) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AccessToken with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AccessTokenMultiError, or
+// nil if none found.
+func (m *AccessToken) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AccessToken) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserId
+
+	// no validation rules for Name
+
+	// no validation rules for TokenPrefix
+
+	// no validation rules for ExpireTime
+
+	// no validation rules for LastUsedTime
+
+	// no validation rules for LastUsedIp
+
+	// no validation rules for RevokeTime
+
+	// no validation rules for CreateTime
+
+	if len(errors) > 0 {
+		return AccessTokenMultiError(errors)
+	}
+
+	return nil
+}
+
+// AccessTokenMultiError is an error wrapping multiple validation errors
+// returned by AccessToken.ValidateAll() if the designated constraints aren't met.
+type AccessTokenMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AccessTokenMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AccessTokenMultiError) AllErrors() []error { return m }
+
+// AccessTokenValidationError is the validation error returned by
+// AccessToken.Validate if the designated constraints aren't met.
+type AccessTokenValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AccessTokenValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AccessTokenValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AccessTokenValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AccessTokenValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AccessTokenValidationError) ErrorName() string { return "AccessTokenValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AccessTokenValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAccessToken.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AccessTokenValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AccessTokenValidationError{}
+
+// Validate checks the field values on CreateServiceAccountReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateServiceAccountReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateServiceAccountReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateServiceAccountReqMultiError, or nil if none found.
+func (m *CreateServiceAccountReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateServiceAccountReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserAccount
+
+	// no validation rules for UserName
+
+	// no validation rules for Role
+
+	// no validation rules for OwnerId
+
+	if len(errors) > 0 {
+		return CreateServiceAccountReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateServiceAccountReqMultiError is an error wrapping multiple validation
+// errors returned by CreateServiceAccountReq.ValidateAll() if the designated
+// constraints aren't met.
+type CreateServiceAccountReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateServiceAccountReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateServiceAccountReqMultiError) AllErrors() []error { return m }
+
+// CreateServiceAccountReqValidationError is the validation error returned by
+// CreateServiceAccountReq.Validate if the designated constraints aren't met.
+type CreateServiceAccountReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateServiceAccountReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateServiceAccountReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateServiceAccountReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateServiceAccountReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateServiceAccountReqValidationError) ErrorName() string {
+	return "CreateServiceAccountReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateServiceAccountReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateServiceAccountReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateServiceAccountReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateServiceAccountReqValidationError{}
+
+// Validate checks the field values on ListServiceAccountsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListServiceAccountsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListServiceAccountsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListServiceAccountsReqMultiError, or nil if none found.
+func (m *ListServiceAccountsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListServiceAccountsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Page
+
+	// no validation rules for PageSize
+
+	if len(errors) > 0 {
+		return ListServiceAccountsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListServiceAccountsReqMultiError is an error wrapping multiple validation
+// errors returned by ListServiceAccountsReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListServiceAccountsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListServiceAccountsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListServiceAccountsReqMultiError) AllErrors() []error { return m }
+
+// ListServiceAccountsReqValidationError is the validation error returned by
+// ListServiceAccountsReq.Validate if the designated constraints aren't met.
+type ListServiceAccountsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListServiceAccountsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListServiceAccountsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListServiceAccountsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListServiceAccountsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListServiceAccountsReqValidationError) ErrorName() string {
+	return "ListServiceAccountsReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListServiceAccountsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListServiceAccountsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListServiceAccountsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListServiceAccountsReqValidationError{}
+
+// Validate checks the field values on ListServiceAccountsReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListServiceAccountsReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListServiceAccountsReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListServiceAccountsReplyMultiError, or nil if none found.
+func (m *ListServiceAccountsReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListServiceAccountsReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListServiceAccountsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListServiceAccountsReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListServiceAccountsReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListServiceAccountsReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListServiceAccountsReplyMultiError is an error wrapping multiple validation
+// errors returned by ListServiceAccountsReply.ValidateAll() if the designated
+// constraints aren't met.
+type ListServiceAccountsReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListServiceAccountsReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListServiceAccountsReplyMultiError) AllErrors() []error { return m }
+
+// ListServiceAccountsReplyValidationError is the validation error returned by
+// ListServiceAccountsReply.Validate if the designated constraints aren't met.
+type ListServiceAccountsReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListServiceAccountsReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListServiceAccountsReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListServiceAccountsReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListServiceAccountsReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListServiceAccountsReplyValidationError) ErrorName() string {
+	return "ListServiceAccountsReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListServiceAccountsReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListServiceAccountsReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListServiceAccountsReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListServiceAccountsReplyValidationError{}
+
+// Validate checks the field values on UpdateServiceAccountReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateServiceAccountReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateServiceAccountReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateServiceAccountReqMultiError, or nil if none found.
+func (m *UpdateServiceAccountReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateServiceAccountReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserName
+
+	// no validation rules for Role
+
+	// no validation rules for OwnerId
+
+	// no validation rules for Disabled
+
+	if len(errors) > 0 {
+		return UpdateServiceAccountReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateServiceAccountReqMultiError is an error wrapping multiple validation
+// errors returned by UpdateServiceAccountReq.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateServiceAccountReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateServiceAccountReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateServiceAccountReqMultiError) AllErrors() []error { return m }
+
+// UpdateServiceAccountReqValidationError is the validation error returned by
+// UpdateServiceAccountReq.Validate if the designated constraints aren't met.
+type UpdateServiceAccountReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateServiceAccountReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateServiceAccountReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateServiceAccountReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateServiceAccountReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateServiceAccountReqValidationError) ErrorName() string {
+	return "UpdateServiceAccountReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateServiceAccountReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateServiceAccountReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateServiceAccountReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateServiceAccountReqValidationError{}
+
+// Validate checks the field values on CreateServiceAccountTokenReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateServiceAccountTokenReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateServiceAccountTokenReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateServiceAccountTokenReqMultiError, or nil if none found.
+func (m *CreateServiceAccountTokenReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateServiceAccountTokenReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for ExpireDays
+
+	if len(errors) > 0 {
+		return CreateServiceAccountTokenReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateServiceAccountTokenReqMultiError is an error wrapping multiple
+// validation errors returned by CreateServiceAccountTokenReq.ValidateAll() if
+// the designated constraints aren't met.
+type CreateServiceAccountTokenReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateServiceAccountTokenReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateServiceAccountTokenReqMultiError) AllErrors() []error { return m }
+
+// CreateServiceAccountTokenReqValidationError is the validation error returned
+// by CreateServiceAccountTokenReq.Validate if the designated constraints
+// aren't met.
+type CreateServiceAccountTokenReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateServiceAccountTokenReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateServiceAccountTokenReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateServiceAccountTokenReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateServiceAccountTokenReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateServiceAccountTokenReqValidationError) ErrorName() string {
+	return "CreateServiceAccountTokenReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateServiceAccountTokenReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateServiceAccountTokenReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateServiceAccountTokenReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateServiceAccountTokenReqValidationError{}
+
+// Validate checks the field values on ServiceAccountReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ServiceAccountReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServiceAccountReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ServiceAccountReplyMultiError, or nil if none found.
+func (m *ServiceAccountReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServiceAccountReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServiceAccountReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServiceAccountReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServiceAccountReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ServiceAccountReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceAccountReplyMultiError is an error wrapping multiple validation
+// errors returned by ServiceAccountReply.ValidateAll() if the designated
+// constraints aren't met.
+type ServiceAccountReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceAccountReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceAccountReplyMultiError) AllErrors() []error { return m }
+
+// ServiceAccountReplyValidationError is the validation error returned by
+// ServiceAccountReply.Validate if the designated constraints aren't met.
+type ServiceAccountReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceAccountReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceAccountReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceAccountReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceAccountReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceAccountReplyValidationError) ErrorName() string {
+	return "ServiceAccountReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ServiceAccountReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServiceAccountReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceAccountReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceAccountReplyValidationError{}
+
+// Validate checks the field values on ServiceAccount with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ServiceAccount) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServiceAccount with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ServiceAccountMultiError,
+// or nil if none found.
+func (m *ServiceAccount) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServiceAccount) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserAccount
+
+	// no validation rules for UserName
+
+	// no validation rules for Role
+
+	// no validation rules for OwnerId
+
+	// no validation rules for UserStatus
+
+	// no validation rules for CreateTime
+
+	if len(errors) > 0 {
+		return ServiceAccountMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceAccountMultiError is an error wrapping multiple validation errors
+// returned by ServiceAccount.ValidateAll() if the designated constraints
+// aren't met.
+type ServiceAccountMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceAccountMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceAccountMultiError) AllErrors() []error { return m }
+
+// ServiceAccountValidationError is the validation error returned by
+// ServiceAccount.Validate if the designated constraints aren't met.
+type ServiceAccountValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceAccountValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceAccountValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceAccountValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceAccountValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceAccountValidationError) ErrorName() string { return "ServiceAccountValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ServiceAccountValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServiceAccount.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceAccountValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceAccountValidationError{}
+
+// Validate checks the field values on StartImpersonationReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StartImpersonationReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StartImpersonationReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StartImpersonationReqMultiError, or nil if none found.
+func (m *StartImpersonationReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StartImpersonationReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Reason
+
+	if len(errors) > 0 {
+		return StartImpersonationReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// StartImpersonationReqMultiError is an error wrapping multiple validation
+// errors returned by StartImpersonationReq.ValidateAll() if the designated
+// constraints aren't met.
+type StartImpersonationReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StartImpersonationReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StartImpersonationReqMultiError) AllErrors() []error { return m }
+
+// StartImpersonationReqValidationError is the validation error returned by
+// StartImpersonationReq.Validate if the designated constraints aren't met.
+type StartImpersonationReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StartImpersonationReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StartImpersonationReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StartImpersonationReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StartImpersonationReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StartImpersonationReqValidationError) ErrorName() string {
+	return "StartImpersonationReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StartImpersonationReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStartImpersonationReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StartImpersonationReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StartImpersonationReqValidationError{}
+
+// Validate checks the field values on StartImpersonationReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StartImpersonationReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StartImpersonationReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StartImpersonationReplyMultiError, or nil if none found.
+func (m *StartImpersonationReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StartImpersonationReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Token
+
+	// no validation rules for ExpireTime
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StartImpersonationReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StartImpersonationReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StartImpersonationReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return StartImpersonationReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// StartImpersonationReplyMultiError is an error wrapping multiple validation
+// errors returned by StartImpersonationReply.ValidateAll() if the designated
+// constraints aren't met.
+type StartImpersonationReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StartImpersonationReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StartImpersonationReplyMultiError) AllErrors() []error { return m }
+
+// StartImpersonationReplyValidationError is the validation error returned by
+// StartImpersonationReply.Validate if the designated constraints aren't met.
+type StartImpersonationReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StartImpersonationReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StartImpersonationReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StartImpersonationReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StartImpersonationReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StartImpersonationReplyValidationError) ErrorName() string {
+	return "StartImpersonationReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StartImpersonationReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStartImpersonationReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StartImpersonationReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StartImpersonationReplyValidationError{}
+
+// Validate checks the field values on RequestMagicLinkReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestMagicLinkReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestMagicLinkReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RequestMagicLinkReqMultiError, or nil if none found.
+func (m *RequestMagicLinkReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestMagicLinkReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Email
+
+	if len(errors) > 0 {
+		return RequestMagicLinkReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// RequestMagicLinkReqMultiError is an error wrapping multiple validation
+// errors returned by RequestMagicLinkReq.ValidateAll() if the designated
+// constraints aren't met.
+type RequestMagicLinkReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestMagicLinkReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestMagicLinkReqMultiError) AllErrors() []error { return m }
+
+// RequestMagicLinkReqValidationError is the validation error returned by
+// RequestMagicLinkReq.Validate if the designated constraints aren't met.
+type RequestMagicLinkReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestMagicLinkReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestMagicLinkReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestMagicLinkReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestMagicLinkReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestMagicLinkReqValidationError) ErrorName() string {
+	return "RequestMagicLinkReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestMagicLinkReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestMagicLinkReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestMagicLinkReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestMagicLinkReqValidationError{}
+
+// Validate checks the field values on MagicLinkLoginReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *MagicLinkLoginReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MagicLinkLoginReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MagicLinkLoginReqMultiError, or nil if none found.
+func (m *MagicLinkLoginReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MagicLinkLoginReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Token
+
+	if len(errors) > 0 {
+		return MagicLinkLoginReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// MagicLinkLoginReqMultiError is an error wrapping multiple validation errors
+// returned by MagicLinkLoginReq.ValidateAll() if the designated constraints
+// aren't met.
+type MagicLinkLoginReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MagicLinkLoginReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MagicLinkLoginReqMultiError) AllErrors() []error { return m }
+
+// MagicLinkLoginReqValidationError is the validation error returned by
+// MagicLinkLoginReq.Validate if the designated constraints aren't met.
+type MagicLinkLoginReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MagicLinkLoginReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MagicLinkLoginReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MagicLinkLoginReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MagicLinkLoginReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MagicLinkLoginReqValidationError) ErrorName() string {
+	return "MagicLinkLoginReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e MagicLinkLoginReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMagicLinkLoginReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MagicLinkLoginReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MagicLinkLoginReqValidationError{}
+
+// Validate checks the field values on PasskeyChallengeReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PasskeyChallengeReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PasskeyChallengeReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PasskeyChallengeReplyMultiError, or nil if none found.
+func (m *PasskeyChallengeReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PasskeyChallengeReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ChallengeId
+
+	// no validation rules for Options
+
+	if len(errors) > 0 {
+		return PasskeyChallengeReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// PasskeyChallengeReplyMultiError is an error wrapping multiple validation
+// errors returned by PasskeyChallengeReply.ValidateAll() if the designated
+// constraints aren't met.
+type PasskeyChallengeReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PasskeyChallengeReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PasskeyChallengeReplyMultiError) AllErrors() []error { return m }
+
+// PasskeyChallengeReplyValidationError is the validation error returned by
+// PasskeyChallengeReply.Validate if the designated constraints aren't met.
+type PasskeyChallengeReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PasskeyChallengeReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PasskeyChallengeReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PasskeyChallengeReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PasskeyChallengeReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PasskeyChallengeReplyValidationError) ErrorName() string {
+	return "PasskeyChallengeReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PasskeyChallengeReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPasskeyChallengeReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PasskeyChallengeReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PasskeyChallengeReplyValidationError{}
+
+// Validate checks the field values on FinishPasskeyRegistrationReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *FinishPasskeyRegistrationReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FinishPasskeyRegistrationReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FinishPasskeyRegistrationReqMultiError, or nil if none found.
+func (m *FinishPasskeyRegistrationReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FinishPasskeyRegistrationReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ChallengeId
+
+	// no validation rules for Name
+
+	// no validation rules for Credential
+
+	if len(errors) > 0 {
+		return FinishPasskeyRegistrationReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// FinishPasskeyRegistrationReqMultiError is an error wrapping multiple
+// validation errors returned by FinishPasskeyRegistrationReq.ValidateAll() if
+// the designated constraints aren't met.
+type FinishPasskeyRegistrationReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FinishPasskeyRegistrationReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FinishPasskeyRegistrationReqMultiError) AllErrors() []error { return m }
+
+// FinishPasskeyRegistrationReqValidationError is the validation error returned
+// by FinishPasskeyRegistrationReq.Validate if the designated constraints
+// aren't met.
+type FinishPasskeyRegistrationReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FinishPasskeyRegistrationReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FinishPasskeyRegistrationReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FinishPasskeyRegistrationReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FinishPasskeyRegistrationReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FinishPasskeyRegistrationReqValidationError) ErrorName() string {
+	return "FinishPasskeyRegistrationReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e FinishPasskeyRegistrationReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFinishPasskeyRegistrationReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FinishPasskeyRegistrationReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FinishPasskeyRegistrationReqValidationError{}
+
+// Validate checks the field values on PasskeyReply with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PasskeyReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PasskeyReply with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PasskeyReplyMultiError, or
+// nil if none found.
+func (m *PasskeyReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PasskeyReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PasskeyReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PasskeyReplyValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PasskeyReplyValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PasskeyReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// PasskeyReplyMultiError is an error wrapping multiple validation errors
+// returned by PasskeyReply.ValidateAll() if the designated constraints aren't met.
+type PasskeyReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PasskeyReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PasskeyReplyMultiError) AllErrors() []error { return m }
+
+// PasskeyReplyValidationError is the validation error returned by
+// PasskeyReply.Validate if the designated constraints aren't met.
+type PasskeyReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PasskeyReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PasskeyReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PasskeyReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PasskeyReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PasskeyReplyValidationError) ErrorName() string { return "PasskeyReplyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PasskeyReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPasskeyReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PasskeyReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PasskeyReplyValidationError{}
+
+// Validate checks the field values on ListPasskeysReply with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListPasskeysReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListPasskeysReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListPasskeysReplyMultiError, or nil if none found.
+func (m *ListPasskeysReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListPasskeysReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListPasskeysReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListPasskeysReplyValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListPasskeysReplyValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListPasskeysReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListPasskeysReplyMultiError is an error wrapping multiple validation errors
+// returned by ListPasskeysReply.ValidateAll() if the designated constraints
+// aren't met.
+type ListPasskeysReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListPasskeysReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListPasskeysReplyMultiError) AllErrors() []error { return m }
+
+// ListPasskeysReplyValidationError is the validation error returned by
+// ListPasskeysReply.Validate if the designated constraints aren't met.
+type ListPasskeysReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListPasskeysReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListPasskeysReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListPasskeysReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListPasskeysReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListPasskeysReplyValidationError) ErrorName() string {
+	return "ListPasskeysReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListPasskeysReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListPasskeysReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListPasskeysReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListPasskeysReplyValidationError{}
+
+// Validate checks the field values on DeletePasskeyReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeletePasskeyReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeletePasskeyReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeletePasskeyReqMultiError, or nil if none found.
+func (m *DeletePasskeyReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeletePasskeyReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return DeletePasskeyReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeletePasskeyReqMultiError is an error wrapping multiple validation errors
+// returned by DeletePasskeyReq.ValidateAll() if the designated constraints
+// aren't met.
+type DeletePasskeyReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeletePasskeyReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeletePasskeyReqMultiError) AllErrors() []error { return m }
+
+// DeletePasskeyReqValidationError is the validation error returned by
+// DeletePasskeyReq.Validate if the designated constraints aren't met.
+type DeletePasskeyReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeletePasskeyReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeletePasskeyReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeletePasskeyReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeletePasskeyReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeletePasskeyReqValidationError) ErrorName() string { return "DeletePasskeyReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DeletePasskeyReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeletePasskeyReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeletePasskeyReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeletePasskeyReqValidationError{}
+
+// Validate checks the field values on BeginPasskeyLoginReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BeginPasskeyLoginReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BeginPasskeyLoginReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BeginPasskeyLoginReqMultiError, or nil if none found.
+func (m *BeginPasskeyLoginReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BeginPasskeyLoginReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserAccount
+
+	if len(errors) > 0 {
+		return BeginPasskeyLoginReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// BeginPasskeyLoginReqMultiError is an error wrapping multiple validation
+// errors returned by BeginPasskeyLoginReq.ValidateAll() if the designated
+// constraints aren't met.
+type BeginPasskeyLoginReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BeginPasskeyLoginReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BeginPasskeyLoginReqMultiError) AllErrors() []error { return m }
+
+// BeginPasskeyLoginReqValidationError is the validation error returned by
+// BeginPasskeyLoginReq.Validate if the designated constraints aren't met.
+type BeginPasskeyLoginReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BeginPasskeyLoginReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BeginPasskeyLoginReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BeginPasskeyLoginReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BeginPasskeyLoginReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BeginPasskeyLoginReqValidationError) ErrorName() string {
+	return "BeginPasskeyLoginReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BeginPasskeyLoginReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBeginPasskeyLoginReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BeginPasskeyLoginReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BeginPasskeyLoginReqValidationError{}
+
+// Validate checks the field values on FinishPasskeyLoginReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *FinishPasskeyLoginReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FinishPasskeyLoginReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FinishPasskeyLoginReqMultiError, or nil if none found.
+func (m *FinishPasskeyLoginReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FinishPasskeyLoginReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ChallengeId
+
+	// no validation rules for Credential
+
+	if len(errors) > 0 {
+		return FinishPasskeyLoginReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// FinishPasskeyLoginReqMultiError is an error wrapping multiple validation
+// errors returned by FinishPasskeyLoginReq.ValidateAll() if the designated
+// constraints aren't met.
+type FinishPasskeyLoginReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FinishPasskeyLoginReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FinishPasskeyLoginReqMultiError) AllErrors() []error { return m }
+
+// FinishPasskeyLoginReqValidationError is the validation error returned by
+// FinishPasskeyLoginReq.Validate if the designated constraints aren't met.
+type FinishPasskeyLoginReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FinishPasskeyLoginReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FinishPasskeyLoginReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FinishPasskeyLoginReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FinishPasskeyLoginReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FinishPasskeyLoginReqValidationError) ErrorName() string {
+	return "FinishPasskeyLoginReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e FinishPasskeyLoginReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFinishPasskeyLoginReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FinishPasskeyLoginReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FinishPasskeyLoginReqValidationError{}
+
+// Validate checks the field values on Passkey with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Passkey) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Passkey with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in PasskeyMultiError, or nil if none found.
+func (m *Passkey) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Passkey) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for CredentialId
+
+	// no validation rules for Aaguid
+
+	// no validation rules for BackupEligible
+
+	// no validation rules for BackupState
+
+	// no validation rules for SignCount
+
+	// no validation rules for CreateTime
+
+	// no validation rules for LastUsedTime
+
+	if len(errors) > 0 {
+		return PasskeyMultiError(errors)
+	}
+
+	return nil
+}
+
+// PasskeyMultiError is an error wrapping multiple validation errors returned
+// by Passkey.ValidateAll() if the designated constraints aren't met.
+type PasskeyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PasskeyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PasskeyMultiError) AllErrors() []error { return m }
+
+// PasskeyValidationError is the validation error returned by Passkey.Validate
+// if the designated constraints aren't met.
+type PasskeyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PasskeyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PasskeyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PasskeyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PasskeyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PasskeyValidationError) ErrorName() string { return "PasskeyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PasskeyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPasskey.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PasskeyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PasskeyValidationError{}
@@ -26,16 +26,39 @@ type UserErrorReason int32
 const (
 	UserErrorReason_UNKNOWN_ERROR UserErrorReason = 0
 	//  Get_Account_Failed = 1 [(errors.code) = 401];
-	UserErrorReason_VALIDATE_ERROR       UserErrorReason = 1
-	UserErrorReason_ACCOUNT_EXIST        UserErrorReason = 2
-	UserErrorReason_ACCOUNT_ILLEGAL      UserErrorReason = 3
-	UserErrorReason_USER_REGISTER_FAILED UserErrorReason = 4
-	UserErrorReason_USER_LOGIN_FAILED    UserErrorReason = 5
-	UserErrorReason_USER_SEARCH_FAILED   UserErrorReason = 6
-	UserErrorReason_USER_DELETE_FAILED   UserErrorReason = 7
-	UserErrorReason_PERMISSION_DENY      UserErrorReason = 8
-	UserErrorReason_LOGIN_STATE_TIMEOUT  UserErrorReason = 9
-	UserErrorReason_USER_LOGOUT_FAILED   UserErrorReason = 10
+	UserErrorReason_VALIDATE_ERROR              UserErrorReason = 1
+	UserErrorReason_ACCOUNT_EXIST               UserErrorReason = 2
+	UserErrorReason_ACCOUNT_ILLEGAL             UserErrorReason = 3
+	UserErrorReason_USER_REGISTER_FAILED        UserErrorReason = 4
+	UserErrorReason_USER_LOGIN_FAILED           UserErrorReason = 5
+	UserErrorReason_USER_SEARCH_FAILED          UserErrorReason = 6
+	UserErrorReason_USER_DELETE_FAILED          UserErrorReason = 7
+	UserErrorReason_PERMISSION_DENY             UserErrorReason = 8
+	UserErrorReason_LOGIN_STATE_TIMEOUT         UserErrorReason = 9
+	UserErrorReason_USER_LOGOUT_FAILED          UserErrorReason = 10
+	UserErrorReason_ACCOUNT_PENDING_APPROVAL    UserErrorReason = 11
+	UserErrorReason_ACCOUNT_REJECTED            UserErrorReason = 12
+	UserErrorReason_USER_APPROVE_FAILED         UserErrorReason = 13
+	UserErrorReason_AUDIT_LOG_SEARCH_FAILED     UserErrorReason = 14
+	UserErrorReason_LOGIN_HISTORY_SEARCH_FAILED UserErrorReason = 15
+	UserErrorReason_LOGIN_VERIFY_REQUIRED       UserErrorReason = 16
+	UserErrorReason_LOGIN_VERIFY_FAILED         UserErrorReason = 17
+	UserErrorReason_WEBHOOK_FAILED              UserErrorReason = 18
+	UserErrorReason_CHANGE_LOG_EXPIRED          UserErrorReason = 19
+	UserErrorReason_OAUTH_FAILED                UserErrorReason = 20
+	UserErrorReason_EXTERNAL_LOGIN_FAILED       UserErrorReason = 21
+	UserErrorReason_IDENTITY_UNLINK_DENIED      UserErrorReason = 22
+	UserErrorReason_ACCOUNT_DISABLED            UserErrorReason = 23
+	UserErrorReason_ACCESS_TOKEN_INVALID        UserErrorReason = 24
+	UserErrorReason_ACCESS_TOKEN_FAILED         UserErrorReason = 25
+	UserErrorReason_SERVICE_ACCOUNT_FAILED      UserErrorReason = 26
+	UserErrorReason_IMPERSONATION_FAILED        UserErrorReason = 27
+	UserErrorReason_MAGIC_LINK_INVALID          UserErrorReason = 28
+	UserErrorReason_PASSKEY_FAILED              UserErrorReason = 29
+	UserErrorReason_PASSKEY_REQUIRED            UserErrorReason = 30
+	UserErrorReason_SESSION_UNAVAILABLE         UserErrorReason = 31
+	UserErrorReason_MAGIC_LINK_RATE_LIMITED     UserErrorReason = 32
+	UserErrorReason_LOGIN_VERIFY_UNAVAILABLE    UserErrorReason = 33
 )
 
 // Enum value maps for UserErrorReason.
@@ -52,19 +75,65 @@ var (
 		8:  "PERMISSION_DENY",
 		9:  "LOGIN_STATE_TIMEOUT",
 		10: "USER_LOGOUT_FAILED",
+		11: "ACCOUNT_PENDING_APPROVAL",
+		12: "ACCOUNT_REJECTED",
+		13: "USER_APPROVE_FAILED",
+		14: "AUDIT_LOG_SEARCH_FAILED",
+		15: "LOGIN_HISTORY_SEARCH_FAILED",
+		16: "LOGIN_VERIFY_REQUIRED",
+		17: "LOGIN_VERIFY_FAILED",
+		18: "WEBHOOK_FAILED",
+		19: "CHANGE_LOG_EXPIRED",
+		20: "OAUTH_FAILED",
+		21: "EXTERNAL_LOGIN_FAILED",
+		22: "IDENTITY_UNLINK_DENIED",
+		23: "ACCOUNT_DISABLED",
+		24: "ACCESS_TOKEN_INVALID",
+		25: "ACCESS_TOKEN_FAILED",
+		26: "SERVICE_ACCOUNT_FAILED",
+		27: "IMPERSONATION_FAILED",
+		28: "MAGIC_LINK_INVALID",
+		29: "PASSKEY_FAILED",
+		30: "PASSKEY_REQUIRED",
+		31: "SESSION_UNAVAILABLE",
+		32: "MAGIC_LINK_RATE_LIMITED",
+		33: "LOGIN_VERIFY_UNAVAILABLE",
 	}
 	UserErrorReason_value = map[string]int32{
-		"UNKNOWN_ERROR":        0,
-		"VALIDATE_ERROR":       1,
-		"ACCOUNT_EXIST":        2,
-		"ACCOUNT_ILLEGAL":      3,
-		"USER_REGISTER_FAILED": 4,
-		"USER_LOGIN_FAILED":    5,
-		"USER_SEARCH_FAILED":   6,
-		"USER_DELETE_FAILED":   7,
-		"PERMISSION_DENY":      8,
-		"LOGIN_STATE_TIMEOUT":  9,
-		"USER_LOGOUT_FAILED":   10,
+		"UNKNOWN_ERROR":               0,
+		"VALIDATE_ERROR":              1,
+		"ACCOUNT_EXIST":               2,
+		"ACCOUNT_ILLEGAL":             3,
+		"USER_REGISTER_FAILED":        4,
+		"USER_LOGIN_FAILED":           5,
+		"USER_SEARCH_FAILED":          6,
+		"USER_DELETE_FAILED":          7,
+		"PERMISSION_DENY":             8,
+		"LOGIN_STATE_TIMEOUT":         9,
+		"USER_LOGOUT_FAILED":          10,
+		"ACCOUNT_PENDING_APPROVAL":    11,
+		"ACCOUNT_REJECTED":            12,
+		"USER_APPROVE_FAILED":         13,
+		"AUDIT_LOG_SEARCH_FAILED":     14,
+		"LOGIN_HISTORY_SEARCH_FAILED": 15,
+		"LOGIN_VERIFY_REQUIRED":       16,
+		"LOGIN_VERIFY_FAILED":         17,
+		"WEBHOOK_FAILED":              18,
+		"CHANGE_LOG_EXPIRED":          19,
+		"OAUTH_FAILED":                20,
+		"EXTERNAL_LOGIN_FAILED":       21,
+		"IDENTITY_UNLINK_DENIED":      22,
+		"ACCOUNT_DISABLED":            23,
+		"ACCESS_TOKEN_INVALID":        24,
+		"ACCESS_TOKEN_FAILED":         25,
+		"SERVICE_ACCOUNT_FAILED":      26,
+		"IMPERSONATION_FAILED":        27,
+		"MAGIC_LINK_INVALID":          28,
+		"PASSKEY_FAILED":              29,
+		"PASSKEY_REQUIRED":            30,
+		"SESSION_UNAVAILABLE":         31,
+		"MAGIC_LINK_RATE_LIMITED":     32,
+		"LOGIN_VERIFY_UNAVAILABLE":    33,
 	}
 )
 
@@ -102,7 +171,7 @@ var file_user_service_v1_user_error_proto_rawDesc = []byte{
 	0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x2e, 0x76, 0x31,
 	0x1a, 0x13, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0x8d, 0x02, 0x0a, 0x0f, 0x55, 0x73, 0x65, 0x72, 0x45, 0x72,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xe2, 0x06, 0x0a, 0x0f, 0x55, 0x73, 0x65, 0x72, 0x45, 0x72,
 	0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x4e, 0x4b,
 	0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
 	0x56, 0x41, 0x4c, 0x49, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01,
@@ -118,10 +187,47 @@ var file_user_service_v1_user_error_proto_rawDesc = []byte{
 	0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4e, 0x59, 0x10, 0x08, 0x12, 0x17,
 	0x0a, 0x13, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x49,
 	0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x09, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x53, 0x45, 0x52, 0x5f,
-	0x4c, 0x4f, 0x47, 0x4f, 0x55, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x0a, 0x1a,
-	0x04, 0xa0, 0x45, 0xf4, 0x03, 0x42, 0x1b, 0x5a, 0x19, 0x61, 0x70, 0x69, 0x2f, 0x75, 0x73, 0x65,
-	0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x62, 0x3b,
-	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4c, 0x4f, 0x47, 0x4f, 0x55, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x0a, 0x12,
+	0x1c, 0x0a, 0x18, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49,
+	0x4e, 0x47, 0x5f, 0x41, 0x50, 0x50, 0x52, 0x4f, 0x56, 0x41, 0x4c, 0x10, 0x0b, 0x12, 0x14, 0x0a,
+	0x10, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45,
+	0x44, 0x10, 0x0c, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x41, 0x50, 0x50, 0x52,
+	0x4f, 0x56, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x0d, 0x12, 0x1b, 0x0a, 0x17,
+	0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x4c, 0x4f, 0x47, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x0e, 0x12, 0x1f, 0x0a, 0x1b, 0x4c, 0x4f, 0x47,
+	0x49, 0x4e, 0x5f, 0x48, 0x49, 0x53, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43,
+	0x48, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x0f, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x4f,
+	0x47, 0x49, 0x4e, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49,
+	0x52, 0x45, 0x44, 0x10, 0x10, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x5f, 0x56,
+	0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x11, 0x12, 0x12,
+	0x0a, 0x0e, 0x57, 0x45, 0x42, 0x48, 0x4f, 0x4f, 0x4b, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44,
+	0x10, 0x12, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4c, 0x4f, 0x47,
+	0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x13, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x41,
+	0x55, 0x54, 0x48, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x14, 0x12, 0x19, 0x0a, 0x15,
+	0x45, 0x58, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x5f, 0x46,
+	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x15, 0x12, 0x1a, 0x0a, 0x16, 0x49, 0x44, 0x45, 0x4e, 0x54,
+	0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x44, 0x45, 0x4e, 0x49, 0x45,
+	0x44, 0x10, 0x16, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x44,
+	0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x17, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x43, 0x43,
+	0x45, 0x53, 0x53, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49,
+	0x44, 0x10, 0x18, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x4f,
+	0x4b, 0x45, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x19, 0x12, 0x1a, 0x0a, 0x16,
+	0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x1a, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x4d, 0x50, 0x45,
+	0x52, 0x53, 0x4f, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44,
+	0x10, 0x1b, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x41, 0x47, 0x49, 0x43, 0x5f, 0x4c, 0x49, 0x4e, 0x4b,
+	0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x1c, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x41,
+	0x53, 0x53, 0x4b, 0x45, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x1d, 0x12, 0x14,
+	0x0a, 0x10, 0x50, 0x41, 0x53, 0x53, 0x4b, 0x45, 0x59, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52,
+	0x45, 0x44, 0x10, 0x1e, 0x12, 0x1d, 0x0a, 0x13, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x55, 0x4e, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x1f, 0x1a, 0x04, 0xa8,
+	0x45, 0xf7, 0x03, 0x12, 0x21, 0x0a, 0x17, 0x4d, 0x41, 0x47, 0x49, 0x43, 0x5f, 0x4c, 0x49, 0x4e,
+	0x4b, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x10, 0x20,
+	0x1a, 0x04, 0xa8, 0x45, 0xad, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x5f,
+	0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x55, 0x4e, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42,
+	0x4c, 0x45, 0x10, 0x21, 0x1a, 0x04, 0xa0, 0x45, 0xf4, 0x03, 0x42, 0x1b, 0x5a, 0x19, 0x61, 0x70,
+	0x69, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x76,
+	0x31, 0x2f, 0x70, 0x62, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -23,7 +23,7 @@ func ErrorUnknownError(format string, args ...interface{}) *errors.Error {
 	return errors.New(500, UserErrorReason_UNKNOWN_ERROR.String(), fmt.Sprintf(format, args...))
 }
 
-//  Get_Account_Failed = 1 [(errors.code) = 401];
+// Get_Account_Failed = 1 [(errors.code) = 401];
 func IsValidateError(err error) bool {
 	if err == nil {
 		return false
@@ -32,7 +32,7 @@ func IsValidateError(err error) bool {
 	return e.Reason == UserErrorReason_VALIDATE_ERROR.String() && e.Code == 500
 }
 
-//  Get_Account_Failed = 1 [(errors.code) = 401];
+// Get_Account_Failed = 1 [(errors.code) = 401];
 func ErrorValidateError(format string, args ...interface{}) *errors.Error {
 	return errors.New(500, UserErrorReason_VALIDATE_ERROR.String(), fmt.Sprintf(format, args...))
 }
@@ -144,3 +144,279 @@ func IsUserLogoutFailed(err error) bool {
 func ErrorUserLogoutFailed(format string, args ...interface{}) *errors.Error {
 	return errors.New(500, UserErrorReason_USER_LOGOUT_FAILED.String(), fmt.Sprintf(format, args...))
 }
+
+func IsAccountPendingApproval(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_ACCOUNT_PENDING_APPROVAL.String() && e.Code == 500
+}
+
+func ErrorAccountPendingApproval(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_ACCOUNT_PENDING_APPROVAL.String(), fmt.Sprintf(format, args...))
+}
+
+func IsAccountRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_ACCOUNT_REJECTED.String() && e.Code == 500
+}
+
+func ErrorAccountRejected(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_ACCOUNT_REJECTED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsUserApproveFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_USER_APPROVE_FAILED.String() && e.Code == 500
+}
+
+func ErrorUserApproveFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_USER_APPROVE_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsAuditLogSearchFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_AUDIT_LOG_SEARCH_FAILED.String() && e.Code == 500
+}
+
+func ErrorAuditLogSearchFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_AUDIT_LOG_SEARCH_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsLoginHistorySearchFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_LOGIN_HISTORY_SEARCH_FAILED.String() && e.Code == 500
+}
+
+func ErrorLoginHistorySearchFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_LOGIN_HISTORY_SEARCH_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsLoginVerifyRequired(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_LOGIN_VERIFY_REQUIRED.String() && e.Code == 500
+}
+
+func ErrorLoginVerifyRequired(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_LOGIN_VERIFY_REQUIRED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsLoginVerifyFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_LOGIN_VERIFY_FAILED.String() && e.Code == 500
+}
+
+func ErrorLoginVerifyFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_LOGIN_VERIFY_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsWebhookFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_WEBHOOK_FAILED.String() && e.Code == 500
+}
+
+func ErrorWebhookFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_WEBHOOK_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsChangeLogExpired(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_CHANGE_LOG_EXPIRED.String() && e.Code == 500
+}
+
+func ErrorChangeLogExpired(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_CHANGE_LOG_EXPIRED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsOauthFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_OAUTH_FAILED.String() && e.Code == 500
+}
+
+func ErrorOauthFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_OAUTH_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsExternalLoginFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_EXTERNAL_LOGIN_FAILED.String() && e.Code == 500
+}
+
+func ErrorExternalLoginFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_EXTERNAL_LOGIN_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsIdentityUnlinkDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_IDENTITY_UNLINK_DENIED.String() && e.Code == 500
+}
+
+func ErrorIdentityUnlinkDenied(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_IDENTITY_UNLINK_DENIED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsAccountDisabled(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_ACCOUNT_DISABLED.String() && e.Code == 500
+}
+
+func ErrorAccountDisabled(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_ACCOUNT_DISABLED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsAccessTokenInvalid(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_ACCESS_TOKEN_INVALID.String() && e.Code == 500
+}
+
+func ErrorAccessTokenInvalid(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_ACCESS_TOKEN_INVALID.String(), fmt.Sprintf(format, args...))
+}
+
+func IsAccessTokenFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_ACCESS_TOKEN_FAILED.String() && e.Code == 500
+}
+
+func ErrorAccessTokenFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_ACCESS_TOKEN_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsServiceAccountFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_SERVICE_ACCOUNT_FAILED.String() && e.Code == 500
+}
+
+func ErrorServiceAccountFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_SERVICE_ACCOUNT_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsImpersonationFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_IMPERSONATION_FAILED.String() && e.Code == 500
+}
+
+func ErrorImpersonationFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_IMPERSONATION_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsMagicLinkInvalid(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_MAGIC_LINK_INVALID.String() && e.Code == 500
+}
+
+func ErrorMagicLinkInvalid(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_MAGIC_LINK_INVALID.String(), fmt.Sprintf(format, args...))
+}
+
+func IsPasskeyFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_PASSKEY_FAILED.String() && e.Code == 500
+}
+
+func ErrorPasskeyFailed(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_PASSKEY_FAILED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsPasskeyRequired(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_PASSKEY_REQUIRED.String() && e.Code == 500
+}
+
+func ErrorPasskeyRequired(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_PASSKEY_REQUIRED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsSessionUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_SESSION_UNAVAILABLE.String() && e.Code == 503
+}
+
+func ErrorSessionUnavailable(format string, args ...interface{}) *errors.Error {
+	return errors.New(503, UserErrorReason_SESSION_UNAVAILABLE.String(), fmt.Sprintf(format, args...))
+}
+
+func IsMagicLinkRateLimited(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_MAGIC_LINK_RATE_LIMITED.String() && e.Code == 429
+}
+
+func ErrorMagicLinkRateLimited(format string, args ...interface{}) *errors.Error {
+	return errors.New(429, UserErrorReason_MAGIC_LINK_RATE_LIMITED.String(), fmt.Sprintf(format, args...))
+}
+
+func IsLoginVerifyUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == UserErrorReason_LOGIN_VERIFY_UNAVAILABLE.String() && e.Code == 500
+}
+
+func ErrorLoginVerifyUnavailable(format string, args ...interface{}) *errors.Error {
+	return errors.New(500, UserErrorReason_LOGIN_VERIFY_UNAVAILABLE.String(), fmt.Sprintf(format, args...))
+}
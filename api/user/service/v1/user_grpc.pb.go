@@ -20,24 +20,159 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	UserService_UserRegister_FullMethodName   = "/user.v1.UserService/UserRegister"
-	UserService_UserLogin_FullMethodName      = "/user.v1.UserService/UserLogin"
-	UserService_SearchUsers_FullMethodName    = "/user.v1.UserService/SearchUsers"
-	UserService_DeleteUser_FullMethodName     = "/user.v1.UserService/DeleteUser"
-	UserService_GetCurrentUser_FullMethodName = "/user.v1.UserService/GetCurrentUser"
-	UserService_UserLogout_FullMethodName     = "/user.v1.UserService/UserLogout"
+	UserService_UserRegister_FullMethodName              = "/user.v1.UserService/UserRegister"
+	UserService_UserLogin_FullMethodName                 = "/user.v1.UserService/UserLogin"
+	UserService_SearchUsers_FullMethodName               = "/user.v1.UserService/SearchUsers"
+	UserService_DeleteUser_FullMethodName                = "/user.v1.UserService/DeleteUser"
+	UserService_GetCurrentUser_FullMethodName            = "/user.v1.UserService/GetCurrentUser"
+	UserService_UserLogout_FullMethodName                = "/user.v1.UserService/UserLogout"
+	UserService_ListPendingUsers_FullMethodName          = "/user.v1.UserService/ListPendingUsers"
+	UserService_ApproveUser_FullMethodName               = "/user.v1.UserService/ApproveUser"
+	UserService_RejectUser_FullMethodName                = "/user.v1.UserService/RejectUser"
+	UserService_ListAuditLogs_FullMethodName             = "/user.v1.UserService/ListAuditLogs"
+	UserService_ListMyLoginHistory_FullMethodName        = "/user.v1.UserService/ListMyLoginHistory"
+	UserService_ListMySessions_FullMethodName            = "/user.v1.UserService/ListMySessions"
+	UserService_ListLoginHistory_FullMethodName          = "/user.v1.UserService/ListLoginHistory"
+	UserService_CreateWebhook_FullMethodName             = "/user.v1.UserService/CreateWebhook"
+	UserService_ListWebhooks_FullMethodName              = "/user.v1.UserService/ListWebhooks"
+	UserService_DeleteWebhook_FullMethodName             = "/user.v1.UserService/DeleteWebhook"
+	UserService_ListWebhookDeliveries_FullMethodName     = "/user.v1.UserService/ListWebhookDeliveries"
+	UserService_RedeliverWebhook_FullMethodName          = "/user.v1.UserService/RedeliverWebhook"
+	UserService_CreateOAuthClient_FullMethodName         = "/user.v1.UserService/CreateOAuthClient"
+	UserService_ListOAuthClients_FullMethodName          = "/user.v1.UserService/ListOAuthClients"
+	UserService_DeleteOAuthClient_FullMethodName         = "/user.v1.UserService/DeleteOAuthClient"
+	UserService_GetOAuthConsent_FullMethodName           = "/user.v1.UserService/GetOAuthConsent"
+	UserService_SubmitOAuthConsent_FullMethodName        = "/user.v1.UserService/SubmitOAuthConsent"
+	UserService_ExternalLogin_FullMethodName             = "/user.v1.UserService/ExternalLogin"
+	UserService_ExternalLoginCallback_FullMethodName     = "/user.v1.UserService/ExternalLoginCallback"
+	UserService_ListIdentities_FullMethodName            = "/user.v1.UserService/ListIdentities"
+	UserService_LinkIdentity_FullMethodName              = "/user.v1.UserService/LinkIdentity"
+	UserService_UnlinkIdentity_FullMethodName            = "/user.v1.UserService/UnlinkIdentity"
+	UserService_SAMLLogin_FullMethodName                 = "/user.v1.UserService/SAMLLogin"
+	UserService_SAMLAssertionConsumer_FullMethodName     = "/user.v1.UserService/SAMLAssertionConsumer"
+	UserService_CreateAccessToken_FullMethodName         = "/user.v1.UserService/CreateAccessToken"
+	UserService_ListAccessTokens_FullMethodName          = "/user.v1.UserService/ListAccessTokens"
+	UserService_RevokeAccessToken_FullMethodName         = "/user.v1.UserService/RevokeAccessToken"
+	UserService_ListUserAccessTokens_FullMethodName      = "/user.v1.UserService/ListUserAccessTokens"
+	UserService_CreateServiceAccount_FullMethodName      = "/user.v1.UserService/CreateServiceAccount"
+	UserService_ListServiceAccounts_FullMethodName       = "/user.v1.UserService/ListServiceAccounts"
+	UserService_UpdateServiceAccount_FullMethodName      = "/user.v1.UserService/UpdateServiceAccount"
+	UserService_CreateServiceAccountToken_FullMethodName = "/user.v1.UserService/CreateServiceAccountToken"
+	UserService_StartImpersonation_FullMethodName        = "/user.v1.UserService/StartImpersonation"
+	UserService_StopImpersonation_FullMethodName         = "/user.v1.UserService/StopImpersonation"
+	UserService_RequestMagicLink_FullMethodName          = "/user.v1.UserService/RequestMagicLink"
+	UserService_MagicLinkLogin_FullMethodName            = "/user.v1.UserService/MagicLinkLogin"
+	UserService_BeginPasskeyRegistration_FullMethodName  = "/user.v1.UserService/BeginPasskeyRegistration"
+	UserService_FinishPasskeyRegistration_FullMethodName = "/user.v1.UserService/FinishPasskeyRegistration"
+	UserService_ListPasskeys_FullMethodName              = "/user.v1.UserService/ListPasskeys"
+	UserService_DeletePasskey_FullMethodName             = "/user.v1.UserService/DeletePasskey"
+	UserService_BeginPasskeyLogin_FullMethodName         = "/user.v1.UserService/BeginPasskeyLogin"
+	UserService_FinishPasskeyLogin_FullMethodName        = "/user.v1.UserService/FinishPasskeyLogin"
+	UserService_WatchUsers_FullMethodName                = "/user.v1.UserService/WatchUsers"
 )
 
 // UserServiceClient is the client API for UserService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserServiceClient interface {
+	//用户注册
 	UserRegister(ctx context.Context, in *UserRegisterReq, opts ...grpc.CallOption) (*UserRegisterReply, error)
+	//用户登录
 	UserLogin(ctx context.Context, in *UserLoginReq, opts ...grpc.CallOption) (*UserLoginReply, error)
+	//用户搜索
 	SearchUsers(ctx context.Context, in *SearchUsersReq, opts ...grpc.CallOption) (*SearchUsersReply, error)
+	//用户删除
 	DeleteUser(ctx context.Context, in *DeleteUserReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//获取当前登录用户信息
 	GetCurrentUser(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCurrentReply, error)
+	//用户退出
 	UserLogout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//待审核用户列表
+	ListPendingUsers(ctx context.Context, in *ListPendingUsersReq, opts ...grpc.CallOption) (*ListPendingUsersReply, error)
+	//用户审核通过
+	ApproveUser(ctx context.Context, in *ApproveUserReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//用户审核拒绝
+	RejectUser(ctx context.Context, in *RejectUserReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//审计日志查询
+	ListAuditLogs(ctx context.Context, in *ListAuditLogsReq, opts ...grpc.CallOption) (*ListAuditLogsReply, error)
+	//当前用户登录历史
+	ListMyLoginHistory(ctx context.Context, in *ListMyLoginHistoryReq, opts ...grpc.CallOption) (*ListLoginHistoryReply, error)
+	//当前用户的登录会话
+	ListMySessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionsReply, error)
+	//用户登录历史查询
+	ListLoginHistory(ctx context.Context, in *ListLoginHistoryReq, opts ...grpc.CallOption) (*ListLoginHistoryReply, error)
+	//新增webhook订阅
+	CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*CreateWebhookReply, error)
+	//webhook订阅列表
+	ListWebhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWebhooksReply, error)
+	//删除webhook订阅
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//webhook投递记录
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesReq, opts ...grpc.CallOption) (*ListWebhookDeliveriesReply, error)
+	//webhook重新投递
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookReq, opts ...grpc.CallOption) (*RedeliverWebhookReply, error)
+	//新增OAuth应用
+	CreateOAuthClient(ctx context.Context, in *CreateOAuthClientReq, opts ...grpc.CallOption) (*CreateOAuthClientReply, error)
+	//OAuth应用列表
+	ListOAuthClients(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOAuthClientsReply, error)
+	//删除OAuth应用
+	DeleteOAuthClient(ctx context.Context, in *DeleteOAuthClientReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//授权页查询授权信息
+	GetOAuthConsent(ctx context.Context, in *OAuthConsentReq, opts ...grpc.CallOption) (*GetOAuthConsentReply, error)
+	//用户确认或拒绝授权
+	SubmitOAuthConsent(ctx context.Context, in *SubmitOAuthConsentReq, opts ...grpc.CallOption) (*SubmitOAuthConsentReply, error)
+	//第三方登录，返回第三方授权地址
+	ExternalLogin(ctx context.Context, in *ExternalLoginReq, opts ...grpc.CallOption) (*ExternalLoginReply, error)
+	//第三方登录回调，登录或绑定成功后返回用户信息
+	ExternalLoginCallback(ctx context.Context, in *ExternalLoginCallbackReq, opts ...grpc.CallOption) (*UserLoginReply, error)
+	//当前用户绑定的第三方账号列表
+	ListIdentities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListIdentitiesReply, error)
+	//绑定第三方账号，返回第三方授权地址
+	LinkIdentity(ctx context.Context, in *ExternalLoginReq, opts ...grpc.CallOption) (*ExternalLoginReply, error)
+	//解绑第三方账号
+	UnlinkIdentity(ctx context.Context, in *UnlinkIdentityReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//SAML登录，返回IdP登录地址
+	SAMLLogin(ctx context.Context, in *ExternalLoginReq, opts ...grpc.CallOption) (*ExternalLoginReply, error)
+	//SAML断言消费服务（ACS），IdP通过浏览器POST断言，登录成功后返回用户信息
+	SAMLAssertionConsumer(ctx context.Context, in *SAMLAssertionReq, opts ...grpc.CallOption) (*UserLoginReply, error)
+	//创建个人访问令牌，令牌只在创建时返回
+	CreateAccessToken(ctx context.Context, in *CreateAccessTokenReq, opts ...grpc.CallOption) (*CreateAccessTokenReply, error)
+	//当前用户的个人访问令牌列表
+	ListAccessTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAccessTokensReply, error)
+	//吊销个人访问令牌，管理员可吊销任意用户的令牌
+	RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//个人访问令牌列表（管理员）
+	ListUserAccessTokens(ctx context.Context, in *ListUserAccessTokensReq, opts ...grpc.CallOption) (*ListUserAccessTokensReply, error)
+	//创建服务账号
+	CreateServiceAccount(ctx context.Context, in *CreateServiceAccountReq, opts ...grpc.CallOption) (*ServiceAccountReply, error)
+	//服务账号列表
+	ListServiceAccounts(ctx context.Context, in *ListServiceAccountsReq, opts ...grpc.CallOption) (*ListServiceAccountsReply, error)
+	//修改服务账号
+	UpdateServiceAccount(ctx context.Context, in *UpdateServiceAccountReq, opts ...grpc.CallOption) (*ServiceAccountReply, error)
+	//创建服务账号API Key
+	CreateServiceAccountToken(ctx context.Context, in *CreateServiceAccountTokenReq, opts ...grpc.CallOption) (*CreateAccessTokenReply, error)
+	//管理员模拟用户登录
+	StartImpersonation(ctx context.Context, in *StartImpersonationReq, opts ...grpc.CallOption) (*StartImpersonationReply, error)
+	//结束模拟登录
+	StopImpersonation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//发送免密登录链接
+	RequestMagicLink(ctx context.Context, in *RequestMagicLinkReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//免密登录链接登录
+	MagicLinkLogin(ctx context.Context, in *MagicLinkLoginReq, opts ...grpc.CallOption) (*UserLoginReply, error)
+	//开始注册通行密钥
+	BeginPasskeyRegistration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PasskeyChallengeReply, error)
+	//完成注册通行密钥
+	FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationReq, opts ...grpc.CallOption) (*PasskeyReply, error)
+	//通行密钥列表
+	ListPasskeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPasskeysReply, error)
+	//删除通行密钥
+	DeletePasskey(ctx context.Context, in *DeletePasskeyReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	//开始通行密钥登录
+	BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginReq, opts ...grpc.CallOption) (*PasskeyChallengeReply, error)
+	//完成通行密钥登录
+	FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginReq, opts ...grpc.CallOption) (*UserLoginReply, error)
+	//订阅用户变更，仅支持grpc
+	WatchUsers(ctx context.Context, in *WatchUsersReq, opts ...grpc.CallOption) (UserService_WatchUsersClient, error)
 }
 
 type userServiceClient struct {
@@ -102,162 +237,1570 @@ func (c *userServiceClient) UserLogout(ctx context.Context, in *emptypb.Empty, o
 	return out, nil
 }
 
+func (c *userServiceClient) ListPendingUsers(ctx context.Context, in *ListPendingUsersReq, opts ...grpc.CallOption) (*ListPendingUsersReply, error) {
+	out := new(ListPendingUsersReply)
+	err := c.cc.Invoke(ctx, UserService_ListPendingUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ApproveUser(ctx context.Context, in *ApproveUserReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_ApproveUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RejectUser(ctx context.Context, in *RejectUserReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RejectUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListAuditLogs(ctx context.Context, in *ListAuditLogsReq, opts ...grpc.CallOption) (*ListAuditLogsReply, error) {
+	out := new(ListAuditLogsReply)
+	err := c.cc.Invoke(ctx, UserService_ListAuditLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListMyLoginHistory(ctx context.Context, in *ListMyLoginHistoryReq, opts ...grpc.CallOption) (*ListLoginHistoryReply, error) {
+	out := new(ListLoginHistoryReply)
+	err := c.cc.Invoke(ctx, UserService_ListMyLoginHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListMySessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionsReply, error) {
+	out := new(ListSessionsReply)
+	err := c.cc.Invoke(ctx, UserService_ListMySessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListLoginHistory(ctx context.Context, in *ListLoginHistoryReq, opts ...grpc.CallOption) (*ListLoginHistoryReply, error) {
+	out := new(ListLoginHistoryReply)
+	err := c.cc.Invoke(ctx, UserService_ListLoginHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*CreateWebhookReply, error) {
+	out := new(CreateWebhookReply)
+	err := c.cc.Invoke(ctx, UserService_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListWebhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWebhooksReply, error) {
+	out := new(ListWebhooksReply)
+	err := c.cc.Invoke(ctx, UserService_ListWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesReq, opts ...grpc.CallOption) (*ListWebhookDeliveriesReply, error) {
+	out := new(ListWebhookDeliveriesReply)
+	err := c.cc.Invoke(ctx, UserService_ListWebhookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookReq, opts ...grpc.CallOption) (*RedeliverWebhookReply, error) {
+	out := new(RedeliverWebhookReply)
+	err := c.cc.Invoke(ctx, UserService_RedeliverWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateOAuthClient(ctx context.Context, in *CreateOAuthClientReq, opts ...grpc.CallOption) (*CreateOAuthClientReply, error) {
+	out := new(CreateOAuthClientReply)
+	err := c.cc.Invoke(ctx, UserService_CreateOAuthClient_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListOAuthClients(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOAuthClientsReply, error) {
+	out := new(ListOAuthClientsReply)
+	err := c.cc.Invoke(ctx, UserService_ListOAuthClients_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteOAuthClient(ctx context.Context, in *DeleteOAuthClientReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_DeleteOAuthClient_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetOAuthConsent(ctx context.Context, in *OAuthConsentReq, opts ...grpc.CallOption) (*GetOAuthConsentReply, error) {
+	out := new(GetOAuthConsentReply)
+	err := c.cc.Invoke(ctx, UserService_GetOAuthConsent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SubmitOAuthConsent(ctx context.Context, in *SubmitOAuthConsentReq, opts ...grpc.CallOption) (*SubmitOAuthConsentReply, error) {
+	out := new(SubmitOAuthConsentReply)
+	err := c.cc.Invoke(ctx, UserService_SubmitOAuthConsent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExternalLogin(ctx context.Context, in *ExternalLoginReq, opts ...grpc.CallOption) (*ExternalLoginReply, error) {
+	out := new(ExternalLoginReply)
+	err := c.cc.Invoke(ctx, UserService_ExternalLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExternalLoginCallback(ctx context.Context, in *ExternalLoginCallbackReq, opts ...grpc.CallOption) (*UserLoginReply, error) {
+	out := new(UserLoginReply)
+	err := c.cc.Invoke(ctx, UserService_ExternalLoginCallback_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListIdentities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListIdentitiesReply, error) {
+	out := new(ListIdentitiesReply)
+	err := c.cc.Invoke(ctx, UserService_ListIdentities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) LinkIdentity(ctx context.Context, in *ExternalLoginReq, opts ...grpc.CallOption) (*ExternalLoginReply, error) {
+	out := new(ExternalLoginReply)
+	err := c.cc.Invoke(ctx, UserService_LinkIdentity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnlinkIdentity(ctx context.Context, in *UnlinkIdentityReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_UnlinkIdentity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SAMLLogin(ctx context.Context, in *ExternalLoginReq, opts ...grpc.CallOption) (*ExternalLoginReply, error) {
+	out := new(ExternalLoginReply)
+	err := c.cc.Invoke(ctx, UserService_SAMLLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SAMLAssertionConsumer(ctx context.Context, in *SAMLAssertionReq, opts ...grpc.CallOption) (*UserLoginReply, error) {
+	out := new(UserLoginReply)
+	err := c.cc.Invoke(ctx, UserService_SAMLAssertionConsumer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateAccessToken(ctx context.Context, in *CreateAccessTokenReq, opts ...grpc.CallOption) (*CreateAccessTokenReply, error) {
+	out := new(CreateAccessTokenReply)
+	err := c.cc.Invoke(ctx, UserService_CreateAccessToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListAccessTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAccessTokensReply, error) {
+	out := new(ListAccessTokensReply)
+	err := c.cc.Invoke(ctx, UserService_ListAccessTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RevokeAccessToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUserAccessTokens(ctx context.Context, in *ListUserAccessTokensReq, opts ...grpc.CallOption) (*ListUserAccessTokensReply, error) {
+	out := new(ListUserAccessTokensReply)
+	err := c.cc.Invoke(ctx, UserService_ListUserAccessTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateServiceAccount(ctx context.Context, in *CreateServiceAccountReq, opts ...grpc.CallOption) (*ServiceAccountReply, error) {
+	out := new(ServiceAccountReply)
+	err := c.cc.Invoke(ctx, UserService_CreateServiceAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListServiceAccounts(ctx context.Context, in *ListServiceAccountsReq, opts ...grpc.CallOption) (*ListServiceAccountsReply, error) {
+	out := new(ListServiceAccountsReply)
+	err := c.cc.Invoke(ctx, UserService_ListServiceAccounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateServiceAccount(ctx context.Context, in *UpdateServiceAccountReq, opts ...grpc.CallOption) (*ServiceAccountReply, error) {
+	out := new(ServiceAccountReply)
+	err := c.cc.Invoke(ctx, UserService_UpdateServiceAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateServiceAccountToken(ctx context.Context, in *CreateServiceAccountTokenReq, opts ...grpc.CallOption) (*CreateAccessTokenReply, error) {
+	out := new(CreateAccessTokenReply)
+	err := c.cc.Invoke(ctx, UserService_CreateServiceAccountToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) StartImpersonation(ctx context.Context, in *StartImpersonationReq, opts ...grpc.CallOption) (*StartImpersonationReply, error) {
+	out := new(StartImpersonationReply)
+	err := c.cc.Invoke(ctx, UserService_StartImpersonation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) StopImpersonation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_StopImpersonation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequestMagicLink(ctx context.Context, in *RequestMagicLinkReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RequestMagicLink_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) MagicLinkLogin(ctx context.Context, in *MagicLinkLoginReq, opts ...grpc.CallOption) (*UserLoginReply, error) {
+	out := new(UserLoginReply)
+	err := c.cc.Invoke(ctx, UserService_MagicLinkLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BeginPasskeyRegistration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PasskeyChallengeReply, error) {
+	out := new(PasskeyChallengeReply)
+	err := c.cc.Invoke(ctx, UserService_BeginPasskeyRegistration_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationReq, opts ...grpc.CallOption) (*PasskeyReply, error) {
+	out := new(PasskeyReply)
+	err := c.cc.Invoke(ctx, UserService_FinishPasskeyRegistration_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListPasskeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPasskeysReply, error) {
+	out := new(ListPasskeysReply)
+	err := c.cc.Invoke(ctx, UserService_ListPasskeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeletePasskey(ctx context.Context, in *DeletePasskeyReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_DeletePasskey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginReq, opts ...grpc.CallOption) (*PasskeyChallengeReply, error) {
+	out := new(PasskeyChallengeReply)
+	err := c.cc.Invoke(ctx, UserService_BeginPasskeyLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginReq, opts ...grpc.CallOption) (*UserLoginReply, error) {
+	out := new(UserLoginReply)
+	err := c.cc.Invoke(ctx, UserService_FinishPasskeyLogin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) WatchUsers(ctx context.Context, in *WatchUsersReq, opts ...grpc.CallOption) (UserService_WatchUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_WatchUsers_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceWatchUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_WatchUsersClient interface {
+	Recv() (*UserChangeEvent, error)
+	grpc.ClientStream
+}
+
+type userServiceWatchUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceWatchUsersClient) Recv() (*UserChangeEvent, error) {
+	m := new(UserChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
 type UserServiceServer interface {
+	//用户注册
 	UserRegister(context.Context, *UserRegisterReq) (*UserRegisterReply, error)
+	//用户登录
 	UserLogin(context.Context, *UserLoginReq) (*UserLoginReply, error)
+	//用户搜索
 	SearchUsers(context.Context, *SearchUsersReq) (*SearchUsersReply, error)
+	//用户删除
 	DeleteUser(context.Context, *DeleteUserReq) (*emptypb.Empty, error)
+	//获取当前登录用户信息
 	GetCurrentUser(context.Context, *emptypb.Empty) (*GetCurrentReply, error)
+	//用户退出
 	UserLogout(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	//待审核用户列表
+	ListPendingUsers(context.Context, *ListPendingUsersReq) (*ListPendingUsersReply, error)
+	//用户审核通过
+	ApproveUser(context.Context, *ApproveUserReq) (*emptypb.Empty, error)
+	//用户审核拒绝
+	RejectUser(context.Context, *RejectUserReq) (*emptypb.Empty, error)
+	//审计日志查询
+	ListAuditLogs(context.Context, *ListAuditLogsReq) (*ListAuditLogsReply, error)
+	//当前用户登录历史
+	ListMyLoginHistory(context.Context, *ListMyLoginHistoryReq) (*ListLoginHistoryReply, error)
+	//当前用户的登录会话
+	ListMySessions(context.Context, *emptypb.Empty) (*ListSessionsReply, error)
+	//用户登录历史查询
+	ListLoginHistory(context.Context, *ListLoginHistoryReq) (*ListLoginHistoryReply, error)
+	//新增webhook订阅
+	CreateWebhook(context.Context, *CreateWebhookReq) (*CreateWebhookReply, error)
+	//webhook订阅列表
+	ListWebhooks(context.Context, *emptypb.Empty) (*ListWebhooksReply, error)
+	//删除webhook订阅
+	DeleteWebhook(context.Context, *DeleteWebhookReq) (*emptypb.Empty, error)
+	//webhook投递记录
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesReq) (*ListWebhookDeliveriesReply, error)
+	//webhook重新投递
+	RedeliverWebhook(context.Context, *RedeliverWebhookReq) (*RedeliverWebhookReply, error)
+	//新增OAuth应用
+	CreateOAuthClient(context.Context, *CreateOAuthClientReq) (*CreateOAuthClientReply, error)
+	//OAuth应用列表
+	ListOAuthClients(context.Context, *emptypb.Empty) (*ListOAuthClientsReply, error)
+	//删除OAuth应用
+	DeleteOAuthClient(context.Context, *DeleteOAuthClientReq) (*emptypb.Empty, error)
+	//授权页查询授权信息
+	GetOAuthConsent(context.Context, *OAuthConsentReq) (*GetOAuthConsentReply, error)
+	//用户确认或拒绝授权
+	SubmitOAuthConsent(context.Context, *SubmitOAuthConsentReq) (*SubmitOAuthConsentReply, error)
+	//第三方登录，返回第三方授权地址
+	ExternalLogin(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error)
+	//第三方登录回调，登录或绑定成功后返回用户信息
+	ExternalLoginCallback(context.Context, *ExternalLoginCallbackReq) (*UserLoginReply, error)
+	//当前用户绑定的第三方账号列表
+	ListIdentities(context.Context, *emptypb.Empty) (*ListIdentitiesReply, error)
+	//绑定第三方账号，返回第三方授权地址
+	LinkIdentity(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error)
+	//解绑第三方账号
+	UnlinkIdentity(context.Context, *UnlinkIdentityReq) (*emptypb.Empty, error)
+	//SAML登录，返回IdP登录地址
+	SAMLLogin(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error)
+	//SAML断言消费服务（ACS），IdP通过浏览器POST断言，登录成功后返回用户信息
+	SAMLAssertionConsumer(context.Context, *SAMLAssertionReq) (*UserLoginReply, error)
+	//创建个人访问令牌，令牌只在创建时返回
+	CreateAccessToken(context.Context, *CreateAccessTokenReq) (*CreateAccessTokenReply, error)
+	//当前用户的个人访问令牌列表
+	ListAccessTokens(context.Context, *emptypb.Empty) (*ListAccessTokensReply, error)
+	//吊销个人访问令牌，管理员可吊销任意用户的令牌
+	RevokeAccessToken(context.Context, *RevokeAccessTokenReq) (*emptypb.Empty, error)
+	//个人访问令牌列表（管理员）
+	ListUserAccessTokens(context.Context, *ListUserAccessTokensReq) (*ListUserAccessTokensReply, error)
+	//创建服务账号
+	CreateServiceAccount(context.Context, *CreateServiceAccountReq) (*ServiceAccountReply, error)
+	//服务账号列表
+	ListServiceAccounts(context.Context, *ListServiceAccountsReq) (*ListServiceAccountsReply, error)
+	//修改服务账号
+	UpdateServiceAccount(context.Context, *UpdateServiceAccountReq) (*ServiceAccountReply, error)
+	//创建服务账号API Key
+	CreateServiceAccountToken(context.Context, *CreateServiceAccountTokenReq) (*CreateAccessTokenReply, error)
+	//管理员模拟用户登录
+	StartImpersonation(context.Context, *StartImpersonationReq) (*StartImpersonationReply, error)
+	//结束模拟登录
+	StopImpersonation(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	//发送免密登录链接
+	RequestMagicLink(context.Context, *RequestMagicLinkReq) (*emptypb.Empty, error)
+	//免密登录链接登录
+	MagicLinkLogin(context.Context, *MagicLinkLoginReq) (*UserLoginReply, error)
+	//开始注册通行密钥
+	BeginPasskeyRegistration(context.Context, *emptypb.Empty) (*PasskeyChallengeReply, error)
+	//完成注册通行密钥
+	FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationReq) (*PasskeyReply, error)
+	//通行密钥列表
+	ListPasskeys(context.Context, *emptypb.Empty) (*ListPasskeysReply, error)
+	//删除通行密钥
+	DeletePasskey(context.Context, *DeletePasskeyReq) (*emptypb.Empty, error)
+	//开始通行密钥登录
+	BeginPasskeyLogin(context.Context, *BeginPasskeyLoginReq) (*PasskeyChallengeReply, error)
+	//完成通行密钥登录
+	FinishPasskeyLogin(context.Context, *FinishPasskeyLoginReq) (*UserLoginReply, error)
+	//订阅用户变更，仅支持grpc
+	WatchUsers(*WatchUsersReq, UserService_WatchUsersServer) error
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (UnimplementedUserServiceServer) UserRegister(context.Context, *UserRegisterReq) (*UserRegisterReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRegister not implemented")
+}
+func (UnimplementedUserServiceServer) UserLogin(context.Context, *UserLoginReq) (*UserLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserLogin not implemented")
+}
+func (UnimplementedUserServiceServer) SearchUsers(context.Context, *SearchUsersReq) (*SearchUsersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetCurrentUser(context.Context, *emptypb.Empty) (*GetCurrentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentUser not implemented")
+}
+func (UnimplementedUserServiceServer) UserLogout(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserLogout not implemented")
+}
+func (UnimplementedUserServiceServer) ListPendingUsers(context.Context, *ListPendingUsersReq) (*ListPendingUsersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingUsers not implemented")
+}
+func (UnimplementedUserServiceServer) ApproveUser(context.Context, *ApproveUserReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveUser not implemented")
+}
+func (UnimplementedUserServiceServer) RejectUser(context.Context, *RejectUserReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectUser not implemented")
+}
+func (UnimplementedUserServiceServer) ListAuditLogs(context.Context, *ListAuditLogsReq) (*ListAuditLogsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditLogs not implemented")
+}
+func (UnimplementedUserServiceServer) ListMyLoginHistory(context.Context, *ListMyLoginHistoryReq) (*ListLoginHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyLoginHistory not implemented")
+}
+func (UnimplementedUserServiceServer) ListMySessions(context.Context, *emptypb.Empty) (*ListSessionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMySessions not implemented")
+}
+func (UnimplementedUserServiceServer) ListLoginHistory(context.Context, *ListLoginHistoryReq) (*ListLoginHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLoginHistory not implemented")
+}
+func (UnimplementedUserServiceServer) CreateWebhook(context.Context, *CreateWebhookReq) (*CreateWebhookReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedUserServiceServer) ListWebhooks(context.Context, *emptypb.Empty) (*ListWebhooksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteWebhook(context.Context, *DeleteWebhookReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedUserServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesReq) (*ListWebhookDeliveriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedUserServiceServer) RedeliverWebhook(context.Context, *RedeliverWebhookReq) (*RedeliverWebhookReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
+func (UnimplementedUserServiceServer) CreateOAuthClient(context.Context, *CreateOAuthClientReq) (*CreateOAuthClientReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOAuthClient not implemented")
+}
+func (UnimplementedUserServiceServer) ListOAuthClients(context.Context, *emptypb.Empty) (*ListOAuthClientsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOAuthClients not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteOAuthClient(context.Context, *DeleteOAuthClientReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOAuthClient not implemented")
+}
+func (UnimplementedUserServiceServer) GetOAuthConsent(context.Context, *OAuthConsentReq) (*GetOAuthConsentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOAuthConsent not implemented")
+}
+func (UnimplementedUserServiceServer) SubmitOAuthConsent(context.Context, *SubmitOAuthConsentReq) (*SubmitOAuthConsentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitOAuthConsent not implemented")
+}
+func (UnimplementedUserServiceServer) ExternalLogin(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExternalLogin not implemented")
+}
+func (UnimplementedUserServiceServer) ExternalLoginCallback(context.Context, *ExternalLoginCallbackReq) (*UserLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExternalLoginCallback not implemented")
+}
+func (UnimplementedUserServiceServer) ListIdentities(context.Context, *emptypb.Empty) (*ListIdentitiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIdentities not implemented")
+}
+func (UnimplementedUserServiceServer) LinkIdentity(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkIdentity not implemented")
+}
+func (UnimplementedUserServiceServer) UnlinkIdentity(context.Context, *UnlinkIdentityReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkIdentity not implemented")
+}
+func (UnimplementedUserServiceServer) SAMLLogin(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SAMLLogin not implemented")
+}
+func (UnimplementedUserServiceServer) SAMLAssertionConsumer(context.Context, *SAMLAssertionReq) (*UserLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SAMLAssertionConsumer not implemented")
+}
+func (UnimplementedUserServiceServer) CreateAccessToken(context.Context, *CreateAccessTokenReq) (*CreateAccessTokenReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessToken not implemented")
+}
+func (UnimplementedUserServiceServer) ListAccessTokens(context.Context, *emptypb.Empty) (*ListAccessTokensReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessTokens not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeAccessToken(context.Context, *RevokeAccessTokenReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAccessToken not implemented")
+}
+func (UnimplementedUserServiceServer) ListUserAccessTokens(context.Context, *ListUserAccessTokensReq) (*ListUserAccessTokensReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserAccessTokens not implemented")
+}
+func (UnimplementedUserServiceServer) CreateServiceAccount(context.Context, *CreateServiceAccountReq) (*ServiceAccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceAccount not implemented")
+}
+func (UnimplementedUserServiceServer) ListServiceAccounts(context.Context, *ListServiceAccountsReq) (*ListServiceAccountsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceAccounts not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateServiceAccount(context.Context, *UpdateServiceAccountReq) (*ServiceAccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServiceAccount not implemented")
+}
+func (UnimplementedUserServiceServer) CreateServiceAccountToken(context.Context, *CreateServiceAccountTokenReq) (*CreateAccessTokenReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceAccountToken not implemented")
+}
+func (UnimplementedUserServiceServer) StartImpersonation(context.Context, *StartImpersonationReq) (*StartImpersonationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartImpersonation not implemented")
+}
+func (UnimplementedUserServiceServer) StopImpersonation(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopImpersonation not implemented")
+}
+func (UnimplementedUserServiceServer) RequestMagicLink(context.Context, *RequestMagicLinkReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestMagicLink not implemented")
+}
+func (UnimplementedUserServiceServer) MagicLinkLogin(context.Context, *MagicLinkLoginReq) (*UserLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MagicLinkLogin not implemented")
+}
+func (UnimplementedUserServiceServer) BeginPasskeyRegistration(context.Context, *emptypb.Empty) (*PasskeyChallengeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyRegistration not implemented")
+}
+func (UnimplementedUserServiceServer) FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationReq) (*PasskeyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyRegistration not implemented")
+}
+func (UnimplementedUserServiceServer) ListPasskeys(context.Context, *emptypb.Empty) (*ListPasskeysReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPasskeys not implemented")
+}
+func (UnimplementedUserServiceServer) DeletePasskey(context.Context, *DeletePasskeyReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePasskey not implemented")
+}
+func (UnimplementedUserServiceServer) BeginPasskeyLogin(context.Context, *BeginPasskeyLoginReq) (*PasskeyChallengeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyLogin not implemented")
+}
+func (UnimplementedUserServiceServer) FinishPasskeyLogin(context.Context, *FinishPasskeyLoginReq) (*UserLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyLogin not implemented")
+}
+func (UnimplementedUserServiceServer) WatchUsers(*WatchUsersReq, UserService_WatchUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUsers not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
 	mustEmbedUnimplementedUserServiceServer()
 }
 
-// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedUserServiceServer struct {
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_UserRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRegisterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserRegister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserRegister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserRegister(ctx, req.(*UserRegisterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserLoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserLogin(ctx, req.(*UserLoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SearchUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SearchUsers(ctx, req.(*SearchUsersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetCurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetCurrentUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetCurrentUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetCurrentUser(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UserLogout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UserLogout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UserLogout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UserLogout(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPendingUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingUsersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPendingUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPendingUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPendingUsers(ctx, req.(*ListPendingUsersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ApproveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ApproveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ApproveUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ApproveUser(ctx, req.(*ApproveUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RejectUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RejectUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RejectUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RejectUser(ctx, req.(*RejectUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListAuditLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListAuditLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListAuditLogs(ctx, req.(*ListAuditLogsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListMyLoginHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyLoginHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListMyLoginHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListMyLoginHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListMyLoginHistory(ctx, req.(*ListMyLoginHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListMySessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListMySessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListMySessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListMySessions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListLoginHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLoginHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListLoginHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListLoginHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListLoginHistory(ctx, req.(*ListLoginHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateWebhook(ctx, req.(*CreateWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListWebhooks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RedeliverWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RedeliverWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOAuthClientReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateOAuthClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateOAuthClient(ctx, req.(*CreateOAuthClientReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListOAuthClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListOAuthClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListOAuthClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListOAuthClients(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOAuthClientReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteOAuthClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteOAuthClient(ctx, req.(*DeleteOAuthClientReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetOAuthConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthConsentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetOAuthConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetOAuthConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetOAuthConsent(ctx, req.(*OAuthConsentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SubmitOAuthConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitOAuthConsentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SubmitOAuthConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SubmitOAuthConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SubmitOAuthConsent(ctx, req.(*SubmitOAuthConsentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExternalLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalLoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExternalLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExternalLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExternalLogin(ctx, req.(*ExternalLoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExternalLoginCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalLoginCallbackReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExternalLoginCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExternalLoginCallback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExternalLoginCallback(ctx, req.(*ExternalLoginCallbackReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListIdentities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListIdentities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListIdentities(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalLoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LinkIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_LinkIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LinkIdentity(ctx, req.(*ExternalLoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnlinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkIdentityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnlinkIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnlinkIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnlinkIdentity(ctx, req.(*UnlinkIdentityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SAMLLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalLoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SAMLLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SAMLLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SAMLLogin(ctx, req.(*ExternalLoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SAMLAssertionConsumer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SAMLAssertionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SAMLAssertionConsumer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SAMLAssertionConsumer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SAMLAssertionConsumer(ctx, req.(*SAMLAssertionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateAccessToken(ctx, req.(*CreateAccessTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListAccessTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListAccessTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListAccessTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListAccessTokens(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAccessTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeAccessToken(ctx, req.(*RevokeAccessTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUserAccessTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserAccessTokensReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserAccessTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserAccessTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserAccessTokens(ctx, req.(*ListUserAccessTokensReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedUserServiceServer) UserRegister(context.Context, *UserRegisterReq) (*UserRegisterReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserRegister not implemented")
+func _UserService_CreateServiceAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceAccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateServiceAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateServiceAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateServiceAccount(ctx, req.(*CreateServiceAccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) UserLogin(context.Context, *UserLoginReq) (*UserLoginReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserLogin not implemented")
+
+func _UserService_ListServiceAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceAccountsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListServiceAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListServiceAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListServiceAccounts(ctx, req.(*ListServiceAccountsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) SearchUsers(context.Context, *SearchUsersReq) (*SearchUsersReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
+
+func _UserService_UpdateServiceAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceAccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateServiceAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateServiceAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateServiceAccount(ctx, req.(*UpdateServiceAccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserReq) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+
+func _UserService_CreateServiceAccountToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceAccountTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateServiceAccountToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateServiceAccountToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateServiceAccountToken(ctx, req.(*CreateServiceAccountTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) GetCurrentUser(context.Context, *emptypb.Empty) (*GetCurrentReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentUser not implemented")
+
+func _UserService_StartImpersonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartImpersonationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).StartImpersonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_StartImpersonation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).StartImpersonation(ctx, req.(*StartImpersonationReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) UserLogout(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserLogout not implemented")
+
+func _UserService_StopImpersonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).StopImpersonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_StopImpersonation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).StopImpersonation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
-// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to UserServiceServer will
-// result in compilation errors.
-type UnsafeUserServiceServer interface {
-	mustEmbedUnimplementedUserServiceServer()
+func _UserService_RequestMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestMagicLinkReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestMagicLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestMagicLink(ctx, req.(*RequestMagicLinkReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
-	s.RegisterService(&UserService_ServiceDesc, srv)
+func _UserService_MagicLinkLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MagicLinkLoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).MagicLinkLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_MagicLinkLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).MagicLinkLogin(ctx, req.(*MagicLinkLoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UserRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRegisterReq)
+func _UserService_BeginPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UserRegister(ctx, in)
+		return srv.(UserServiceServer).BeginPasskeyRegistration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UserRegister_FullMethodName,
+		FullMethod: UserService_BeginPasskeyRegistration_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UserRegister(ctx, req.(*UserRegisterReq))
+		return srv.(UserServiceServer).BeginPasskeyRegistration(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UserLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserLoginReq)
+func _UserService_FinishPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyRegistrationReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UserLogin(ctx, in)
+		return srv.(UserServiceServer).FinishPasskeyRegistration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UserLogin_FullMethodName,
+		FullMethod: UserService_FinishPasskeyRegistration_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UserLogin(ctx, req.(*UserLoginReq))
+		return srv.(UserServiceServer).FinishPasskeyRegistration(ctx, req.(*FinishPasskeyRegistrationReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchUsersReq)
+func _UserService_ListPasskeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).SearchUsers(ctx, in)
+		return srv.(UserServiceServer).ListPasskeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_SearchUsers_FullMethodName,
+		FullMethod: UserService_ListPasskeys_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).SearchUsers(ctx, req.(*SearchUsersReq))
+		return srv.(UserServiceServer).ListPasskeys(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteUserReq)
+func _UserService_DeletePasskey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePasskeyReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).DeleteUser(ctx, in)
+		return srv.(UserServiceServer).DeletePasskey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_DeleteUser_FullMethodName,
+		FullMethod: UserService_DeletePasskey_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserReq))
+		return srv.(UserServiceServer).DeletePasskey(ctx, req.(*DeletePasskeyReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetCurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _UserService_BeginPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginPasskeyLoginReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetCurrentUser(ctx, in)
+		return srv.(UserServiceServer).BeginPasskeyLogin(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetCurrentUser_FullMethodName,
+		FullMethod: UserService_BeginPasskeyLogin_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetCurrentUser(ctx, req.(*emptypb.Empty))
+		return srv.(UserServiceServer).BeginPasskeyLogin(ctx, req.(*BeginPasskeyLoginReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UserLogout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _UserService_FinishPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyLoginReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UserLogout(ctx, in)
+		return srv.(UserServiceServer).FinishPasskeyLogin(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UserLogout_FullMethodName,
+		FullMethod: UserService_FinishPasskeyLogin_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UserLogout(ctx, req.(*emptypb.Empty))
+		return srv.(UserServiceServer).FinishPasskeyLogin(ctx, req.(*FinishPasskeyLoginReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_WatchUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUsersReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).WatchUsers(m, &userServiceWatchUsersServer{stream})
+}
+
+type UserService_WatchUsersServer interface {
+	Send(*UserChangeEvent) error
+	grpc.ServerStream
+}
+
+type userServiceWatchUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceWatchUsersServer) Send(m *UserChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,7 +1832,181 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UserLogout",
 			Handler:    _UserService_UserLogout_Handler,
 		},
+		{
+			MethodName: "ListPendingUsers",
+			Handler:    _UserService_ListPendingUsers_Handler,
+		},
+		{
+			MethodName: "ApproveUser",
+			Handler:    _UserService_ApproveUser_Handler,
+		},
+		{
+			MethodName: "RejectUser",
+			Handler:    _UserService_RejectUser_Handler,
+		},
+		{
+			MethodName: "ListAuditLogs",
+			Handler:    _UserService_ListAuditLogs_Handler,
+		},
+		{
+			MethodName: "ListMyLoginHistory",
+			Handler:    _UserService_ListMyLoginHistory_Handler,
+		},
+		{
+			MethodName: "ListMySessions",
+			Handler:    _UserService_ListMySessions_Handler,
+		},
+		{
+			MethodName: "ListLoginHistory",
+			Handler:    _UserService_ListLoginHistory_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _UserService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _UserService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _UserService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _UserService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _UserService_RedeliverWebhook_Handler,
+		},
+		{
+			MethodName: "CreateOAuthClient",
+			Handler:    _UserService_CreateOAuthClient_Handler,
+		},
+		{
+			MethodName: "ListOAuthClients",
+			Handler:    _UserService_ListOAuthClients_Handler,
+		},
+		{
+			MethodName: "DeleteOAuthClient",
+			Handler:    _UserService_DeleteOAuthClient_Handler,
+		},
+		{
+			MethodName: "GetOAuthConsent",
+			Handler:    _UserService_GetOAuthConsent_Handler,
+		},
+		{
+			MethodName: "SubmitOAuthConsent",
+			Handler:    _UserService_SubmitOAuthConsent_Handler,
+		},
+		{
+			MethodName: "ExternalLogin",
+			Handler:    _UserService_ExternalLogin_Handler,
+		},
+		{
+			MethodName: "ExternalLoginCallback",
+			Handler:    _UserService_ExternalLoginCallback_Handler,
+		},
+		{
+			MethodName: "ListIdentities",
+			Handler:    _UserService_ListIdentities_Handler,
+		},
+		{
+			MethodName: "LinkIdentity",
+			Handler:    _UserService_LinkIdentity_Handler,
+		},
+		{
+			MethodName: "UnlinkIdentity",
+			Handler:    _UserService_UnlinkIdentity_Handler,
+		},
+		{
+			MethodName: "SAMLLogin",
+			Handler:    _UserService_SAMLLogin_Handler,
+		},
+		{
+			MethodName: "SAMLAssertionConsumer",
+			Handler:    _UserService_SAMLAssertionConsumer_Handler,
+		},
+		{
+			MethodName: "CreateAccessToken",
+			Handler:    _UserService_CreateAccessToken_Handler,
+		},
+		{
+			MethodName: "ListAccessTokens",
+			Handler:    _UserService_ListAccessTokens_Handler,
+		},
+		{
+			MethodName: "RevokeAccessToken",
+			Handler:    _UserService_RevokeAccessToken_Handler,
+		},
+		{
+			MethodName: "ListUserAccessTokens",
+			Handler:    _UserService_ListUserAccessTokens_Handler,
+		},
+		{
+			MethodName: "CreateServiceAccount",
+			Handler:    _UserService_CreateServiceAccount_Handler,
+		},
+		{
+			MethodName: "ListServiceAccounts",
+			Handler:    _UserService_ListServiceAccounts_Handler,
+		},
+		{
+			MethodName: "UpdateServiceAccount",
+			Handler:    _UserService_UpdateServiceAccount_Handler,
+		},
+		{
+			MethodName: "CreateServiceAccountToken",
+			Handler:    _UserService_CreateServiceAccountToken_Handler,
+		},
+		{
+			MethodName: "StartImpersonation",
+			Handler:    _UserService_StartImpersonation_Handler,
+		},
+		{
+			MethodName: "StopImpersonation",
+			Handler:    _UserService_StopImpersonation_Handler,
+		},
+		{
+			MethodName: "RequestMagicLink",
+			Handler:    _UserService_RequestMagicLink_Handler,
+		},
+		{
+			MethodName: "MagicLinkLogin",
+			Handler:    _UserService_MagicLinkLogin_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyRegistration",
+			Handler:    _UserService_BeginPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyRegistration",
+			Handler:    _UserService_FinishPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "ListPasskeys",
+			Handler:    _UserService_ListPasskeys_Handler,
+		},
+		{
+			MethodName: "DeletePasskey",
+			Handler:    _UserService_DeletePasskey_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyLogin",
+			Handler:    _UserService_BeginPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyLogin",
+			Handler:    _UserService_FinishPasskeyLogin_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchUsers",
+			Handler:       _UserService_WatchUsers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/service/v1/user.proto",
 }
@@ -20,17 +20,101 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationUserServiceApproveUser = "/user.v1.UserService/ApproveUser"
+const OperationUserServiceBeginPasskeyLogin = "/user.v1.UserService/BeginPasskeyLogin"
+const OperationUserServiceBeginPasskeyRegistration = "/user.v1.UserService/BeginPasskeyRegistration"
+const OperationUserServiceCreateAccessToken = "/user.v1.UserService/CreateAccessToken"
+const OperationUserServiceCreateOAuthClient = "/user.v1.UserService/CreateOAuthClient"
+const OperationUserServiceCreateServiceAccount = "/user.v1.UserService/CreateServiceAccount"
+const OperationUserServiceCreateServiceAccountToken = "/user.v1.UserService/CreateServiceAccountToken"
+const OperationUserServiceCreateWebhook = "/user.v1.UserService/CreateWebhook"
+const OperationUserServiceDeleteOAuthClient = "/user.v1.UserService/DeleteOAuthClient"
+const OperationUserServiceDeletePasskey = "/user.v1.UserService/DeletePasskey"
 const OperationUserServiceDeleteUser = "/user.v1.UserService/DeleteUser"
+const OperationUserServiceDeleteWebhook = "/user.v1.UserService/DeleteWebhook"
+const OperationUserServiceExternalLogin = "/user.v1.UserService/ExternalLogin"
+const OperationUserServiceExternalLoginCallback = "/user.v1.UserService/ExternalLoginCallback"
+const OperationUserServiceFinishPasskeyLogin = "/user.v1.UserService/FinishPasskeyLogin"
+const OperationUserServiceFinishPasskeyRegistration = "/user.v1.UserService/FinishPasskeyRegistration"
 const OperationUserServiceGetCurrentUser = "/user.v1.UserService/GetCurrentUser"
+const OperationUserServiceGetOAuthConsent = "/user.v1.UserService/GetOAuthConsent"
+const OperationUserServiceLinkIdentity = "/user.v1.UserService/LinkIdentity"
+const OperationUserServiceListAccessTokens = "/user.v1.UserService/ListAccessTokens"
+const OperationUserServiceListAuditLogs = "/user.v1.UserService/ListAuditLogs"
+const OperationUserServiceListIdentities = "/user.v1.UserService/ListIdentities"
+const OperationUserServiceListLoginHistory = "/user.v1.UserService/ListLoginHistory"
+const OperationUserServiceListMyLoginHistory = "/user.v1.UserService/ListMyLoginHistory"
+const OperationUserServiceListMySessions = "/user.v1.UserService/ListMySessions"
+const OperationUserServiceListOAuthClients = "/user.v1.UserService/ListOAuthClients"
+const OperationUserServiceListPasskeys = "/user.v1.UserService/ListPasskeys"
+const OperationUserServiceListPendingUsers = "/user.v1.UserService/ListPendingUsers"
+const OperationUserServiceListServiceAccounts = "/user.v1.UserService/ListServiceAccounts"
+const OperationUserServiceListUserAccessTokens = "/user.v1.UserService/ListUserAccessTokens"
+const OperationUserServiceListWebhookDeliveries = "/user.v1.UserService/ListWebhookDeliveries"
+const OperationUserServiceListWebhooks = "/user.v1.UserService/ListWebhooks"
+const OperationUserServiceMagicLinkLogin = "/user.v1.UserService/MagicLinkLogin"
+const OperationUserServiceRedeliverWebhook = "/user.v1.UserService/RedeliverWebhook"
+const OperationUserServiceRejectUser = "/user.v1.UserService/RejectUser"
+const OperationUserServiceRequestMagicLink = "/user.v1.UserService/RequestMagicLink"
+const OperationUserServiceRevokeAccessToken = "/user.v1.UserService/RevokeAccessToken"
+const OperationUserServiceSAMLAssertionConsumer = "/user.v1.UserService/SAMLAssertionConsumer"
+const OperationUserServiceSAMLLogin = "/user.v1.UserService/SAMLLogin"
 const OperationUserServiceSearchUsers = "/user.v1.UserService/SearchUsers"
+const OperationUserServiceStartImpersonation = "/user.v1.UserService/StartImpersonation"
+const OperationUserServiceStopImpersonation = "/user.v1.UserService/StopImpersonation"
+const OperationUserServiceSubmitOAuthConsent = "/user.v1.UserService/SubmitOAuthConsent"
+const OperationUserServiceUnlinkIdentity = "/user.v1.UserService/UnlinkIdentity"
+const OperationUserServiceUpdateServiceAccount = "/user.v1.UserService/UpdateServiceAccount"
 const OperationUserServiceUserLogin = "/user.v1.UserService/UserLogin"
 const OperationUserServiceUserLogout = "/user.v1.UserService/UserLogout"
 const OperationUserServiceUserRegister = "/user.v1.UserService/UserRegister"
 
 type UserServiceHTTPServer interface {
+	ApproveUser(context.Context, *ApproveUserReq) (*emptypb.Empty, error)
+	BeginPasskeyLogin(context.Context, *BeginPasskeyLoginReq) (*PasskeyChallengeReply, error)
+	BeginPasskeyRegistration(context.Context, *emptypb.Empty) (*PasskeyChallengeReply, error)
+	CreateAccessToken(context.Context, *CreateAccessTokenReq) (*CreateAccessTokenReply, error)
+	CreateOAuthClient(context.Context, *CreateOAuthClientReq) (*CreateOAuthClientReply, error)
+	CreateServiceAccount(context.Context, *CreateServiceAccountReq) (*ServiceAccountReply, error)
+	CreateServiceAccountToken(context.Context, *CreateServiceAccountTokenReq) (*CreateAccessTokenReply, error)
+	CreateWebhook(context.Context, *CreateWebhookReq) (*CreateWebhookReply, error)
+	DeleteOAuthClient(context.Context, *DeleteOAuthClientReq) (*emptypb.Empty, error)
+	DeletePasskey(context.Context, *DeletePasskeyReq) (*emptypb.Empty, error)
 	DeleteUser(context.Context, *DeleteUserReq) (*emptypb.Empty, error)
+	DeleteWebhook(context.Context, *DeleteWebhookReq) (*emptypb.Empty, error)
+	ExternalLogin(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error)
+	ExternalLoginCallback(context.Context, *ExternalLoginCallbackReq) (*UserLoginReply, error)
+	FinishPasskeyLogin(context.Context, *FinishPasskeyLoginReq) (*UserLoginReply, error)
+	FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationReq) (*PasskeyReply, error)
 	GetCurrentUser(context.Context, *emptypb.Empty) (*GetCurrentReply, error)
+	GetOAuthConsent(context.Context, *OAuthConsentReq) (*GetOAuthConsentReply, error)
+	LinkIdentity(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error)
+	ListAccessTokens(context.Context, *emptypb.Empty) (*ListAccessTokensReply, error)
+	ListAuditLogs(context.Context, *ListAuditLogsReq) (*ListAuditLogsReply, error)
+	ListIdentities(context.Context, *emptypb.Empty) (*ListIdentitiesReply, error)
+	ListLoginHistory(context.Context, *ListLoginHistoryReq) (*ListLoginHistoryReply, error)
+	ListMyLoginHistory(context.Context, *ListMyLoginHistoryReq) (*ListLoginHistoryReply, error)
+	ListMySessions(context.Context, *emptypb.Empty) (*ListSessionsReply, error)
+	ListOAuthClients(context.Context, *emptypb.Empty) (*ListOAuthClientsReply, error)
+	ListPasskeys(context.Context, *emptypb.Empty) (*ListPasskeysReply, error)
+	ListPendingUsers(context.Context, *ListPendingUsersReq) (*ListPendingUsersReply, error)
+	ListServiceAccounts(context.Context, *ListServiceAccountsReq) (*ListServiceAccountsReply, error)
+	ListUserAccessTokens(context.Context, *ListUserAccessTokensReq) (*ListUserAccessTokensReply, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesReq) (*ListWebhookDeliveriesReply, error)
+	ListWebhooks(context.Context, *emptypb.Empty) (*ListWebhooksReply, error)
+	MagicLinkLogin(context.Context, *MagicLinkLoginReq) (*UserLoginReply, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookReq) (*RedeliverWebhookReply, error)
+	RejectUser(context.Context, *RejectUserReq) (*emptypb.Empty, error)
+	RequestMagicLink(context.Context, *RequestMagicLinkReq) (*emptypb.Empty, error)
+	RevokeAccessToken(context.Context, *RevokeAccessTokenReq) (*emptypb.Empty, error)
+	SAMLAssertionConsumer(context.Context, *SAMLAssertionReq) (*UserLoginReply, error)
+	SAMLLogin(context.Context, *ExternalLoginReq) (*ExternalLoginReply, error)
 	SearchUsers(context.Context, *SearchUsersReq) (*SearchUsersReply, error)
+	StartImpersonation(context.Context, *StartImpersonationReq) (*StartImpersonationReply, error)
+	StopImpersonation(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	SubmitOAuthConsent(context.Context, *SubmitOAuthConsentReq) (*SubmitOAuthConsentReply, error)
+	UnlinkIdentity(context.Context, *UnlinkIdentityReq) (*emptypb.Empty, error)
+	UpdateServiceAccount(context.Context, *UpdateServiceAccountReq) (*ServiceAccountReply, error)
 	UserLogin(context.Context, *UserLoginReq) (*UserLoginReply, error)
 	UserLogout(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	UserRegister(context.Context, *UserRegisterReq) (*UserRegisterReply, error)
@@ -44,6 +128,48 @@ func RegisterUserServiceHTTPServer(s *http.Server, srv UserServiceHTTPServer) {
 	r.POST("api/user/delete", _UserService_DeleteUser0_HTTP_Handler(srv))
 	r.GET("api/user/current", _UserService_GetCurrentUser0_HTTP_Handler(srv))
 	r.POST("api/user/logout", _UserService_UserLogout0_HTTP_Handler(srv))
+	r.POST("api/user/pending/list", _UserService_ListPendingUsers0_HTTP_Handler(srv))
+	r.POST("api/user/approve", _UserService_ApproveUser0_HTTP_Handler(srv))
+	r.POST("api/user/reject", _UserService_RejectUser0_HTTP_Handler(srv))
+	r.POST("api/audit/list", _UserService_ListAuditLogs0_HTTP_Handler(srv))
+	r.POST("api/user/login/history", _UserService_ListMyLoginHistory0_HTTP_Handler(srv))
+	r.GET("api/user/session/list", _UserService_ListMySessions0_HTTP_Handler(srv))
+	r.POST("api/user/login/history/list", _UserService_ListLoginHistory0_HTTP_Handler(srv))
+	r.POST("api/webhook/create", _UserService_CreateWebhook0_HTTP_Handler(srv))
+	r.GET("api/webhook/list", _UserService_ListWebhooks0_HTTP_Handler(srv))
+	r.POST("api/webhook/delete", _UserService_DeleteWebhook0_HTTP_Handler(srv))
+	r.POST("api/webhook/delivery/list", _UserService_ListWebhookDeliveries0_HTTP_Handler(srv))
+	r.POST("api/webhook/delivery/redeliver", _UserService_RedeliverWebhook0_HTTP_Handler(srv))
+	r.POST("api/oauth/client/create", _UserService_CreateOAuthClient0_HTTP_Handler(srv))
+	r.GET("api/oauth/client/list", _UserService_ListOAuthClients0_HTTP_Handler(srv))
+	r.POST("api/oauth/client/delete", _UserService_DeleteOAuthClient0_HTTP_Handler(srv))
+	r.POST("api/oauth/consent", _UserService_GetOAuthConsent0_HTTP_Handler(srv))
+	r.POST("api/oauth/consent/submit", _UserService_SubmitOAuthConsent0_HTTP_Handler(srv))
+	r.POST("api/user/external/login", _UserService_ExternalLogin0_HTTP_Handler(srv))
+	r.GET("api/user/external/{provider}/callback", _UserService_ExternalLoginCallback0_HTTP_Handler(srv))
+	r.GET("api/user/identity/list", _UserService_ListIdentities0_HTTP_Handler(srv))
+	r.POST("api/user/identity/link", _UserService_LinkIdentity0_HTTP_Handler(srv))
+	r.POST("api/user/identity/unlink", _UserService_UnlinkIdentity0_HTTP_Handler(srv))
+	r.POST("api/user/saml/login", _UserService_SAMLLogin0_HTTP_Handler(srv))
+	r.POST("api/user/saml/{provider}/acs", _UserService_SAMLAssertionConsumer0_HTTP_Handler(srv))
+	r.POST("api/user/token/create", _UserService_CreateAccessToken0_HTTP_Handler(srv))
+	r.GET("api/user/token/list", _UserService_ListAccessTokens0_HTTP_Handler(srv))
+	r.POST("api/user/token/revoke", _UserService_RevokeAccessToken0_HTTP_Handler(srv))
+	r.POST("api/user/token/admin/list", _UserService_ListUserAccessTokens0_HTTP_Handler(srv))
+	r.POST("api/service/account/create", _UserService_CreateServiceAccount0_HTTP_Handler(srv))
+	r.POST("api/service/account/list", _UserService_ListServiceAccounts0_HTTP_Handler(srv))
+	r.POST("api/service/account/update", _UserService_UpdateServiceAccount0_HTTP_Handler(srv))
+	r.POST("api/service/account/token/create", _UserService_CreateServiceAccountToken0_HTTP_Handler(srv))
+	r.POST("api/user/impersonate/start", _UserService_StartImpersonation0_HTTP_Handler(srv))
+	r.POST("api/user/impersonate/stop", _UserService_StopImpersonation0_HTTP_Handler(srv))
+	r.POST("api/user/login/magic/request", _UserService_RequestMagicLink0_HTTP_Handler(srv))
+	r.POST("api/user/login/magic", _UserService_MagicLinkLogin0_HTTP_Handler(srv))
+	r.POST("api/user/passkey/register/begin", _UserService_BeginPasskeyRegistration0_HTTP_Handler(srv))
+	r.POST("api/user/passkey/register/finish", _UserService_FinishPasskeyRegistration0_HTTP_Handler(srv))
+	r.GET("api/user/passkey/list", _UserService_ListPasskeys0_HTTP_Handler(srv))
+	r.POST("api/user/passkey/delete", _UserService_DeletePasskey0_HTTP_Handler(srv))
+	r.POST("api/user/passkey/login/begin", _UserService_BeginPasskeyLogin0_HTTP_Handler(srv))
+	r.POST("api/user/passkey/login/finish", _UserService_FinishPasskeyLogin0_HTTP_Handler(srv))
 }
 
 func _UserService_UserRegister0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
@@ -160,10 +286,856 @@ func _UserService_UserLogout0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx h
 	}
 }
 
+func _UserService_ListPendingUsers0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListPendingUsersReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListPendingUsers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListPendingUsers(ctx, req.(*ListPendingUsersReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListPendingUsersReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ApproveUser0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ApproveUserReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceApproveUser)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ApproveUser(ctx, req.(*ApproveUserReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_RejectUser0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RejectUserReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceRejectUser)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RejectUser(ctx, req.(*RejectUserReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListAuditLogs0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListAuditLogsReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListAuditLogs)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListAuditLogs(ctx, req.(*ListAuditLogsReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListAuditLogsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListMyLoginHistory0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListMyLoginHistoryReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListMyLoginHistory)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListMyLoginHistory(ctx, req.(*ListMyLoginHistoryReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListLoginHistoryReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListMySessions0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListMySessions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListMySessions(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListSessionsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListLoginHistory0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListLoginHistoryReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListLoginHistory)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListLoginHistory(ctx, req.(*ListLoginHistoryReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListLoginHistoryReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_CreateWebhook0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateWebhookReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceCreateWebhook)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateWebhook(ctx, req.(*CreateWebhookReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateWebhookReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListWebhooks0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListWebhooks)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListWebhooks(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListWebhooksReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_DeleteWebhook0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteWebhookReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceDeleteWebhook)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteWebhook(ctx, req.(*DeleteWebhookReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListWebhookDeliveries0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListWebhookDeliveriesReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListWebhookDeliveries)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListWebhookDeliveriesReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_RedeliverWebhook0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RedeliverWebhookReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceRedeliverWebhook)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RedeliverWebhook(ctx, req.(*RedeliverWebhookReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RedeliverWebhookReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_CreateOAuthClient0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateOAuthClientReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceCreateOAuthClient)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateOAuthClient(ctx, req.(*CreateOAuthClientReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateOAuthClientReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListOAuthClients0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListOAuthClients)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListOAuthClients(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListOAuthClientsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_DeleteOAuthClient0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteOAuthClientReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceDeleteOAuthClient)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteOAuthClient(ctx, req.(*DeleteOAuthClientReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_GetOAuthConsent0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in OAuthConsentReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceGetOAuthConsent)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetOAuthConsent(ctx, req.(*OAuthConsentReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetOAuthConsentReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_SubmitOAuthConsent0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SubmitOAuthConsentReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceSubmitOAuthConsent)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SubmitOAuthConsent(ctx, req.(*SubmitOAuthConsentReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SubmitOAuthConsentReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ExternalLogin0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ExternalLoginReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceExternalLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ExternalLogin(ctx, req.(*ExternalLoginReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ExternalLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ExternalLoginCallback0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ExternalLoginCallbackReq
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceExternalLoginCallback)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ExternalLoginCallback(ctx, req.(*ExternalLoginCallbackReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListIdentities0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListIdentities)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListIdentities(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListIdentitiesReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_LinkIdentity0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ExternalLoginReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceLinkIdentity)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.LinkIdentity(ctx, req.(*ExternalLoginReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ExternalLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_UnlinkIdentity0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UnlinkIdentityReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceUnlinkIdentity)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UnlinkIdentity(ctx, req.(*UnlinkIdentityReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_SAMLLogin0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ExternalLoginReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceSAMLLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SAMLLogin(ctx, req.(*ExternalLoginReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ExternalLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_SAMLAssertionConsumer0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SAMLAssertionReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceSAMLAssertionConsumer)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SAMLAssertionConsumer(ctx, req.(*SAMLAssertionReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_CreateAccessToken0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateAccessTokenReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceCreateAccessToken)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateAccessToken(ctx, req.(*CreateAccessTokenReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateAccessTokenReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListAccessTokens0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListAccessTokens)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListAccessTokens(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListAccessTokensReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_RevokeAccessToken0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RevokeAccessTokenReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceRevokeAccessToken)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RevokeAccessToken(ctx, req.(*RevokeAccessTokenReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListUserAccessTokens0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListUserAccessTokensReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListUserAccessTokens)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListUserAccessTokens(ctx, req.(*ListUserAccessTokensReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListUserAccessTokensReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_CreateServiceAccount0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateServiceAccountReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceCreateServiceAccount)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateServiceAccount(ctx, req.(*CreateServiceAccountReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ServiceAccountReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListServiceAccounts0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListServiceAccountsReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListServiceAccounts)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListServiceAccounts(ctx, req.(*ListServiceAccountsReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListServiceAccountsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_UpdateServiceAccount0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UpdateServiceAccountReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceUpdateServiceAccount)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateServiceAccount(ctx, req.(*UpdateServiceAccountReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ServiceAccountReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_CreateServiceAccountToken0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateServiceAccountTokenReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceCreateServiceAccountToken)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateServiceAccountToken(ctx, req.(*CreateServiceAccountTokenReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateAccessTokenReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_StartImpersonation0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in StartImpersonationReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceStartImpersonation)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.StartImpersonation(ctx, req.(*StartImpersonationReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*StartImpersonationReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_StopImpersonation0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceStopImpersonation)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.StopImpersonation(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_RequestMagicLink0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RequestMagicLinkReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceRequestMagicLink)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RequestMagicLink(ctx, req.(*RequestMagicLinkReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_MagicLinkLogin0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in MagicLinkLoginReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceMagicLinkLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.MagicLinkLogin(ctx, req.(*MagicLinkLoginReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_BeginPasskeyRegistration0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceBeginPasskeyRegistration)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BeginPasskeyRegistration(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PasskeyChallengeReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_FinishPasskeyRegistration0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in FinishPasskeyRegistrationReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceFinishPasskeyRegistration)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.FinishPasskeyRegistration(ctx, req.(*FinishPasskeyRegistrationReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PasskeyReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListPasskeys0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in emptypb.Empty
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListPasskeys)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListPasskeys(ctx, req.(*emptypb.Empty))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListPasskeysReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_DeletePasskey0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeletePasskeyReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceDeletePasskey)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeletePasskey(ctx, req.(*DeletePasskeyReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_BeginPasskeyLogin0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BeginPasskeyLoginReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceBeginPasskeyLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BeginPasskeyLogin(ctx, req.(*BeginPasskeyLoginReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PasskeyChallengeReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_FinishPasskeyLogin0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in FinishPasskeyLoginReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceFinishPasskeyLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.FinishPasskeyLogin(ctx, req.(*FinishPasskeyLoginReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type UserServiceHTTPClient interface {
+	ApproveUser(ctx context.Context, req *ApproveUserReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	BeginPasskeyLogin(ctx context.Context, req *BeginPasskeyLoginReq, opts ...http.CallOption) (rsp *PasskeyChallengeReply, err error)
+	BeginPasskeyRegistration(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *PasskeyChallengeReply, err error)
+	CreateAccessToken(ctx context.Context, req *CreateAccessTokenReq, opts ...http.CallOption) (rsp *CreateAccessTokenReply, err error)
+	CreateOAuthClient(ctx context.Context, req *CreateOAuthClientReq, opts ...http.CallOption) (rsp *CreateOAuthClientReply, err error)
+	CreateServiceAccount(ctx context.Context, req *CreateServiceAccountReq, opts ...http.CallOption) (rsp *ServiceAccountReply, err error)
+	CreateServiceAccountToken(ctx context.Context, req *CreateServiceAccountTokenReq, opts ...http.CallOption) (rsp *CreateAccessTokenReply, err error)
+	CreateWebhook(ctx context.Context, req *CreateWebhookReq, opts ...http.CallOption) (rsp *CreateWebhookReply, err error)
+	DeleteOAuthClient(ctx context.Context, req *DeleteOAuthClientReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	DeletePasskey(ctx context.Context, req *DeletePasskeyReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
 	DeleteUser(ctx context.Context, req *DeleteUserReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	DeleteWebhook(ctx context.Context, req *DeleteWebhookReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	ExternalLogin(ctx context.Context, req *ExternalLoginReq, opts ...http.CallOption) (rsp *ExternalLoginReply, err error)
+	ExternalLoginCallback(ctx context.Context, req *ExternalLoginCallbackReq, opts ...http.CallOption) (rsp *UserLoginReply, err error)
+	FinishPasskeyLogin(ctx context.Context, req *FinishPasskeyLoginReq, opts ...http.CallOption) (rsp *UserLoginReply, err error)
+	FinishPasskeyRegistration(ctx context.Context, req *FinishPasskeyRegistrationReq, opts ...http.CallOption) (rsp *PasskeyReply, err error)
 	GetCurrentUser(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *GetCurrentReply, err error)
+	GetOAuthConsent(ctx context.Context, req *OAuthConsentReq, opts ...http.CallOption) (rsp *GetOAuthConsentReply, err error)
+	LinkIdentity(ctx context.Context, req *ExternalLoginReq, opts ...http.CallOption) (rsp *ExternalLoginReply, err error)
+	ListAccessTokens(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *ListAccessTokensReply, err error)
+	ListAuditLogs(ctx context.Context, req *ListAuditLogsReq, opts ...http.CallOption) (rsp *ListAuditLogsReply, err error)
+	ListIdentities(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *ListIdentitiesReply, err error)
+	ListLoginHistory(ctx context.Context, req *ListLoginHistoryReq, opts ...http.CallOption) (rsp *ListLoginHistoryReply, err error)
+	ListMyLoginHistory(ctx context.Context, req *ListMyLoginHistoryReq, opts ...http.CallOption) (rsp *ListLoginHistoryReply, err error)
+	ListMySessions(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *ListSessionsReply, err error)
+	ListOAuthClients(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *ListOAuthClientsReply, err error)
+	ListPasskeys(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *ListPasskeysReply, err error)
+	ListPendingUsers(ctx context.Context, req *ListPendingUsersReq, opts ...http.CallOption) (rsp *ListPendingUsersReply, err error)
+	ListServiceAccounts(ctx context.Context, req *ListServiceAccountsReq, opts ...http.CallOption) (rsp *ListServiceAccountsReply, err error)
+	ListUserAccessTokens(ctx context.Context, req *ListUserAccessTokensReq, opts ...http.CallOption) (rsp *ListUserAccessTokensReply, err error)
+	ListWebhookDeliveries(ctx context.Context, req *ListWebhookDeliveriesReq, opts ...http.CallOption) (rsp *ListWebhookDeliveriesReply, err error)
+	ListWebhooks(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *ListWebhooksReply, err error)
+	MagicLinkLogin(ctx context.Context, req *MagicLinkLoginReq, opts ...http.CallOption) (rsp *UserLoginReply, err error)
+	RedeliverWebhook(ctx context.Context, req *RedeliverWebhookReq, opts ...http.CallOption) (rsp *RedeliverWebhookReply, err error)
+	RejectUser(ctx context.Context, req *RejectUserReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	RequestMagicLink(ctx context.Context, req *RequestMagicLinkReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	RevokeAccessToken(ctx context.Context, req *RevokeAccessTokenReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	SAMLAssertionConsumer(ctx context.Context, req *SAMLAssertionReq, opts ...http.CallOption) (rsp *UserLoginReply, err error)
+	SAMLLogin(ctx context.Context, req *ExternalLoginReq, opts ...http.CallOption) (rsp *ExternalLoginReply, err error)
 	SearchUsers(ctx context.Context, req *SearchUsersReq, opts ...http.CallOption) (rsp *SearchUsersReply, err error)
+	StartImpersonation(ctx context.Context, req *StartImpersonationReq, opts ...http.CallOption) (rsp *StartImpersonationReply, err error)
+	StopImpersonation(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	SubmitOAuthConsent(ctx context.Context, req *SubmitOAuthConsentReq, opts ...http.CallOption) (rsp *SubmitOAuthConsentReply, err error)
+	UnlinkIdentity(ctx context.Context, req *UnlinkIdentityReq, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	UpdateServiceAccount(ctx context.Context, req *UpdateServiceAccountReq, opts ...http.CallOption) (rsp *ServiceAccountReply, err error)
 	UserLogin(ctx context.Context, req *UserLoginReq, opts ...http.CallOption) (rsp *UserLoginReply, err error)
 	UserLogout(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
 	UserRegister(ctx context.Context, req *UserRegisterReq, opts ...http.CallOption) (rsp *UserRegisterReply, err error)
@@ -177,11 +1149,206 @@ func NewUserServiceHTTPClient(client *http.Client) UserServiceHTTPClient {
 	return &UserServiceHTTPClientImpl{client}
 }
 
-func (c *UserServiceHTTPClientImpl) DeleteUser(ctx context.Context, in *DeleteUserReq, opts ...http.CallOption) (*emptypb.Empty, error) {
-	var out emptypb.Empty
-	pattern := "api/user/delete"
+func (c *UserServiceHTTPClientImpl) ApproveUser(ctx context.Context, in *ApproveUserReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/approve"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceApproveUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginReq, opts ...http.CallOption) (*PasskeyChallengeReply, error) {
+	var out PasskeyChallengeReply
+	pattern := "api/user/passkey/login/begin"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceBeginPasskeyLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) BeginPasskeyRegistration(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*PasskeyChallengeReply, error) {
+	var out PasskeyChallengeReply
+	pattern := "api/user/passkey/register/begin"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceBeginPasskeyRegistration))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) CreateAccessToken(ctx context.Context, in *CreateAccessTokenReq, opts ...http.CallOption) (*CreateAccessTokenReply, error) {
+	var out CreateAccessTokenReply
+	pattern := "api/user/token/create"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceCreateAccessToken))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) CreateOAuthClient(ctx context.Context, in *CreateOAuthClientReq, opts ...http.CallOption) (*CreateOAuthClientReply, error) {
+	var out CreateOAuthClientReply
+	pattern := "api/oauth/client/create"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceCreateOAuthClient))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) CreateServiceAccount(ctx context.Context, in *CreateServiceAccountReq, opts ...http.CallOption) (*ServiceAccountReply, error) {
+	var out ServiceAccountReply
+	pattern := "api/service/account/create"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceCreateServiceAccount))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) CreateServiceAccountToken(ctx context.Context, in *CreateServiceAccountTokenReq, opts ...http.CallOption) (*CreateAccessTokenReply, error) {
+	var out CreateAccessTokenReply
+	pattern := "api/service/account/token/create"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceCreateServiceAccountToken))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...http.CallOption) (*CreateWebhookReply, error) {
+	var out CreateWebhookReply
+	pattern := "api/webhook/create"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceCreateWebhook))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) DeleteOAuthClient(ctx context.Context, in *DeleteOAuthClientReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/oauth/client/delete"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceDeleteOAuthClient))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) DeletePasskey(ctx context.Context, in *DeletePasskeyReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/passkey/delete"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceDeletePasskey))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) DeleteUser(ctx context.Context, in *DeleteUserReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/delete"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceDeleteUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) DeleteWebhook(ctx context.Context, in *DeleteWebhookReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/webhook/delete"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceDeleteWebhook))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ExternalLogin(ctx context.Context, in *ExternalLoginReq, opts ...http.CallOption) (*ExternalLoginReply, error) {
+	var out ExternalLoginReply
+	pattern := "api/user/external/login"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceExternalLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ExternalLoginCallback(ctx context.Context, in *ExternalLoginCallbackReq, opts ...http.CallOption) (*UserLoginReply, error) {
+	var out UserLoginReply
+	pattern := "api/user/external/{provider}/callback"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceExternalLoginCallback))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginReq, opts ...http.CallOption) (*UserLoginReply, error) {
+	var out UserLoginReply
+	pattern := "api/user/passkey/login/finish"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceFinishPasskeyLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationReq, opts ...http.CallOption) (*PasskeyReply, error) {
+	var out PasskeyReply
+	pattern := "api/user/passkey/register/finish"
 	path := binding.EncodeURL(pattern, in, false)
-	opts = append(opts, http.Operation(OperationUserServiceDeleteUser))
+	opts = append(opts, http.Operation(OperationUserServiceFinishPasskeyRegistration))
 	opts = append(opts, http.PathTemplate(pattern))
 	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
 	if err != nil {
@@ -203,6 +1370,292 @@ func (c *UserServiceHTTPClientImpl) GetCurrentUser(ctx context.Context, in *empt
 	return &out, err
 }
 
+func (c *UserServiceHTTPClientImpl) GetOAuthConsent(ctx context.Context, in *OAuthConsentReq, opts ...http.CallOption) (*GetOAuthConsentReply, error) {
+	var out GetOAuthConsentReply
+	pattern := "api/oauth/consent"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceGetOAuthConsent))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) LinkIdentity(ctx context.Context, in *ExternalLoginReq, opts ...http.CallOption) (*ExternalLoginReply, error) {
+	var out ExternalLoginReply
+	pattern := "api/user/identity/link"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceLinkIdentity))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListAccessTokens(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*ListAccessTokensReply, error) {
+	var out ListAccessTokensReply
+	pattern := "api/user/token/list"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListAccessTokens))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListAuditLogs(ctx context.Context, in *ListAuditLogsReq, opts ...http.CallOption) (*ListAuditLogsReply, error) {
+	var out ListAuditLogsReply
+	pattern := "api/audit/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceListAuditLogs))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListIdentities(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*ListIdentitiesReply, error) {
+	var out ListIdentitiesReply
+	pattern := "api/user/identity/list"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListIdentities))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListLoginHistory(ctx context.Context, in *ListLoginHistoryReq, opts ...http.CallOption) (*ListLoginHistoryReply, error) {
+	var out ListLoginHistoryReply
+	pattern := "api/user/login/history/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceListLoginHistory))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListMyLoginHistory(ctx context.Context, in *ListMyLoginHistoryReq, opts ...http.CallOption) (*ListLoginHistoryReply, error) {
+	var out ListLoginHistoryReply
+	pattern := "api/user/login/history"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceListMyLoginHistory))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListMySessions(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*ListSessionsReply, error) {
+	var out ListSessionsReply
+	pattern := "api/user/session/list"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListMySessions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListOAuthClients(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*ListOAuthClientsReply, error) {
+	var out ListOAuthClientsReply
+	pattern := "api/oauth/client/list"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListOAuthClients))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListPasskeys(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*ListPasskeysReply, error) {
+	var out ListPasskeysReply
+	pattern := "api/user/passkey/list"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListPasskeys))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListPendingUsers(ctx context.Context, in *ListPendingUsersReq, opts ...http.CallOption) (*ListPendingUsersReply, error) {
+	var out ListPendingUsersReply
+	pattern := "api/user/pending/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceListPendingUsers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListServiceAccounts(ctx context.Context, in *ListServiceAccountsReq, opts ...http.CallOption) (*ListServiceAccountsReply, error) {
+	var out ListServiceAccountsReply
+	pattern := "api/service/account/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceListServiceAccounts))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListUserAccessTokens(ctx context.Context, in *ListUserAccessTokensReq, opts ...http.CallOption) (*ListUserAccessTokensReply, error) {
+	var out ListUserAccessTokensReply
+	pattern := "api/user/token/admin/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceListUserAccessTokens))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesReq, opts ...http.CallOption) (*ListWebhookDeliveriesReply, error) {
+	var out ListWebhookDeliveriesReply
+	pattern := "api/webhook/delivery/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceListWebhookDeliveries))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) ListWebhooks(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*ListWebhooksReply, error) {
+	var out ListWebhooksReply
+	pattern := "api/webhook/list"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListWebhooks))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) MagicLinkLogin(ctx context.Context, in *MagicLinkLoginReq, opts ...http.CallOption) (*UserLoginReply, error) {
+	var out UserLoginReply
+	pattern := "api/user/login/magic"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceMagicLinkLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookReq, opts ...http.CallOption) (*RedeliverWebhookReply, error) {
+	var out RedeliverWebhookReply
+	pattern := "api/webhook/delivery/redeliver"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceRedeliverWebhook))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) RejectUser(ctx context.Context, in *RejectUserReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/reject"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceRejectUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) RequestMagicLink(ctx context.Context, in *RequestMagicLinkReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/login/magic/request"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceRequestMagicLink))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/token/revoke"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceRevokeAccessToken))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) SAMLAssertionConsumer(ctx context.Context, in *SAMLAssertionReq, opts ...http.CallOption) (*UserLoginReply, error) {
+	var out UserLoginReply
+	pattern := "api/user/saml/{provider}/acs"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceSAMLAssertionConsumer))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) SAMLLogin(ctx context.Context, in *ExternalLoginReq, opts ...http.CallOption) (*ExternalLoginReply, error) {
+	var out ExternalLoginReply
+	pattern := "api/user/saml/login"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceSAMLLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
 func (c *UserServiceHTTPClientImpl) SearchUsers(ctx context.Context, in *SearchUsersReq, opts ...http.CallOption) (*SearchUsersReply, error) {
 	var out SearchUsersReply
 	pattern := "api/user/search"
@@ -216,6 +1669,71 @@ func (c *UserServiceHTTPClientImpl) SearchUsers(ctx context.Context, in *SearchU
 	return &out, err
 }
 
+func (c *UserServiceHTTPClientImpl) StartImpersonation(ctx context.Context, in *StartImpersonationReq, opts ...http.CallOption) (*StartImpersonationReply, error) {
+	var out StartImpersonationReply
+	pattern := "api/user/impersonate/start"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceStartImpersonation))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) StopImpersonation(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/impersonate/stop"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceStopImpersonation))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) SubmitOAuthConsent(ctx context.Context, in *SubmitOAuthConsentReq, opts ...http.CallOption) (*SubmitOAuthConsentReply, error) {
+	var out SubmitOAuthConsentReply
+	pattern := "api/oauth/consent/submit"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceSubmitOAuthConsent))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) UnlinkIdentity(ctx context.Context, in *UnlinkIdentityReq, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "api/user/identity/unlink"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceUnlinkIdentity))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *UserServiceHTTPClientImpl) UpdateServiceAccount(ctx context.Context, in *UpdateServiceAccountReq, opts ...http.CallOption) (*ServiceAccountReply, error) {
+	var out ServiceAccountReply
+	pattern := "api/service/account/update"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceUpdateServiceAccount))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
 func (c *UserServiceHTTPClientImpl) UserLogin(ctx context.Context, in *UserLoginReq, opts ...http.CallOption) (*UserLoginReply, error) {
 	var out UserLoginReply
 	pattern := "api/user/login"
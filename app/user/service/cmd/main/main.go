@@ -2,10 +2,12 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"github.com/go-kratos/kratos/v2"
 	"os"
 
 	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"github.com/user-center/user-center-backend/app/user/service/internal/server"
 
 	"github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/config/file"
@@ -31,7 +33,7 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, js *server.JobServer) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -41,6 +43,7 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 		kratos.Server(
 			gs,
 			hs,
+			js,
 		),
 	)
 }
@@ -72,7 +75,15 @@ func main() {
 		panic(err)
 	}
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Constant, logger)
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(&bc, logger, flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Constant, bc.Oauth, bc.Ldap, bc.Saml, bc.Webauthn, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"github.com/user-center/user-center-backend/app/user/service/internal/data"
+	"gorm.io/gorm"
+	"os"
+	"text/tabwriter"
+)
+
+const migrateUsage = `usage: main -conf <config> migrate <command>
+
+commands:
+  up               执行所有未执行的迁移
+  status           查看迁移执行状态
+  create <name>    在 -dir 目录下每种数据库的迁移目录中创建下一个版本的迁移文件
+`
+
+// runMigrate 数据库迁移子命令
+func runMigrate(bc *conf.Config, logger log.Logger, args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := fs.String("dir", "../../internal/data/migrations", "migration source dir for create, eg: -dir ../../internal/data/migrations")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), migrateUsage)
+		fs.PrintDefaults()
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("migrate command required")
+	}
+
+	if fs.Arg(0) == "create" {
+		if fs.NArg() != 2 {
+			fs.Usage()
+			return errors.New("migration name required")
+		}
+		files, err := data.CreateMigration(*dir, fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			fmt.Printf("created %s\n", file)
+		}
+		return nil
+	}
+
+	db, cleanup := data.NewDB(bc.Data)
+	defer cleanup()
+	// 初始管理员密码在迁移提交后输出，迁移失败回滚时不输出
+	var adminPassword string
+	hooks := map[int64]data.MigrationHook{
+		1: func(ctx context.Context, tx *gorm.DB) error {
+			var err error
+			adminPassword, err = data.SeedAdminPassword(ctx, tx)
+			return err
+		},
+	}
+	migrator, err := data.NewMigrator(db, hooks, logger)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	switch fs.Arg(0) {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%v migration(s) applied\n", len(applied))
+		if adminPassword != "" {
+			fmt.Printf("initial admin password: %s\nplease change it after first login, it will not be shown again\n", adminPassword)
+		}
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, status := range statuses {
+			applied := "pending"
+			if !status.AppliedTime.IsZero() {
+				applied = status.AppliedTime.Format("2006-01-02 15:04:05")
+			}
+			if status.AppliedTime.IsZero() && status.Executed > 0 {
+				applied = fmt.Sprintf("partial (%v/%v statements)", status.Executed, status.Statements)
+			}
+			if status.Modified {
+				applied += " (modified)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Migration.Version, status.Migration.Name, applied)
+		}
+		return w.Flush()
+	}
+	fs.Usage()
+	return errors.Errorf("unknown migrate command: %s", fs.Arg(0))
+}
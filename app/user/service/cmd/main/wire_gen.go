@@ -19,25 +19,101 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, userConstant *conf.UserConstant, logger log.Logger) (*kratos.App, func(), error) {
-	db := data.NewDB(confData)
-	cmdable := data.NewRedis(confData)
-	dataData, cleanup, err := data.NewData(db, cmdable, logger, userConstant)
+func wireApp(confServer *conf.Server, confData *conf.Data, userConstant *conf.UserConstant, oAuth *conf.OAuth, ldap *conf.LDAP, saml *conf.SAML, webAuthn *conf.WebAuthn, logger log.Logger) (*kratos.App, func(), error) {
+	db, cleanup := data.NewDB(confData)
+	replicas, cleanup2 := data.NewReplicas(confData, logger)
+	redisBreaker := data.NewRedisBreaker(confData, logger)
+	universalClient, cleanup3 := data.NewRedis(confData, redisBreaker)
+	dataData, cleanup4, err := data.NewData(db, replicas, universalClient, redisBreaker, logger, userConstant, confData)
 	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
 		return nil, nil, err
 	}
 	userRepo := data.NewUserRepo(dataData, logger)
 	recovery := data.NewRecovery(dataData)
 	transaction := data.NewTransaction(dataData)
-	userUseCase := biz.NewUserUseCase(userRepo, recovery, transaction, logger, userConstant)
+	mailer := data.NewMailer(confData, logger)
+	auditRepo := data.NewAuditRepo(dataData, logger)
+	auditRecorder := biz.NewAuditRecorder(auditRepo, logger)
+	loginHistoryRepo := data.NewLoginHistoryRepo(dataData, logger)
+	loginHistoryUseCase := biz.NewLoginHistoryUseCase(loginHistoryRepo, logger, userConstant)
+	outboxRepo := data.NewOutboxRepo(dataData, logger)
+	userChangeRepo := data.NewUserChangeRepo(dataData, logger)
+	webhookRepo := data.NewWebhookRepo(dataData, logger)
+	webhookSender := data.NewWebhookSender()
+	webhookUseCase := biz.NewWebhookUseCase(webhookRepo, userRepo, webhookSender, auditRecorder, logger, userConstant)
+	eventUseCase := biz.NewEventUseCase(outboxRepo, userChangeRepo, webhookUseCase, logger)
+	userUseCase := biz.NewUserUseCase(userRepo, recovery, transaction, logger, userConstant, mailer, auditRecorder, loginHistoryUseCase, eventUseCase)
 	authRepo := data.NewAuthRepo(dataData, logger)
-	authRepoUseCase := biz.NewAuthRepoUseCase(authRepo, recovery, transaction, logger)
+	geoLocator, err := data.NewGeoLocator(confData, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	loginRiskRules := biz.NewLoginRiskRules(geoLocator, userConstant, logger)
+	loginRiskDetector := biz.NewLoginRiskDetector(loginHistoryRepo, loginRiskRules, mailer, logger)
+	directory := data.NewDirectory(ldap, logger)
+	identityRepo := data.NewIdentityRepo(dataData, logger)
+	ldapAuthenticator := biz.NewLDAPAuthenticator(directory, identityRepo, authRepo, userRepo, transaction, eventUseCase, auditRecorder, ldap, userConstant, logger)
+	authenticators := biz.NewAuthenticators(authRepo, ldapAuthenticator, userConstant, logger)
+	passkeyRepo := data.NewPasskeyRepo(dataData, logger)
+	authRepoUseCase := biz.NewAuthRepoUseCase(authRepo, userRepo, recovery, transaction, logger, userConstant, auditRecorder, loginHistoryUseCase, loginRiskDetector, mailer, eventUseCase, authenticators, passkeyRepo, identityRepo, webAuthn)
 	validateUseCase := biz.NewValidateUseCase()
-	userService := service.NewUserService(userUseCase, authRepoUseCase, validateUseCase, logger)
+	userChangeFeed := biz.NewUserChangeFeed(userChangeRepo, userRepo, userConstant, logger)
+	oAuthRepo := data.NewOAuthRepo(dataData, logger)
+	tokenSigner, err := data.NewTokenSigner(oAuth, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	oAuthUseCase := biz.NewOAuthUseCase(oAuthRepo, userRepo, authRepo, tokenSigner, transaction, oAuth, userConstant, auditRecorder, logger)
+	externalProviders := data.NewExternalProviders(oAuth, logger)
+	identityUseCase := biz.NewIdentityUseCase(identityRepo, externalProviders, authRepo, userRepo, authRepoUseCase, transaction, userConstant, auditRecorder, loginHistoryUseCase, eventUseCase, logger)
+	samlProviders, err := data.NewSAMLProviders(saml, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	samlUseCase := biz.NewSAMLUseCase(samlProviders, identityRepo, identityUseCase, saml, logger)
+	groupRepo := data.NewGroupRepo(dataData, logger)
+	scimUseCase := biz.NewScimUseCase(userRepo, authRepo, groupRepo, transaction, eventUseCase, auditRecorder, userConstant, logger)
+	accessTokenRepo := data.NewAccessTokenRepo(dataData, logger)
+	accessTokenUseCase := biz.NewAccessTokenUseCase(accessTokenRepo, userRepo, auditRecorder, userConstant, logger)
+	serviceAccountUseCase := biz.NewServiceAccountUseCase(userRepo, authRepo, accessTokenRepo, transaction, eventUseCase, auditRecorder, userConstant, logger)
+	impersonationUseCase := biz.NewImpersonationUseCase(authRepo, userRepo, auditRecorder, userConstant, logger)
+	passkeyRelyingParty, err := data.NewPasskeyRelyingParty(webAuthn, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	passkeyUseCase := biz.NewPasskeyUseCase(passkeyRepo, authRepo, userRepo, passkeyRelyingParty, authRepoUseCase, auditRecorder, loginHistoryUseCase, webAuthn, logger)
+	healthRepo := data.NewHealthRepo(dataData, logger)
+	healthUseCase := biz.NewHealthUseCase(healthRepo, logger)
+	userService := service.NewUserService(userUseCase, authRepoUseCase, validateUseCase, webhookUseCase, userChangeFeed, oAuthUseCase, identityUseCase, samlUseCase, scimUseCase, accessTokenUseCase, serviceAccountUseCase, impersonationUseCase, passkeyUseCase, healthUseCase, logger)
 	grpcServer := server.NewGRPCServer(confServer, userService, logger)
 	httpServer := server.NewHTTPServer(confServer, userService, logger)
-	app := newApp(logger, grpcServer, httpServer)
+	eventPublisher := data.NewEventPublisher(dataData, confData, logger)
+	outboxRelay := biz.NewOutboxRelay(outboxRepo, eventPublisher, userConstant, logger)
+	jobServer := server.NewJobServer(loginHistoryUseCase, webhookUseCase, outboxRelay, userChangeFeed, logger)
+	app := newApp(logger, grpcServer, httpServer, jobServer)
 	return app, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
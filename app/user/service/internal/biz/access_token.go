@@ -0,0 +1,339 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"strings"
+	"time"
+)
+
+type AccessTokenRepo interface {
+	CreateAccessToken(ctx context.Context, token *AccessToken) error
+	GetAccessToken(ctx context.Context, id int64) (*AccessToken, error)
+	GetAccessTokenByHash(ctx context.Context, tokenHash string) (*AccessToken, error)
+	ListAccessTokens(ctx context.Context, userId, page, pageSize int32) ([]*AccessToken, int64, error)
+	RevokeAccessToken(ctx context.Context, id int64) error
+	TouchAccessToken(ctx context.Context, id int64, ip string) error
+}
+
+// AccessTokenUseCase 个人访问令牌，供脚本、CI等非交互场景调用接口
+type AccessTokenUseCase struct {
+	repo     AccessTokenRepo
+	userRepo UserRepo
+	audit    *AuditRecorder
+	conf     *conf.UserConstant
+	log      *log.Helper
+}
+
+// AccessTokenPrefix 个人访问令牌前缀，用于区分其他Bearer令牌
+const AccessTokenPrefix = "ucp_"
+
+const (
+	AccessTokenScopeRead  = "read"
+	AccessTokenScopeWrite = "write"
+)
+
+const (
+	AuditActionAccessTokenCreate = "access_token.create"
+	AuditActionAccessTokenRevoke = "access_token.revoke"
+)
+
+const (
+	// 最近使用时间的最小更新间隔，避免每次请求都写库
+	accessTokenTouchInterval = time.Minute
+	// 令牌列表中展示的前缀长度
+	accessTokenPrefixLength = 12
+)
+
+// 各权限范围允许调用的接口，未列出的接口（登录、注册、令牌创建、第三方账号绑定等）只能通过会话调用
+var accessTokenScopeOperations = map[string][]string{
+	AccessTokenScopeRead: {
+		v1.UserService_GetCurrentUser_FullMethodName,
+		v1.UserService_SearchUsers_FullMethodName,
+		v1.UserService_ListPendingUsers_FullMethodName,
+		v1.UserService_ListAuditLogs_FullMethodName,
+		v1.UserService_ListMyLoginHistory_FullMethodName,
+		v1.UserService_ListMySessions_FullMethodName,
+		v1.UserService_ListLoginHistory_FullMethodName,
+		v1.UserService_ListWebhooks_FullMethodName,
+		v1.UserService_ListWebhookDeliveries_FullMethodName,
+		v1.UserService_ListOAuthClients_FullMethodName,
+		v1.UserService_ListIdentities_FullMethodName,
+		v1.UserService_ListAccessTokens_FullMethodName,
+		v1.UserService_ListUserAccessTokens_FullMethodName,
+		v1.UserService_ListServiceAccounts_FullMethodName,
+		v1.UserService_WatchUsers_FullMethodName,
+	},
+	AccessTokenScopeWrite: {
+		v1.UserService_DeleteUser_FullMethodName,
+		v1.UserService_ApproveUser_FullMethodName,
+		v1.UserService_RejectUser_FullMethodName,
+		v1.UserService_CreateWebhook_FullMethodName,
+		v1.UserService_DeleteWebhook_FullMethodName,
+		v1.UserService_RedeliverWebhook_FullMethodName,
+		v1.UserService_CreateOAuthClient_FullMethodName,
+		v1.UserService_DeleteOAuthClient_FullMethodName,
+		v1.UserService_RevokeAccessToken_FullMethodName,
+		v1.UserService_CreateServiceAccount_FullMethodName,
+		v1.UserService_UpdateServiceAccount_FullMethodName,
+	},
+}
+
+// AccessToken 个人访问令牌，只保存令牌摘要，Scopes以空格分隔
+type AccessToken struct {
+	Id           int64
+	UserId       int32
+	Name         string
+	TokenHash    string
+	TokenPrefix  string
+	Scopes       string
+	ExpireTime   time.Time
+	LastUsedTime time.Time
+	LastUsedIp   string
+	RevokeTime   time.Time
+	CreateTime   time.Time
+}
+
+type CreateAccessToken struct {
+	Name       string   `validate:"required,max=64" comment:"令牌名称"`
+	Scopes     []string `validate:"required,min=1,dive,oneof=read write" comment:"权限范围"`
+	ExpireDays int32    `validate:"required,gte=1,lte=365" comment:"有效天数"`
+}
+
+type RevokeAccessToken struct {
+	Id int64 `validate:"required,gt=0" comment:"令牌Id"`
+}
+
+type ListUserAccessTokens struct {
+	UserId   int32 `validate:"gte=0" comment:"用户Id"`
+	Page     int32 `validate:"gte=0" comment:"页码"`
+	PageSize int32 `validate:"gte=0,lte=100" comment:"每页数量"`
+}
+
+func NewAccessTokenUseCase(repo AccessTokenRepo, userRepo UserRepo, audit *AuditRecorder, conf *conf.UserConstant, logger log.Logger) *AccessTokenUseCase {
+	return &AccessTokenUseCase{
+		repo:     repo,
+		userRepo: userRepo,
+		audit:    audit,
+		conf:     conf,
+		log:      log.NewHelper(log.With(logger, "module", "user/biz/accessTokenUseCase")),
+	}
+}
+
+// CreateAccessToken 创建个人访问令牌
+//1. 只能在登录会话中创建，不能用令牌创建令牌
+//2. 令牌随机生成，数据库中只保存摘要，明文只在创建时返回
+func (r *AccessTokenUseCase) CreateAccessToken(ctx context.Context, create *CreateAccessToken) (token *AccessToken, secret string, err error) {
+	userId := ctx.Value("userId").(int32)
+	defer func() {
+		auditLog := &AuditLog{Action: AuditActionAccessTokenCreate, ActorId: userId, TargetId: userId, Detail: create.Name}
+		if token != nil {
+			auditLog.Detail = fmt.Sprintf("id(%v), name(%s), scopes(%s)", token.Id, token.Name, token.Scopes)
+		}
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	if _, ok := ctx.Value(principalKey{}).(*principal); ok {
+		return nil, "", v1.ErrorPermissionDeny("access token can only be created in login session")
+	}
+	_, err = sessionUser(ctx, r.userRepo, userId)
+	if kerrors.IsNotFound(err) {
+		return nil, "", v1.ErrorLoginStateTimeout("")
+	}
+	if err != nil {
+		return nil, "", v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	return issueAccessToken(ctx, r.repo, userId, create)
+}
+
+// ListAccessTokens 当前用户的个人访问令牌
+func (r *AccessTokenUseCase) ListAccessTokens(ctx context.Context) ([]*AccessToken, error) {
+	userId := ctx.Value("userId").(int32)
+	_, err := sessionUser(ctx, r.userRepo, userId)
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorLoginStateTimeout("")
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	tokens, _, err := r.repo.ListAccessTokens(ctx, userId, 0, 0)
+	if err != nil {
+		return nil, v1.ErrorAccessTokenFailed("%s", err.Error())
+	}
+	return tokens, nil
+}
+
+// ListUserAccessTokens 个人访问令牌列表
+//1. 判断是否有管理员权限，服务账号负责人可以查询该服务账号的API Key
+//2. 按用户分页查询，未指定用户时查询全部
+func (r *AccessTokenUseCase) ListUserAccessTokens(ctx context.Context, userId, page, pageSize int32) ([]*AccessToken, int64, error) {
+	adminId := ctx.Value("userId").(int32)
+	err := checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if v1.IsPermissionDeny(err) && userId != 0 {
+		err = r.checkTokenManager(ctx, adminId, userId)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	tokens, total, err := r.repo.ListAccessTokens(ctx, userId, page, pageSize)
+	if err != nil {
+		return nil, 0, v1.ErrorAccessTokenFailed("%s", err.Error())
+	}
+	return tokens, total, nil
+}
+
+// RevokeAccessToken 吊销个人访问令牌
+//1. 用户只能吊销自己的令牌，管理员可以吊销任意用户的令牌，负责人可以吊销服务账号的API Key
+//2. 已吊销的令牌重复吊销不报错
+func (r *AccessTokenUseCase) RevokeAccessToken(ctx context.Context, id int64) (err error) {
+	userId := ctx.Value("userId").(int32)
+	var token *AccessToken
+	defer func() {
+		auditLog := &AuditLog{Action: AuditActionAccessTokenRevoke, ActorId: userId, Detail: fmt.Sprintf("id(%v)", id)}
+		if token != nil {
+			auditLog.TargetId = token.UserId
+		}
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	_, err = sessionUser(ctx, r.userRepo, userId)
+	if kerrors.IsNotFound(err) {
+		return v1.ErrorLoginStateTimeout("")
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	token, err = r.repo.GetAccessToken(ctx, id)
+	if kerrors.IsNotFound(err) {
+		return v1.ErrorAccessTokenFailed("access token not found: id(%v)", id)
+	}
+	if err != nil {
+		return v1.ErrorAccessTokenFailed("%s", err.Error())
+	}
+	if token.UserId != userId {
+		err = r.checkTokenManager(ctx, userId, token.UserId)
+		if err != nil {
+			return err
+		}
+	}
+	if !token.RevokeTime.IsZero() {
+		return nil
+	}
+
+	err = r.repo.RevokeAccessToken(ctx, id)
+	if err != nil {
+		return v1.ErrorAccessTokenFailed("%s", err.Error())
+	}
+	return nil
+}
+
+// checkTokenManager 管理员可以管理任意用户的令牌，负责人可以管理服务账号的API Key
+func (r *AccessTokenUseCase) checkTokenManager(ctx context.Context, userId, tokenUserId int32) error {
+	owner, err := r.userRepo.GetCurrentUser(ctx, tokenUserId)
+	if kerrors.IsNotFound(err) {
+		return checkAdmin(ctx, r.userRepo, r.conf, userId)
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	return checkServiceAccountManager(ctx, r.userRepo, r.conf, userId, owner)
+}
+
+// Authenticate 校验个人访问令牌，通过后将令牌所属用户写入上下文
+//1. 按摘要查询令牌，已吊销、已过期的令牌无效
+//2. 令牌所属用户必须为正常状态
+//3. 令牌的权限范围必须允许调用该接口
+//4. 最近使用时间和ip按最小间隔更新
+func (r *AccessTokenUseCase) Authenticate(ctx context.Context, secret, operation string) (context.Context, error) {
+	token, err := r.repo.GetAccessTokenByHash(ctx, hashToken(secret))
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorAccessTokenInvalid("access token not found")
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	now := time.Now()
+	if !token.RevokeTime.IsZero() || !now.Before(token.ExpireTime) {
+		return nil, v1.ErrorAccessTokenInvalid("access token revoked or expired: id(%v)", token.Id)
+	}
+
+	user, err := r.userRepo.GetCurrentUser(ctx, token.UserId)
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorAccessTokenInvalid("access token user not found: id(%v)", token.Id)
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	err = validateUserStatus(user)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Allow(operation) {
+		return nil, v1.ErrorPermissionDeny("access token scope not allowed: id(%v), operation(%s)", token.Id, operation)
+	}
+
+	if now.Sub(token.LastUsedTime) >= accessTokenTouchInterval {
+		ip, _ := ctx.Value("clientIp").(string)
+		err = r.repo.TouchAccessToken(ctx, token.Id, ip)
+		if err != nil {
+			r.log.Errorf("fail to touch access token: id(%v), error(%v)", token.Id, err)
+		}
+	}
+
+	ctx = context.WithValue(ctx, "userId", user.Id)
+	return context.WithValue(ctx, principalKey{}, &principal{token: token, user: user}), nil
+}
+
+// Allow 令牌的权限范围是否允许调用该接口，write包含read
+func (t *AccessToken) Allow(operation string) bool {
+	for _, scope := range strings.Fields(t.Scopes) {
+		scopes := []string{scope}
+		if scope == AccessTokenScopeWrite {
+			scopes = append(scopes, AccessTokenScopeRead)
+		}
+		for _, item := range scopes {
+			for _, allowed := range accessTokenScopeOperations[item] {
+				if allowed == operation {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// issueAccessToken 为用户生成令牌，数据库中只保存摘要
+func issueAccessToken(ctx context.Context, repo AccessTokenRepo, userId int32, create *CreateAccessToken) (*AccessToken, string, error) {
+	random, err := randomToken()
+	if err != nil {
+		return nil, "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	secret := AccessTokenPrefix + random
+	token := &AccessToken{
+		UserId:      userId,
+		Name:        create.Name,
+		TokenHash:   hashToken(secret),
+		TokenPrefix: secret[:accessTokenPrefixLength],
+		Scopes:      strings.Join(uniqueFields(create.Scopes), " "),
+		ExpireTime:  time.Now().AddDate(0, 0, int(create.ExpireDays)),
+	}
+	err = repo.CreateAccessToken(ctx, token)
+	if err != nil {
+		return nil, "", v1.ErrorAccessTokenFailed("%s", err.Error())
+	}
+	return token, secret, nil
+}
@@ -0,0 +1,114 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"strings"
+	"time"
+)
+
+type AuditRepo interface {
+	CreateAuditLog(ctx context.Context, auditLog *AuditLog) error
+	ListAuditLogs(ctx context.Context, query *AuditLogQuery) ([]*AuditLog, int64, error)
+}
+
+// AuditRecorder 审计日志记录，只追加不修改
+type AuditRecorder struct {
+	repo AuditRepo
+	log  *log.Helper
+}
+
+const (
+	AuditActionUserRegister = "user.register"
+	AuditActionUserLogin    = "user.login"
+	AuditActionUserLogout   = "user.logout"
+	AuditActionUserDelete   = "user.delete"
+	AuditActionUserApprove  = "user.approve"
+	AuditActionUserReject   = "user.reject"
+)
+
+const (
+	AuditResultSuccess = "success"
+	AuditResultFailure = "failure"
+)
+
+// AuditLog 审计日志
+type AuditLog struct {
+	Id         int64
+	ActorId    int32
+	TargetId   int32
+	Action     string
+	Ip         string
+	UserAgent  string
+	Result     string
+	Reason     string
+	Detail     string
+	CreateTime time.Time
+}
+
+// AuditLogQuery 审计日志查询条件，零值表示不过滤
+type AuditLogQuery struct {
+	ActorId   int32
+	TargetId  int32
+	Action    string
+	Result    string
+	StartTime time.Time
+	EndTime   time.Time
+	Page      int32
+	PageSize  int32
+}
+
+type ListAuditLogs struct {
+	ActorId   int32  `validate:"gte=0" comment:"操作者Id"`
+	TargetId  int32  `validate:"gte=0" comment:"目标用户Id"`
+	Action    string `validate:"max=64" comment:"操作"`
+	Result    string `validate:"omitempty,oneof=success failure" comment:"结果"`
+	StartTime string `validate:"omitempty,datetime=2006-01-02 15:04:05" comment:"开始时间"`
+	EndTime   string `validate:"omitempty,datetime=2006-01-02 15:04:05" comment:"结束时间"`
+	Page      int32  `validate:"gte=0" comment:"页码"`
+	PageSize  int32  `validate:"gte=0,lte=100" comment:"每页数量"`
+}
+
+func NewAuditRecorder(repo AuditRepo, logger log.Logger) *AuditRecorder {
+	return &AuditRecorder{
+		repo: repo,
+		log:  log.NewHelper(log.With(logger, "module", "user/biz/auditRecorder")),
+	}
+}
+
+// Record 记录审计日志
+//1. 从请求上下文中补充ip和user agent
+//2. 模拟登录期间的操作记录在管理员名下，并注明被模拟的用户
+//3. 根据err判断操作结果，失败时记录错误原因
+//4. 审计日志写入失败只打印日志，不影响业务
+func (r *AuditRecorder) Record(ctx context.Context, auditLog *AuditLog, err error) {
+	auditLog.Ip, _ = ctx.Value("clientIp").(string)
+	auditLog.UserAgent, _ = ctx.Value("userAgent").(string)
+	if p, ok := ctx.Value(principalKey{}).(*principal); ok && p.impersonatorId != 0 && auditLog.ActorId == p.user.Id {
+		auditLog.ActorId = p.impersonatorId
+		auditLog.Detail = strings.TrimSpace(fmt.Sprintf("%s impersonating(%v)", auditLog.Detail, p.user.Id))
+	}
+	auditLog.Result = AuditResultSuccess
+	if err != nil {
+		auditLog.Result = AuditResultFailure
+		auditLog.Reason = kerrors.FromError(err).Reason
+	}
+
+	rerr := r.repo.CreateAuditLog(ctx, auditLog)
+	if rerr != nil {
+		r.log.Errorf("fail to record audit log: action(%s), actorId(%v), targetId(%v), error(%v)", auditLog.Action, auditLog.ActorId, auditLog.TargetId, rerr)
+	}
+}
+
+// List 分页查询审计日志
+func (r *AuditRecorder) List(ctx context.Context, query *AuditLogQuery) ([]*AuditLog, int64, error) {
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 20
+	}
+	return r.repo.ListAuditLogs(ctx, query)
+}
@@ -3,47 +3,98 @@ package biz
 import (
 	"context"
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/pkg/errors"
 	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"math/big"
 	"regexp"
+	"time"
 )
 
 type AuthRepo interface {
 	AccountExist(ctx context.Context, userAccount string) (bool, error)
-	UserRegister(ctx context.Context, userAccount, passwordHash string) (int32, error)
+	UserRegister(ctx context.Context, userAccount, passwordHash, email string, userStatus int32) (int32, error)
 	UserLogin(ctx context.Context, userAccount, passwordHash string) (*User, error)
 	UserLogout(ctx context.Context, userId int32) error
 	SetLoginSession(ctx context.Context, userInfo *User) error
+	SetLoginVerifyCode(ctx context.Context, userId int32, fingerprint, code string, timeout time.Duration) error
+	GetLoginVerifyCode(ctx context.Context, userId int32, fingerprint string) (string, error)
+	DeleteLoginVerifyCode(ctx context.Context, userId int32, fingerprint string) error
+	CreateUser(ctx context.Context, user *User) (int32, error)
+	SetMagicLink(ctx context.Context, tokenHash string, link *MagicLink, timeout time.Duration) error
+	TakeMagicLink(ctx context.Context, tokenHash string) (*MagicLink, error)
+	IncrMagicLinkRequest(ctx context.Context, key string, window time.Duration) (int64, error)
+	SetImpersonation(ctx context.Context, tokenHash string, impersonation *Impersonation, timeout time.Duration) error
+	GetImpersonation(ctx context.Context, tokenHash string) (*Impersonation, error)
+	DeleteImpersonation(ctx context.Context, tokenHash string) error
+	SetPasskeyChallenge(ctx context.Context, challengeId string, challenge *PasskeyChallenge, timeout time.Duration) error
+	TakePasskeyChallenge(ctx context.Context, challengeId string) (*PasskeyChallenge, error)
+	SetPasskeyPending(ctx context.Context, userId int32, fingerprint string, timeout time.Duration) error
+	TakePasskeyPending(ctx context.Context, userId int32, fingerprint string) error
 }
 
+// ErrSessionUnavailable 登录态存储不可用，此时登录和退出登录明确失败，不能当作成功
+var ErrSessionUnavailable = errors.New("session store unavailable")
+
 type AuthRepoUseCase struct {
-	repo AuthRepo
-	log  *log.Helper
-	re   Recovery
-	tm   Transaction
+	repo           AuthRepo
+	userRepo       UserRepo
+	log            *log.Helper
+	re             Recovery
+	tm             Transaction
+	conf           *conf.UserConstant
+	audit          *AuditRecorder
+	history        *LoginHistoryUseCase
+	risk           *LoginRiskDetector
+	mailer         Mailer
+	event          *EventUseCase
+	authenticators Authenticators
+	passkeyRepo    PasskeyRepo
+	identityRepo   IdentityRepo
+	webauthn       *conf.WebAuthn
 }
 
+// 新设备登录验证码有效期
+const loginVerifyCodeTimeout = 5 * time.Minute
+
 // UserRegister DO对象，带简单校验
 type UserRegister struct {
 	UserAccount   string `validate:"required,min=4" comment:"用户名"`
-	UserPassword  string `validate:"required,min=4,max=8" comment:"用户密码"`
-	CheckPassword string `validate:"required,min=4,max=8" comment:"重复密码"`
+	UserPassword  string `validate:"required,min=4,max=128" comment:"用户密码"`
+	CheckPassword string `validate:"required,min=4,max=128" comment:"重复密码"`
+	Email         string `validate:"omitempty,email" comment:"邮箱"`
 }
 
 // UserLogin DO对象，带简单校验
 type UserLogin struct {
 	UserAccount  string `validate:"required,min=4" comment:"用户名"`
-	UserPassword string `validate:"required,min=4,max=8" comment:"用户密码"`
+	UserPassword string `validate:"required,min=4,max=128" comment:"用户密码"`
+	VerifyCode   string `validate:"omitempty,len=6,numeric" comment:"验证码"`
 }
 
-func NewAuthRepoUseCase(repo AuthRepo, re Recovery, tm Transaction, logger log.Logger) *AuthRepoUseCase {
+func NewAuthRepoUseCase(repo AuthRepo, userRepo UserRepo, re Recovery, tm Transaction, logger log.Logger, conf *conf.UserConstant, audit *AuditRecorder, history *LoginHistoryUseCase, risk *LoginRiskDetector, mailer Mailer, event *EventUseCase, authenticators Authenticators, passkeyRepo PasskeyRepo, identityRepo IdentityRepo, webauthn *conf.WebAuthn) *AuthRepoUseCase {
 	return &AuthRepoUseCase{
-		repo: repo,
-		log:  log.NewHelper(log.With(logger, "module", "user/biz/AuthRepoUseCase")),
-		tm:   tm,
-		re:   re,
+		repo:           repo,
+		userRepo:       userRepo,
+		log:            log.NewHelper(log.With(logger, "module", "user/biz/AuthRepoUseCase")),
+		tm:             tm,
+		re:             re,
+		conf:           conf,
+		audit:          audit,
+		history:        history,
+		risk:           risk,
+		mailer:         mailer,
+		event:          event,
+		authenticators: authenticators,
+		passkeyRepo:    passkeyRepo,
+		identityRepo:   identityRepo,
+		webauthn:       webauthn,
 	}
 }
 
@@ -58,29 +109,52 @@ func NewAuthRepoUseCase(repo AuthRepo, re Recovery, tm Transaction, logger log.L
 //5. 账户不包含特殊字符
 //6. 密码和校验密码相同
 //3. 对密码进行加密（密码千万不要直接以明文存储到数据库中）
-//4. 向数据库插入用户数据
-func (r *AuthRepoUseCase) UserRegister(ctx context.Context, userAccount, userPassword, checkPassword string) (int32, error) {
+//4. 向数据库插入用户数据，开启注册审核时账户状态为待审核，同一事务中写入注册事件
+//5. 记录审计日志
+func (r *AuthRepoUseCase) UserRegister(ctx context.Context, userAccount, userPassword, checkPassword, email string) (id int32, userStatus int32, err error) {
+	auditLog := &AuditLog{Action: AuditActionUserRegister, Detail: fmt.Sprintf("userAccount(%s)", userAccount)}
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
 	// 1、密码一致性校验
-	err := r.isPasswordEqlCheckPassword(userPassword, checkPassword)
+	err = r.isPasswordEqlCheckPassword(userPassword, checkPassword)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	// 2、账户合法性校验
 	err = r.validateAccountBeforeRegister(ctx, userAccount)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	// 3、加密
-	passwordHash := r.passwordMD5Hash(userPassword)
+	passwordHash := passwordMD5Hash(userPassword)
 
 	// 4、插入数据
-	id, err := r.repo.UserRegister(ctx, userAccount, passwordHash)
+	userStatus = UserStatusNormal
+	if r.conf.RegisterApproval {
+		userStatus = UserStatusPending
+	}
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		id, err = r.repo.UserRegister(ctx, userAccount, passwordHash, email, userStatus)
+		if err != nil {
+			return err
+		}
+		return r.event.Emit(ctx, NewUserEvent(EventUserRegistered, id, &User{
+			Id:          id,
+			UserAccount: userAccount,
+			Email:       email,
+			UserStatus:  userStatus,
+			Role:        r.conf.DefaultRole,
+		}))
+	})
 	if err != nil {
-		return 0, v1.ErrorUserRegisterFailed("%s", err.Error())
+		return 0, 0, v1.ErrorUserRegisterFailed("%s", err.Error())
 	}
-	return id, nil
+	auditLog.ActorId, auditLog.TargetId = id, id
+	return id, userStatus, nil
 }
 
 func (r *AuthRepoUseCase) isPasswordEqlCheckPassword(userPassword, checkPassword string) error {
@@ -112,7 +186,7 @@ func (r *AuthRepoUseCase) validateAccountBeforeRegister(ctx context.Context, use
 }
 
 func (r *AuthRepoUseCase) isAccountExist(ctx context.Context, userAccount string) (bool, error) {
-	return r.repo.AccountExist(ctx, userAccount)
+	return r.repo.AccountExist(ReadPrimary(ctx), userAccount)
 }
 
 func (r *AuthRepoUseCase) isAccountWordsValidate(userAccount string) (bool, error) {
@@ -125,7 +199,12 @@ func (r *AuthRepoUseCase) isAccountWordsValidate(userAccount string) (bool, erro
 	return match, nil
 }
 
-func (r *AuthRepoUseCase) passwordMD5Hash(userAccount string) string {
+// PasswordHash 本地密码摘要，供初始化管理员密码等用例之外的场景使用
+func PasswordHash(password string) string {
+	return passwordMD5Hash(password)
+}
+
+func passwordMD5Hash(userAccount string) string {
 	m := md5.New()
 	m.Write([]byte(userAccount))
 	return hex.EncodeToString(m.Sum(nil))
@@ -139,41 +218,175 @@ func (r *AuthRepoUseCase) passwordMD5Hash(userAccount string) string {
 //	2. 账户长度不小于 4 位
 //	3. 密码就不小于 8 位
 //	4. 账户不包含特殊字符
-//2. 校验密码是否输入正确，要和数据库中的密文密码去对比
-//3. 用户信息脱敏，隐藏敏感信息，防止数据库中的字段泄露
-//4. 我们要记录用户的登录态（session），将其存到服务器上（redis）
+//2. 按配置顺序依次尝试认证后端校验密码，本地密码要和数据库中的密文密码去对比，LDAP用户首次登录时自动开通
+//3. 校验账户类型和状态，服务账号、待审核、审核未通过的账户不允许登录
+//4. 已注册通行密钥的用户按配置要求通行密钥二次验证，识别新设备、异常地点登录，按配置要求邮箱验证码二次验证，并邮件提醒用户
+//5. 用户信息脱敏，隐藏敏感信息，防止数据库中的字段泄露
+//6. 我们要记录用户的登录态（session），将其存到服务器上（redis）
 // 		cookie
-//5. 返回脱敏后的用户信息
-func (r *AuthRepoUseCase) UserLogin(ctx context.Context, userAccount, userPassword string) (*User, error) {
+//7. 返回脱敏后的用户信息
+//8. 无论成功失败都记录审计日志和登录历史
+func (r *AuthRepoUseCase) UserLogin(ctx context.Context, userAccount, userPassword, verifyCode string) (user *User, err error) {
+	auditLog := &AuditLog{Action: AuditActionUserLogin, Detail: fmt.Sprintf("userAccount(%s)", userAccount)}
+	history := newLoginHistory(ctx, userAccount, LoginMethodPassword)
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+		r.history.Record(ctx, history, err)
+	}()
+
 	// 1、账户合法性校验
-	err := r.validateAccountBeforeLogin(ctx, userAccount)
+	err = r.validateAccountBeforeLogin(ctx, userAccount)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3、加密
-	passwordHash := r.passwordMD5Hash(userPassword)
-
-	// 4、登录
-	user, err := r.repo.UserLogin(ctx, userAccount, passwordHash)
+	// 3、按配置顺序依次尝试认证后端
+	user, err = r.authenticate(ctx, userAccount, userPassword, history)
 	if err != nil {
 		return nil, v1.ErrorUserLoginFailed("%s", err.Error())
 	}
+	auditLog.ActorId, auditLog.TargetId = user.Id, user.Id
+	history.UserId = user.Id
 
-	// 5、存储登录的session
-	err = r.repo.SetLoginSession(ctx, user)
+	// 4、已注册通行密钥的用户按配置要求通行密钥二次验证
+	err = r.requirePasskey(ctx, user, history.Fingerprint)
 	if err != nil {
-		return nil, v1.ErrorUserLoginFailed("set user login session failed: %s", err.Error())
+		return nil, err
 	}
 
+	// 5、账户校验、新设备登录识别并存储session
+	err = r.startSession(ctx, user, history, verifyCode, false)
+	if err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
+// startSession 认证通过后创建登录会话，账号密码登录、免密登录链接和通行密钥登录共用
+//1. 账户类型和状态校验
+//2. 新设备、异常地点登录识别，本次登录未经邮箱或通行密钥验证时按配置要求二次验证
+//3. 存储登录的session，新设备登录邮件提醒用户
+func (r *AuthRepoUseCase) startSession(ctx context.Context, user *User, history *LoginHistory, verifyCode string, verified bool) error {
+	err := validateLoginUser(user)
+	if err != nil {
+		return err
+	}
+
+	history.Unrecognized = r.risk.Detect(ctx, history)
+	if history.Unrecognized && r.conf.LoginStepUp && !verified {
+		err = r.stepUp(ctx, user, history.Fingerprint, verifyCode)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.repo.SetLoginSession(ctx, user)
+	if errors.Is(err, ErrSessionUnavailable) {
+		return v1.ErrorSessionUnavailable("set user login session failed: %s", err.Error())
+	}
+	if err != nil {
+		return v1.ErrorUserLoginFailed("set user login session failed: %s", err.Error())
+	}
+
+	if history.Unrecognized {
+		r.risk.Notify(ctx, user, history)
+	}
+	return nil
+}
+
+// authenticate 依次尝试认证后端，账号不存在时交给下一个认证后端，其它错误直接返回
+func (r *AuthRepoUseCase) authenticate(ctx context.Context, userAccount, userPassword string, history *LoginHistory) (*User, error) {
+	err := errors.Errorf("no authenticator available: userAccount(%s)", userAccount)
+	for _, authenticator := range r.authenticators {
+		var user *User
+		user, err = authenticator.Authenticate(ctx, userAccount, userPassword)
+		if err == nil {
+			history.Method = authenticator.Method()
+			return user, nil
+		}
+		if !kerrors.IsNotFound(err) {
+			history.Method = authenticator.Method()
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// stepUp 未识别的登录二次验证，不能跳过
+//1. 有邮箱时使用邮箱验证码
+//2. 没有邮箱时使用已注册的通行密钥，通行密钥登录完成后创建session
+//3. 都没有时无法验证，拒绝登录
+func (r *AuthRepoUseCase) stepUp(ctx context.Context, user *User, fingerprint, verifyCode string) error {
+	if user.Email != "" {
+		return r.verifyUnrecognizedLogin(ctx, user, fingerprint, verifyCode)
+	}
+	err := r.passkeyStepUp(ctx, user, fingerprint)
+	if err != nil {
+		return err
+	}
+	return v1.ErrorLoginVerifyUnavailable("unrecognized login without email or passkey: userId(%v)", user.Id)
+}
+
+// verifyUnrecognizedLogin 未识别的登录需要邮箱验证码二次验证
+//1. 未携带验证码时生成验证码发送到用户邮箱，并拒绝本次登录
+//2. 携带验证码时校验验证码，校验通过后验证码失效
+func (r *AuthRepoUseCase) verifyUnrecognizedLogin(ctx context.Context, user *User, fingerprint, verifyCode string) error {
+	if verifyCode == "" {
+		code, err := r.generateVerifyCode()
+		if err != nil {
+			return v1.ErrorUnknownError("%s", err.Error())
+		}
+		err = r.repo.SetLoginVerifyCode(ctx, user.Id, fingerprint, code, loginVerifyCodeTimeout)
+		if err != nil {
+			return v1.ErrorUserLoginFailed("set login verify code failed: %s", err.Error())
+		}
+		body := fmt.Sprintf("您好，您的账号 %s 正在新的设备或地点登录，验证码：%s，%v分钟内有效。如果不是您本人操作，请尽快修改密码。",
+			user.UserAccount, code, int(loginVerifyCodeTimeout.Minutes()))
+		err = r.mailer.Send(ctx, user.Email, "登录验证码", body)
+		if err != nil {
+			return v1.ErrorUserLoginFailed("send login verify code failed: %s", err.Error())
+		}
+		return v1.ErrorLoginVerifyRequired("unrecognized login: userId(%v)", user.Id)
+	}
+
+	code, err := r.repo.GetLoginVerifyCode(ctx, user.Id, fingerprint)
+	if kerrors.IsNotFound(err) {
+		return v1.ErrorLoginVerifyFailed("login verify code expired: userId(%v)", user.Id)
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	if subtle.ConstantTimeCompare([]byte(code), []byte(verifyCode)) != 1 {
+		return v1.ErrorLoginVerifyFailed("login verify code mismatch: userId(%v)", user.Id)
+	}
+	err = r.repo.DeleteLoginVerifyCode(ctx, user.Id, fingerprint)
+	if err != nil {
+		r.log.Errorf("fail to delete login verify code: userId(%v), error(%v)", user.Id, err)
+	}
+	return nil
+}
+
+func (r *AuthRepoUseCase) generateVerifyCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", errors.Wrapf(err, "generate verify code error")
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
 // UserLogout 注销逻辑
 //1. 移除redis中的session即可
-func (r *AuthRepoUseCase) UserLogout(ctx context.Context) error {
+//2. 记录审计日志
+func (r *AuthRepoUseCase) UserLogout(ctx context.Context) (err error) {
 	userId := ctx.Value("userId").(int32)
-	err := r.repo.UserLogout(ctx, userId)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionUserLogout, ActorId: userId, TargetId: userId}, err)
+	}()
+
+	err = r.repo.UserLogout(ctx, userId)
+	if errors.Is(err, ErrSessionUnavailable) {
+		return v1.ErrorSessionUnavailable("%s", err.Error())
+	}
 	if err != nil {
 		return v1.ErrorUserLogoutFailed("%s", err.Error())
 	}
@@ -191,3 +404,24 @@ func (r *AuthRepoUseCase) validateAccountBeforeLogin(ctx context.Context, userAc
 	}
 	return nil
 }
+
+// validateLoginUser 服务账号只能使用API Key调用接口，不允许交互式登录
+func validateLoginUser(user *User) error {
+	if user.UserType == UserTypeService {
+		return v1.ErrorUserLoginFailed("service account(%s) can not login", user.UserAccount)
+	}
+	return validateUserStatus(user)
+}
+
+// validateUserStatus 待审核、审核未通过、已停用的账户不允许登录
+func validateUserStatus(user *User) error {
+	switch user.UserStatus {
+	case UserStatusPending:
+		return v1.ErrorAccountPendingApproval("account(%s) pending approval", user.UserAccount)
+	case UserStatusRejected:
+		return v1.ErrorAccountRejected("account(%s) rejected", user.UserAccount)
+	case UserStatusDisabled:
+		return v1.ErrorAccountDisabled("account(%s) disabled", user.UserAccount)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"strings"
+	"time"
+)
+
+// Authenticator 账号密码认证后端，账号不存在时返回NotFound，由下一个认证后端继续认证
+type Authenticator interface {
+	Method() string
+	Authenticate(ctx context.Context, userAccount, userPassword string) (*User, error)
+}
+
+// Authenticators 按配置顺序排列的认证后端
+type Authenticators []Authenticator
+
+// Directory 目录服务，先用服务账号搜索用户，再用用户的DN和密码绑定校验
+type Directory interface {
+	Authenticate(ctx context.Context, userAccount, userPassword string) (*DirectoryEntry, error)
+}
+
+const (
+	AuthenticatorLocal = "local"
+	AuthenticatorLDAP  = "ldap"
+)
+
+// DirectoryEntry 目录中的用户，Id优先使用entryUUID、objectGUID等不随改名变化的属性，没有时为DN
+type DirectoryEntry struct {
+	Id       string
+	Dn       string
+	UserName string
+	Email    string
+	Phone    string
+	Groups   []string
+}
+
+func NewAuthenticators(repo AuthRepo, ldap *LDAPAuthenticator, conf *conf.UserConstant, logger log.Logger) Authenticators {
+	l := log.NewHelper(log.With(logger, "module", "user/biz/authenticators"))
+	names := conf.Authenticators
+	if len(names) == 0 {
+		names = []string{AuthenticatorLocal}
+	}
+
+	authenticators := make(Authenticators, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case AuthenticatorLocal:
+			authenticators = append(authenticators, &localAuthenticator{repo: repo})
+		case AuthenticatorLDAP:
+			if ldap.conf.GetUrl() == "" {
+				l.Warn("ldap authenticator enabled but url not configured")
+				continue
+			}
+			authenticators = append(authenticators, ldap)
+		default:
+			l.Warnf("unknown authenticator: %s", name)
+		}
+	}
+	return authenticators
+}
+
+// localAuthenticator 本地密码认证
+type localAuthenticator struct {
+	repo AuthRepo
+}
+
+func (a *localAuthenticator) Method() string {
+	return LoginMethodPassword
+}
+
+func (a *localAuthenticator) Authenticate(ctx context.Context, userAccount, userPassword string) (*User, error) {
+	return a.repo.UserLogin(ctx, userAccount, passwordMD5Hash(userPassword))
+}
+
+// LDAPAuthenticator 目录服务认证，首次登录时自动开通本地用户，并按所属组同步角色
+type LDAPAuthenticator struct {
+	directory Directory
+	identity  IdentityRepo
+	authRepo  AuthRepo
+	userRepo  UserRepo
+	tm        Transaction
+	event     *EventUseCase
+	audit     *AuditRecorder
+	conf      *conf.LDAP
+	uconf     *conf.UserConstant
+	log       *log.Helper
+}
+
+func NewLDAPAuthenticator(directory Directory, identity IdentityRepo, authRepo AuthRepo, userRepo UserRepo, tm Transaction, event *EventUseCase, audit *AuditRecorder, conf *conf.LDAP, uconf *conf.UserConstant, logger log.Logger) *LDAPAuthenticator {
+	return &LDAPAuthenticator{
+		directory: directory,
+		identity:  identity,
+		authRepo:  authRepo,
+		userRepo:  userRepo,
+		tm:        tm,
+		event:     event,
+		audit:     audit,
+		conf:      conf,
+		uconf:     uconf,
+		log:       log.NewHelper(log.With(logger, "module", "user/biz/ldapAuthenticator")),
+	}
+}
+
+func (a *LDAPAuthenticator) Method() string {
+	return LoginMethodLDAP
+}
+
+// Authenticate 目录服务认证
+//1. 搜索用户并用用户密码绑定，用户不在目录中时返回NotFound
+//2. 按目录中的唯一标识查询绑定的本地用户，所属组对应的角色有变化时同步
+//3. 没有绑定的本地用户时自动开通，账号与本地已有账号冲突时拒绝登录
+func (a *LDAPAuthenticator) Authenticate(ctx context.Context, userAccount, userPassword string) (*User, error) {
+	entry, err := a.directory.Authenticate(ctx, userAccount, userPassword)
+	if err != nil {
+		return nil, err
+	}
+	role := a.role(entry.Groups)
+
+	identity, err := a.identity.GetIdentity(ctx, AuthenticatorLDAP, entry.Id)
+	if kerrors.IsNotFound(err) {
+		return a.provision(ctx, userAccount, role, entry)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.identity.UpdateIdentityLogin(ctx, identity.Id, entry.Email)
+	if err != nil {
+		a.log.Errorf("fail to update identity login: id(%v), error(%v)", identity.Id, err)
+	}
+	user, err := a.userRepo.GetCurrentUser(ctx, identity.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	err = a.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := a.userRepo.UpdateUserRole(ctx, user.Id, role)
+		if err != nil {
+			return err
+		}
+		user.Role = role
+		return a.event.Emit(ctx, NewUserEvent(EventUserUpdated, user.Id, user))
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.log.Infof("ldap user role synced: userId(%v), role(%v)", user.Id, role)
+	return user, nil
+}
+
+// provision 开通目录用户对应的本地用户，没有本地密码
+func (a *LDAPAuthenticator) provision(ctx context.Context, userAccount string, role int32, entry *DirectoryEntry) (*User, error) {
+	exist, err := a.authRepo.AccountExist(ReadPrimary(ctx), userAccount)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.Errorf("account exists but not linked to ldap: userAccount(%s)", userAccount)
+	}
+
+	user := &User{
+		UserAccount: userAccount,
+		UserName:    entry.UserName,
+		Email:       entry.Email,
+		Phone:       entry.Phone,
+		UserStatus:  UserStatusNormal,
+		Role:        role,
+	}
+	err = a.tm.ExecTx(ctx, func(ctx context.Context) error {
+		user.Id, err = a.authRepo.CreateUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		err = a.identity.CreateIdentity(ctx, &Identity{
+			UserId:        user.Id,
+			Provider:      AuthenticatorLDAP,
+			Subject:       entry.Id,
+			Email:         entry.Email,
+			LastLoginTime: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		return a.event.Emit(ctx, NewUserEvent(EventUserRegistered, user.Id, user))
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.audit.Record(ctx, &AuditLog{Action: AuditActionUserRegister, ActorId: user.Id, TargetId: user.Id,
+		Detail: fmt.Sprintf("userAccount(%s), provider(%s), dn(%s)", userAccount, AuthenticatorLDAP, entry.Dn)}, nil)
+	return user, nil
+}
+
+// role 所属组对应的最大角色，没有对应的组时为默认角色
+func (a *LDAPAuthenticator) role(groups []string) int32 {
+	role := a.uconf.DefaultRole
+	for _, group := range groups {
+		for dn, mapped := range a.conf.GetGroupRoles() {
+			if strings.EqualFold(dn, group) && mapped > role {
+				role = mapped
+			}
+		}
+	}
+	return role
+}
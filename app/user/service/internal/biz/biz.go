@@ -3,15 +3,49 @@ package biz
 import (
 	"context"
 	"github.com/google/wire"
+	"time"
 )
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewUserUseCase, NewValidateUseCase, NewAuthRepoUseCase)
+var ProviderSet = wire.NewSet(NewUserUseCase, NewValidateUseCase, NewAuthRepoUseCase, NewAuditRecorder, NewLoginHistoryUseCase, NewLoginRiskRules, NewLoginRiskDetector, NewEventUseCase, NewWebhookUseCase, NewOutboxRelay, NewUserChangeFeed, NewOAuthUseCase, NewIdentityUseCase, NewAuthenticators, NewLDAPAuthenticator, NewSAMLUseCase, NewScimUseCase, NewAccessTokenUseCase, NewServiceAccountUseCase, NewImpersonationUseCase, NewPasskeyUseCase, NewHealthUseCase)
 
 type Transaction interface {
 	ExecTx(context.Context, func(ctx context.Context) error) error
 }
 
+// readPrimaryKey 上下文中要求读主库的标记
+type readPrimaryKey struct{}
+
+// ReadPrimary 后续的读操作使用主库，用于先检查再写入和写入后立即读取等需要读到最新数据的场景；事务中的读操作总是使用主库
+func ReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readPrimaryKey{}, true)
+}
+
+// IsReadPrimary 是否要求读主库
+func IsReadPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(readPrimaryKey{}).(bool)
+	return primary
+}
+
 type Recovery interface {
 	GroupRecover(context.Context, func(ctx context.Context) error) func() error
 }
+
+// Job 后台定时任务，由server层的JobServer按Interval周期执行
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// retryBackoff 第n次失败后的重试间隔，从base起按2的指数增长，最长为max
+func retryBackoff(attempts int32, base, max time.Duration) time.Duration {
+	backoff := base
+	for i := int32(1); i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
@@ -0,0 +1,111 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const (
+	EventUserRegistered    = "user.registered"
+	EventUserUpdated       = "user.updated"
+	EventUserDeleted       = "user.deleted"
+	EventUserStatusChanged = "user.status_changed"
+)
+
+// UserEvent 用户生命周期事件
+type UserEvent struct {
+	Id         string        `json:"id"`
+	Type       string        `json:"type"`
+	UserId     int32         `json:"userId"`
+	Data       *UserSnapshot `json:"data,omitempty"`
+	OccurredAt time.Time     `json:"occurredAt"`
+}
+
+// UserSnapshot 事件中携带的脱敏用户信息
+type UserSnapshot struct {
+	Id          int32  `json:"id"`
+	UserName    string `json:"userName"`
+	UserAccount string `json:"userAccount"`
+	AvatarUrl   string `json:"avatarUrl"`
+	Email       string `json:"email"`
+	Gender      int32  `json:"gender"`
+	UserStatus  int32  `json:"userStatus"`
+	UserRole    int32  `json:"userRole"`
+}
+
+// EventUseCase 用户事件分发，必须在业务变更的同一事务中调用，保证事件不丢失
+type EventUseCase struct {
+	outbox  OutboxRepo
+	changes UserChangeRepo
+	webhook *WebhookUseCase
+	log     *log.Helper
+}
+
+func NewEventUseCase(outbox OutboxRepo, changes UserChangeRepo, webhook *WebhookUseCase, logger log.Logger) *EventUseCase {
+	return &EventUseCase{
+		outbox:  outbox,
+		changes: changes,
+		webhook: webhook,
+		log:     log.NewHelper(log.With(logger, "module", "user/biz/eventUseCase")),
+	}
+}
+
+// NewUserEvent 生成用户事件，user为空时不携带用户信息
+func NewUserEvent(eventType string, userId int32, user *User) *UserEvent {
+	event := &UserEvent{
+		Id:         uuid.NewString(),
+		Type:       eventType,
+		UserId:     userId,
+		OccurredAt: time.Now(),
+	}
+	if user != nil {
+		event.Data = &UserSnapshot{
+			Id:          user.Id,
+			UserName:    user.UserName,
+			UserAccount: user.UserAccount,
+			AvatarUrl:   user.AvatarUrl,
+			Email:       user.Email,
+			Gender:      user.Gender,
+			UserStatus:  user.UserStatus,
+			UserRole:    user.Role,
+		}
+	}
+	return event
+}
+
+// Emit 写入事件，ctx需携带事务
+//1. 写入事务发件箱，由OutboxRelay发布到消息总线
+//2. 追加用户变更日志，供WatchUsers订阅方断点续传
+//3. 为订阅了该事件的webhook生成待投递记录
+func (r *EventUseCase) Emit(ctx context.Context, event *UserEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("json marshal error: event(%v)", event.Id))
+	}
+	err = r.outbox.CreateOutboxEvent(ctx, &OutboxEvent{
+		EventId:         event.Id,
+		EventType:       event.Type,
+		UserId:          event.UserId,
+		Payload:         string(payload),
+		Status:          OutboxEventPending,
+		NextAttemptTime: event.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+	err = r.changes.AppendUserChange(ctx, &UserChange{
+		EventId:    event.Id,
+		ChangeType: userChangeType(event.Type),
+		UserId:     event.UserId,
+		Payload:    string(payload),
+	})
+	if err != nil {
+		return err
+	}
+	return r.webhook.Enqueue(ctx, event)
+}
@@ -0,0 +1,102 @@
+package biz
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 健康状态
+const (
+	HealthOk          = "ok"
+	HealthDegraded    = "degraded"
+	HealthUnavailable = "unavailable"
+)
+
+// ComponentHealth 依赖组件的健康状态
+type ComponentHealth struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Health 服务整体健康状态
+type Health struct {
+	Status     string             `json:"status"`
+	Components []*ComponentHealth `json:"components"`
+}
+
+// CacheStats 缓存命中统计，进程启动后累计
+type CacheStats struct {
+	Name          string
+	LocalHits     uint64
+	RedisHits     uint64
+	Misses        uint64
+	Errors        uint64
+	Invalidations uint64
+}
+
+type HealthRepo interface {
+	CheckDB(ctx context.Context) *ComponentHealth
+	CheckRedis(ctx context.Context) *ComponentHealth
+	CheckReplicas(ctx context.Context) []*ComponentHealth
+	SessionFallback() bool
+	CacheStats() []*CacheStats
+}
+
+type HealthUseCase struct {
+	repo HealthRepo
+	log  *log.Helper
+}
+
+func NewHealthUseCase(repo HealthRepo, logger log.Logger) *HealthUseCase {
+	return &HealthUseCase{
+		repo: repo,
+		log:  log.NewHelper(log.With(logger, "module", "user/biz/health")),
+	}
+}
+
+// Check 检查依赖组件
+//1. 数据库不可用时服务不可用
+//2. Redis不可用时，开启了数据库兜底登录态则降级运行，否则无法登录，服务不可用
+//3. 只读副本不可用时读请求转到主库，降级运行
+func (r *HealthUseCase) Check(ctx context.Context) *Health {
+	db := r.repo.CheckDB(ctx)
+	redis := r.repo.CheckRedis(ctx)
+	health := &Health{
+		Status:     HealthOk,
+		Components: []*ComponentHealth{db, redis},
+	}
+	if redis.Status != HealthOk {
+		if r.repo.SessionFallback() {
+			redis.Status = HealthDegraded
+			health.Status = HealthDegraded
+		} else {
+			health.Status = HealthUnavailable
+		}
+	}
+	for _, replica := range r.repo.CheckReplicas(ctx) {
+		if replica.Status != HealthOk {
+			replica.Status = HealthDegraded
+			if health.Status == HealthOk {
+				health.Status = HealthDegraded
+			}
+		}
+		health.Components = append(health.Components, replica)
+	}
+	if db.Status != HealthOk {
+		health.Status = HealthUnavailable
+	}
+	if health.Status != HealthOk {
+		for _, component := range health.Components {
+			if component.Status != HealthOk {
+				r.log.Warnf("service health %s: %s(%s %s)", health.Status, component.Name, component.Status, component.Detail)
+			}
+		}
+	}
+	return health
+}
+
+// CacheStats 缓存命中统计
+func (r *HealthUseCase) CacheStats() []*CacheStats {
+	return r.repo.CacheStats()
+}
@@ -0,0 +1,454 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"time"
+)
+
+type IdentityRepo interface {
+	CreateIdentity(ctx context.Context, identity *Identity) error
+	GetIdentity(ctx context.Context, provider, subject string) (*Identity, error)
+	GetIdentityById(ctx context.Context, id int64) (*Identity, error)
+	ListIdentities(ctx context.Context, userId int32) ([]*Identity, error)
+	CountIdentities(ctx context.Context, userId int32) (int64, error)
+	DeleteIdentity(ctx context.Context, id int64) error
+	UpdateIdentityLogin(ctx context.Context, id int64, email string) error
+	UseSAMLAssertion(ctx context.Context, assertionId string, timeout time.Duration) (bool, error)
+	SetExternalLoginState(ctx context.Context, stateHash string, state *ExternalLoginState, timeout time.Duration) error
+	TakeExternalLoginState(ctx context.Context, stateHash string) (*ExternalLoginState, error)
+}
+
+// ExternalProviders 已配置的第三方登录提供方，未配置时返回NotFound
+type ExternalProviders interface {
+	Get(name string) (ExternalProvider, error)
+}
+
+// ExternalProvider 第三方登录提供方，OIDC提供方校验id_token，其它提供方通过用户信息接口获取身份
+type ExternalProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, nonce, codeVerifier string) (*ExternalIdentity, error)
+}
+
+type IdentityUseCase struct {
+	repo      IdentityRepo
+	providers ExternalProviders
+	authRepo  AuthRepo
+	userRepo  UserRepo
+	auth      *AuthRepoUseCase
+	tm        Transaction
+	conf      *conf.UserConstant
+	audit     *AuditRecorder
+	history   *LoginHistoryUseCase
+	event     *EventUseCase
+	log       *log.Helper
+}
+
+const (
+	AuditActionIdentityLink   = "identity.link"
+	AuditActionIdentityUnlink = "identity.unlink"
+)
+
+// 第三方登录state有效期
+const externalLoginStateTimeout = 10 * time.Minute
+
+// Identity 用户绑定的第三方账号，provider和subject唯一
+type Identity struct {
+	Id            int64
+	UserId        int32
+	Provider      string
+	Subject       string
+	Email         string
+	CreateTime    time.Time
+	LastLoginTime time.Time
+}
+
+type ExternalLogin struct {
+	Provider   string `validate:"required,max=64" comment:"第三方登录提供方"`
+	VerifyCode string `validate:"omitempty,len=6,numeric" comment:"验证码"`
+}
+
+type UnlinkIdentity struct {
+	Id int64 `validate:"required,gt=0" comment:"第三方账号Id"`
+}
+
+// ExternalIdentity 第三方返回的用户身份
+type ExternalIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Phone         string
+	AvatarUrl     string
+}
+
+// ExternalLoginState 发起第三方登录时保存的状态，LinkUserId不为0时为绑定账号，RequestId为SAML AuthnRequest的id，
+// VerifyCode为发起登录时携带的二次验证码
+type ExternalLoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+	LinkUserId   int32  `json:"linkUserId"`
+	RequestId    string `json:"requestId"`
+	VerifyCode   string `json:"verifyCode"`
+}
+
+func NewIdentityUseCase(repo IdentityRepo, providers ExternalProviders, authRepo AuthRepo, userRepo UserRepo, auth *AuthRepoUseCase, tm Transaction, conf *conf.UserConstant, audit *AuditRecorder, history *LoginHistoryUseCase, event *EventUseCase, logger log.Logger) *IdentityUseCase {
+	return &IdentityUseCase{
+		repo:      repo,
+		providers: providers,
+		authRepo:  authRepo,
+		userRepo:  userRepo,
+		auth:      auth,
+		tm:        tm,
+		conf:      conf,
+		audit:     audit,
+		history:   history,
+		event:     event,
+		log:       log.NewHelper(log.With(logger, "module", "user/biz/identityUseCase")),
+	}
+}
+
+// ExternalLogin 发起第三方登录，返回第三方授权地址，verifyCode在回调时用于未识别登录的二次验证
+func (r *IdentityUseCase) ExternalLogin(ctx context.Context, provider, verifyCode string) (string, error) {
+	return r.authorizeURL(ctx, provider, &ExternalLoginState{VerifyCode: verifyCode})
+}
+
+// LinkIdentity 登录会话或个人访问令牌的用户发起绑定第三方账号，返回第三方授权地址
+func (r *IdentityUseCase) LinkIdentity(ctx context.Context, provider string) (string, error) {
+	user, err := r.loginUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	return r.authorizeURL(ctx, provider, &ExternalLoginState{LinkUserId: user.Id})
+}
+
+// loginUser 第三方账号只能由登录会话或个人访问令牌的用户管理，请求头中的用户id本身不能作为凭据
+func (r *IdentityUseCase) loginUser(ctx context.Context) (*User, error) {
+	userId, _ := ctx.Value("userId").(int32)
+	user, err := sessionUser(ctx, r.userRepo, userId)
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorLoginStateTimeout("")
+	}
+	if errors.Is(err, ErrSessionUnavailable) {
+		return nil, v1.ErrorSessionUnavailable("%s", err.Error())
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return user, nil
+}
+
+// authorizeURL 生成第三方授权地址
+//1. state、nonce、PKCE code_verifier均随机生成，state只保存摘要
+//2. state一次有效，回调时取出并删除
+func (r *IdentityUseCase) authorizeURL(ctx context.Context, provider string, loginState *ExternalLoginState) (string, error) {
+	p, err := r.providers.Get(provider)
+	if kerrors.IsNotFound(err) {
+		return "", v1.ErrorExternalLoginFailed("unknown provider: %s", provider)
+	}
+	if err != nil {
+		return "", v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	verifier, err := randomToken()
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	loginState.Provider, loginState.Nonce, loginState.CodeVerifier = provider, nonce, verifier
+	err = r.repo.SetExternalLoginState(ctx, hashToken(state), loginState, externalLoginStateTimeout)
+	if err != nil {
+		return "", v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	authorizeUrl, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	return authorizeUrl, nil
+}
+
+// ExternalLoginCallback 第三方登录回调
+//1. 校验state，换取第三方身份
+//2. 发起时为绑定账号的，绑定到当前用户
+//3. 否则按第三方身份登录，返回登录的用户
+func (r *IdentityUseCase) ExternalLoginCallback(ctx context.Context, provider, code, state, errCode string) (*User, error) {
+	if errCode != "" {
+		return nil, v1.ErrorExternalLoginFailed("provider(%s) error: %s", provider, errCode)
+	}
+	if code == "" || state == "" {
+		return nil, v1.ErrorExternalLoginFailed("code or state missing")
+	}
+	loginState, err := r.repo.TakeExternalLoginState(ctx, hashToken(state))
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorExternalLoginFailed("state expired")
+	}
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	if loginState.Provider != provider {
+		return nil, v1.ErrorExternalLoginFailed("provider mismatch: %s", provider)
+	}
+
+	p, err := r.providers.Get(provider)
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("unknown provider: %s", provider)
+	}
+	external, err := p.Exchange(ctx, code, loginState.Nonce, loginState.CodeVerifier)
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	if external.Subject == "" {
+		return nil, v1.ErrorExternalLoginFailed("provider(%s) subject missing", provider)
+	}
+
+	if loginState.LinkUserId != 0 {
+		return r.linkIdentity(ctx, loginState.LinkUserId, provider, external)
+	}
+	return r.externalLogin(ctx, provider, LoginMethodExternal, external, loginState.VerifyCode)
+}
+
+// externalLogin 第三方身份登录
+//1. 已绑定的第三方账号直接登录绑定的用户
+//2. 未绑定时，邮箱已验证且只对应一个本地用户的，自动绑定到该用户
+//3. 否则自动开通新用户并绑定，开启注册审核时新用户为待审核
+//4. 与账号密码登录相同，按配置要求通行密钥二次验证，再经同一流程校验账户、识别未识别的登录并存储session
+//5. 无论成功失败都记录审计日志和登录历史
+func (r *IdentityUseCase) externalLogin(ctx context.Context, provider, method string, external *ExternalIdentity, verifyCode string) (user *User, err error) {
+	auditLog := &AuditLog{Action: AuditActionUserLogin, Detail: fmt.Sprintf("provider(%s), subject(%s)", provider, external.Subject)}
+	history := newLoginHistory(ctx, "", method)
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+		r.history.Record(ctx, history, err)
+	}()
+
+	user, err = r.resolveUser(ctx, provider, external)
+	if err != nil {
+		return nil, err
+	}
+	auditLog.ActorId, auditLog.TargetId = user.Id, user.Id
+	history.UserId, history.UserAccount = user.Id, user.UserAccount
+
+	err = r.auth.requirePasskey(ctx, user, history.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	err = r.auth.startSession(ctx, user, history, verifyCode, false)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// resolveUser 查询第三方身份对应的本地用户，没有时绑定或开通
+func (r *IdentityUseCase) resolveUser(ctx context.Context, provider string, external *ExternalIdentity) (*User, error) {
+	identity, err := r.repo.GetIdentity(ctx, provider, external.Subject)
+	if err == nil {
+		err = r.repo.UpdateIdentityLogin(ctx, identity.Id, external.Email)
+		if err != nil {
+			r.log.Errorf("fail to update identity login: id(%v), error(%v)", identity.Id, err)
+		}
+		user, err := r.userRepo.GetCurrentUser(ctx, identity.UserId)
+		if err != nil {
+			return nil, v1.ErrorUserLoginFailed("%s", err.Error())
+		}
+		return user, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+
+	var user *User
+	if external.Email != "" && external.EmailVerified {
+		users, err := r.userRepo.ListUsersByEmail(ctx, external.Email)
+		if err != nil {
+			return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+		}
+		if len(users) > 1 {
+			return nil, v1.ErrorExternalLoginFailed("email(%s) matches multiple users", external.Email)
+		}
+		if len(users) == 1 {
+			user = users[0]
+		}
+	}
+
+	created := user == nil
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		if created {
+			user, err = r.createUser(ctx, provider, external)
+			if err != nil {
+				return err
+			}
+		}
+		return r.repo.CreateIdentity(ctx, &Identity{
+			UserId:        user.Id,
+			Provider:      provider,
+			Subject:       external.Subject,
+			Email:         external.Email,
+			LastLoginTime: time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	if created {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionUserRegister, ActorId: user.Id, TargetId: user.Id,
+			Detail: fmt.Sprintf("userAccount(%s), provider(%s)", user.UserAccount, provider)}, nil)
+	} else {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionIdentityLink, ActorId: user.Id, TargetId: user.Id,
+			Detail: fmt.Sprintf("provider(%s), subject(%s), email(%s)", provider, external.Subject, external.Email)}, nil)
+	}
+	return user, nil
+}
+
+// createUser 开通第三方登录的用户，账号由提供方标识加随机串生成，没有本地密码
+func (r *IdentityUseCase) createUser(ctx context.Context, provider string, external *ExternalIdentity) (*User, error) {
+	buf := make([]byte, 4)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "generate user account error")
+	}
+	user := &User{
+		UserAccount: fmt.Sprintf("%s_%s", provider, hex.EncodeToString(buf)),
+		UserName:    external.Name,
+		Phone:       external.Phone,
+		AvatarUrl:   external.AvatarUrl,
+		UserStatus:  UserStatusNormal,
+		Role:        r.conf.DefaultRole,
+	}
+	if external.EmailVerified {
+		user.Email = external.Email
+	}
+	if r.conf.RegisterApproval {
+		user.UserStatus = UserStatusPending
+	}
+	user.Id, err = r.authRepo.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	err = r.event.Emit(ctx, NewUserEvent(EventUserRegistered, user.Id, user))
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// linkIdentity 绑定第三方账号到发起绑定的用户
+//1. 回调时仍需登录会话，且必须由发起绑定的用户完成，防止将第三方账号绑定到他人账户
+//2. 第三方账号已绑定其它用户时拒绝
+func (r *IdentityUseCase) linkIdentity(ctx context.Context, linkUserId int32, provider string, external *ExternalIdentity) (user *User, err error) {
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionIdentityLink, ActorId: linkUserId, TargetId: linkUserId,
+			Detail: fmt.Sprintf("provider(%s), subject(%s)", provider, external.Subject)}, err)
+	}()
+
+	current, err := r.loginUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := current.Id
+	if userId != linkUserId {
+		return nil, v1.ErrorPermissionDeny("link user mismatch: userId(%v)", userId)
+	}
+
+	identity, err := r.repo.GetIdentity(ctx, provider, external.Subject)
+	switch {
+	case err == nil && identity.UserId != userId:
+		return nil, v1.ErrorExternalLoginFailed("identity linked to another user: provider(%s)", provider)
+	case err == nil:
+	case kerrors.IsNotFound(err):
+		err = r.repo.CreateIdentity(ctx, &Identity{
+			UserId:        userId,
+			Provider:      provider,
+			Subject:       external.Subject,
+			Email:         external.Email,
+			LastLoginTime: time.Now(),
+		})
+		if err != nil {
+			return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+		}
+	default:
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+
+	user, err = r.userRepo.GetCurrentUser(ReadPrimary(ctx), userId)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return user, nil
+}
+
+// ListIdentities 当前用户绑定的第三方账号
+func (r *IdentityUseCase) ListIdentities(ctx context.Context) ([]*Identity, error) {
+	user, err := r.loginUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := r.repo.ListIdentities(ctx, user.Id)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return identities, nil
+}
+
+// UnlinkIdentity 解绑第三方账号
+//1. 需要登录会话，只能解绑自己的第三方账号，LDAP账号由目录服务管理，不能解绑
+//2. 没有本地密码时至少保留一个第三方账号，避免无法登录
+//3. 事务中锁定用户的第三方账号后再检查，避免并发解绑
+func (r *IdentityUseCase) UnlinkIdentity(ctx context.Context, id int64) (err error) {
+	userId, _ := ctx.Value("userId").(int32)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionIdentityUnlink, ActorId: userId, TargetId: userId,
+			Detail: fmt.Sprintf("id(%v)", id)}, err)
+	}()
+
+	_, err = r.loginUser(ctx)
+	if err != nil {
+		return err
+	}
+	identity, err := r.repo.GetIdentityById(ctx, id)
+	if kerrors.IsNotFound(err) || (err == nil && identity.UserId != userId) {
+		return v1.ErrorPermissionDeny("identity not found: id(%v)", id)
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	if identity.Provider == AuthenticatorLDAP {
+		return v1.ErrorPermissionDeny("ldap identity managed by directory: id(%v)", id)
+	}
+	// 用户信息缓存中没有密码，读主库判断是否设置了密码
+	user, err := r.userRepo.GetCurrentUser(ReadPrimary(ctx), userId)
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		count, err := r.auth.loginMethods(ctx, user)
+		if err != nil {
+			return err
+		}
+		if count <= 1 {
+			return v1.ErrorIdentityUnlinkDenied("last login method: userId(%v)", userId)
+		}
+		err = r.repo.DeleteIdentity(ctx, id)
+		if err != nil {
+			return v1.ErrorUnknownError("%s", err.Error())
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,194 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"time"
+)
+
+// ImpersonationUseCase 管理员模拟用户登录，用于客服复现用户看到的内容
+type ImpersonationUseCase struct {
+	authRepo AuthRepo
+	userRepo UserRepo
+	audit    *AuditRecorder
+	conf     *conf.UserConstant
+	log      *log.Helper
+}
+
+// ImpersonationTokenPrefix 模拟登录令牌前缀，用于区分其他Bearer令牌
+const ImpersonationTokenPrefix = "uci_"
+
+// 模拟登录会话默认有效期
+const defaultImpersonationTimeout = 15 * time.Minute
+
+const (
+	AuditActionImpersonationStart   = "impersonation.start"
+	AuditActionImpersonationStop    = "impersonation.stop"
+	AuditActionImpersonationRequest = "impersonation.request"
+)
+
+// 模拟登录期间允许调用的接口，只包含查看用户自己信息的只读接口和结束模拟登录，新增的接口默认禁止
+var impersonationAllowedOperations = []string{
+	v1.UserService_GetCurrentUser_FullMethodName,
+	v1.UserService_ListMyLoginHistory_FullMethodName,
+	v1.UserService_ListMySessions_FullMethodName,
+	v1.UserService_ListIdentities_FullMethodName,
+	v1.UserService_ListAccessTokens_FullMethodName,
+	v1.UserService_ListPasskeys_FullMethodName,
+	v1.UserService_GetOAuthConsent_FullMethodName,
+	v1.UserService_StopImpersonation_FullMethodName,
+}
+
+// Impersonation 模拟登录会话，同时记录管理员和被模拟的用户
+type Impersonation struct {
+	AdminId    int32     `json:"adminId"`
+	UserId     int32     `json:"userId"`
+	Reason     string    `json:"reason"`
+	ExpireTime time.Time `json:"expireTime"`
+}
+
+type StartImpersonation struct {
+	UserId int32  `validate:"required,gt=0" comment:"用户Id"`
+	Reason string `validate:"required,max=256" comment:"原因"`
+}
+
+func NewImpersonationUseCase(authRepo AuthRepo, userRepo UserRepo, audit *AuditRecorder, conf *conf.UserConstant, logger log.Logger) *ImpersonationUseCase {
+	return &ImpersonationUseCase{
+		authRepo: authRepo,
+		userRepo: userRepo,
+		audit:    audit,
+		conf:     conf,
+		log:      log.NewHelper(log.With(logger, "module", "user/biz/impersonationUseCase")),
+	}
+}
+
+// StartImpersonation 开始模拟登录
+//1. 只能由管理员在登录会话中发起
+//2. 不能模拟自己、管理员和服务账号，被模拟的用户必须可以正常登录
+//3. 生成模拟登录令牌，会话只保存在缓存中，到期自动失效
+//4. 记录审计日志
+func (r *ImpersonationUseCase) StartImpersonation(ctx context.Context, start *StartImpersonation) (user *User, impersonation *Impersonation, secret string, err error) {
+	adminId := ctx.Value("userId").(int32)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionImpersonationStart, ActorId: adminId, TargetId: start.UserId, Detail: fmt.Sprintf("reason(%s)", start.Reason)}, err)
+	}()
+
+	if _, ok := ctx.Value(principalKey{}).(*principal); ok {
+		return nil, nil, "", v1.ErrorPermissionDeny("impersonation can only be started in login session")
+	}
+	err = checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if start.UserId == adminId {
+		return nil, nil, "", v1.ErrorImpersonationFailed("can not impersonate self")
+	}
+	user, err = r.userRepo.GetCurrentUser(ctx, start.UserId)
+	if kerrors.IsNotFound(err) {
+		return nil, nil, "", v1.ErrorImpersonationFailed("user not found: userId(%v)", start.UserId)
+	}
+	if err != nil {
+		return nil, nil, "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	if user.Role == r.conf.AdminRole {
+		return nil, nil, "", v1.ErrorImpersonationFailed("can not impersonate admin: userId(%v)", user.Id)
+	}
+	err = validateLoginUser(user)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	random, err := randomToken()
+	if err != nil {
+		return nil, nil, "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	secret = ImpersonationTokenPrefix + random
+	timeout := r.timeout()
+	impersonation = &Impersonation{
+		AdminId:    adminId,
+		UserId:     user.Id,
+		Reason:     start.Reason,
+		ExpireTime: time.Now().Add(timeout),
+	}
+	err = r.authRepo.SetImpersonation(ctx, hashToken(secret), impersonation, timeout)
+	if err != nil {
+		return nil, nil, "", v1.ErrorImpersonationFailed("%s", err.Error())
+	}
+	return user, impersonation, secret, nil
+}
+
+// StopImpersonation 使用模拟登录令牌结束模拟登录，令牌立即失效
+func (r *ImpersonationUseCase) StopImpersonation(ctx context.Context, secret string) (err error) {
+	impersonation, err := r.authRepo.GetImpersonation(ctx, hashToken(secret))
+	if kerrors.IsNotFound(err) {
+		return v1.ErrorLoginStateTimeout("")
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionImpersonationStop, ActorId: impersonation.AdminId, TargetId: impersonation.UserId}, err)
+	}()
+
+	err = r.authRepo.DeleteImpersonation(ctx, hashToken(secret))
+	if err != nil {
+		return v1.ErrorImpersonationFailed("%s", err.Error())
+	}
+	return nil
+}
+
+// Authenticate 校验模拟登录令牌，通过后以被模拟的用户作为当前用户
+//1. 会话过期或已结束时返回登录过期
+//2. 只允许调用只读接口，修改凭证、登录方式、会话和数据的接口都禁止
+//3. 被模拟的用户必须仍可正常登录
+//4. 每次请求都以管理员身份记录审计日志
+func (r *ImpersonationUseCase) Authenticate(ctx context.Context, secret, operation string) (_ context.Context, err error) {
+	impersonation, err := r.authRepo.GetImpersonation(ctx, hashToken(secret))
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorLoginStateTimeout("impersonation expired")
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionImpersonationRequest, ActorId: impersonation.AdminId, TargetId: impersonation.UserId, Detail: fmt.Sprintf("operation(%s)", operation)}, err)
+	}()
+
+	if !impersonationAllowed(operation) {
+		return nil, v1.ErrorPermissionDeny("operation not allowed during impersonation: %s", operation)
+	}
+	user, err := r.userRepo.GetCurrentUser(ctx, impersonation.UserId)
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorLoginStateTimeout("impersonated user not found")
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	err = validateLoginUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, "userId", user.Id)
+	return context.WithValue(ctx, principalKey{}, &principal{user: user, impersonatorId: impersonation.AdminId}), nil
+}
+
+func impersonationAllowed(operation string) bool {
+	for _, allowed := range impersonationAllowedOperations {
+		if allowed == operation {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ImpersonationUseCase) timeout() time.Duration {
+	if r.conf.ImpersonationTimeout > 0 {
+		return time.Duration(r.conf.ImpersonationTimeout) * time.Second
+	}
+	return defaultImpersonationTimeout
+}
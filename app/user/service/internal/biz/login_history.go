@@ -0,0 +1,146 @@
+package biz
+
+import (
+	"context"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"time"
+)
+
+type LoginHistoryRepo interface {
+	CreateLoginHistory(ctx context.Context, history *LoginHistory) error
+	ListLoginHistory(ctx context.Context, userId, page, pageSize int32) ([]*LoginHistory, int64, error)
+	ListSuccessLoginHistory(ctx context.Context, userId int32, limit int) ([]*LoginHistory, error)
+	PruneLoginHistory(ctx context.Context, before time.Time) (int64, error)
+}
+
+type LoginHistoryUseCase struct {
+	repo LoginHistoryRepo
+	log  *log.Helper
+	conf *conf.UserConstant
+}
+
+const (
+	LoginMethodPassword  = "password"
+	LoginMethodExternal  = "external"
+	LoginMethodLDAP      = "ldap"
+	LoginMethodSAML      = "saml"
+	LoginMethodMagicLink = "magic_link"
+	LoginMethodPasskey   = "passkey"
+)
+
+// LoginHistory 用户登录历史
+type LoginHistory struct {
+	Id           int64
+	UserId       int32
+	UserAccount  string
+	Ip           string
+	UserAgent    string
+	Fingerprint  string
+	Method       string
+	Success      bool
+	Unrecognized bool
+	Reason       string
+	CreateTime   time.Time
+}
+
+// LoginSession 登录会话，来自建立会话的那次成功登录
+type LoginSession struct {
+	Ip           string
+	UserAgent    string
+	Method       string
+	LoginTime    time.Time
+	Unrecognized bool
+}
+
+type ListLoginHistory struct {
+	UserId   int32 `validate:"gte=0" comment:"用户Id"`
+	Page     int32 `validate:"gte=0" comment:"页码"`
+	PageSize int32 `validate:"gte=0,lte=100" comment:"每页数量"`
+}
+
+func NewLoginHistoryUseCase(repo LoginHistoryRepo, logger log.Logger, conf *conf.UserConstant) *LoginHistoryUseCase {
+	return &LoginHistoryUseCase{
+		repo: repo,
+		log:  log.NewHelper(log.With(logger, "module", "user/biz/loginHistoryUseCase")),
+		conf: conf,
+	}
+}
+
+// newLoginHistory 根据请求上下文生成一条登录记录
+func newLoginHistory(ctx context.Context, userAccount, method string) *LoginHistory {
+	history := &LoginHistory{
+		UserAccount: userAccount,
+		Method:      method,
+		Fingerprint: deviceFingerprint(ctx),
+	}
+	history.Ip, _ = ctx.Value("clientIp").(string)
+	history.UserAgent, _ = ctx.Value("userAgent").(string)
+	return history
+}
+
+// Record 记录一次登录，写入失败只打印日志，不影响登录
+func (r *LoginHistoryUseCase) Record(ctx context.Context, history *LoginHistory, err error) {
+	history.Success = err == nil
+	if err != nil {
+		history.Reason = kerrors.FromError(err).Reason
+	}
+
+	rerr := r.repo.CreateLoginHistory(ctx, history)
+	if rerr != nil {
+		r.log.Errorf("fail to record login history: userAccount(%s), error(%v)", history.UserAccount, rerr)
+	}
+}
+
+// List 分页查询用户的登录历史
+func (r *LoginHistoryUseCase) List(ctx context.Context, userId, page, pageSize int32) ([]*LoginHistory, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	return r.repo.ListLoginHistory(ctx, userId, page, pageSize)
+}
+
+// Sessions 用户当前的登录会话，每个用户只保留最近一次登录的会话，取最近一次成功登录的记录
+func (r *LoginHistoryUseCase) Sessions(ctx context.Context, userId int32) ([]*LoginSession, error) {
+	history, err := r.repo.ListSuccessLoginHistory(ctx, userId, 1)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*LoginSession, 0, len(history))
+	for _, item := range history {
+		sessions = append(sessions, &LoginSession{
+			Ip:           item.Ip,
+			UserAgent:    item.UserAgent,
+			Method:       item.Method,
+			LoginTime:    item.CreateTime,
+			Unrecognized: item.Unrecognized,
+		})
+	}
+	return sessions, nil
+}
+
+// PruneJob 按保留时间定期清理过期的登录历史，未配置保留时间时永久保留
+func (r *LoginHistoryUseCase) PruneJob() *Job {
+	return &Job{
+		Name:     "login-history-prune",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			if r.conf.LoginHistoryRetention <= 0 {
+				return nil
+			}
+			before := time.Now().Add(-time.Second * time.Duration(r.conf.LoginHistoryRetention))
+			count, err := r.repo.PruneLoginHistory(ctx, before)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				r.log.Infof("pruned login history: count(%v), before(%v)", count, before)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,179 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net"
+	"time"
+)
+
+// GeoLocator 根据ip解析地理位置，无法解析时返回空字符串
+type GeoLocator interface {
+	Locate(ctx context.Context, ip string) (string, error)
+}
+
+// LoginRiskRule 登录风险规则，返回true表示本次登录与账户的历史登录不符
+type LoginRiskRule interface {
+	Unrecognized(ctx context.Context, attempt *LoginHistory, history []*LoginHistory) bool
+}
+
+// LoginRiskRules 按配置启用的风险规则
+type LoginRiskRules []LoginRiskRule
+
+const (
+	LoginRiskRuleDevice  = "device"
+	LoginRiskRuleIpRange = "ip_range"
+	LoginRiskRuleGeo     = "geo"
+)
+
+// LoginRiskDetector 新设备、异常地点登录识别
+type LoginRiskDetector struct {
+	repo   LoginHistoryRepo
+	rules  LoginRiskRules
+	mailer Mailer
+	log    *log.Helper
+}
+
+// 参与比对的历史登录条数
+const loginRiskHistorySize = 50
+
+// NewLoginRiskRules 按配置创建风险规则，未配置时启用全部规则
+func NewLoginRiskRules(geo GeoLocator, conf *conf.UserConstant, logger log.Logger) LoginRiskRules {
+	l := log.NewHelper(log.With(logger, "module", "user/biz/loginRiskRules"))
+	names := conf.LoginRiskRules
+	if len(names) == 0 {
+		names = []string{LoginRiskRuleDevice, LoginRiskRuleIpRange, LoginRiskRuleGeo}
+	}
+
+	rules := make(LoginRiskRules, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case LoginRiskRuleDevice:
+			rules = append(rules, &deviceRiskRule{})
+		case LoginRiskRuleIpRange:
+			rules = append(rules, &ipRangeRiskRule{})
+		case LoginRiskRuleGeo:
+			rules = append(rules, &geoRiskRule{geo: geo})
+		default:
+			l.Warnf("unknown login risk rule: %s", name)
+		}
+	}
+	return rules
+}
+
+func NewLoginRiskDetector(repo LoginHistoryRepo, rules LoginRiskRules, mailer Mailer, logger log.Logger) *LoginRiskDetector {
+	return &LoginRiskDetector{
+		repo:   repo,
+		rules:  rules,
+		mailer: mailer,
+		log:    log.NewHelper(log.With(logger, "module", "user/biz/loginRiskDetector")),
+	}
+}
+
+// Detect 判断本次登录是否来自未使用过的设备或ip段
+//1. 账户没有成功登录过时不做判断
+//2. 任意一条规则不符即视为未识别的登录
+func (d *LoginRiskDetector) Detect(ctx context.Context, attempt *LoginHistory) bool {
+	history, err := d.repo.ListSuccessLoginHistory(ctx, attempt.UserId, loginRiskHistorySize)
+	if err != nil {
+		d.log.Errorf("fail to list login history: userId(%v), error(%v)", attempt.UserId, err)
+		return false
+	}
+	if len(history) == 0 {
+		return false
+	}
+	for _, rule := range d.rules {
+		if rule.Unrecognized(ctx, attempt, history) {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify 邮件提醒用户有未识别的登录
+func (d *LoginRiskDetector) Notify(ctx context.Context, user *User, attempt *LoginHistory) {
+	if user.Email == "" {
+		return
+	}
+
+	body := fmt.Sprintf("您好，您的账号 %s 于 %s 在新的设备或地点登录。\nIP：%s\n设备：%s\n如果不是您本人操作，请尽快修改密码。",
+		user.UserAccount, time.Now().Format("2006-01-02 15:04:05"), attempt.Ip, attempt.UserAgent)
+	err := d.mailer.Send(ctx, user.Email, "新设备登录提醒", body)
+	if err != nil {
+		d.log.Errorf("fail to notify unrecognized login: userId(%v), error(%v)", user.Id, err)
+	}
+}
+
+// deviceFingerprint 设备指纹，优先使用客户端上报的设备id，否则使用user agent摘要
+func deviceFingerprint(ctx context.Context) string {
+	if deviceId, _ := ctx.Value("deviceId").(string); deviceId != "" {
+		return deviceId
+	}
+	userAgent, _ := ctx.Value("userAgent").(string)
+	sum := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(sum[:8])
+}
+
+type deviceRiskRule struct{}
+
+func (r *deviceRiskRule) Unrecognized(_ context.Context, attempt *LoginHistory, history []*LoginHistory) bool {
+	for _, item := range history {
+		if item.Fingerprint == attempt.Fingerprint {
+			return false
+		}
+	}
+	return true
+}
+
+// ipRangeRiskRule ipv4按/24，ipv6按/48比对ip段
+type ipRangeRiskRule struct{}
+
+func (r *ipRangeRiskRule) Unrecognized(_ context.Context, attempt *LoginHistory, history []*LoginHistory) bool {
+	network := ipRange(attempt.Ip)
+	if network == "" {
+		return false
+	}
+	for _, item := range history {
+		if ipRange(item.Ip) == network {
+			return false
+		}
+	}
+	return true
+}
+
+func ipRange(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
+
+// geoRiskRule 地理位置比对，未接入GeoLocator或无法解析时不做判断
+type geoRiskRule struct {
+	geo GeoLocator
+}
+
+func (r *geoRiskRule) Unrecognized(ctx context.Context, attempt *LoginHistory, history []*LoginHistory) bool {
+	if r.geo == nil {
+		return false
+	}
+	location, err := r.geo.Locate(ctx, attempt.Ip)
+	if err != nil || location == "" {
+		return false
+	}
+	for _, item := range history {
+		known, err := r.geo.Locate(ctx, item.Ip)
+		if err == nil && known == location {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package biz
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 免密登录链接默认有效期和请求限流
+const (
+	defaultMagicLinkTimeout     = 10 * time.Minute
+	defaultMagicLinkEmailLimit  = 5
+	defaultMagicLinkIpLimit     = 20
+	defaultMagicLinkLimitWindow = time.Hour
+)
+
+const AuditActionMagicLinkRequest = "user.magic_link.request"
+
+// MagicLink 免密登录链接，只能使用一次，且只能在请求链接的浏览器中使用
+//1. 请求链接时生成随机的浏览器凭证，通过HttpOnly Cookie下发，缓存中只保存摘要
+//2. 使用链接时必须携带相同的凭证，仅获取到邮件中的链接无法登录
+type MagicLink struct {
+	UserId     int32     `json:"userId"`
+	NonceHash  string    `json:"nonceHash"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+type RequestMagicLink struct {
+	Email string `validate:"required,email,max=512" comment:"邮箱"`
+}
+
+type MagicLinkLogin struct {
+	Token string `validate:"required,max=128" comment:"登录令牌"`
+}
+
+// RequestMagicLink 发送免密登录链接，返回需要写入浏览器Cookie的凭证
+//1. 判断是否开启免密登录，按ip和邮箱限流
+//2. 生成浏览器凭证，按邮箱查询可以登录的账户，每个账户生成一个链接，令牌摘要和凭证摘要存入缓存
+//3. 所有链接通过一封邮件发送，邮箱不存在或没有可登录的账户时同样返回成功和凭证，避免泄露邮箱是否注册
+//4. 记录审计日志
+func (r *AuthRepoUseCase) RequestMagicLink(ctx context.Context, email string) (nonce string, err error) {
+	auditLog := &AuditLog{Action: AuditActionMagicLinkRequest, Detail: fmt.Sprintf("email(%s)", email)}
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	if !r.conf.MagicLinkLogin {
+		return "", v1.ErrorPermissionDeny("magic link login disabled")
+	}
+	err = r.checkMagicLinkLimit(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	nonce, err = randomToken()
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	users, err := r.userRepo.ListUsersByEmail(ctx, email)
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	timeout := r.magicLinkTimeout()
+	lines := make([]string, 0, len(users))
+	for _, user := range users {
+		if validateLoginUser(user) != nil {
+			continue
+		}
+		token, err := randomToken()
+		if err != nil {
+			return "", v1.ErrorUnknownError("%s", err.Error())
+		}
+		err = r.repo.SetMagicLink(ctx, hashToken(token), &MagicLink{UserId: user.Id, NonceHash: hashToken(nonce), CreateTime: time.Now()}, timeout)
+		if err != nil {
+			return "", v1.ErrorUnknownError("%s", err.Error())
+		}
+		lines = append(lines, fmt.Sprintf("账号 %s：%s", user.UserAccount, r.magicLinkUrl(token)))
+	}
+	if len(lines) == 0 {
+		return nonce, nil
+	}
+	auditLog.ActorId, auditLog.TargetId = users[0].Id, users[0].Id
+
+	body := fmt.Sprintf("您好，请在发起请求的浏览器中打开以下链接登录，%v分钟内有效且只能使用一次。如果不是您本人操作，请忽略本邮件。\n%s",
+		int(timeout.Minutes()), strings.Join(lines, "\n"))
+	err = r.mailer.Send(ctx, email, "登录链接", body)
+	if err != nil {
+		return "", v1.ErrorUnknownError("send magic link failed: %s", err.Error())
+	}
+	return nonce, nil
+}
+
+// checkMagicLinkLimit 按ip和邮箱分别计数，窗口内超过次数时拒绝，邮箱不存在时同样计数
+func (r *AuthRepoUseCase) checkMagicLinkLimit(ctx context.Context, email string) error {
+	window := defaultMagicLinkLimitWindow
+	if r.conf.MagicLinkLimitWindow > 0 {
+		window = time.Duration(r.conf.MagicLinkLimitWindow) * time.Second
+	}
+	ipLimit, emailLimit := int64(defaultMagicLinkIpLimit), int64(defaultMagicLinkEmailLimit)
+	if r.conf.MagicLinkIpLimit > 0 {
+		ipLimit = int64(r.conf.MagicLinkIpLimit)
+	}
+	if r.conf.MagicLinkEmailLimit > 0 {
+		emailLimit = int64(r.conf.MagicLinkEmailLimit)
+	}
+
+	ip, _ := ctx.Value("clientIp").(string)
+	limits := []struct {
+		key   string
+		limit int64
+	}{
+		{key: "ip_" + ip, limit: ipLimit},
+		{key: "email_" + strings.ToLower(email), limit: emailLimit},
+	}
+	for _, item := range limits {
+		count, err := r.repo.IncrMagicLinkRequest(ctx, item.key, window)
+		if err != nil {
+			return v1.ErrorUnknownError("%s", err.Error())
+		}
+		if count > item.limit {
+			return v1.ErrorMagicLinkRateLimited("too many magic link requests: %s", item.key)
+		}
+	}
+	return nil
+}
+
+// MagicLinkLogin 免密登录链接登录，nonce为请求链接时写入浏览器Cookie的凭证
+//1. 判断是否开启免密登录
+//2. 从缓存中取出并删除令牌，令牌只能使用一次
+//3. 校验浏览器凭证与请求链接时下发的一致
+//4. 与账号密码登录使用相同的会话创建流程，邮箱已通过链接验证，不再要求验证码二次验证
+//5. 无论成功失败都记录审计日志和登录历史
+func (r *AuthRepoUseCase) MagicLinkLogin(ctx context.Context, token, nonce string) (user *User, err error) {
+	auditLog := &AuditLog{Action: AuditActionUserLogin, Detail: "method(magic_link)"}
+	history := newLoginHistory(ctx, "", LoginMethodMagicLink)
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+		r.history.Record(ctx, history, err)
+	}()
+
+	if !r.conf.MagicLinkLogin {
+		return nil, v1.ErrorPermissionDeny("magic link login disabled")
+	}
+	link, err := r.repo.TakeMagicLink(ctx, hashToken(token))
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorMagicLinkInvalid("magic link not found")
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	auditLog.ActorId, auditLog.TargetId = link.UserId, link.UserId
+	history.UserId = link.UserId
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(link.NonceHash), []byte(hashToken(nonce))) != 1 {
+		return nil, v1.ErrorMagicLinkInvalid("magic link used in another browser: userId(%v)", link.UserId)
+	}
+
+	user, err = r.userRepo.GetCurrentUser(ctx, link.UserId)
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorMagicLinkInvalid("user not found: userId(%v)", link.UserId)
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	history.UserAccount = user.UserAccount
+
+	err = r.startSession(ctx, user, history, "", true)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *AuthRepoUseCase) magicLinkTimeout() time.Duration {
+	if r.conf.MagicLinkTimeout > 0 {
+		return time.Duration(r.conf.MagicLinkTimeout) * time.Second
+	}
+	return defaultMagicLinkTimeout
+}
+
+func (r *AuthRepoUseCase) magicLinkUrl(token string) string {
+	separator := "?"
+	if strings.Contains(r.conf.MagicLinkUrl, "?") {
+		separator = "&"
+	}
+	return r.conf.MagicLinkUrl + separator + "token=" + url.QueryEscape(token)
+}
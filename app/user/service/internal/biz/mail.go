@@ -0,0 +1,8 @@
+package biz
+
+import "context"
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
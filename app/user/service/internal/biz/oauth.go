@@ -0,0 +1,870 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type OAuthRepo interface {
+	CreateOAuthClient(ctx context.Context, client *OAuthClient) error
+	GetOAuthClient(ctx context.Context, clientId string) (*OAuthClient, error)
+	ListOAuthClients(ctx context.Context) ([]*OAuthClient, error)
+	DeleteOAuthClient(ctx context.Context, clientId string) error
+	GetOAuthConsent(ctx context.Context, userId int32, clientId string) (*OAuthConsent, error)
+	SaveOAuthConsent(ctx context.Context, consent *OAuthConsent) error
+	SetAuthorizationCode(ctx context.Context, codeHash string, code *OAuthAuthorizationCode, timeout time.Duration) error
+	TakeAuthorizationCode(ctx context.Context, codeHash string) (*OAuthAuthorizationCode, error)
+	CreateRefreshToken(ctx context.Context, token *OAuthRefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*OAuthRefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash string) (bool, error)
+}
+
+// TokenSigner 令牌签名，使用非对称密钥以便资源服务通过公钥验证
+type TokenSigner interface {
+	Sign(claims jwt.Claims) (string, error)
+	Parse(token string, claims jwt.Claims) error
+	JWKS() []*JSONWebKey
+}
+
+type OAuthUseCase struct {
+	repo     OAuthRepo
+	userRepo UserRepo
+	authRepo AuthRepo
+	signer   TokenSigner
+	tm       Transaction
+	conf     *conf.OAuth
+	uconf    *conf.UserConstant
+	audit    *AuditRecorder
+	log      *log.Helper
+}
+
+const (
+	OAuthGrantAuthorizationCode = "authorization_code"
+	OAuthGrantRefreshToken      = "refresh_token"
+	OAuthGrantClientCredentials = "client_credentials"
+)
+
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrUnauthorizedClient      = "unauthorized_client"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrAccessDenied            = "access_denied"
+	OAuthErrServerError             = "server_error"
+	OAuthErrLoginRequired           = "login_required"
+	OAuthErrConsentRequired         = "consent_required"
+	OAuthErrInvalidToken            = "invalid_token"
+	OAuthErrInsufficientScope       = "insufficient_scope"
+)
+
+const (
+	PKCEMethodS256  = "S256"
+	PKCEMethodPlain = "plain"
+)
+
+const (
+	AuditActionOAuthClientCreate = "oauth.client.create"
+	AuditActionOAuthClientDelete = "oauth.client.delete"
+	AuditActionOAuthConsent      = "oauth.consent"
+)
+
+const (
+	defaultAccessTokenTTL  = time.Hour
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	defaultCodeTTL         = 10 * time.Minute
+)
+
+// OAuthClient 接入的第三方应用，多个回调地址、授权类型、权限范围均以空格分隔
+type OAuthClient struct {
+	Id                     int64
+	ClientId               string
+	ClientSecretHash       string
+	Name                   string
+	RedirectUris           string
+	PostLogoutRedirectUris string
+	GrantTypes             string
+	Scopes                 string
+	Public                 bool
+	CreatorId              int32
+	CreateTime             time.Time
+}
+
+// OAuthConsent 用户对应用已授予的权限范围
+type OAuthConsent struct {
+	Id         int64
+	UserId     int32
+	ClientId   string
+	Scopes     string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// OAuthAuthorizationCode 授权码，只能使用一次
+type OAuthAuthorizationCode struct {
+	ClientId            string    `json:"clientId"`
+	UserId              int32     `json:"userId"`
+	RedirectUri         string    `json:"redirectUri"`
+	RedirectUriSupplied bool      `json:"redirectUriSupplied"` // 授权请求是否携带了redirect_uri，携带时兑换也必须携带相同的值
+	Scope               string    `json:"scope"`
+	CodeChallenge       string    `json:"codeChallenge"`
+	CodeChallengeMethod string    `json:"codeChallengeMethod"`
+	Nonce               string    `json:"nonce"`
+	AuthTime            time.Time `json:"authTime"`
+}
+
+// OAuthRefreshToken 刷新令牌，只保存摘要，使用后轮换
+type OAuthRefreshToken struct {
+	Id         int64
+	TokenHash  string
+	ClientId   string
+	UserId     int32
+	Scope      string
+	ExpireTime time.Time
+	Revoked    bool
+	CreateTime time.Time
+}
+
+// OAuthAccessClaims 访问令牌，sub为用户id，客户端凭证授权时为client_id
+type OAuthAccessClaims struct {
+	Scope    string `json:"scope,omitempty"`
+	ClientId string `json:"client_id"`
+	jwt.RegisteredClaims
+}
+
+// OAuthError 按RFC 6749返回给客户端的错误
+type OAuthError struct {
+	Code        string
+	Description string
+	StatusCode  int
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func newOAuthError(code, description string) *OAuthError {
+	statusCode := http.StatusBadRequest
+	switch code {
+	case OAuthErrInvalidClient, OAuthErrLoginRequired, OAuthErrInvalidToken:
+		statusCode = http.StatusUnauthorized
+	case OAuthErrAccessDenied, OAuthErrConsentRequired, OAuthErrInsufficientScope:
+		statusCode = http.StatusForbidden
+	case OAuthErrServerError:
+		statusCode = http.StatusInternalServerError
+	}
+	return &OAuthError{Code: code, Description: description, StatusCode: statusCode}
+}
+
+// OAuthAuthorizeRequest 授权请求参数
+type OAuthAuthorizeRequest struct {
+	ResponseType        string
+	ClientId            string
+	RedirectUri         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+}
+
+// OAuthAuthorizeResult 授权结果，RedirectUri不为空时跳转到该地址，未配置登录页或授权页时只返回需要登录或授权
+type OAuthAuthorizeResult struct {
+	RedirectUri     string
+	LoginRequired   bool
+	ConsentRequired bool
+}
+
+// OAuthTokenRequest 令牌请求参数
+type OAuthTokenRequest struct {
+	GrantType    string
+	ClientId     string
+	ClientSecret string
+	Code         string
+	RedirectUri  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthToken 令牌响应
+type OAuthToken struct {
+	AccessToken  string
+	TokenType    string
+	ExpiresIn    int64
+	RefreshToken string
+	Scope        string
+	IdToken      string
+}
+
+type CreateOAuthClient struct {
+	Name                   string   `validate:"required,max=64" comment:"应用名称"`
+	RedirectUris           []string `validate:"dive,required,url,max=512" comment:"回调地址"`
+	PostLogoutRedirectUris []string `validate:"dive,required,url,max=512" comment:"退出登录回调地址"`
+	GrantTypes             []string `validate:"required,dive,oneof=authorization_code refresh_token client_credentials" comment:"授权类型"`
+	Scopes                 []string `validate:"dive,required,max=64,excludesall= " comment:"权限范围"`
+	Public                 bool
+}
+
+type DeleteOAuthClient struct {
+	ClientId string `validate:"required,max=64" comment:"应用Id"`
+}
+
+type OAuthAuthorize struct {
+	ClientId            string `validate:"required,max=64" comment:"应用Id"`
+	RedirectUri         string `validate:"max=512" comment:"回调地址"`
+	Scope               string `validate:"max=512" comment:"权限范围"`
+	State               string `validate:"max=512" comment:"状态"`
+	CodeChallenge       string `validate:"max=128" comment:"PKCE challenge"`
+	CodeChallengeMethod string `validate:"omitempty,oneof=S256 plain" comment:"PKCE challenge方式"`
+	Nonce               string `validate:"max=512" comment:"nonce"`
+}
+
+func NewOAuthUseCase(repo OAuthRepo, userRepo UserRepo, authRepo AuthRepo, signer TokenSigner, tm Transaction, conf *conf.OAuth, uconf *conf.UserConstant, audit *AuditRecorder, logger log.Logger) *OAuthUseCase {
+	return &OAuthUseCase{
+		repo:     repo,
+		userRepo: userRepo,
+		authRepo: authRepo,
+		signer:   signer,
+		tm:       tm,
+		conf:     conf,
+		uconf:    uconf,
+		audit:    audit,
+		log:      log.NewHelper(log.With(logger, "module", "user/biz/oauthUseCase")),
+	}
+}
+
+// CreateClient 管理员注册应用，client_secret只在创建时返回
+//1. 公开客户端（如SPA、移动端）没有密钥，必须使用PKCE，且不能使用客户端凭证授权
+//2. 使用授权码授权时必须登记回调地址
+func (r *OAuthUseCase) CreateClient(ctx context.Context, create *CreateOAuthClient) (client *OAuthClient, secret string, err error) {
+	userId, _ := ctx.Value("userId").(int32)
+	defer func() {
+		auditLog := &AuditLog{ActorId: userId, Action: AuditActionOAuthClientCreate, Detail: create.Name}
+		if client != nil {
+			auditLog.Detail = client.ClientId
+		}
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	err = checkAdmin(ctx, r.userRepo, r.uconf, userId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	grantTypes := uniqueFields(create.GrantTypes)
+	if create.Public && containsField(grantTypes, OAuthGrantClientCredentials) {
+		return nil, "", v1.ErrorOauthFailed("public client can not use client_credentials")
+	}
+	if containsField(grantTypes, OAuthGrantAuthorizationCode) && len(create.RedirectUris) == 0 {
+		return nil, "", v1.ErrorOauthFailed("redirect uri is required for authorization_code")
+	}
+
+	client = &OAuthClient{
+		ClientId:               uuid.NewString(),
+		Name:                   create.Name,
+		RedirectUris:           strings.Join(uniqueFields(create.RedirectUris), " "),
+		PostLogoutRedirectUris: strings.Join(uniqueFields(create.PostLogoutRedirectUris), " "),
+		GrantTypes:             strings.Join(grantTypes, " "),
+		Scopes:                 strings.Join(uniqueFields(create.Scopes), " "),
+		Public:                 create.Public,
+		CreatorId:              userId,
+	}
+	if !create.Public {
+		secret, err = randomToken()
+		if err != nil {
+			return nil, "", v1.ErrorUnknownError("%s", err.Error())
+		}
+		client.ClientSecretHash = hashToken(secret)
+	}
+	err = r.repo.CreateOAuthClient(ctx, client)
+	if err != nil {
+		return nil, "", v1.ErrorOauthFailed("%s", err.Error())
+	}
+	return client, secret, nil
+}
+
+// ListClients 管理员查询应用列表
+func (r *OAuthUseCase) ListClients(ctx context.Context) ([]*OAuthClient, error) {
+	userId, _ := ctx.Value("userId").(int32)
+	err := checkAdmin(ctx, r.userRepo, r.uconf, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := r.repo.ListOAuthClients(ctx)
+	if err != nil {
+		return nil, v1.ErrorOauthFailed("%s", err.Error())
+	}
+	return clients, nil
+}
+
+// DeleteClient 管理员删除应用，已签发的访问令牌在过期前仍然有效
+func (r *OAuthUseCase) DeleteClient(ctx context.Context, clientId string) (err error) {
+	userId, _ := ctx.Value("userId").(int32)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{ActorId: userId, Action: AuditActionOAuthClientDelete, Detail: clientId}, err)
+	}()
+
+	err = checkAdmin(ctx, r.userRepo, r.uconf, userId)
+	if err != nil {
+		return err
+	}
+
+	err = r.repo.DeleteOAuthClient(ctx, clientId)
+	if err != nil {
+		return v1.ErrorOauthFailed("%s", err.Error())
+	}
+	return nil
+}
+
+// Authorize 授权端点
+//1. 应用或回调地址不合法时不跳转，直接返回错误，其余错误通过回调地址返回
+//2. 用户未登录时需要先登录
+//3. 用户已授予全部权限范围时直接签发授权码，否则需要用户确认授权
+func (r *OAuthUseCase) Authorize(ctx context.Context, req *OAuthAuthorizeRequest) (*OAuthAuthorizeResult, error) {
+	client, redirectUri, scopes, err := r.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		if redirectUri == "" {
+			return nil, err
+		}
+		return &OAuthAuthorizeResult{RedirectUri: authorizeErrorRedirect(redirectUri, req.State, err)}, nil
+	}
+
+	userId, _ := ctx.Value("userId").(int32)
+	if userId == 0 || !r.isLogin(ctx, userId) {
+		result := &OAuthAuthorizeResult{LoginRequired: true}
+		if loginUrl := r.conf.GetLoginUrl(); loginUrl != "" {
+			returnTo := strings.TrimRight(r.conf.GetIssuer(), "/") + "/oauth/authorize?" + authorizeQuery(req).Encode()
+			result.RedirectUri = appendQuery(loginUrl, url.Values{"return_to": {returnTo}})
+		}
+		return result, nil
+	}
+
+	granted, err := r.isConsentGranted(ctx, userId, client.ClientId, scopes)
+	if err != nil {
+		return nil, err
+	}
+	if !granted {
+		result := &OAuthAuthorizeResult{ConsentRequired: true}
+		if consentUrl := r.conf.GetConsentUrl(); consentUrl != "" {
+			result.RedirectUri = appendQuery(consentUrl, authorizeQuery(req))
+		}
+		return result, nil
+	}
+
+	location, err := r.issueCode(ctx, userId, client, redirectUri, scopes, req)
+	if err != nil {
+		return nil, err
+	}
+	return &OAuthAuthorizeResult{RedirectUri: location}, nil
+}
+
+// GetConsent 授权页查询应用信息和需要授予的权限范围
+func (r *OAuthUseCase) GetConsent(ctx context.Context, req *OAuthAuthorizeRequest) (*OAuthClient, []string, bool, error) {
+	userId, _ := ctx.Value("userId").(int32)
+	if userId == 0 || !r.isLogin(ctx, userId) {
+		return nil, nil, false, v1.ErrorLoginStateTimeout("")
+	}
+
+	client, _, scopes, err := r.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		return nil, nil, false, v1.ErrorOauthFailed("%s", err.Error())
+	}
+	granted, err := r.isConsentGranted(ctx, userId, client.ClientId, scopes)
+	if err != nil {
+		return nil, nil, false, v1.ErrorOauthFailed("%s", err.Error())
+	}
+	return client, scopes, granted, nil
+}
+
+// Consent 用户确认或拒绝授权，返回跳转回应用的地址
+//1. 拒绝时通过回调地址返回access_denied
+//2. 同意时合并保存已授予的权限范围并签发授权码
+func (r *OAuthUseCase) Consent(ctx context.Context, req *OAuthAuthorizeRequest, approve bool) (location string, err error) {
+	userId, _ := ctx.Value("userId").(int32)
+	defer func() {
+		detail := fmt.Sprintf("clientId: %s, scope: %s, approve: %v", req.ClientId, req.Scope, approve)
+		r.audit.Record(ctx, &AuditLog{ActorId: userId, TargetId: userId, Action: AuditActionOAuthConsent, Detail: detail}, err)
+	}()
+
+	if userId == 0 || !r.isLogin(ctx, userId) {
+		return "", v1.ErrorLoginStateTimeout("")
+	}
+
+	client, redirectUri, scopes, err := r.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		if redirectUri == "" {
+			return "", v1.ErrorOauthFailed("%s", err.Error())
+		}
+		return authorizeErrorRedirect(redirectUri, req.State, err), nil
+	}
+	if !approve {
+		return authorizeErrorRedirect(redirectUri, req.State, newOAuthError(OAuthErrAccessDenied, "the user denied the request")), nil
+	}
+
+	consent, err := r.repo.GetOAuthConsent(ctx, userId, client.ClientId)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return "", v1.ErrorOauthFailed("%s", err.Error())
+	}
+	if consent == nil {
+		consent = &OAuthConsent{UserId: userId, ClientId: client.ClientId}
+	}
+	consent.Scopes = strings.Join(uniqueFields(append(strings.Fields(consent.Scopes), scopes...)), " ")
+	err = r.repo.SaveOAuthConsent(ctx, consent)
+	if err != nil {
+		return "", v1.ErrorOauthFailed("%s", err.Error())
+	}
+	return r.issueCode(ctx, userId, client, redirectUri, scopes, req)
+}
+
+// checkAuthorizeRequest 校验授权请求，返回的回调地址为空表示不能跳转回应用
+func (r *OAuthUseCase) checkAuthorizeRequest(ctx context.Context, req *OAuthAuthorizeRequest) (*OAuthClient, string, []string, error) {
+	client, err := r.repo.GetOAuthClient(ctx, req.ClientId)
+	if kerrors.IsNotFound(err) {
+		return nil, "", nil, newOAuthError(OAuthErrInvalidClient, "unknown client_id")
+	}
+	if err != nil {
+		return nil, "", nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+
+	redirectUris := strings.Fields(client.RedirectUris)
+	redirectUri := req.RedirectUri
+	if redirectUri == "" && len(redirectUris) == 1 {
+		redirectUri = redirectUris[0]
+	}
+	if redirectUri == "" || !containsField(redirectUris, redirectUri) {
+		return nil, "", nil, newOAuthError(OAuthErrInvalidRequest, "redirect_uri is not registered")
+	}
+
+	if req.ResponseType != "" && req.ResponseType != "code" {
+		return nil, redirectUri, nil, newOAuthError(OAuthErrUnsupportedResponseType, "only response_type=code is supported")
+	}
+	if !client.AllowGrant(OAuthGrantAuthorizationCode) {
+		return nil, redirectUri, nil, newOAuthError(OAuthErrUnauthorizedClient, "authorization_code grant is not allowed")
+	}
+	scopes, oerr := client.checkScope(req.Scope)
+	if oerr != nil {
+		return nil, redirectUri, nil, oerr
+	}
+	if req.CodeChallenge == "" {
+		if client.Public {
+			return nil, redirectUri, nil, newOAuthError(OAuthErrInvalidRequest, "code_challenge is required for public clients")
+		}
+	} else if method := req.CodeChallengeMethod; method != "" && method != PKCEMethodS256 && method != PKCEMethodPlain {
+		return nil, redirectUri, nil, newOAuthError(OAuthErrInvalidRequest, "unsupported code_challenge_method")
+	}
+	return client, redirectUri, scopes, nil
+}
+
+func (r *OAuthUseCase) issueCode(ctx context.Context, userId int32, client *OAuthClient, redirectUri string, scopes []string, req *OAuthAuthorizeRequest) (string, error) {
+	code, err := randomToken()
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	method := req.CodeChallengeMethod
+	if req.CodeChallenge != "" && method == "" {
+		method = PKCEMethodPlain
+	}
+	err = r.repo.SetAuthorizationCode(ctx, hashToken(code), &OAuthAuthorizationCode{
+		ClientId:            client.ClientId,
+		UserId:              userId,
+		RedirectUri:         redirectUri,
+		RedirectUriSupplied: req.RedirectUri != "",
+		Scope:               strings.Join(scopes, " "),
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: method,
+		Nonce:               req.Nonce,
+		AuthTime:            time.Now(),
+	}, durationOr(r.conf.GetCodeTtl().AsDuration(), defaultCodeTTL))
+	if err != nil {
+		return "", v1.ErrorOauthFailed("%s", err.Error())
+	}
+
+	query := url.Values{"code": {code}}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	return appendQuery(redirectUri, query), nil
+}
+
+// Token 令牌端点，返回的错误为*OAuthError
+func (r *OAuthUseCase) Token(ctx context.Context, req *OAuthTokenRequest) (*OAuthToken, error) {
+	client, err := r.authenticateClient(ctx, req.ClientId, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if req.GrantType != OAuthGrantAuthorizationCode && req.GrantType != OAuthGrantRefreshToken && req.GrantType != OAuthGrantClientCredentials {
+		return nil, newOAuthError(OAuthErrUnsupportedGrantType, fmt.Sprintf("unsupported grant_type: %s", req.GrantType))
+	}
+	if !client.AllowGrant(req.GrantType) {
+		return nil, newOAuthError(OAuthErrUnauthorizedClient, fmt.Sprintf("%s grant is not allowed", req.GrantType))
+	}
+
+	switch req.GrantType {
+	case OAuthGrantAuthorizationCode:
+		return r.exchangeCode(ctx, client, req)
+	case OAuthGrantRefreshToken:
+		return r.refresh(ctx, client, req)
+	default:
+		return r.clientCredentials(ctx, client, req)
+	}
+}
+
+// authenticateClient 校验客户端身份，公开客户端只校验client_id
+func (r *OAuthUseCase) authenticateClient(ctx context.Context, clientId, clientSecret string) (*OAuthClient, error) {
+	if clientId == "" {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client_id is required")
+	}
+	client, err := r.repo.GetOAuthClient(ctx, clientId)
+	if kerrors.IsNotFound(err) {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	if client.Public {
+		return client, nil
+	}
+	if clientSecret == "" || subtle.ConstantTimeCompare([]byte(hashToken(clientSecret)), []byte(client.ClientSecretHash)) != 1 {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+// exchangeCode 授权码换取令牌
+//1. 授权码只能使用一次，且必须由同一应用兑换；授权请求携带了redirect_uri时兑换必须携带相同的值（RFC 6749 4.1.3）
+//2. 授权请求携带了code_challenge时校验code_verifier
+//3. 用户已被删除或禁用时拒绝签发
+func (r *OAuthUseCase) exchangeCode(ctx context.Context, client *OAuthClient, req *OAuthTokenRequest) (*OAuthToken, error) {
+	if req.Code == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "code is required")
+	}
+	code, err := r.repo.TakeAuthorizationCode(ctx, hashToken(req.Code))
+	if kerrors.IsNotFound(err) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "code is invalid or expired")
+	}
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	if code.ClientId != client.ClientId {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "code was issued to another client")
+	}
+	if (code.RedirectUriSupplied || req.RedirectUri != "") && req.RedirectUri != code.RedirectUri {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "redirect_uri mismatch")
+	}
+	if code.CodeChallenge != "" && !verifyCodeChallenge(code.CodeChallenge, code.CodeChallengeMethod, req.CodeVerifier) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "code_verifier mismatch")
+	}
+
+	user, oerr := r.activeUser(ctx, code.UserId)
+	if oerr != nil {
+		return nil, oerr
+	}
+	return r.issueToken(ctx, client, user, code.Scope, code.Nonce, code.AuthTime)
+}
+
+// refresh 刷新令牌换取新令牌，旧的刷新令牌立即失效
+func (r *OAuthUseCase) refresh(ctx context.Context, client *OAuthClient, req *OAuthTokenRequest) (*OAuthToken, error) {
+	if req.RefreshToken == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "refresh_token is required")
+	}
+	tokenHash := hashToken(req.RefreshToken)
+	refreshToken, err := r.repo.GetRefreshToken(ctx, tokenHash)
+	if kerrors.IsNotFound(err) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "refresh_token is invalid")
+	}
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	if refreshToken.ClientId != client.ClientId || refreshToken.Revoked || time.Now().After(refreshToken.ExpireTime) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "refresh_token is invalid or expired")
+	}
+
+	scope := refreshToken.Scope
+	if req.Scope != "" {
+		granted := strings.Fields(refreshToken.Scope)
+		for _, item := range strings.Fields(req.Scope) {
+			if !containsField(granted, item) {
+				return nil, newOAuthError(OAuthErrInvalidScope, fmt.Sprintf("scope %s was not granted", item))
+			}
+		}
+		scope = strings.Join(uniqueFields(strings.Fields(req.Scope)), " ")
+	}
+	user, oerr := r.activeUser(ctx, refreshToken.UserId)
+	if oerr != nil {
+		return nil, oerr
+	}
+
+	var token *OAuthToken
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		revoked, err := r.repo.RevokeRefreshToken(ctx, tokenHash)
+		if err != nil {
+			return newOAuthError(OAuthErrServerError, err.Error())
+		}
+		if !revoked {
+			return newOAuthError(OAuthErrInvalidGrant, "refresh_token is invalid")
+		}
+		token, err = r.issueToken(ctx, client, user, scope, "", time.Time{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// clientCredentials 应用以自身身份获取令牌，不签发刷新令牌
+func (r *OAuthUseCase) clientCredentials(_ context.Context, client *OAuthClient, req *OAuthTokenRequest) (*OAuthToken, error) {
+	if client.Public {
+		return nil, newOAuthError(OAuthErrUnauthorizedClient, "public client can not use client_credentials")
+	}
+	scopes, oerr := client.checkScope(req.Scope)
+	if oerr != nil {
+		return nil, oerr
+	}
+	scope := strings.Join(scopes, " ")
+	accessToken, expiresIn, err := r.signAccessToken(client.ClientId, client.ClientId, scope)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	return &OAuthToken{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   expiresIn,
+		Scope:       scope,
+	}, nil
+}
+
+// issueToken 签发访问令牌，权限范围包含openid时同时签发ID令牌，应用允许刷新时签发刷新令牌
+func (r *OAuthUseCase) issueToken(ctx context.Context, client *OAuthClient, user *User, scope, nonce string, authTime time.Time) (*OAuthToken, error) {
+	userId := user.Id
+	accessToken, expiresIn, err := r.signAccessToken(strconv.Itoa(int(userId)), client.ClientId, scope)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	token := &OAuthToken{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   expiresIn,
+		Scope:       scope,
+	}
+	if containsField(strings.Fields(scope), OIDCScopeOpenId) {
+		token.IdToken, err = r.signIdToken(client, user, scope, nonce, authTime)
+		if err != nil {
+			return nil, newOAuthError(OAuthErrServerError, err.Error())
+		}
+	}
+	if !client.AllowGrant(OAuthGrantRefreshToken) {
+		return token, nil
+	}
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	err = r.repo.CreateRefreshToken(ctx, &OAuthRefreshToken{
+		TokenHash:  hashToken(refreshToken),
+		ClientId:   client.ClientId,
+		UserId:     userId,
+		Scope:      scope,
+		ExpireTime: time.Now().Add(durationOr(r.conf.GetRefreshTokenTtl().AsDuration(), defaultRefreshTokenTTL)),
+	})
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	token.RefreshToken = refreshToken
+	return token, nil
+}
+
+func (r *OAuthUseCase) signAccessToken(subject, clientId, scope string) (string, int64, error) {
+	ttl := durationOr(r.conf.GetAccessTokenTtl().AsDuration(), defaultAccessTokenTTL)
+	now := time.Now()
+	accessToken, err := r.signer.Sign(&OAuthAccessClaims{
+		Scope:    scope,
+		ClientId: clientId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    r.conf.GetIssuer(),
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{clientId},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return accessToken, int64(ttl / time.Second), nil
+}
+
+func (r *OAuthUseCase) activeUser(ctx context.Context, userId int32) (*User, *OAuthError) {
+	user, err := r.userRepo.GetCurrentUser(ctx, userId)
+	if err != nil || user.UserStatus != UserStatusNormal {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "user is not active")
+	}
+	return user, nil
+}
+
+func (r *OAuthUseCase) isLogin(ctx context.Context, userId int32) bool {
+	_, err := r.userRepo.GetUserSession(ctx, userId)
+	return err == nil
+}
+
+func (r *OAuthUseCase) isConsentGranted(ctx context.Context, userId int32, clientId string, scopes []string) (bool, error) {
+	consent, err := r.repo.GetOAuthConsent(ctx, userId, clientId)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, newOAuthError(OAuthErrServerError, err.Error())
+	}
+	granted := strings.Fields(consent.Scopes)
+	for _, scope := range scopes {
+		if !containsField(granted, scope) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// AllowGrant 是否允许使用该授权类型
+func (c *OAuthClient) AllowGrant(grantType string) bool {
+	return containsField(strings.Fields(c.GrantTypes), grantType)
+}
+
+// checkScope 校验请求的权限范围，未指定时使用应用登记的全部权限范围
+func (c *OAuthClient) checkScope(scope string) ([]string, *OAuthError) {
+	allowed := strings.Fields(c.Scopes)
+	requested := uniqueFields(strings.Fields(scope))
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+	for _, item := range requested {
+		if !containsField(allowed, item) {
+			return nil, newOAuthError(OAuthErrInvalidScope, fmt.Sprintf("scope %s is not allowed", item))
+		}
+	}
+	return requested, nil
+}
+
+// authorizeErrorRedirect 通过回调地址返回授权错误
+func authorizeErrorRedirect(redirectUri, state string, err error) string {
+	var oerr *OAuthError
+	if !errors.As(err, &oerr) {
+		oerr = newOAuthError(OAuthErrServerError, err.Error())
+	}
+	query := url.Values{"error": {oerr.Code}, "error_description": {oerr.Description}}
+	if state != "" {
+		query.Set("state", state)
+	}
+	return appendQuery(redirectUri, query)
+}
+
+// authorizeQuery 授权请求参数
+func authorizeQuery(req *OAuthAuthorizeRequest) url.Values {
+	query := url.Values{}
+	for k, v := range map[string]string{
+		"response_type":         req.ResponseType,
+		"client_id":             req.ClientId,
+		"redirect_uri":          req.RedirectUri,
+		"scope":                 req.Scope,
+		"state":                 req.State,
+		"code_challenge":        req.CodeChallenge,
+		"code_challenge_method": req.CodeChallengeMethod,
+		"nonce":                 req.Nonce,
+	} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	return query
+}
+
+// appendQuery 在地址上追加查询参数
+func appendQuery(rawUrl string, values url.Values) string {
+	location, err := url.Parse(rawUrl)
+	if err != nil {
+		return rawUrl
+	}
+	query := location.Query()
+	for k, v := range values {
+		query[k] = v
+	}
+	location.RawQuery = query.Encode()
+	return location.String()
+}
+
+// verifyCodeChallenge 按RFC 7636校验code_verifier
+func verifyCodeChallenge(challenge, method, verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	expected := verifier
+	if method == PKCEMethodS256 {
+		sum := sha256.Sum256([]byte(verifier))
+		expected = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// randomToken 生成随机令牌
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", errors.Wrapf(err, "generate random token error")
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken 令牌摘要，数据库和缓存中只保存摘要
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func containsField(fields []string, field string) bool {
+	for _, item := range fields {
+		if item == field {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueFields(fields []string) []string {
+	result := make([]string, 0, len(fields))
+	for _, item := range fields {
+		if item != "" && !containsField(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func durationOr(d, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
@@ -0,0 +1,205 @@
+package biz
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OIDCScopeOpenId  = "openid"
+	OIDCScopeProfile = "profile"
+	OIDCScopeEmail   = "email"
+	OIDCScopePhone   = "phone"
+)
+
+// JSONWebKey 公钥，按RFC 7517输出
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// OIDCLogoutRequest RP发起的退出登录请求
+type OIDCLogoutRequest struct {
+	IdTokenHint           string
+	ClientId              string
+	PostLogoutRedirectUri string
+	State                 string
+}
+
+// idTokenHintLeeway 退出登录时ID令牌过期后仍可使用的时间，兼容客户端与服务端的时钟偏差
+const idTokenHintLeeway = time.Minute
+
+// idTokenHintClaims 退出登录时携带的ID令牌，过期时间按idTokenHintLeeway放宽
+type idTokenHintClaims struct {
+	jwt.RegisteredClaims
+}
+
+func (c *idTokenHintClaims) Valid() error {
+	if !c.VerifyExpiresAt(time.Now().Add(-idTokenHintLeeway), true) {
+		return jwt.NewValidationError("id_token_hint is expired", jwt.ValidationErrorExpired)
+	}
+	return nil
+}
+
+// Discovery OpenID Provider元数据
+func (r *OAuthUseCase) Discovery() map[string]interface{} {
+	issuer := strings.TrimRight(r.conf.GetIssuer(), "/")
+	return map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth/authorize",
+		"token_endpoint":                        issuer + "/oauth/token",
+		"userinfo_endpoint":                     issuer + "/userinfo",
+		"jwks_uri":                              issuer + "/oauth/jwks",
+		"end_session_endpoint":                  issuer + "/oauth/logout",
+		"response_types_supported":              []string{"code"},
+		"response_modes_supported":              []string{"query"},
+		"grant_types_supported":                 []string{OAuthGrantAuthorizationCode, OAuthGrantRefreshToken, OAuthGrantClientCredentials},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{OIDCScopeOpenId, OIDCScopeProfile, OIDCScopeEmail, OIDCScopePhone},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{PKCEMethodS256, PKCEMethodPlain},
+		"claims_supported": []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "azp",
+			"name", "preferred_username", "picture", "gender", "email", "email_verified", "phone_number",
+		},
+	}
+}
+
+// JWKS 签名公钥
+func (r *OAuthUseCase) JWKS() []*JSONWebKey {
+	return r.signer.JWKS()
+}
+
+// UserInfo 按访问令牌的权限范围返回用户信息
+//1. 只接受本服务签发的用户访问令牌，客户端凭证签发的令牌没有对应用户
+//2. 访问令牌必须包含openid权限范围
+func (r *OAuthUseCase) UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+	claims := &OAuthAccessClaims{}
+	err := r.signer.Parse(accessToken, claims)
+	if err != nil || claims.Issuer != r.conf.GetIssuer() || claims.Subject == claims.ClientId {
+		return nil, newOAuthError(OAuthErrInvalidToken, "access token is invalid or expired")
+	}
+	scopes := strings.Fields(claims.Scope)
+	if !containsField(scopes, OIDCScopeOpenId) {
+		return nil, newOAuthError(OAuthErrInsufficientScope, "openid scope is required")
+	}
+	userId, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrInvalidToken, "access token subject is invalid")
+	}
+	user, oerr := r.activeUser(ctx, int32(userId))
+	if oerr != nil {
+		return nil, newOAuthError(OAuthErrInvalidToken, oerr.Description)
+	}
+	return r.userClaims(user, scopes), nil
+}
+
+// EndSession RP发起的退出登录，返回退出后跳转的地址，为空表示不跳转
+//1. id_token_hint用于确定应用和用户，client_id与其中的aud不一致时拒绝
+//2. 退出后跳转地址必须是应用登记过的地址
+//3. 只退出当前登录的用户，id_token_hint中的用户与当前用户不一致时拒绝，未登录时不退出任何用户
+func (r *OAuthUseCase) EndSession(ctx context.Context, req *OIDCLogoutRequest) (location string, err error) {
+	userId, _ := ctx.Value("userId").(int32)
+	if userId != 0 && !r.isLogin(ctx, userId) {
+		userId = 0
+	}
+	defer func() {
+		if userId != 0 {
+			r.audit.Record(ctx, &AuditLog{Action: AuditActionUserLogout, ActorId: userId, TargetId: userId, Detail: req.ClientId}, err)
+		}
+	}()
+
+	clientId := req.ClientId
+	if req.IdTokenHint != "" {
+		hint := &idTokenHintClaims{}
+		perr := r.signer.Parse(req.IdTokenHint, hint)
+		if perr != nil || hint.Issuer != r.conf.GetIssuer() || len(hint.Audience) == 0 {
+			return "", newOAuthError(OAuthErrInvalidRequest, "id_token_hint is invalid")
+		}
+		if clientId != "" && !hint.VerifyAudience(clientId, true) {
+			return "", newOAuthError(OAuthErrInvalidRequest, "client_id does not match id_token_hint")
+		}
+		if userId != 0 && hint.Subject != strconv.Itoa(int(userId)) {
+			return "", newOAuthError(OAuthErrInvalidRequest, "id_token_hint does not match the current user")
+		}
+		clientId = hint.Audience[0]
+	}
+
+	if req.PostLogoutRedirectUri != "" {
+		if clientId == "" {
+			return "", newOAuthError(OAuthErrInvalidRequest, "client_id or id_token_hint is required")
+		}
+		client, cerr := r.repo.GetOAuthClient(ctx, clientId)
+		if cerr != nil || !containsField(strings.Fields(client.PostLogoutRedirectUris), req.PostLogoutRedirectUri) {
+			return "", newOAuthError(OAuthErrInvalidRequest, "post_logout_redirect_uri is not registered")
+		}
+		location = req.PostLogoutRedirectUri
+		if req.State != "" {
+			location = appendQuery(location, map[string][]string{"state": {req.State}})
+		}
+	}
+
+	if userId != 0 {
+		err = r.authRepo.UserLogout(ctx, userId)
+		if err != nil {
+			return "", v1.ErrorUserLogoutFailed("%s", err.Error())
+		}
+	}
+	return location, nil
+}
+
+// signIdToken 签发ID令牌，按权限范围携带用户信息
+func (r *OAuthUseCase) signIdToken(client *OAuthClient, user *User, scope, nonce string, authTime time.Time) (string, error) {
+	ttl := durationOr(r.conf.GetAccessTokenTtl().AsDuration(), defaultAccessTokenTTL)
+	now := time.Now()
+	claims := r.userClaims(user, strings.Fields(scope))
+	claims["iss"] = r.conf.GetIssuer()
+	claims["aud"] = client.ClientId
+	claims["azp"] = client.ClientId
+	claims["exp"] = now.Add(ttl).Unix()
+	claims["iat"] = now.Unix()
+	claims["jti"] = uuid.NewString()
+	if !authTime.IsZero() {
+		claims["auth_time"] = authTime.Unix()
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	return r.signer.Sign(jwt.MapClaims(claims))
+}
+
+// userClaims 将用户信息映射为OIDC标准声明
+func (r *OAuthUseCase) userClaims(user *User, scopes []string) map[string]interface{} {
+	claims := map[string]interface{}{
+		"sub": strconv.Itoa(int(user.Id)),
+	}
+	if containsField(scopes, OIDCScopeProfile) {
+		claims["name"] = user.UserName
+		claims["preferred_username"] = user.UserAccount
+		if user.AvatarUrl != "" {
+			claims["picture"] = user.AvatarUrl
+		}
+		if gender, ok := r.conf.GetGenderClaims()[user.Gender]; ok {
+			claims["gender"] = gender
+		}
+	}
+	if containsField(scopes, OIDCScopeEmail) && user.Email != "" {
+		claims["email"] = user.Email
+		claims["email_verified"] = false
+	}
+	if containsField(scopes, OIDCScopePhone) && user.Phone != "" {
+		claims["phone_number"] = user.Phone
+		claims["phone_number_verified"] = false
+	}
+	return claims
+}
@@ -0,0 +1,133 @@
+package biz
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"time"
+)
+
+type OutboxRepo interface {
+	CreateOutboxEvent(ctx context.Context, event *OutboxEvent) error
+	ListDueOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*OutboxEvent, error)
+	ClaimOutboxEvent(ctx context.Context, id int64, nextAttemptTime, leaseUntil time.Time) (bool, error)
+	UpdateOutboxEvent(ctx context.Context, event *OutboxEvent) error
+	PrunePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventPublisher 将事件发布到消息总线，EventId作为去重id，消费方需按EventId幂等处理
+type EventPublisher interface {
+	Publish(ctx context.Context, event *OutboxEvent) error
+}
+
+const (
+	OutboxEventPending   = "pending"
+	OutboxEventPublished = "published"
+)
+
+const (
+	outboxBaseBackoff = time.Second
+	outboxMaxBackoff  = time.Minute
+	outboxLease       = 30 * time.Second
+	outboxBatchSize   = 100
+)
+
+// OutboxEvent 事务发件箱中的事件
+type OutboxEvent struct {
+	Id              int64
+	EventId         string
+	EventType       string
+	UserId          int32
+	Payload         string
+	Status          string
+	Attempts        int32
+	NextAttemptTime time.Time
+	LastError       string
+	CreateTime      time.Time
+	UpdateTime      time.Time
+}
+
+// OutboxRelay 将发件箱中的事件转发到消息总线，至少投递一次
+type OutboxRelay struct {
+	repo      OutboxRepo
+	publisher EventPublisher
+	conf      *conf.UserConstant
+	log       *log.Helper
+}
+
+func NewOutboxRelay(repo OutboxRepo, publisher EventPublisher, conf *conf.UserConstant, logger log.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		repo:      repo,
+		publisher: publisher,
+		conf:      conf,
+		log:       log.NewHelper(log.With(logger, "module", "user/biz/outboxRelay")),
+	}
+}
+
+// RelayJob 定期发布待发送的事件，失败后按指数退避无限重试
+func (r *OutboxRelay) RelayJob() *Job {
+	return &Job{
+		Name:     "outbox-relay",
+		Interval: time.Second,
+		Run:      r.relayDue,
+	}
+}
+
+// CleanupJob 按保留时间清理已发布的事件，未配置保留时间时永久保留
+func (r *OutboxRelay) CleanupJob() *Job {
+	return &Job{
+		Name:     "outbox-cleanup",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			if r.conf.OutboxRetention <= 0 {
+				return nil
+			}
+			before := time.Now().Add(-time.Second * time.Duration(r.conf.OutboxRetention))
+			count, err := r.repo.PrunePublishedOutboxEvents(ctx, before)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				r.log.Infof("pruned outbox events: count(%v), before(%v)", count, before)
+			}
+			return nil
+		},
+	}
+}
+
+// relayDue 发布到期的事件
+//1. 多副本部署时通过租约抢占事件，租约内未完成的事件会被再次发布
+//2. 发布成功后标记为已发布，发布失败记录错误并推迟下次发布时间
+func (r *OutboxRelay) relayDue(ctx context.Context) error {
+	now := time.Now()
+	events, err := r.repo.ListDueOutboxEvents(ctx, now, outboxBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		claimed, err := r.repo.ClaimOutboxEvent(ctx, event.Id, event.NextAttemptTime, now.Add(outboxLease))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		event.Attempts++
+		err = r.publisher.Publish(ctx, event)
+		if err != nil {
+			event.LastError = err.Error()
+			event.NextAttemptTime = time.Now().Add(retryBackoff(event.Attempts, outboxBaseBackoff, outboxMaxBackoff))
+			r.log.Errorf("fail to publish outbox event: eventId(%s), attempts(%v), error(%v)", event.EventId, event.Attempts, err)
+		} else {
+			event.Status = OutboxEventPublished
+			event.LastError = ""
+		}
+		err = r.repo.UpdateOutboxEvent(ctx, event)
+		if err != nil {
+			r.log.Errorf("fail to update outbox event: eventId(%s), error(%v)", event.EventId, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,489 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"strconv"
+	"time"
+)
+
+// PasskeyRepo 用户注册的通行密钥
+type PasskeyRepo interface {
+	CreatePasskey(ctx context.Context, passkey *Passkey) error
+	ListPasskeys(ctx context.Context, userId int32) ([]*Passkey, error)
+	UpdatePasskeyUsage(ctx context.Context, passkey *Passkey) error
+	DeletePasskey(ctx context.Context, id int64, userId int32) error
+}
+
+// PasskeyRelyingParty WebAuthn依赖方，生成注册和登录选项，校验认证器返回的证明和断言
+type PasskeyRelyingParty interface {
+	BeginRegistration(user *User, passkeys []*Passkey) (options, session []byte, err error)
+	FinishRegistration(user *User, passkeys []*Passkey, session []byte, credential string) (*Passkey, error)
+	BeginLogin(user *User, passkeys []*Passkey, userVerification bool) (options, session []byte, err error)
+	FinishLogin(session []byte, credential string, lookup PasskeyLookup) (*PasskeyAssertion, error)
+}
+
+// PasskeyLookup 按用户句柄查询用户和用户的通行密钥
+type PasskeyLookup func(userHandle []byte) (*User, []*Passkey, error)
+
+type PasskeyUseCase struct {
+	repo     PasskeyRepo
+	authRepo AuthRepo
+	userRepo UserRepo
+	rp       PasskeyRelyingParty
+	auth     *AuthRepoUseCase
+	audit    *AuditRecorder
+	history  *LoginHistoryUseCase
+	conf     *conf.WebAuthn
+	log      *log.Helper
+}
+
+// 挑战默认有效期
+const defaultPasskeyChallengeTimeout = 5 * time.Minute
+
+// 每个用户最多注册的通行密钥数量
+const maxPasskeyCount = 20
+
+const (
+	PasskeyPurposeRegister = "register"
+	PasskeyPurposeLogin    = "login"
+)
+
+const (
+	AuditActionPasskeyRegister = "passkey.register"
+	AuditActionPasskeyDelete   = "passkey.delete"
+)
+
+// Passkey 通行密钥，凭证id为base64url编码，签名计数用于识别被克隆的认证器
+type Passkey struct {
+	Id              int64
+	UserId          int32
+	Name            string
+	CredentialId    string
+	PublicKey       []byte
+	AttestationType string
+	Transports      string
+	Aaguid          string
+	SignCount       int64
+	BackupEligible  bool
+	BackupState     bool
+	LastUsedTime    time.Time
+	CreateTime      time.Time
+}
+
+// PasskeyChallenge 注册或登录仪式的挑战，保存在缓存中，只能校验一次
+type PasskeyChallenge struct {
+	UserId       int32  `json:"userId"`
+	Purpose      string `json:"purpose"`
+	SecondFactor bool   `json:"secondFactor"`
+	Session      []byte `json:"session"`
+}
+
+// PasskeyAssertion 断言校验结果，通行密钥中的签名计数和备份状态已更新为本次断言的值
+type PasskeyAssertion struct {
+	User         *User
+	Passkey      *Passkey
+	CloneWarning bool
+}
+
+type FinishPasskeyRegistration struct {
+	ChallengeId string `validate:"required,max=128" comment:"挑战id"`
+	Name        string `validate:"required,max=64" comment:"名称"`
+	Credential  string `validate:"required,max=16384" comment:"凭证"`
+}
+
+type DeletePasskey struct {
+	Id int64 `validate:"required,gt=0" comment:"通行密钥Id"`
+}
+
+type BeginPasskeyLogin struct {
+	UserAccount string `validate:"max=256" comment:"用户名"`
+}
+
+type FinishPasskeyLogin struct {
+	ChallengeId string `validate:"required,max=128" comment:"挑战id"`
+	Credential  string `validate:"required,max=16384" comment:"凭证"`
+}
+
+func NewPasskeyUseCase(repo PasskeyRepo, authRepo AuthRepo, userRepo UserRepo, rp PasskeyRelyingParty, auth *AuthRepoUseCase, audit *AuditRecorder, history *LoginHistoryUseCase, conf *conf.WebAuthn, logger log.Logger) *PasskeyUseCase {
+	return &PasskeyUseCase{
+		repo:     repo,
+		authRepo: authRepo,
+		userRepo: userRepo,
+		rp:       rp,
+		auth:     auth,
+		audit:    audit,
+		history:  history,
+		conf:     conf,
+		log:      log.NewHelper(log.With(logger, "module", "user/biz/passkeyUseCase")),
+	}
+}
+
+// BeginPasskeyRegistration 开始注册通行密钥
+//1. 判断是否开启通行密钥，只能在登录会话中注册，每个用户的通行密钥数量有上限
+//2. 生成注册选项，已注册的凭证不能重复注册
+//3. 挑战存入缓存，返回挑战id和注册选项
+func (r *PasskeyUseCase) BeginPasskeyRegistration(ctx context.Context) (challengeId string, options []byte, err error) {
+	err = r.enabled()
+	if err != nil {
+		return "", nil, err
+	}
+	user, err := r.loginUser(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	passkeys, err := r.repo.ListPasskeys(ctx, user.Id)
+	if err != nil {
+		return "", nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	if len(passkeys) >= maxPasskeyCount {
+		return "", nil, v1.ErrorPasskeyFailed("passkey count exceeds limit: %v", maxPasskeyCount)
+	}
+
+	options, session, err := r.rp.BeginRegistration(user, passkeys)
+	if err != nil {
+		return "", nil, v1.ErrorPasskeyFailed("%s", err.Error())
+	}
+	challengeId, err = r.setChallenge(ctx, &PasskeyChallenge{UserId: user.Id, Purpose: PasskeyPurposeRegister, Session: session})
+	if err != nil {
+		return "", nil, err
+	}
+	return challengeId, options, nil
+}
+
+// FinishPasskeyRegistration 完成注册通行密钥
+//1. 取出挑战，挑战必须由当前用户发起
+//2. 校验认证器返回的证明，保存通行密钥
+//3. 记录审计日志
+func (r *PasskeyUseCase) FinishPasskeyRegistration(ctx context.Context, finish *FinishPasskeyRegistration) (passkey *Passkey, err error) {
+	userId := ctx.Value("userId").(int32)
+	auditLog := &AuditLog{Action: AuditActionPasskeyRegister, ActorId: userId, TargetId: userId, Detail: fmt.Sprintf("name(%s)", finish.Name)}
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	err = r.enabled()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := r.takeChallenge(ctx, finish.ChallengeId, PasskeyPurposeRegister)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UserId != userId {
+		return nil, v1.ErrorPasskeyFailed("passkey challenge not issued to user: userId(%v)", userId)
+	}
+	user, err := r.loginUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := r.repo.ListPasskeys(ctx, user.Id)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	passkey, err = r.rp.FinishRegistration(user, passkeys, challenge.Session, finish.Credential)
+	if err != nil {
+		return nil, v1.ErrorPasskeyFailed("%s", err.Error())
+	}
+	passkey.Name = finish.Name
+	err = r.repo.CreatePasskey(ctx, passkey)
+	if err != nil {
+		return nil, v1.ErrorPasskeyFailed("%s", err.Error())
+	}
+	auditLog.Detail = fmt.Sprintf("id(%v), name(%s), aaguid(%s)", passkey.Id, passkey.Name, passkey.Aaguid)
+	return passkey, nil
+}
+
+// ListPasskeys 当前用户的通行密钥
+func (r *PasskeyUseCase) ListPasskeys(ctx context.Context) ([]*Passkey, error) {
+	user, err := r.loginUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := r.repo.ListPasskeys(ctx, user.Id)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return passkeys, nil
+}
+
+// DeletePasskey 删除当前用户的通行密钥，并记录审计日志
+func (r *PasskeyUseCase) DeletePasskey(ctx context.Context, id int64) (err error) {
+	userId := ctx.Value("userId").(int32)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionPasskeyDelete, ActorId: userId, TargetId: userId, Detail: fmt.Sprintf("id(%v)", id)}, err)
+	}()
+
+	_, err = r.loginUser(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.repo.DeletePasskey(ctx, id, userId)
+	if kerrors.IsNotFound(err) {
+		return v1.ErrorPasskeyFailed("passkey not found: id(%v)", id)
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	return nil
+}
+
+// BeginPasskeyLogin 开始通行密钥登录
+//1. 判断是否开启通行密钥
+//2. 指定的账号注册了通行密钥时只允许使用该账号的通行密钥，账号不存在或未注册时由认证器选择可发现凭证
+//3. 账号密码已验证、等待通行密钥二次验证时作为第二因素，不要求认证器验证用户，否则作为第一因素必须验证用户
+//4. 挑战存入缓存，返回挑战id和登录选项
+func (r *PasskeyUseCase) BeginPasskeyLogin(ctx context.Context, userAccount string) (challengeId string, options []byte, err error) {
+	err = r.enabled()
+	if err != nil {
+		return "", nil, err
+	}
+
+	challenge := &PasskeyChallenge{Purpose: PasskeyPurposeLogin}
+	var user *User
+	var passkeys []*Passkey
+	if userAccount != "" {
+		user, passkeys, err = r.accountPasskeys(ctx, userAccount)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	if len(passkeys) == 0 {
+		user, passkeys = nil, nil
+	}
+	if user != nil {
+		challenge.UserId = user.Id
+		err = r.authRepo.TakePasskeyPending(ctx, user.Id, deviceFingerprint(ctx))
+		if err != nil && !kerrors.IsNotFound(err) {
+			return "", nil, v1.ErrorUnknownError("%s", err.Error())
+		}
+		challenge.SecondFactor = err == nil
+	}
+
+	options, challenge.Session, err = r.rp.BeginLogin(user, passkeys, !challenge.SecondFactor)
+	if err != nil {
+		return "", nil, v1.ErrorPasskeyFailed("%s", err.Error())
+	}
+	challengeId, err = r.setChallenge(ctx, challenge)
+	if err != nil {
+		return "", nil, err
+	}
+	return challengeId, options, nil
+}
+
+// FinishPasskeyLogin 完成通行密钥登录
+//1. 取出挑战，按挑战中的用户或断言中的用户句柄查询用户和通行密钥
+//2. 校验断言，签名计数回退说明认证器可能被克隆，拒绝登录
+//3. 更新签名计数和最近使用时间
+//4. 与账号密码登录使用相同的会话创建流程，通行密钥可防钓鱼，不再要求验证码二次验证
+//5. 无论成功失败都记录审计日志和登录历史
+func (r *PasskeyUseCase) FinishPasskeyLogin(ctx context.Context, finish *FinishPasskeyLogin) (user *User, err error) {
+	auditLog := &AuditLog{Action: AuditActionUserLogin, Detail: fmt.Sprintf("method(%s)", LoginMethodPasskey)}
+	history := newLoginHistory(ctx, "", LoginMethodPasskey)
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+		r.history.Record(ctx, history, err)
+	}()
+
+	err = r.enabled()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := r.takeChallenge(ctx, finish.ChallengeId, PasskeyPurposeLogin)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.SecondFactor {
+		auditLog.Detail = fmt.Sprintf("method(%s), secondFactor(true)", LoginMethodPasskey)
+	}
+
+	assertion, err := r.rp.FinishLogin(challenge.Session, finish.Credential, func(userHandle []byte) (*User, []*Passkey, error) {
+		userId, err := parsePasskeyUserHandle(userHandle)
+		if err != nil {
+			return nil, nil, err
+		}
+		if challenge.UserId != 0 && challenge.UserId != userId {
+			return nil, nil, errors.Errorf("user handle mismatch: userId(%v)", userId)
+		}
+		return r.userPasskeys(ctx, userId)
+	})
+	if err != nil {
+		return nil, v1.ErrorPasskeyFailed("%s", err.Error())
+	}
+	user = assertion.User
+	auditLog.ActorId, auditLog.TargetId = user.Id, user.Id
+	history.UserId, history.UserAccount = user.Id, user.UserAccount
+	if assertion.CloneWarning {
+		r.log.Warnf("passkey sign count regressed: userId(%v), passkeyId(%v)", user.Id, assertion.Passkey.Id)
+		return nil, v1.ErrorPasskeyFailed("passkey sign count regressed, authenticator may be cloned: id(%v)", assertion.Passkey.Id)
+	}
+
+	err = r.repo.UpdatePasskeyUsage(ctx, assertion.Passkey)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	err = r.auth.startSession(ctx, user, history, "", true)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *PasskeyUseCase) enabled() error {
+	if r.conf.GetRpId() == "" {
+		return v1.ErrorPasskeyFailed("passkey disabled")
+	}
+	return nil
+}
+
+// loginUser 通行密钥只能由登录会话中的用户管理
+func (r *PasskeyUseCase) loginUser(ctx context.Context) (*User, error) {
+	userId := ctx.Value("userId").(int32)
+	user, err := sessionUser(ctx, r.userRepo, userId)
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorLoginStateTimeout("")
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return user, nil
+}
+
+func (r *PasskeyUseCase) userPasskeys(ctx context.Context, userId int32) (*User, []*Passkey, error) {
+	user, err := r.userRepo.GetCurrentUser(ctx, userId)
+	if kerrors.IsNotFound(err) {
+		return nil, nil, v1.ErrorPasskeyFailed("user not found: userId(%v)", userId)
+	}
+	if err != nil {
+		return nil, nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	passkeys, err := r.repo.ListPasskeys(ctx, userId)
+	if err != nil {
+		return nil, nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return user, passkeys, nil
+}
+
+// accountPasskeys 按账号查询用户和通行密钥，账号不存在时返回空
+func (r *PasskeyUseCase) accountPasskeys(ctx context.Context, userAccount string) (*User, []*Passkey, error) {
+	user, err := r.userRepo.GetUserByAccount(ctx, userAccount)
+	if kerrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	passkeys, err := r.repo.ListPasskeys(ctx, user.Id)
+	if err != nil {
+		return nil, nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return user, passkeys, nil
+}
+
+func (r *PasskeyUseCase) setChallenge(ctx context.Context, challenge *PasskeyChallenge) (string, error) {
+	challengeId, err := randomToken()
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	err = r.authRepo.SetPasskeyChallenge(ctx, hashToken(challengeId), challenge, PasskeyChallengeTimeout(r.conf))
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	return challengeId, nil
+}
+
+func (r *PasskeyUseCase) takeChallenge(ctx context.Context, challengeId, purpose string) (*PasskeyChallenge, error) {
+	challenge, err := r.authRepo.TakePasskeyChallenge(ctx, hashToken(challengeId))
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorPasskeyFailed("passkey challenge expired")
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	if challenge.Purpose != purpose {
+		return nil, v1.ErrorPasskeyFailed("passkey challenge purpose mismatch: %s", challenge.Purpose)
+	}
+	return challenge, nil
+}
+
+// requirePasskey 开启通行密钥二次验证且用户已注册通行密钥时，账号密码验证通过后记录等待标记，要求继续使用通行密钥登录
+func (r *AuthRepoUseCase) requirePasskey(ctx context.Context, user *User, fingerprint string) error {
+	if !r.webauthn.GetSecondFactor() {
+		return nil
+	}
+	return r.passkeyStepUp(ctx, user, fingerprint)
+}
+
+// passkeyStepUp 用户已注册通行密钥时记录待完成的二次验证，返回PASSKEY_REQUIRED，未注册时返回nil
+func (r *AuthRepoUseCase) passkeyStepUp(ctx context.Context, user *User, fingerprint string) error {
+	if r.webauthn.GetRpId() == "" {
+		return nil
+	}
+	passkeys, err := r.passkeyRepo.ListPasskeys(ctx, user.Id)
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	if len(passkeys) == 0 {
+		return nil
+	}
+	err = r.repo.SetPasskeyPending(ctx, user.Id, fingerprint, PasskeyChallengeTimeout(r.webauthn))
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	return v1.ErrorPasskeyRequired("passkey required: userId(%v)", user.Id)
+}
+
+// loginMethods 统计用户可以独立完成登录的方式，事务中调用时锁定用户的第三方账号
+//1. 设置了密码计1
+//2. 每个绑定的第三方账号计1
+//3. 开启通行密钥时每个注册的通行密钥计1
+//4. 开启免密登录且设置了邮箱时计1
+func (r *AuthRepoUseCase) loginMethods(ctx context.Context, user *User) (int64, error) {
+	var count int64
+	if user.UserPassword != "" {
+		count++
+	}
+	identities, err := r.identityRepo.CountIdentities(ctx, user.Id)
+	if err != nil {
+		return 0, v1.ErrorUnknownError("%s", err.Error())
+	}
+	count += identities
+	if r.webauthn.GetRpId() != "" {
+		passkeys, err := r.passkeyRepo.ListPasskeys(ctx, user.Id)
+		if err != nil {
+			return 0, v1.ErrorUnknownError("%s", err.Error())
+		}
+		count += int64(len(passkeys))
+	}
+	if r.conf.MagicLinkLogin && user.Email != "" {
+		count++
+	}
+	return count, nil
+}
+
+// PasskeyChallengeTimeout 挑战有效期，同时作为认证器等待用户操作的超时时间
+func PasskeyChallengeTimeout(conf *conf.WebAuthn) time.Duration {
+	if conf.GetChallengeTtl().AsDuration() > 0 {
+		return conf.GetChallengeTtl().AsDuration()
+	}
+	return defaultPasskeyChallengeTimeout
+}
+
+// PasskeyUserHandle 用户句柄，注册时写入认证器，可发现凭证登录时由认证器返回
+func PasskeyUserHandle(userId int32) []byte {
+	return []byte(strconv.Itoa(int(userId)))
+}
+
+func parsePasskeyUserHandle(userHandle []byte) (int32, error) {
+	userId, err := strconv.ParseInt(string(userHandle), 10, 32)
+	if err != nil || userId <= 0 {
+		return 0, errors.Errorf("invalid user handle: %q", userHandle)
+	}
+	return int32(userId), nil
+}
@@ -0,0 +1,29 @@
+package biz
+
+import "context"
+
+// principalKey 上下文中保存令牌认证结果的键
+type principalKey struct{}
+
+// principal 个人访问令牌或模拟登录令牌认证通过的请求主体
+type principal struct {
+	token          *AccessToken
+	user           *User
+	impersonatorId int32
+}
+
+// Impersonator 模拟登录的管理员id，不是模拟登录时为0
+func Impersonator(ctx context.Context) int32 {
+	if p, ok := ctx.Value(principalKey{}).(*principal); ok {
+		return p.impersonatorId
+	}
+	return 0
+}
+
+// sessionUser 当前请求的登录用户，使用令牌认证时为令牌对应的用户，否则从session中获取
+func sessionUser(ctx context.Context, repo UserRepo, userId int32) (*User, error) {
+	if p, ok := ctx.Value(principalKey{}).(*principal); ok && p.user.Id == userId {
+		return p.user, nil
+	}
+	return repo.GetUserSession(ctx, userId)
+}
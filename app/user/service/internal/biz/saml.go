@@ -0,0 +1,217 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"time"
+)
+
+// SAMLProviders 已配置的SAML IdP，未配置时返回NotFound
+type SAMLProviders interface {
+	Get(name string) (SAMLProvider, error)
+}
+
+// SAMLProvider 对接单个IdP的SP，校验断言签名、签发者、受众、接收地址和有效期
+type SAMLProvider interface {
+	Metadata() ([]byte, error)
+	AuthnRequestURL(ctx context.Context, relayState string) (string, string, error)
+	ParseResponse(ctx context.Context, samlResponse string, requestIds []string) (*SAMLAssertion, error)
+}
+
+type SAMLUseCase struct {
+	providers SAMLProviders
+	repo      IdentityRepo
+	identity  *IdentityUseCase
+	conf      *conf.SAML
+	log       *log.Helper
+}
+
+const (
+	SAMLAttributeUserName  = "userName"
+	SAMLAttributeEmail     = "email"
+	SAMLAttributePhone     = "phone"
+	SAMLAttributeAvatarUrl = "avatarUrl"
+)
+
+// 断言没有有效期时防重放记录的保留时间
+const defaultSAMLAssertionTimeout = time.Hour
+
+// SAMLAssertion 校验通过的断言，属性同时按Name和FriendlyName索引
+type SAMLAssertion struct {
+	Id           string
+	NameId       string
+	InResponseTo string
+	Attributes   map[string][]string
+	ExpireTime   time.Time
+}
+
+type SAMLAssertionConsume struct {
+	Provider     string `validate:"required,max=64" comment:"SAML提供方"`
+	SAMLResponse string `validate:"required" comment:"SAML响应"`
+	RelayState   string `validate:"max=256" comment:"RelayState"`
+}
+
+func NewSAMLUseCase(providers SAMLProviders, repo IdentityRepo, identity *IdentityUseCase, conf *conf.SAML, logger log.Logger) *SAMLUseCase {
+	return &SAMLUseCase{
+		providers: providers,
+		repo:      repo,
+		identity:  identity,
+		conf:      conf,
+		log:       log.NewHelper(log.With(logger, "module", "user/biz/samlUseCase")),
+	}
+}
+
+// Metadata SP元数据，提供给IdP配置
+func (r *SAMLUseCase) Metadata(_ context.Context, provider string) ([]byte, error) {
+	p, err := r.provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := p.Metadata()
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	return metadata, nil
+}
+
+// Login 发起SP登录，返回携带AuthnRequest的IdP登录地址
+//1. RelayState随机生成，只保存摘要，与AuthnRequest的id和二次验证码关联
+//2. ACS校验断言的InResponseTo与发起的AuthnRequest一致
+func (r *SAMLUseCase) Login(ctx context.Context, provider, verifyCode string) (string, error) {
+	p, err := r.provider(provider)
+	if err != nil {
+		return "", err
+	}
+	relayState, err := randomToken()
+	if err != nil {
+		return "", v1.ErrorUnknownError("%s", err.Error())
+	}
+	loginUrl, requestId, err := p.AuthnRequestURL(ctx, relayState)
+	if err != nil {
+		return "", v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	err = r.repo.SetExternalLoginState(ctx, hashToken(relayState), &ExternalLoginState{
+		Provider:   samlIdentityProvider(provider),
+		RequestId:  requestId,
+		VerifyCode: verifyCode,
+	}, externalLoginStateTimeout)
+	if err != nil {
+		return "", v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	return loginUrl, nil
+}
+
+// AssertionConsumer 处理IdP返回的断言
+//1. 按RelayState找到发起的AuthnRequest，找不到时仅在允许IdP发起登录时继续
+//2. 校验断言，同一断言只能使用一次
+//3. 按配置的属性映射得到用户信息，按第三方身份登录，首次登录时自动开通用户
+func (r *SAMLUseCase) AssertionConsumer(ctx context.Context, provider, samlResponse, relayState string) (*User, error) {
+	p, err := r.provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	providerConf := r.providerConf(provider)
+
+	var loginState *ExternalLoginState
+	if relayState != "" {
+		loginState, err = r.repo.TakeExternalLoginState(ctx, hashToken(relayState))
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+		}
+		if loginState != nil && loginState.Provider != samlIdentityProvider(provider) {
+			return nil, v1.ErrorExternalLoginFailed("provider mismatch: %s", provider)
+		}
+	}
+	var requestIds []string
+	switch {
+	case loginState != nil:
+		requestIds = []string{loginState.RequestId}
+	case !providerConf.GetAllowIdpInitiated():
+		return nil, v1.ErrorExternalLoginFailed("unsolicited saml response: provider(%s)", provider)
+	}
+
+	assertion, err := p.ParseResponse(ctx, samlResponse, requestIds)
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	if loginState != nil && assertion.InResponseTo != loginState.RequestId {
+		return nil, v1.ErrorExternalLoginFailed("saml response not in response to request: provider(%s)", provider)
+	}
+	timeout := time.Until(assertion.ExpireTime)
+	if timeout <= 0 {
+		timeout = defaultSAMLAssertionTimeout
+	}
+	unused, err := r.repo.UseSAMLAssertion(ctx, assertion.Id, timeout)
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	if !unused {
+		return nil, v1.ErrorExternalLoginFailed("saml assertion replayed: id(%s)", assertion.Id)
+	}
+
+	external := r.mapAttributes(providerConf, assertion)
+	if external.Subject == "" {
+		return nil, v1.ErrorExternalLoginFailed("saml subject missing: provider(%s)", provider)
+	}
+	var verifyCode string
+	if loginState != nil {
+		verifyCode = loginState.VerifyCode
+	}
+	return r.identity.externalLogin(ctx, samlIdentityProvider(provider), LoginMethodSAML, external, verifyCode)
+}
+
+// mapAttributes 按配置将断言属性映射到用户字段，未配置唯一标识属性时使用NameID
+func (r *SAMLUseCase) mapAttributes(providerConf *conf.SAMLProvider, assertion *SAMLAssertion) *ExternalIdentity {
+	attribute := func(name string) string {
+		values := assertion.Attributes[providerConf.GetAttributes()[name]]
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+
+	external := &ExternalIdentity{
+		Subject:       assertion.NameId,
+		Name:          attribute(SAMLAttributeUserName),
+		Email:         attribute(SAMLAttributeEmail),
+		EmailVerified: providerConf.GetTrustEmail(),
+		Phone:         attribute(SAMLAttributePhone),
+		AvatarUrl:     attribute(SAMLAttributeAvatarUrl),
+	}
+	if providerConf.GetSubjectAttribute() != "" {
+		external.Subject = ""
+		if values := assertion.Attributes[providerConf.GetSubjectAttribute()]; len(values) > 0 {
+			external.Subject = values[0]
+		}
+	}
+	return external
+}
+
+func (r *SAMLUseCase) provider(name string) (SAMLProvider, error) {
+	p, err := r.providers.Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil, v1.ErrorExternalLoginFailed("unknown saml provider: %s", name)
+	}
+	if err != nil {
+		return nil, v1.ErrorExternalLoginFailed("%s", err.Error())
+	}
+	return p, nil
+}
+
+func (r *SAMLUseCase) providerConf(name string) *conf.SAMLProvider {
+	for _, item := range r.conf.GetProviders() {
+		if item.GetName() == name {
+			return item
+		}
+	}
+	return &conf.SAMLProvider{}
+}
+
+// samlIdentityProvider SAML提供方在第三方账号中的标识，与OIDC提供方区分
+func samlIdentityProvider(name string) string {
+	return fmt.Sprintf("saml_%s", name)
+}
@@ -0,0 +1,1152 @@
+package biz
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type GroupRepo interface {
+	CreateGroup(ctx context.Context, group *Group) error
+	GetGroup(ctx context.Context, id int64) (*Group, error)
+	ListGroups(ctx context.Context, conditions []*FilterCondition, offset, limit int32) ([]*Group, int64, error)
+	UpdateGroup(ctx context.Context, group *Group) error
+	DeleteGroup(ctx context.Context, id int64) error
+	ListGroupMembers(ctx context.Context, groupIds []int64) ([]*GroupMember, error)
+	AddGroupMembers(ctx context.Context, groupId int64, userIds []int32) error
+	RemoveGroupMembers(ctx context.Context, groupId int64, userIds []int32) error
+	SetGroupMembers(ctx context.Context, groupId int64, userIds []int32) error
+	DeleteUserMemberships(ctx context.Context, userId int32) error
+}
+
+// ScimUseCase SCIM 2.0用户和组开通，供身份提供方推送用户和组
+type ScimUseCase struct {
+	userRepo  UserRepo
+	authRepo  AuthRepo
+	groupRepo GroupRepo
+	tm        Transaction
+	event     *EventUseCase
+	audit     *AuditRecorder
+	conf      *conf.UserConstant
+	log       *log.Helper
+}
+
+const (
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ScimSchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+// SCIM错误类型
+const (
+	ScimTypeInvalidFilter = "invalidFilter"
+	ScimTypeInvalidSyntax = "invalidSyntax"
+	ScimTypeInvalidPath   = "invalidPath"
+	ScimTypeInvalidValue  = "invalidValue"
+	ScimTypeUniqueness    = "uniqueness"
+	ScimTypeMutability    = "mutability"
+	ScimTypeNoTarget      = "noTarget"
+)
+
+const (
+	AuditActionScimUserCreate  = "scim.user.create"
+	AuditActionScimUserUpdate  = "scim.user.update"
+	AuditActionScimUserDelete  = "scim.user.delete"
+	AuditActionScimGroupCreate = "scim.group.create"
+	AuditActionScimGroupUpdate = "scim.group.update"
+	AuditActionScimGroupDelete = "scim.group.delete"
+)
+
+const (
+	scimMaxCount     = 100
+	scimMaxAttribute = 256
+)
+
+// 过滤条件中的属性，由数据层转换为对应的列
+const (
+	FilterAttributeId          = "id"
+	FilterAttributeUserAccount = "userAccount"
+	FilterAttributeUserName    = "userName"
+	FilterAttributeEmail       = "email"
+	FilterAttributePhone       = "phone"
+	FilterAttributeUserStatus  = "userStatus"
+	FilterAttributeDisplayName = "displayName"
+	FilterAttributeExternalId  = "externalId"
+	FilterAttributeMember      = "member"
+)
+
+const (
+	FilterOperatorEq = "eq"
+	FilterOperatorNe = "ne"
+	FilterOperatorCo = "co"
+	FilterOperatorSw = "sw"
+	FilterOperatorEw = "ew"
+	FilterOperatorPr = "pr"
+)
+
+const (
+	scimOpAdd     = "add"
+	scimOpReplace = "replace"
+	scimOpRemove  = "remove"
+)
+
+// FilterCondition 过滤条件，多个条件之间为and关系
+type FilterCondition struct {
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+type Group struct {
+	Id          int64
+	DisplayName string
+	ExternalId  string
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
+
+type GroupMember struct {
+	GroupId     int64
+	UserId      int32
+	UserAccount string
+	UserName    string
+}
+
+// ScimUser SCIM用户资源，userName对应账号，displayName对应用户昵称
+type ScimUser struct {
+	Schemas      []string          `json:"schemas"`
+	Id           string            `json:"id,omitempty"`
+	ExternalId   string            `json:"externalId,omitempty"`
+	UserName     string            `json:"userName"`
+	Name         *ScimName         `json:"name,omitempty"`
+	DisplayName  string            `json:"displayName,omitempty"`
+	Emails       []*ScimMultiValue `json:"emails,omitempty"`
+	PhoneNumbers []*ScimMultiValue `json:"phoneNumbers,omitempty"`
+	Photos       []*ScimMultiValue `json:"photos,omitempty"`
+	Active       *bool             `json:"active,omitempty"`
+	Password     string            `json:"password,omitempty"`
+	Meta         *ScimMeta         `json:"meta,omitempty"`
+}
+
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// ScimMultiValue 多值属性，如邮箱、电话、组成员
+type ScimMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type ScimGroup struct {
+	Schemas     []string          `json:"schemas"`
+	Id          string            `json:"id,omitempty"`
+	ExternalId  string            `json:"externalId,omitempty"`
+	DisplayName string            `json:"displayName"`
+	Members     []*ScimMultiValue `json:"members,omitempty"`
+	Meta        *ScimMeta         `json:"meta,omitempty"`
+}
+
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type ScimPatch struct {
+	Schemas    []string              `json:"schemas"`
+	Operations []*ScimPatchOperation `json:"Operations"`
+}
+
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ScimError 按RFC 7644返回给身份提供方的错误
+type ScimError struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *ScimError) Error() string {
+	return fmt.Sprintf("scim error: status(%v), scimType(%s), detail(%s)", e.Status, e.ScimType, e.Detail)
+}
+
+// ScimListQuery 列表查询，startIndex从1开始，count为0时只返回总数
+type ScimListQuery struct {
+	Filter         string
+	StartIndex     int32
+	Count          int32
+	ExcludeMembers bool
+}
+
+func NewScimUseCase(userRepo UserRepo, authRepo AuthRepo, groupRepo GroupRepo, tm Transaction, event *EventUseCase, audit *AuditRecorder, conf *conf.UserConstant, logger log.Logger) *ScimUseCase {
+	return &ScimUseCase{
+		userRepo:  userRepo,
+		authRepo:  authRepo,
+		groupRepo: groupRepo,
+		tm:        tm,
+		event:     event,
+		audit:     audit,
+		conf:      conf,
+		log:       log.NewHelper(log.With(logger, "module", "user/biz/scimUseCase")),
+	}
+}
+
+// Authorize 校验SCIM凭证，未配置凭证时拒绝所有请求
+func (r *ScimUseCase) Authorize(_ context.Context, token string) error {
+	if token != "" {
+		for _, item := range r.conf.ScimTokens {
+			if item != "" && subtle.ConstantTimeCompare([]byte(item), []byte(token)) == 1 {
+				return nil
+			}
+		}
+	}
+	return &ScimError{Status: http.StatusUnauthorized, Detail: "invalid scim token"}
+}
+
+func (r *ScimUseCase) GetUser(ctx context.Context, id string) (*ScimUser, error) {
+	user, err := r.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return scimUserResource(user), nil
+}
+
+// ListUsers 按过滤条件分页查询用户
+func (r *ScimUseCase) ListUsers(ctx context.Context, query *ScimListQuery) ([]*ScimUser, int64, error) {
+	conditions, err := r.filterConditions(query, scimUserFilterAttribute)
+	if err != nil {
+		return nil, 0, err
+	}
+	users, total, err := r.userRepo.ListUsers(ctx, conditions, query.StartIndex-1, query.Count)
+	if err != nil {
+		return nil, 0, v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	resources := make([]*ScimUser, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, scimUserResource(user))
+	}
+	return resources, total, nil
+}
+
+// CreateUser 开通用户
+//1. 账号已存在时返回uniqueness错误
+//2. 身份提供方推送的用户不需要审核，active为false时创建为已停用状态
+//3. 同一事务中写入注册事件
+func (r *ScimUseCase) CreateUser(ctx context.Context, resource *ScimUser) (result *ScimUser, err error) {
+	user := &User{
+		UserStatus: UserStatusNormal,
+		Role:       r.conf.DefaultRole,
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionScimUserCreate, TargetId: user.Id, Detail: fmt.Sprintf("userAccount(%s)", user.UserAccount)}, err)
+	}()
+
+	err = applyScimUser(user, resource)
+	if err != nil {
+		return nil, err
+	}
+	exist, err := r.authRepo.AccountExist(ReadPrimary(ctx), user.UserAccount)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	if exist {
+		return nil, scimError(http.StatusConflict, ScimTypeUniqueness, "userName(%s) already exists", user.UserAccount)
+	}
+
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		id, err := r.authRepo.CreateUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		user.Id = id
+		return r.event.Emit(ctx, NewUserEvent(EventUserRegistered, user.Id, user))
+	})
+	if err != nil {
+		return nil, v1.ErrorUserRegisterFailed("%s", err.Error())
+	}
+	return r.GetUser(ctx, strconv.Itoa(int(user.Id)))
+}
+
+// ReplaceUser 整体替换用户属性，未传active时保持原状态，未传密码时保持原密码
+func (r *ScimUseCase) ReplaceUser(ctx context.Context, id string, resource *ScimUser) (*ScimUser, error) {
+	before, err := r.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	after := *before
+	after.UserPassword = ""
+	err = applyScimUser(&after, resource)
+	if err != nil {
+		return nil, err
+	}
+	return r.updateUser(ctx, before, &after)
+}
+
+// PatchUser 按PATCH操作修改用户属性，不保存的属性忽略
+func (r *ScimUseCase) PatchUser(ctx context.Context, id string, patch *ScimPatch) (*ScimUser, error) {
+	before, err := r.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	after := *before
+	after.UserPassword = ""
+	for _, operation := range patch.Operations {
+		op := strings.ToLower(operation.Op)
+		switch {
+		case op != scimOpAdd && op != scimOpReplace && op != scimOpRemove:
+			return nil, scimError(http.StatusBadRequest, ScimTypeInvalidSyntax, "unsupported patch op: %s", operation.Op)
+		case operation.Path != "":
+			err = applyScimUserAttribute(&after, operation.Path, operation.Value, op == scimOpRemove)
+		case op == scimOpRemove:
+			return nil, scimError(http.StatusBadRequest, ScimTypeNoTarget, "path required for remove")
+		default:
+			var values map[string]json.RawMessage
+			if json.Unmarshal(operation.Value, &values) != nil {
+				return nil, scimError(http.StatusBadRequest, ScimTypeInvalidValue, "patch value must be an object")
+			}
+			for attribute, value := range values {
+				err = applyScimUserAttribute(&after, attribute, value, false)
+				if err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r.updateUser(ctx, before, &after)
+}
+
+// updateUser 保存用户修改
+//1. 账号修改后与其他账号冲突时返回uniqueness错误
+//2. 同一事务中写入事件，状态变化时为状态变更事件
+//3. 用户被停用时清除登录态
+func (r *ScimUseCase) updateUser(ctx context.Context, before, after *User) (result *ScimUser, err error) {
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionScimUserUpdate, TargetId: after.Id, Detail: fmt.Sprintf("userAccount(%s)", after.UserAccount)}, err)
+	}()
+
+	if after.UserAccount != before.UserAccount {
+		exist, err := r.authRepo.AccountExist(ReadPrimary(ctx), after.UserAccount)
+		if err != nil {
+			return nil, v1.ErrorUnknownError("%s", err.Error())
+		}
+		if exist {
+			return nil, scimError(http.StatusConflict, ScimTypeUniqueness, "userName(%s) already exists", after.UserAccount)
+		}
+	}
+
+	eventType := EventUserUpdated
+	if after.UserStatus != before.UserStatus {
+		eventType = EventUserStatusChanged
+	}
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.userRepo.UpdateUser(ctx, after)
+		if err != nil {
+			return err
+		}
+		return r.event.Emit(ctx, NewUserEvent(eventType, after.Id, after))
+	})
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	if after.UserStatus != UserStatusNormal && before.UserStatus == UserStatusNormal {
+		err = r.authRepo.UserLogout(ctx, after.Id)
+		if err != nil {
+			r.log.Errorf("fail to clear disabled user session: userId(%v), error(%v)", after.Id, err)
+		}
+	}
+	return r.GetUser(ctx, strconv.Itoa(int(after.Id)))
+}
+
+// DeleteUser 删除用户，与管理员删除用户使用同一删除逻辑，同时移除组成员关系并清除登录态
+func (r *ScimUseCase) DeleteUser(ctx context.Context, id string) (err error) {
+	user, err := r.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionScimUserDelete, TargetId: user.Id, Detail: fmt.Sprintf("userAccount(%s)", user.UserAccount)}, err)
+	}()
+
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.userRepo.DeleteUser(ctx, user.Id)
+		if err != nil {
+			return err
+		}
+		err = r.groupRepo.DeleteUserMemberships(ctx, user.Id)
+		if err != nil {
+			return err
+		}
+		return r.event.Emit(ctx, NewUserEvent(EventUserDeleted, user.Id, nil))
+	})
+	if err != nil {
+		return v1.ErrorUserDeleteFailed("%s", err.Error())
+	}
+
+	err = r.authRepo.UserLogout(ctx, user.Id)
+	if err != nil {
+		r.log.Errorf("fail to clear deleted user session: userId(%v), error(%v)", user.Id, err)
+	}
+	return nil
+}
+
+func (r *ScimUseCase) GetGroup(ctx context.Context, id string, excludeMembers bool) (*ScimGroup, error) {
+	group, err := r.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := r.groupResources(ctx, []*Group{group}, excludeMembers)
+	if err != nil {
+		return nil, err
+	}
+	return resources[0], nil
+}
+
+// ListGroups 按过滤条件分页查询组
+func (r *ScimUseCase) ListGroups(ctx context.Context, query *ScimListQuery) ([]*ScimGroup, int64, error) {
+	conditions, err := r.filterConditions(query, scimGroupFilterAttribute)
+	if err != nil {
+		return nil, 0, err
+	}
+	groups, total, err := r.groupRepo.ListGroups(ctx, conditions, query.StartIndex-1, query.Count)
+	if err != nil {
+		return nil, 0, v1.ErrorUnknownError("%s", err.Error())
+	}
+	resources, err := r.groupResources(ctx, groups, query.ExcludeMembers)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resources, total, nil
+}
+
+// CreateGroup 创建组，组名已存在时返回uniqueness错误，成员不存在时返回invalidValue错误
+func (r *ScimUseCase) CreateGroup(ctx context.Context, resource *ScimGroup) (result *ScimGroup, err error) {
+	group := &Group{}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionScimGroupCreate, Detail: fmt.Sprintf("groupId(%v), displayName(%s)", group.Id, group.DisplayName)}, err)
+	}()
+
+	err = applyScimGroup(group, resource)
+	if err != nil {
+		return nil, err
+	}
+	userIds, err := scimMemberIds(resource.Members)
+	if err != nil {
+		return nil, err
+	}
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.checkGroupName(ctx, group)
+		if err != nil {
+			return err
+		}
+		err = r.groupRepo.CreateGroup(ctx, group)
+		if err != nil {
+			return err
+		}
+		return r.groupRepo.AddGroupMembers(ctx, group.Id, userIds)
+	})
+	if err != nil {
+		return nil, scimGroupError(err)
+	}
+	return r.GetGroup(ctx, strconv.FormatInt(group.Id, 10), false)
+}
+
+// ReplaceGroup 整体替换组名和成员
+func (r *ScimUseCase) ReplaceGroup(ctx context.Context, id string, resource *ScimGroup) (result *ScimGroup, err error) {
+	group, err := r.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionScimGroupUpdate, Detail: fmt.Sprintf("groupId(%v), displayName(%s)", group.Id, group.DisplayName)}, err)
+	}()
+
+	group.ExternalId = ""
+	err = applyScimGroup(group, resource)
+	if err != nil {
+		return nil, err
+	}
+	userIds, err := scimMemberIds(resource.Members)
+	if err != nil {
+		return nil, err
+	}
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.checkGroupName(ctx, group)
+		if err != nil {
+			return err
+		}
+		err = r.groupRepo.UpdateGroup(ctx, group)
+		if err != nil {
+			return err
+		}
+		return r.groupRepo.SetGroupMembers(ctx, group.Id, userIds)
+	})
+	if err != nil {
+		return nil, scimGroupError(err)
+	}
+	return r.GetGroup(ctx, id, false)
+}
+
+// PatchGroup 按PATCH操作修改组名和成员，所有操作在同一事务中执行
+func (r *ScimUseCase) PatchGroup(ctx context.Context, id string, patch *ScimPatch) (result *ScimGroup, err error) {
+	group, err := r.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionScimGroupUpdate, Detail: fmt.Sprintf("groupId(%v), displayName(%s)", group.Id, group.DisplayName)}, err)
+	}()
+
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		for _, operation := range patch.Operations {
+			op := strings.ToLower(operation.Op)
+			switch {
+			case op != scimOpAdd && op != scimOpReplace && op != scimOpRemove:
+				return scimError(http.StatusBadRequest, ScimTypeInvalidSyntax, "unsupported patch op: %s", operation.Op)
+			case operation.Path != "":
+				err := r.patchGroupAttribute(ctx, group, op, operation.Path, operation.Value)
+				if err != nil {
+					return err
+				}
+			case op == scimOpRemove:
+				return scimError(http.StatusBadRequest, ScimTypeNoTarget, "path required for remove")
+			default:
+				var values map[string]json.RawMessage
+				if json.Unmarshal(operation.Value, &values) != nil {
+					return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "patch value must be an object")
+				}
+				for attribute, value := range values {
+					err := r.patchGroupAttribute(ctx, group, op, attribute, value)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+		err := r.checkGroupName(ctx, group)
+		if err != nil {
+			return err
+		}
+		return r.groupRepo.UpdateGroup(ctx, group)
+	})
+	if err != nil {
+		return nil, scimGroupError(err)
+	}
+	return r.GetGroup(ctx, id, false)
+}
+
+// patchGroupAttribute 执行单个组属性的PATCH操作，成员变更直接写入，组名等属性在操作全部执行后保存
+func (r *ScimUseCase) patchGroupAttribute(ctx context.Context, group *Group, op, path string, value json.RawMessage) error {
+	attribute, filter := scimAttributePath(path, ScimSchemaGroup)
+	remove := op == scimOpRemove
+	switch attribute {
+	case "displayname":
+		if remove {
+			return scimError(http.StatusBadRequest, ScimTypeMutability, "displayName is required")
+		}
+		return scimStringValue(value, &group.DisplayName)
+	case "externalid":
+		if remove {
+			group.ExternalId = ""
+			return nil
+		}
+		return scimStringValue(value, &group.ExternalId)
+	case "members":
+		var userIds []int32
+		if filter != "" {
+			if !remove {
+				return scimError(http.StatusBadRequest, ScimTypeInvalidPath, "unsupported path: %s", path)
+			}
+			userId, err := scimMemberFilter(filter)
+			if err != nil {
+				return err
+			}
+			return r.groupRepo.RemoveGroupMembers(ctx, group.Id, []int32{userId})
+		}
+		if len(value) > 0 && string(value) != "null" {
+			var members []*ScimMultiValue
+			if json.Unmarshal(value, &members) != nil {
+				return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "members must be an array")
+			}
+			var err error
+			userIds, err = scimMemberIds(members)
+			if err != nil {
+				return err
+			}
+		}
+		switch {
+		case op == scimOpAdd:
+			return r.groupRepo.AddGroupMembers(ctx, group.Id, userIds)
+		case remove && len(userIds) > 0:
+			return r.groupRepo.RemoveGroupMembers(ctx, group.Id, userIds)
+		default:
+			return r.groupRepo.SetGroupMembers(ctx, group.Id, userIds)
+		}
+	case "id", "meta":
+		return nil
+	}
+	return scimError(http.StatusBadRequest, ScimTypeInvalidPath, "unsupported path: %s", path)
+}
+
+// DeleteGroup 删除组及其成员关系
+func (r *ScimUseCase) DeleteGroup(ctx context.Context, id string) (err error) {
+	group, err := r.getGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionScimGroupDelete, Detail: fmt.Sprintf("groupId(%v), displayName(%s)", group.Id, group.DisplayName)}, err)
+	}()
+
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		return r.groupRepo.DeleteGroup(ctx, group.Id)
+	})
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	return nil
+}
+
+func (r *ScimUseCase) getUser(ctx context.Context, id string) (*User, error) {
+	userId, err := strconv.ParseInt(id, 10, 32)
+	if err != nil || userId <= 0 {
+		return nil, scimError(http.StatusNotFound, "", "user(%s) not found", id)
+	}
+	user, err := r.userRepo.GetCurrentUser(ctx, int32(userId))
+	if kerrors.IsNotFound(err) {
+		return nil, scimError(http.StatusNotFound, "", "user(%s) not found", id)
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return user, nil
+}
+
+func (r *ScimUseCase) getGroup(ctx context.Context, id string) (*Group, error) {
+	groupId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || groupId <= 0 {
+		return nil, scimError(http.StatusNotFound, "", "group(%s) not found", id)
+	}
+	group, err := r.groupRepo.GetGroup(ctx, groupId)
+	if kerrors.IsNotFound(err) {
+		return nil, scimError(http.StatusNotFound, "", "group(%s) not found", id)
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return group, nil
+}
+
+// checkGroupName 组名不能与其他组重复
+func (r *ScimUseCase) checkGroupName(ctx context.Context, group *Group) error {
+	groups, _, err := r.groupRepo.ListGroups(ctx, []*FilterCondition{{Attribute: FilterAttributeDisplayName, Operator: FilterOperatorEq, Value: group.DisplayName}}, 0, 1)
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 && groups[0].Id != group.Id {
+		return scimError(http.StatusConflict, ScimTypeUniqueness, "displayName(%s) already exists", group.DisplayName)
+	}
+	return nil
+}
+
+// groupResources 批量查询组成员并转换为SCIM资源
+func (r *ScimUseCase) groupResources(ctx context.Context, groups []*Group, excludeMembers bool) ([]*ScimGroup, error) {
+	members := make(map[int64][]*GroupMember)
+	if !excludeMembers && len(groups) > 0 {
+		groupIds := make([]int64, 0, len(groups))
+		for _, group := range groups {
+			groupIds = append(groupIds, group.Id)
+		}
+		list, err := r.groupRepo.ListGroupMembers(ctx, groupIds)
+		if err != nil {
+			return nil, v1.ErrorUnknownError("%s", err.Error())
+		}
+		for _, member := range list {
+			members[member.GroupId] = append(members[member.GroupId], member)
+		}
+	}
+
+	resources := make([]*ScimGroup, 0, len(groups))
+	for _, group := range groups {
+		resources = append(resources, scimGroupResource(group, members[group.Id]))
+	}
+	return resources, nil
+}
+
+// filterConditions 规范化分页参数并解析过滤表达式
+func (r *ScimUseCase) filterConditions(query *ScimListQuery, mapping func(condition *FilterCondition) (*FilterCondition, error)) ([]*FilterCondition, error) {
+	if query.StartIndex < 1 {
+		query.StartIndex = 1
+	}
+	if query.Count < 0 {
+		query.Count = 0
+	}
+	if query.Count > scimMaxCount {
+		query.Count = scimMaxCount
+	}
+	if strings.TrimSpace(query.Filter) == "" {
+		return nil, nil
+	}
+
+	parsed, err := parseScimFilter(query.Filter)
+	if err != nil {
+		return nil, scimError(http.StatusBadRequest, ScimTypeInvalidFilter, "%s", err.Error())
+	}
+	conditions := make([]*FilterCondition, 0, len(parsed))
+	for _, item := range parsed {
+		condition, err := mapping(item)
+		if err != nil {
+			return nil, err
+		}
+		if condition != nil {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions, nil
+}
+
+// scimUserFilterAttribute 将SCIM用户属性转换为用户字段，active转换为用户状态条件
+func scimUserFilterAttribute(condition *FilterCondition) (*FilterCondition, error) {
+	attribute, _ := scimAttributePath(condition.Attribute, ScimSchemaUser)
+	switch attribute {
+	case "id":
+		return &FilterCondition{Attribute: FilterAttributeId, Operator: condition.Operator, Value: condition.Value}, nil
+	case "username":
+		return &FilterCondition{Attribute: FilterAttributeUserAccount, Operator: condition.Operator, Value: condition.Value}, nil
+	case "displayname", "name.formatted":
+		return &FilterCondition{Attribute: FilterAttributeUserName, Operator: condition.Operator, Value: condition.Value}, nil
+	case "emails", "emails.value":
+		return &FilterCondition{Attribute: FilterAttributeEmail, Operator: condition.Operator, Value: condition.Value}, nil
+	case "phonenumbers", "phonenumbers.value":
+		return &FilterCondition{Attribute: FilterAttributePhone, Operator: condition.Operator, Value: condition.Value}, nil
+	case "active":
+		if condition.Operator == FilterOperatorPr {
+			return nil, nil
+		}
+		active, err := strconv.ParseBool(condition.Value)
+		if err != nil || (condition.Operator != FilterOperatorEq && condition.Operator != FilterOperatorNe) {
+			return nil, scimError(http.StatusBadRequest, ScimTypeInvalidFilter, "unsupported active filter")
+		}
+		operator := FilterOperatorEq
+		if active != (condition.Operator == FilterOperatorEq) {
+			operator = FilterOperatorNe
+		}
+		return &FilterCondition{Attribute: FilterAttributeUserStatus, Operator: operator, Value: strconv.Itoa(int(UserStatusNormal))}, nil
+	}
+	return nil, scimError(http.StatusBadRequest, ScimTypeInvalidFilter, "unsupported filter attribute: %s", condition.Attribute)
+}
+
+// scimGroupFilterAttribute 将SCIM组属性转换为组字段，成员只支持eq
+func scimGroupFilterAttribute(condition *FilterCondition) (*FilterCondition, error) {
+	attribute, _ := scimAttributePath(condition.Attribute, ScimSchemaGroup)
+	switch attribute {
+	case "id":
+		return &FilterCondition{Attribute: FilterAttributeId, Operator: condition.Operator, Value: condition.Value}, nil
+	case "displayname":
+		return &FilterCondition{Attribute: FilterAttributeDisplayName, Operator: condition.Operator, Value: condition.Value}, nil
+	case "externalid":
+		return &FilterCondition{Attribute: FilterAttributeExternalId, Operator: condition.Operator, Value: condition.Value}, nil
+	case "members", "members.value":
+		if condition.Operator != FilterOperatorEq {
+			return nil, scimError(http.StatusBadRequest, ScimTypeInvalidFilter, "unsupported members filter")
+		}
+		return &FilterCondition{Attribute: FilterAttributeMember, Operator: condition.Operator, Value: condition.Value}, nil
+	}
+	return nil, scimError(http.StatusBadRequest, ScimTypeInvalidFilter, "unsupported filter attribute: %s", condition.Attribute)
+}
+
+// parseScimFilter 解析过滤表达式，支持"属性 操作符 值"通过and连接，不支持or、not、括号和复杂属性过滤
+func parseScimFilter(filter string) ([]*FilterCondition, error) {
+	conditions := make([]*FilterCondition, 0)
+	rest := strings.TrimSpace(filter)
+	for {
+		var attribute, operator, value string
+		attribute, rest = nextFilterToken(rest)
+		operator, rest = nextFilterToken(rest)
+		operator = strings.ToLower(operator)
+		if attribute == "" || operator == "" || strings.ContainsAny(attribute, "()[]\"") {
+			return nil, fmt.Errorf("invalid filter: %s", filter)
+		}
+		switch operator {
+		case FilterOperatorPr:
+		case FilterOperatorEq, FilterOperatorNe, FilterOperatorCo, FilterOperatorSw, FilterOperatorEw:
+			var err error
+			value, rest, err = nextFilterValue(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter: %s", filter)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported filter operator: %s", operator)
+		}
+		conditions = append(conditions, &FilterCondition{Attribute: attribute, Operator: operator, Value: value})
+
+		if rest == "" {
+			return conditions, nil
+		}
+		var logical string
+		logical, rest = nextFilterToken(rest)
+		if !strings.EqualFold(logical, "and") {
+			return nil, fmt.Errorf("unsupported filter expression: %s", logical)
+		}
+	}
+}
+
+func nextFilterToken(s string) (string, string) {
+	s = strings.TrimLeft(s, " ")
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		return s[:i], strings.TrimLeft(s[i:], " ")
+	}
+	return s, ""
+}
+
+// nextFilterValue 解析比较值，字符串按JSON字符串解析，null视为空字符串
+func nextFilterValue(s string) (string, string, error) {
+	s = strings.TrimLeft(s, " ")
+	if !strings.HasPrefix(s, `"`) {
+		token, rest := nextFilterToken(s)
+		switch {
+		case token == "":
+			return "", "", fmt.Errorf("missing filter value")
+		case token == "null":
+			return "", rest, nil
+		case strings.ContainsAny(token, "()[]\""):
+			return "", "", fmt.Errorf("invalid filter value: %s", token)
+		}
+		return token, rest, nil
+	}
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			var value string
+			if err := json.Unmarshal([]byte(s[:i+1]), &value); err != nil {
+				return "", "", err
+			}
+			return value, strings.TrimLeft(s[i+1:], " "), nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated filter value")
+}
+
+// scimAttributePath 规范化属性路径，去掉核心schema前缀并转为小写，返回属性路径和方括号中的过滤表达式
+//  如emails[type eq "work"].value返回emails.value和type eq "work"
+func scimAttributePath(path, schema string) (string, string) {
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+		path = path[len(schema)+1:]
+	}
+	var filter string
+	if start := strings.IndexByte(path, '['); start >= 0 {
+		if end := strings.LastIndexByte(path, ']'); end > start {
+			filter = path[start+1 : end]
+			path = path[:start] + path[end+1:]
+		}
+	}
+	return strings.ToLower(path), filter
+}
+
+// applyScimUser 将创建、替换请求中的用户资源写入用户
+func applyScimUser(user *User, resource *ScimUser) error {
+	user.UserAccount = strings.TrimSpace(resource.UserName)
+	if user.UserAccount == "" || len(user.UserAccount) > scimMaxAttribute {
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid userName")
+	}
+	user.UserName = scimDisplayName(resource.DisplayName, resource.Name)
+	user.Email = scimPrimaryValue(resource.Emails)
+	user.Phone = scimPrimaryValue(resource.PhoneNumbers)
+	user.AvatarUrl = scimPrimaryValue(resource.Photos)
+	if resource.Active != nil {
+		setScimUserActive(user, *resource.Active)
+	}
+	if resource.Password != "" {
+		user.UserPassword = passwordMD5Hash(resource.Password)
+	}
+	return checkScimUser(user)
+}
+
+// applyScimUserAttribute 执行单个用户属性的PATCH操作
+func applyScimUserAttribute(user *User, path string, value json.RawMessage, remove bool) error {
+	attribute, _ := scimAttributePath(path, ScimSchemaUser)
+	var err error
+	switch attribute {
+	case "username":
+		if remove {
+			return scimError(http.StatusBadRequest, ScimTypeMutability, "userName is required")
+		}
+		err = scimStringValue(value, &user.UserAccount)
+		user.UserAccount = strings.TrimSpace(user.UserAccount)
+	case "displayname", "name.formatted":
+		user.UserName = ""
+		if !remove {
+			err = scimStringValue(value, &user.UserName)
+		}
+	case "name":
+		user.UserName = ""
+		if !remove {
+			name := &ScimName{}
+			if json.Unmarshal(value, name) != nil {
+				return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid name")
+			}
+			user.UserName = scimDisplayName("", name)
+		}
+	case "emails", "emails.value":
+		err = scimMultiValue(attribute, value, remove, &user.Email)
+	case "phonenumbers", "phonenumbers.value":
+		err = scimMultiValue(attribute, value, remove, &user.Phone)
+	case "photos", "photos.value":
+		err = scimMultiValue(attribute, value, remove, &user.AvatarUrl)
+	case "active":
+		if remove {
+			return scimError(http.StatusBadRequest, ScimTypeMutability, "active can not be removed")
+		}
+		active, ok := scimBoolValue(value)
+		if !ok {
+			return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid active")
+		}
+		setScimUserActive(user, active)
+	case "password":
+		var password string
+		if remove {
+			return scimError(http.StatusBadRequest, ScimTypeMutability, "password can not be removed")
+		}
+		err = scimStringValue(value, &password)
+		if password != "" {
+			user.UserPassword = passwordMD5Hash(password)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return checkScimUser(user)
+}
+
+// setScimUserActive active为true时置为正常状态，为false时停用正常状态的用户，待审核等状态保持不变
+func setScimUserActive(user *User, active bool) {
+	switch {
+	case active:
+		user.UserStatus = UserStatusNormal
+	case user.UserStatus == UserStatusNormal:
+		user.UserStatus = UserStatusDisabled
+	}
+}
+
+func checkScimUser(user *User) error {
+	switch {
+	case user.UserAccount == "" || len(user.UserAccount) > scimMaxAttribute:
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid userName")
+	case len(user.UserName) > scimMaxAttribute:
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid displayName")
+	case len(user.Email) > 512:
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid email")
+	case len(user.Phone) > 128:
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid phoneNumber")
+	case len(user.AvatarUrl) > 1024:
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid photo")
+	}
+	return nil
+}
+
+func applyScimGroup(group *Group, resource *ScimGroup) error {
+	group.DisplayName = strings.TrimSpace(resource.DisplayName)
+	if group.DisplayName == "" || len(group.DisplayName) > scimMaxAttribute {
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid displayName")
+	}
+	if resource.ExternalId != "" {
+		group.ExternalId = resource.ExternalId
+	}
+	if len(group.ExternalId) > scimMaxAttribute {
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid externalId")
+	}
+	return nil
+}
+
+// scimMultiValue 多值属性只保存主值，路径为属性本身时取数组中的主值
+func scimMultiValue(attribute string, value json.RawMessage, remove bool, target *string) error {
+	if remove {
+		*target = ""
+		return nil
+	}
+	if strings.HasSuffix(attribute, ".value") {
+		return scimStringValue(value, target)
+	}
+	var values []*ScimMultiValue
+	if json.Unmarshal(value, &values) != nil {
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "%s must be an array", attribute)
+	}
+	*target = scimPrimaryValue(values)
+	return nil
+}
+
+func scimPrimaryValue(values []*ScimMultiValue) string {
+	for _, value := range values {
+		if value != nil && value.Primary {
+			return value.Value
+		}
+	}
+	for _, value := range values {
+		if value != nil {
+			return value.Value
+		}
+	}
+	return ""
+}
+
+func scimDisplayName(displayName string, name *ScimName) string {
+	switch {
+	case displayName != "":
+		return displayName
+	case name == nil:
+		return ""
+	case name.Formatted != "":
+		return name.Formatted
+	}
+	return strings.TrimSpace(name.GivenName + " " + name.FamilyName)
+}
+
+func scimStringValue(value json.RawMessage, target *string) error {
+	if json.Unmarshal(value, target) != nil {
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "value must be a string")
+	}
+	return nil
+}
+
+// scimBoolValue 兼容部分身份提供方以字符串传递布尔值
+func scimBoolValue(value json.RawMessage) (bool, bool) {
+	var b bool
+	if json.Unmarshal(value, &b) == nil {
+		return b, true
+	}
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, true
+		}
+	}
+	return false, false
+}
+
+func scimMemberIds(members []*ScimMultiValue) ([]int32, error) {
+	userIds := make([]int32, 0, len(members))
+	for _, member := range members {
+		if member == nil {
+			continue
+		}
+		userId, err := strconv.ParseInt(member.Value, 10, 32)
+		if err != nil || userId <= 0 {
+			return nil, scimError(http.StatusBadRequest, ScimTypeInvalidValue, "invalid member: %s", member.Value)
+		}
+		userIds = append(userIds, int32(userId))
+	}
+	return userIds, nil
+}
+
+// scimMemberFilter 解析members[value eq "id"]中的成员id
+func scimMemberFilter(filter string) (int32, error) {
+	conditions, err := parseScimFilter(filter)
+	if err != nil || len(conditions) != 1 || !strings.EqualFold(conditions[0].Attribute, "value") || conditions[0].Operator != FilterOperatorEq {
+		return 0, scimError(http.StatusBadRequest, ScimTypeInvalidFilter, "unsupported members filter: %s", filter)
+	}
+	userIds, err := scimMemberIds([]*ScimMultiValue{{Value: conditions[0].Value}})
+	if err != nil {
+		return 0, err
+	}
+	return userIds[0], nil
+}
+
+// scimGroupError 成员用户不存在时返回invalidValue错误，SCIM协议错误原样返回
+func scimGroupError(err error) error {
+	var serr *ScimError
+	if errors.As(err, &serr) {
+		return serr
+	}
+	if kerrors.IsNotFound(err) {
+		return scimError(http.StatusBadRequest, ScimTypeInvalidValue, "member not found: %s", kerrors.FromError(err).Message)
+	}
+	return v1.ErrorUnknownError("%s", err.Error())
+}
+
+func scimUserResource(user *User) *ScimUser {
+	active := user.UserStatus == UserStatusNormal
+	resource := &ScimUser{
+		Schemas:     []string{ScimSchemaUser},
+		Id:          strconv.Itoa(int(user.Id)),
+		UserName:    user.UserAccount,
+		DisplayName: user.UserName,
+		Active:      &active,
+		Meta:        &ScimMeta{ResourceType: "User"},
+	}
+	if !user.CreateTime.IsZero() {
+		resource.Meta.Created = user.CreateTime.UTC().Format(time.RFC3339)
+	}
+	if user.UserName != "" {
+		resource.Name = &ScimName{Formatted: user.UserName}
+	}
+	if user.Email != "" {
+		resource.Emails = []*ScimMultiValue{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	if user.Phone != "" {
+		resource.PhoneNumbers = []*ScimMultiValue{{Value: user.Phone, Type: "work", Primary: true}}
+	}
+	if user.AvatarUrl != "" {
+		resource.Photos = []*ScimMultiValue{{Value: user.AvatarUrl, Type: "photo", Primary: true}}
+	}
+	return resource
+}
+
+func scimGroupResource(group *Group, members []*GroupMember) *ScimGroup {
+	resource := &ScimGroup{
+		Schemas:     []string{ScimSchemaGroup},
+		Id:          strconv.FormatInt(group.Id, 10),
+		ExternalId:  group.ExternalId,
+		DisplayName: group.DisplayName,
+		Meta:        &ScimMeta{ResourceType: "Group"},
+	}
+	if !group.CreateTime.IsZero() {
+		resource.Meta.Created = group.CreateTime.UTC().Format(time.RFC3339)
+	}
+	if !group.UpdateTime.IsZero() {
+		resource.Meta.LastModified = group.UpdateTime.UTC().Format(time.RFC3339)
+	}
+	for _, member := range members {
+		display := member.UserName
+		if display == "" {
+			display = member.UserAccount
+		}
+		resource.Members = append(resource.Members, &ScimMultiValue{Value: strconv.Itoa(int(member.UserId)), Display: display, Type: "User"})
+	}
+	return resource
+}
+
+func scimError(status int, scimType, format string, args ...interface{}) *ScimError {
+	return &ScimError{Status: status, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
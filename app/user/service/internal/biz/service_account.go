@@ -0,0 +1,258 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"regexp"
+)
+
+// ServiceAccountUseCase 服务账号，代表后端服务的非登录主体，通过API Key调用接口
+type ServiceAccountUseCase struct {
+	userRepo  UserRepo
+	authRepo  AuthRepo
+	tokenRepo AccessTokenRepo
+	tm        Transaction
+	event     *EventUseCase
+	audit     *AuditRecorder
+	conf      *conf.UserConstant
+	log       *log.Helper
+}
+
+const (
+	AuditActionServiceAccountCreate      = "service_account.create"
+	AuditActionServiceAccountUpdate      = "service_account.update"
+	AuditActionServiceAccountTokenCreate = "service_account.token.create"
+)
+
+type CreateServiceAccount struct {
+	UserAccount string `validate:"required,min=4,max=256" comment:"账号"`
+	UserName    string `validate:"max=256" comment:"名称"`
+	Role        int32  `validate:"gte=0" comment:"角色"`
+	OwnerId     int32  `validate:"gte=0" comment:"负责人Id"`
+}
+
+type ListServiceAccounts struct {
+	Page     int32 `validate:"gte=0" comment:"页码"`
+	PageSize int32 `validate:"gte=0,lte=100" comment:"每页数量"`
+}
+
+type UpdateServiceAccount struct {
+	Id       int32  `validate:"required,gt=0" comment:"服务账号Id"`
+	UserName string `validate:"max=256" comment:"名称"`
+	Role     int32  `validate:"gte=0" comment:"角色"`
+	OwnerId  int32  `validate:"required,gt=0" comment:"负责人Id"`
+	Disabled bool
+}
+
+type CreateServiceAccountToken struct {
+	Id int32 `validate:"required,gt=0" comment:"服务账号Id"`
+	CreateAccessToken
+}
+
+func NewServiceAccountUseCase(userRepo UserRepo, authRepo AuthRepo, tokenRepo AccessTokenRepo, tm Transaction, event *EventUseCase, audit *AuditRecorder, conf *conf.UserConstant, logger log.Logger) *ServiceAccountUseCase {
+	return &ServiceAccountUseCase{
+		userRepo:  userRepo,
+		authRepo:  authRepo,
+		tokenRepo: tokenRepo,
+		tm:        tm,
+		event:     event,
+		audit:     audit,
+		conf:      conf,
+		log:       log.NewHelper(log.With(logger, "module", "user/biz/serviceAccountUseCase")),
+	}
+}
+
+// CreateServiceAccount 创建服务账号
+//1. 判断是否有管理员权限
+//2. 校验账号合法性和负责人，未指定负责人时为当前管理员
+//3. 服务账号没有本地密码，不能登录，同一事务中写入注册事件
+func (r *ServiceAccountUseCase) CreateServiceAccount(ctx context.Context, create *CreateServiceAccount) (account *User, err error) {
+	adminId := ctx.Value("userId").(int32)
+	account = &User{
+		UserAccount: create.UserAccount,
+		UserName:    create.UserName,
+		UserStatus:  UserStatusNormal,
+		Role:        create.Role,
+		UserType:    UserTypeService,
+		OwnerId:     create.OwnerId,
+	}
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionServiceAccountCreate, ActorId: adminId, TargetId: account.Id, Detail: fmt.Sprintf("userAccount(%s), role(%v), ownerId(%v)", account.UserAccount, account.Role, account.OwnerId)}, err)
+	}()
+
+	err = checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return nil, err
+	}
+	if account.OwnerId == 0 {
+		account.OwnerId = adminId
+	}
+	err = r.validateOwner(ctx, account.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+	illegal, err := regexp.MatchString("[^a-zA-Z0-9_]+", account.UserAccount)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	if illegal {
+		return nil, v1.ErrorAccountIllegal("account(%s) illegal!", account.UserAccount)
+	}
+	exist, err := r.authRepo.AccountExist(ReadPrimary(ctx), account.UserAccount)
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	if exist {
+		return nil, v1.ErrorAccountExist("account(%s) exist!", account.UserAccount)
+	}
+
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		id, err := r.authRepo.CreateUser(ctx, account)
+		if err != nil {
+			return err
+		}
+		account.Id = id
+		return r.event.Emit(ctx, NewUserEvent(EventUserRegistered, account.Id, account))
+	})
+	if err != nil {
+		return nil, v1.ErrorServiceAccountFailed("%s", err.Error())
+	}
+	return r.getServiceAccount(ctx, account.Id)
+}
+
+// ListServiceAccounts 服务账号列表，管理员查询全部，其他用户只能查询自己负责的服务账号
+func (r *ServiceAccountUseCase) ListServiceAccounts(ctx context.Context, page, pageSize int32) ([]*User, int64, error) {
+	userId := ctx.Value("userId").(int32)
+	ownerId := int32(0)
+	err := checkAdmin(ctx, r.userRepo, r.conf, userId)
+	if v1.IsPermissionDeny(err) {
+		ownerId, err = userId, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	accounts, total, err := r.userRepo.ListServiceAccounts(ctx, ownerId, page, pageSize)
+	if err != nil {
+		return nil, 0, v1.ErrorServiceAccountFailed("%s", err.Error())
+	}
+	return accounts, total, nil
+}
+
+// UpdateServiceAccount 修改服务账号名称、角色、负责人和停用状态
+//1. 判断是否有管理员权限
+//2. 停用后该服务账号的API Key立即失效，启用后恢复
+//3. 同一事务中写入更新事件
+func (r *ServiceAccountUseCase) UpdateServiceAccount(ctx context.Context, update *UpdateServiceAccount) (account *User, err error) {
+	adminId := ctx.Value("userId").(int32)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionServiceAccountUpdate, ActorId: adminId, TargetId: update.Id, Detail: fmt.Sprintf("role(%v), ownerId(%v), disabled(%v)", update.Role, update.OwnerId, update.Disabled)}, err)
+	}()
+
+	err = checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return nil, err
+	}
+	account, err = r.getServiceAccount(ctx, update.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = r.validateOwner(ctx, update.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	account.UserName = update.UserName
+	account.Role = update.Role
+	account.OwnerId = update.OwnerId
+	account.UserStatus = UserStatusNormal
+	if update.Disabled {
+		account.UserStatus = UserStatusDisabled
+	}
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.userRepo.UpdateServiceAccount(ctx, account)
+		if err != nil {
+			return err
+		}
+		return r.event.Emit(ctx, NewUserEvent(EventUserUpdated, account.Id, account))
+	})
+	if err != nil {
+		return nil, v1.ErrorServiceAccountFailed("%s", err.Error())
+	}
+	return account, nil
+}
+
+// CreateServiceAccountToken 为服务账号创建API Key
+//1. 只能在登录会话中由管理员或服务账号负责人创建
+//2. API Key与个人访问令牌相同，明文只在创建时返回
+func (r *ServiceAccountUseCase) CreateServiceAccountToken(ctx context.Context, create *CreateServiceAccountToken) (token *AccessToken, secret string, err error) {
+	userId := ctx.Value("userId").(int32)
+	defer func() {
+		auditLog := &AuditLog{Action: AuditActionServiceAccountTokenCreate, ActorId: userId, TargetId: create.Id, Detail: create.Name}
+		if token != nil {
+			auditLog.Detail = fmt.Sprintf("id(%v), name(%s), scopes(%s)", token.Id, token.Name, token.Scopes)
+		}
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	if _, ok := ctx.Value(principalKey{}).(*principal); ok {
+		return nil, "", v1.ErrorPermissionDeny("access token can only be created in login session")
+	}
+	account, err := r.getServiceAccount(ctx, create.Id)
+	if err != nil {
+		return nil, "", err
+	}
+	err = checkServiceAccountManager(ctx, r.userRepo, r.conf, userId, account)
+	if err != nil {
+		return nil, "", err
+	}
+	return issueAccessToken(ctx, r.tokenRepo, account.Id, &create.CreateAccessToken)
+}
+
+func (r *ServiceAccountUseCase) getServiceAccount(ctx context.Context, id int32) (*User, error) {
+	account, err := r.userRepo.GetCurrentUser(ctx, id)
+	if kerrors.IsNotFound(err) || err == nil && account.UserType != UserTypeService {
+		return nil, v1.ErrorServiceAccountFailed("service account not found: id(%v)", id)
+	}
+	if err != nil {
+		return nil, v1.ErrorUnknownError("%s", err.Error())
+	}
+	return account, nil
+}
+
+// validateOwner 负责人必须是存在的普通用户
+func (r *ServiceAccountUseCase) validateOwner(ctx context.Context, ownerId int32) error {
+	owner, err := r.userRepo.GetCurrentUser(ctx, ownerId)
+	if kerrors.IsNotFound(err) || err == nil && owner.UserType != UserTypeNormal {
+		return v1.ErrorServiceAccountFailed("owner not found: ownerId(%v)", ownerId)
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	return nil
+}
+
+// checkServiceAccountManager 管理员和服务账号负责人可以管理服务账号的API Key
+func checkServiceAccountManager(ctx context.Context, repo UserRepo, conf *conf.UserConstant, userId int32, account *User) error {
+	if account.UserType == UserTypeService && account.OwnerId == userId {
+		_, err := sessionUser(ctx, repo, userId)
+		if kerrors.IsNotFound(err) {
+			return v1.ErrorLoginStateTimeout("")
+		}
+		if err != nil {
+			return v1.ErrorUnknownError("%s", err.Error())
+		}
+		return nil
+	}
+	return checkAdmin(ctx, repo, conf, userId)
+}
@@ -2,6 +2,7 @@ package biz
 
 import (
 	"context"
+	"fmt"
 	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	ut "github.com/go-playground/universal-translator"
@@ -14,21 +15,47 @@ import (
 )
 
 type UserRepo interface {
-	SearchUsers(ctx context.Context, userName string) ([]*User, error)
+	SearchUsers(ctx context.Context, userName string, includeServiceAccounts bool) ([]*User, error)
 	DeleteUser(ctx context.Context, userName int32) error
 	GetUserRoleById(ctx context.Context, userId int32) (int32, error)
 	GetUserSession(ctx context.Context, userId int32) (*User, error)
 	GetCurrentUser(ctx context.Context, userId int32) (*User, error)
+	GetUserByAccount(ctx context.Context, userAccount string) (*User, error)
+	ListUsersByStatus(ctx context.Context, userStatus, page, pageSize int32) ([]*User, int64, error)
+	UpdateUserStatus(ctx context.Context, userId, fromStatus, toStatus int32) error
+	UpdateUserRole(ctx context.Context, userId, role int32) error
+	CreateUserApproval(ctx context.Context, approval *UserApproval) error
+	ListUsersByEmail(ctx context.Context, email string) ([]*User, error)
+	ListUsers(ctx context.Context, conditions []*FilterCondition, offset, limit int32) ([]*User, int64, error)
+	UpdateUser(ctx context.Context, user *User) error
+	ListServiceAccounts(ctx context.Context, ownerId, page, pageSize int32) ([]*User, int64, error)
+	UpdateServiceAccount(ctx context.Context, account *User) error
 }
 
 type UserUseCase struct {
-	repo UserRepo
-	log  *log.Helper
-	re   Recovery
-	tm   Transaction
-	conf *conf.UserConstant
+	repo    UserRepo
+	log     *log.Helper
+	re      Recovery
+	tm      Transaction
+	conf    *conf.UserConstant
+	mailer  Mailer
+	audit   *AuditRecorder
+	history *LoginHistoryUseCase
+	event   *EventUseCase
 }
 
+const (
+	UserStatusNormal   int32 = 0 // 正常
+	UserStatusPending  int32 = 1 // 待审核
+	UserStatusRejected int32 = 2 // 审核未通过
+	UserStatusDisabled int32 = 3 // 已停用
+)
+
+const (
+	UserTypeNormal  int32 = 0 // 普通用户
+	UserTypeService int32 = 1 // 服务账号，不能登录，只能使用API Key调用接口
+)
+
 //easyjson:json
 type User struct {
 	Id           int32
@@ -41,24 +68,55 @@ type User struct {
 	Email        string
 	UserStatus   int32
 	Role         int32
+	UserType     int32
+	OwnerId      int32
 	CreateTime   time.Time
 }
 
 type SearchUser struct {
-	UserName string
+	UserName               string
+	IncludeServiceAccounts bool
 }
 
 type DeleteUser struct {
 	Id int32 `validate:"required,gt=0" comment:"用户Id"`
 }
 
-func NewUserUseCase(repo UserRepo, re Recovery, tm Transaction, logger log.Logger, conf *conf.UserConstant) *UserUseCase {
+type ListPendingUsers struct {
+	Page     int32 `validate:"gte=0" comment:"页码"`
+	PageSize int32 `validate:"gte=0,lte=100" comment:"每页数量"`
+}
+
+type ApproveUser struct {
+	Id int32 `validate:"required,gt=0" comment:"用户Id"`
+}
+
+type RejectUser struct {
+	Id     int32  `validate:"required,gt=0" comment:"用户Id"`
+	Reason string `validate:"required,max=256" comment:"拒绝原因"`
+}
+
+// UserApproval 用户审核记录
+type UserApproval struct {
+	Id         int32
+	UserId     int32
+	ReviewerId int32
+	UserStatus int32
+	Reason     string
+	CreateTime time.Time
+}
+
+func NewUserUseCase(repo UserRepo, re Recovery, tm Transaction, logger log.Logger, conf *conf.UserConstant, mailer Mailer, audit *AuditRecorder, history *LoginHistoryUseCase, event *EventUseCase) *UserUseCase {
 	return &UserUseCase{
-		repo: repo,
-		log:  log.NewHelper(log.With(logger, "module", "user/biz/userUseCase")),
-		tm:   tm,
-		re:   re,
-		conf: conf,
+		repo:    repo,
+		log:     log.NewHelper(log.With(logger, "module", "user/biz/userUseCase")),
+		tm:      tm,
+		re:      re,
+		conf:    conf,
+		mailer:  mailer,
+		audit:   audit,
+		history: history,
+		event:   event,
 	}
 }
 
@@ -75,15 +133,15 @@ func (i *User) DoValidate(trans ut.Translator, validate *validator.Validate) err
 
 // SearchUsers 用户搜索逻辑
 //1. 判断是否有管理员权限
-//2. 根据用户名进行模糊查询
-func (r *UserUseCase) SearchUsers(ctx context.Context, userName string) ([]*User, error) {
+//2. 根据用户名进行模糊查询，默认不返回服务账号
+func (r *UserUseCase) SearchUsers(ctx context.Context, search *SearchUser) ([]*User, error) {
 	adminId := ctx.Value("userId").(int32)
 	err := r.isAdmin(ctx, adminId)
 	if err != nil {
 		return nil, err
 	}
 
-	users, err := r.repo.SearchUsers(ctx, userName)
+	users, err := r.repo.SearchUsers(ctx, search.UserName, search.IncludeServiceAccounts)
 	if err != nil {
 		return nil, v1.ErrorUserSearchFailed("%s", err.Error())
 	}
@@ -92,15 +150,26 @@ func (r *UserUseCase) SearchUsers(ctx context.Context, userName string) ([]*User
 
 // DeleteUser 用户删除逻辑
 //1. 判断是否有管理员权限
-//2. 根据用户id进行逻辑删除
-func (r *UserUseCase) DeleteUser(ctx context.Context, userId int32) error {
+//2. 根据用户id进行逻辑删除，同一事务中写入删除事件
+//3. 记录审计日志
+func (r *UserUseCase) DeleteUser(ctx context.Context, userId int32) (err error) {
 	adminId := ctx.Value("userId").(int32)
-	err := r.isAdmin(ctx, adminId)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{Action: AuditActionUserDelete, ActorId: adminId, TargetId: userId}, err)
+	}()
+
+	err = r.isAdmin(ctx, adminId)
 	if err != nil {
 		return err
 	}
 
-	err = r.repo.DeleteUser(ctx, userId)
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.repo.DeleteUser(ctx, userId)
+		if err != nil {
+			return err
+		}
+		return r.event.Emit(ctx, NewUserEvent(EventUserDeleted, userId, nil))
+	})
 	if err != nil {
 		return v1.ErrorUserDeleteFailed("%s", err.Error())
 	}
@@ -126,8 +195,190 @@ func (r *UserUseCase) GetCurrentUser(ctx context.Context) (*User, bool, error) {
 	return user, false, nil
 }
 
+// ListPendingUsers 待审核用户列表
+//1. 判断是否有管理员权限
+//2. 分页查询待审核的用户
+func (r *UserUseCase) ListPendingUsers(ctx context.Context, page, pageSize int32) ([]*User, int64, error) {
+	adminId := ctx.Value("userId").(int32)
+	err := r.isAdmin(ctx, adminId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	users, total, err := r.repo.ListUsersByStatus(ctx, UserStatusPending, page, pageSize)
+	if err != nil {
+		return nil, 0, v1.ErrorUserSearchFailed("%s", err.Error())
+	}
+	return users, total, nil
+}
+
+// ApproveUser 用户审核通过
+//1. 判断是否有管理员权限
+//2. 将待审核用户置为正常状态，并记录审核结果
+//3. 邮件通知用户审核结果
+func (r *UserUseCase) ApproveUser(ctx context.Context, userId int32) error {
+	err := r.reviewUser(ctx, userId, UserStatusNormal, "")
+	r.audit.Record(ctx, &AuditLog{Action: AuditActionUserApprove, ActorId: ctx.Value("userId").(int32), TargetId: userId}, err)
+	return err
+}
+
+// RejectUser 用户审核拒绝
+//1. 判断是否有管理员权限
+//2. 将待审核用户置为审核未通过状态，并记录拒绝原因
+//3. 邮件通知用户审核结果
+func (r *UserUseCase) RejectUser(ctx context.Context, userId int32, reason string) error {
+	err := r.reviewUser(ctx, userId, UserStatusRejected, reason)
+	r.audit.Record(ctx, &AuditLog{Action: AuditActionUserReject, ActorId: ctx.Value("userId").(int32), TargetId: userId, Detail: reason}, err)
+	return err
+}
+
+// ListAuditLogs 审计日志查询
+//1. 判断是否有管理员权限
+//2. 根据条件分页查询审计日志
+func (r *UserUseCase) ListAuditLogs(ctx context.Context, query *AuditLogQuery) ([]*AuditLog, int64, error) {
+	adminId := ctx.Value("userId").(int32)
+	err := r.isAdmin(ctx, adminId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logs, total, err := r.audit.List(ctx, query)
+	if err != nil {
+		return nil, 0, v1.ErrorAuditLogSearchFailed("%s", err.Error())
+	}
+	return logs, total, nil
+}
+
+// ListMyLoginHistory 当前登录用户的登录历史
+//1. 判断session是否存在
+//2. 分页查询当前用户的登录历史
+func (r *UserUseCase) ListMyLoginHistory(ctx context.Context, page, pageSize int32) ([]*LoginHistory, int64, error) {
+	userId := ctx.Value("userId").(int32)
+	exist, err := r.isSessionExist(ctx, userId)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !exist {
+		return nil, 0, v1.ErrorLoginStateTimeout("")
+	}
+
+	list, total, err := r.history.List(ctx, userId, page, pageSize)
+	if err != nil {
+		return nil, 0, v1.ErrorLoginHistorySearchFailed("%s", err.Error())
+	}
+	return list, total, nil
+}
+
+// ListMySessions 当前登录用户的登录会话
+//1. 判断session是否存在
+//2. 查询会话对应的登录记录，未识别的登录会带有标记
+func (r *UserUseCase) ListMySessions(ctx context.Context) ([]*LoginSession, error) {
+	userId := ctx.Value("userId").(int32)
+	exist, err := r.isSessionExist(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, v1.ErrorLoginStateTimeout("")
+	}
+
+	sessions, err := r.history.Sessions(ctx, userId)
+	if err != nil {
+		return nil, v1.ErrorLoginHistorySearchFailed("%s", err.Error())
+	}
+	return sessions, nil
+}
+
+// ListLoginHistory 指定用户的登录历史
+//1. 判断是否有管理员权限
+//2. 分页查询指定用户的登录历史
+func (r *UserUseCase) ListLoginHistory(ctx context.Context, userId, page, pageSize int32) ([]*LoginHistory, int64, error) {
+	adminId := ctx.Value("userId").(int32)
+	err := r.isAdmin(ctx, adminId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list, total, err := r.history.List(ctx, userId, page, pageSize)
+	if err != nil {
+		return nil, 0, v1.ErrorLoginHistorySearchFailed("%s", err.Error())
+	}
+	return list, total, nil
+}
+
+func (r *UserUseCase) reviewUser(ctx context.Context, userId, userStatus int32, reason string) error {
+	adminId := ctx.Value("userId").(int32)
+	err := r.isAdmin(ctx, adminId)
+	if err != nil {
+		return err
+	}
+
+	user, err := r.repo.GetCurrentUser(ReadPrimary(ctx), userId)
+	if err != nil {
+		return v1.ErrorUserApproveFailed("%s", err.Error())
+	}
+	if user.UserStatus != UserStatusPending {
+		return v1.ErrorUserApproveFailed("user(%v) is not pending approval", userId)
+	}
+
+	err = r.tm.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.repo.UpdateUserStatus(ctx, userId, UserStatusPending, userStatus)
+		if err != nil {
+			return err
+		}
+		err = r.repo.CreateUserApproval(ctx, &UserApproval{
+			UserId:     userId,
+			ReviewerId: adminId,
+			UserStatus: userStatus,
+			Reason:     reason,
+		})
+		if err != nil {
+			return err
+		}
+		changed := *user
+		changed.UserStatus = userStatus
+		return r.event.Emit(ctx, NewUserEvent(EventUserStatusChanged, userId, &changed))
+	})
+	if err != nil {
+		return v1.ErrorUserApproveFailed("%s", err.Error())
+	}
+
+	r.notifyReviewResult(ctx, user, userStatus, reason)
+	return nil
+}
+
+func (r *UserUseCase) notifyReviewResult(ctx context.Context, user *User, userStatus int32, reason string) {
+	if user.Email == "" {
+		return
+	}
+
+	subject := "账号审核通过"
+	body := fmt.Sprintf("您好，您的账号 %s 已通过审核，现在可以登录了。", user.UserAccount)
+	if userStatus == UserStatusRejected {
+		subject = "账号审核未通过"
+		body = fmt.Sprintf("您好，您的账号 %s 未通过审核，原因：%s", user.UserAccount, reason)
+	}
+	err := r.mailer.Send(ctx, user.Email, subject, body)
+	if err != nil {
+		r.log.Errorf("fail to notify review result: userId(%v), error(%v)", user.Id, err)
+	}
+}
+
 func (r *UserUseCase) isAdmin(ctx context.Context, userId int32) error {
-	role, err := r.repo.GetUserRoleById(ctx, userId)
+	return checkAdmin(ctx, r.repo, r.conf, userId)
+}
+
+// checkAdmin 根据session或个人访问令牌所属用户的角色判断是否为管理员
+//1. session或令牌用于确认登录状态
+//2. 角色以最新的用户信息为准，session中保存的角色在修改后可能已经过期
+func checkAdmin(ctx context.Context, repo UserRepo, conf *conf.UserConstant, userId int32) error {
+	_, err := sessionUser(ctx, repo, userId)
 	if kerrors.IsNotFound(err) {
 		return v1.ErrorLoginStateTimeout("")
 	}
@@ -136,17 +387,28 @@ func (r *UserUseCase) isAdmin(ctx context.Context, userId int32) error {
 		return v1.ErrorUnknownError("%s", err.Error())
 	}
 
-	if role != r.conf.AdminRole {
-		return v1.ErrorPermissionDeny("userId: %s, userRole: %v", userId, role)
+	role, err := repo.GetUserRoleById(ctx, userId)
+	if kerrors.IsNotFound(err) {
+		return v1.ErrorLoginStateTimeout("")
+	}
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+
+	if role != conf.AdminRole {
+		return v1.ErrorPermissionDeny("userId: %v, userRole: %v", userId, role)
 	}
 	return nil
 }
 
 func (r *UserUseCase) isSessionExist(ctx context.Context, userId int32) (bool, error) {
-	_, err := r.repo.GetUserSession(ctx, userId)
+	_, err := sessionUser(ctx, r.repo, userId)
 	if kerrors.IsNotFound(err) {
 		return false, nil
 	}
+	if errors.Is(err, ErrSessionUnavailable) {
+		return false, v1.ErrorSessionUnavailable("%s", err.Error())
+	}
 	if err != nil {
 		return false, v1.ErrorUnknownError("%s", err.Error())
 	}
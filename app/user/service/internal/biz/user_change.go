@@ -0,0 +1,251 @@
+package biz
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/pkg/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"sort"
+	"time"
+)
+
+type UserChangeRepo interface {
+	AppendUserChange(ctx context.Context, change *UserChange) error
+	ListUserChanges(ctx context.Context, afterSeq int64, limit int) ([]*UserChange, error)
+	ListUserChangesBySeq(ctx context.Context, seqs []int64) ([]*UserChange, error)
+	GetUserChangeSeqRange(ctx context.Context) (int64, int64, error)
+	PruneUserChanges(ctx context.Context, beforeSeq int64) (int64, error)
+}
+
+const (
+	UserChangeCreate       = "create"
+	UserChangeUpdate       = "update"
+	UserChangeDelete       = "delete"
+	UserChangeStatusChange = "status_change"
+)
+
+const (
+	userChangeBatchSize    = 100
+	userChangePollInterval = 500 * time.Millisecond
+	defaultChangeGapWait   = 5 * time.Second
+	defaultChangeGapRescan = 5 * time.Minute
+	defaultChangeLogSize   = 100000
+	serviceTokenHeader     = "X-Service-Token"
+)
+
+// UserChange 用户变更日志，Seq单调递增，作为订阅方断点续传的位置
+type UserChange struct {
+	Seq        int64
+	EventId    string
+	ChangeType string
+	UserId     int32
+	Payload    string
+	CreateTime time.Time
+}
+
+// UserChangeFeed 用户变更订阅，变更日志按条数保留
+type UserChangeFeed struct {
+	repo     UserChangeRepo
+	userRepo UserRepo
+	conf     *conf.UserConstant
+	log      *log.Helper
+}
+
+func NewUserChangeFeed(repo UserChangeRepo, userRepo UserRepo, conf *conf.UserConstant, logger log.Logger) *UserChangeFeed {
+	return &UserChangeFeed{
+		repo:     repo,
+		userRepo: userRepo,
+		conf:     conf,
+		log:      log.NewHelper(log.With(logger, "module", "user/biz/userChangeFeed")),
+	}
+}
+
+// Event 解析变更对应的用户事件
+func (c *UserChange) Event() (*UserEvent, error) {
+	event := &UserEvent{}
+	err := json.Unmarshal([]byte(c.Payload), event)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("json unmarshal error: seq(%v)", c.Seq))
+	}
+	return event, nil
+}
+
+// userChangeType 用户事件对应的变更类型
+func userChangeType(eventType string) string {
+	switch eventType {
+	case EventUserRegistered:
+		return UserChangeCreate
+	case EventUserDeleted:
+		return UserChangeDelete
+	case EventUserStatusChanged:
+		return UserChangeStatusChange
+	default:
+		return UserChangeUpdate
+	}
+}
+
+// Watch 推送变更日志
+//1. 只允许管理员或持有服务凭证的内部服务订阅
+//2. fromSeq为0时从最新位置开始推送，否则推送fromSeq之后的变更
+//3. fromSeq之后的变更已被清理时返回错误，订阅方需全量同步后重新订阅
+//4. 并发事务可能乱序提交，序号出现空洞时等待changeGapWait，超时后先推送后续变更
+//5. 跳过的序号在changeGapRescan内继续补查，期间提交的变更补发（序号小于已推送的序号），超过后视为事务已回滚
+func (r *UserChangeFeed) Watch(ctx context.Context, fromSeq int64, send func(*UserChange) error) error {
+	err := r.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	minSeq, maxSeq, err := r.repo.GetUserChangeSeqRange(ctx)
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	switch {
+	case fromSeq <= 0:
+		fromSeq = maxSeq
+	case minSeq > 0 && fromSeq < minSeq-1:
+		return v1.ErrorChangeLogExpired("fromSeq: %v, minSeq: %v", fromSeq, minSeq)
+	}
+
+	gapWait, gapRescan := r.gapWait(), r.gapRescan()
+	// 跳过的序号及停止补查的时间
+	skipped := make(map[int64]time.Time)
+	ticker := time.NewTicker(userChangePollInterval)
+	defer ticker.Stop()
+	for {
+		err = r.rescan(ctx, skipped, send)
+		if err != nil {
+			return err
+		}
+
+		changes, err := r.repo.ListUserChanges(ctx, fromSeq, userChangeBatchSize)
+		if err != nil {
+			return v1.ErrorUnknownError("%s", err.Error())
+		}
+		complete := len(changes) == userChangeBatchSize
+		for _, change := range changes {
+			if change.Seq != fromSeq+1 && time.Since(change.CreateTime) < gapWait {
+				complete = false
+				break
+			}
+			for seq := fromSeq + 1; seq < change.Seq; seq++ {
+				skipped[seq] = time.Now().Add(gapRescan)
+			}
+			err = send(change)
+			if err != nil {
+				return err
+			}
+			fromSeq = change.Seq
+		}
+		if complete {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// rescan 补查跳过的序号，补发期间提交的变更，超过补查时间的序号不再等待
+func (r *UserChangeFeed) rescan(ctx context.Context, skipped map[int64]time.Time, send func(*UserChange) error) error {
+	if len(skipped) == 0 {
+		return nil
+	}
+	seqs := make([]int64, 0, len(skipped))
+	for seq := range skipped {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool {
+		return seqs[i] < seqs[j]
+	})
+
+	changes, err := r.repo.ListUserChangesBySeq(ctx, seqs)
+	if err != nil {
+		return v1.ErrorUnknownError("%s", err.Error())
+	}
+	for _, change := range changes {
+		err = send(change)
+		if err != nil {
+			return err
+		}
+		delete(skipped, change.Seq)
+	}
+	for seq, deadline := range skipped {
+		if time.Now().After(deadline) {
+			r.log.Warnf("user change seq skipped: seq(%v)", seq)
+			delete(skipped, seq)
+		}
+	}
+	return nil
+}
+
+func (r *UserChangeFeed) gapWait() time.Duration {
+	if r.conf.ChangeGapWait > 0 {
+		return time.Duration(r.conf.ChangeGapWait) * time.Second
+	}
+	return defaultChangeGapWait
+}
+
+func (r *UserChangeFeed) gapRescan() time.Duration {
+	if r.conf.ChangeGapRescan > 0 {
+		return time.Duration(r.conf.ChangeGapRescan) * time.Second
+	}
+	return defaultChangeGapRescan
+}
+
+// PruneJob 定期清理超出保留条数的变更日志
+func (r *UserChangeFeed) PruneJob() *Job {
+	return &Job{
+		Name:     "user-change-prune",
+		Interval: time.Minute,
+		Run: func(ctx context.Context) error {
+			size := r.conf.ChangeLogSize
+			if size <= 0 {
+				size = defaultChangeLogSize
+			}
+			_, maxSeq, err := r.repo.GetUserChangeSeqRange(ctx)
+			if err != nil {
+				return err
+			}
+			if maxSeq <= size {
+				return nil
+			}
+			count, err := r.repo.PruneUserChanges(ctx, maxSeq-size+1)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				r.log.Infof("pruned user changes: count(%v), maxSeq(%v)", count, maxSeq)
+			}
+			return nil
+		},
+	}
+}
+
+// authorize 校验服务凭证，未携带凭证时按管理员校验
+func (r *UserChangeFeed) authorize(ctx context.Context) error {
+	if header, ok := transport.FromServerContext(ctx); ok {
+		if token := header.RequestHeader().Get(serviceTokenHeader); token != "" {
+			for _, item := range r.conf.ServiceTokens {
+				if item != "" && subtle.ConstantTimeCompare([]byte(item), []byte(token)) == 1 {
+					return nil
+				}
+			}
+			return v1.ErrorPermissionDeny("invalid service token")
+		}
+	}
+
+	userId, _ := ctx.Value("userId").(int32)
+	if userId == 0 {
+		return v1.ErrorPermissionDeny("userId: 0")
+	}
+	return checkAdmin(ctx, r.userRepo, r.conf, userId)
+}
@@ -56,6 +56,14 @@ func easyjson9e1087fdDecodeGithubComUserCenterUserCenterBackendAppUserServiceInt
 			out.UserStatus = int32(in.Int32())
 		case "role":
 			out.Role = int32(in.Int32())
+		case "userType":
+			out.UserType = int32(in.Int32())
+		case "ownerId":
+			out.OwnerId = int32(in.Int32())
+		case "createTime":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreateTime).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -120,6 +128,21 @@ func easyjson9e1087fdEncodeGithubComUserCenterUserCenterBackendAppUserServiceInt
 		out.RawString(prefix)
 		out.Int32(int32(in.Role))
 	}
+	{
+		const prefix string = ",\"userType\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.UserType))
+	}
+	{
+		const prefix string = ",\"ownerId\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.OwnerId))
+	}
+	{
+		const prefix string = ",\"createTime\":"
+		out.RawString(prefix)
+		out.Raw((in.CreateTime).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
@@ -0,0 +1,386 @@
+package biz
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type WebhookRepo interface {
+	CreateWebhook(ctx context.Context, webhook *Webhook) (int64, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhooks(ctx context.Context) ([]*Webhook, error)
+	CreateDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookId int64, status string, page, pageSize int32) ([]*WebhookDelivery, int64, error)
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+	ClaimDelivery(ctx context.Context, id int64, nextAttemptTime, leaseUntil time.Time) (bool, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+}
+
+// WebhookSender 发送webhook请求，返回http状态码
+type WebhookSender interface {
+	Send(ctx context.Context, url string, header map[string]string, body []byte) (int, error)
+}
+
+type WebhookUseCase struct {
+	repo     WebhookRepo
+	userRepo UserRepo
+	sender   WebhookSender
+	audit    *AuditRecorder
+	log      *log.Helper
+	conf     *conf.UserConstant
+}
+
+const (
+	AuditActionWebhookCreate    = "webhook.create"
+	AuditActionWebhookDelete    = "webhook.delete"
+	AuditActionWebhookRedeliver = "webhook.redeliver"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+const (
+	webhookMaxAttempts   = 10
+	webhookBaseBackoff   = 10 * time.Second
+	webhookMaxBackoff    = time.Hour
+	webhookDeliveryLease = time.Minute
+	webhookBatchSize     = 100
+	webhookAllEvents     = "*"
+)
+
+// Webhook webhook订阅
+type Webhook struct {
+	Id         int64
+	Url        string
+	Secret     string
+	Events     string
+	CreatorId  int32
+	CreateTime time.Time
+}
+
+// WebhookDelivery webhook投递记录，同时作为持久化的投递队列
+type WebhookDelivery struct {
+	Id              int64
+	WebhookId       int64
+	EventId         string
+	EventType       string
+	Payload         string
+	Status          string
+	Attempts        int32
+	NextAttemptTime time.Time
+	LastStatusCode  int32
+	LastError       string
+	CreateTime      time.Time
+	UpdateTime      time.Time
+}
+
+type CreateWebhook struct {
+	Url    string   `validate:"required,url,max=1024" comment:"回调地址"`
+	Events []string `validate:"required,min=1,dive,oneof=* user.registered user.deleted user.status_changed" comment:"订阅事件"`
+	Secret string   `validate:"omitempty,min=16,max=128" comment:"签名密钥"`
+}
+
+type DeleteWebhook struct {
+	Id int64 `validate:"required,gt=0" comment:"webhook Id"`
+}
+
+type ListWebhookDeliveries struct {
+	WebhookId int64  `validate:"gte=0" comment:"webhook Id"`
+	Status    string `validate:"omitempty,oneof=pending succeeded failed" comment:"投递状态"`
+	Page      int32  `validate:"gte=0" comment:"页码"`
+	PageSize  int32  `validate:"gte=0,lte=100" comment:"每页数量"`
+}
+
+type RedeliverWebhook struct {
+	DeliveryId int64 `validate:"required,gt=0" comment:"投递记录Id"`
+}
+
+func NewWebhookUseCase(repo WebhookRepo, userRepo UserRepo, sender WebhookSender, audit *AuditRecorder, logger log.Logger, conf *conf.UserConstant) *WebhookUseCase {
+	return &WebhookUseCase{
+		repo:     repo,
+		userRepo: userRepo,
+		sender:   sender,
+		audit:    audit,
+		log:      log.NewHelper(log.With(logger, "module", "user/biz/webhookUseCase")),
+		conf:     conf,
+	}
+}
+
+// CreateWebhook 新增webhook订阅
+//1. 判断是否有管理员权限
+//2. 未指定签名密钥时随机生成，密钥只在创建时返回，审计日志中不记录密钥
+func (r *WebhookUseCase) CreateWebhook(ctx context.Context, url string, events []string, secret string) (webhook *Webhook, err error) {
+	adminId := ctx.Value("userId").(int32)
+	defer func() {
+		auditLog := &AuditLog{ActorId: adminId, Action: AuditActionWebhookCreate, Detail: fmt.Sprintf("url(%s), events(%s)", url, strings.Join(events, ","))}
+		if webhook != nil {
+			auditLog.Detail = fmt.Sprintf("webhookId(%v), %s", webhook.Id, auditLog.Detail)
+		}
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	err = checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		secret, err = r.generateSecret()
+		if err != nil {
+			return nil, v1.ErrorUnknownError("%s", err.Error())
+		}
+	}
+	webhook = &Webhook{
+		Url:       url,
+		Secret:    secret,
+		Events:    strings.Join(events, ","),
+		CreatorId: adminId,
+	}
+	webhook.Id, err = r.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, v1.ErrorWebhookFailed("%s", err.Error())
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook 删除webhook订阅
+func (r *WebhookUseCase) DeleteWebhook(ctx context.Context, id int64) (err error) {
+	adminId := ctx.Value("userId").(int32)
+	defer func() {
+		r.audit.Record(ctx, &AuditLog{ActorId: adminId, Action: AuditActionWebhookDelete, Detail: fmt.Sprintf("webhookId(%v)", id)}, err)
+	}()
+
+	err = checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return err
+	}
+
+	err = r.repo.DeleteWebhook(ctx, id)
+	if err != nil {
+		return v1.ErrorWebhookFailed("%s", err.Error())
+	}
+	return nil
+}
+
+// ListWebhooks webhook订阅列表
+func (r *WebhookUseCase) ListWebhooks(ctx context.Context) ([]*Webhook, error) {
+	adminId := ctx.Value("userId").(int32)
+	err := checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := r.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, v1.ErrorWebhookFailed("%s", err.Error())
+	}
+	return webhooks, nil
+}
+
+// ListDeliveries webhook投递记录
+func (r *WebhookUseCase) ListDeliveries(ctx context.Context, webhookId int64, status string, page, pageSize int32) ([]*WebhookDelivery, int64, error) {
+	adminId := ctx.Value("userId").(int32)
+	err := checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	deliveries, total, err := r.repo.ListDeliveries(ctx, webhookId, status, page, pageSize)
+	if err != nil {
+		return nil, 0, v1.ErrorWebhookFailed("%s", err.Error())
+	}
+	return deliveries, total, nil
+}
+
+// Redeliver 手动重新投递，复制原投递记录生成新的待投递记录
+func (r *WebhookUseCase) Redeliver(ctx context.Context, deliveryId int64) (delivery *WebhookDelivery, err error) {
+	adminId := ctx.Value("userId").(int32)
+	auditLog := &AuditLog{ActorId: adminId, Action: AuditActionWebhookRedeliver, Detail: fmt.Sprintf("deliveryId(%v)", deliveryId)}
+	defer func() {
+		r.audit.Record(ctx, auditLog, err)
+	}()
+
+	err = checkAdmin(ctx, r.userRepo, r.conf, adminId)
+	if err != nil {
+		return nil, err
+	}
+
+	origin, err := r.repo.GetDelivery(ctx, deliveryId)
+	if err != nil {
+		return nil, v1.ErrorWebhookFailed("%s", err.Error())
+	}
+	auditLog.Detail = fmt.Sprintf("deliveryId(%v), webhookId(%v), eventId(%s)", deliveryId, origin.WebhookId, origin.EventId)
+	delivery = &WebhookDelivery{
+		WebhookId:       origin.WebhookId,
+		EventId:         origin.EventId,
+		EventType:       origin.EventType,
+		Payload:         origin.Payload,
+		Status:          WebhookDeliveryPending,
+		NextAttemptTime: time.Now(),
+	}
+	err = r.repo.CreateDeliveries(ctx, []*WebhookDelivery{delivery})
+	if err != nil {
+		return nil, v1.ErrorWebhookFailed("%s", err.Error())
+	}
+	return delivery, nil
+}
+
+// Enqueue 为订阅了该事件的webhook生成待投递记录，ctx需携带事务
+func (r *WebhookUseCase) Enqueue(ctx context.Context, event *UserEvent) error {
+	webhooks, err := r.repo.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("json marshal error: event(%v)", event.Id))
+	}
+	deliveries := make([]*WebhookDelivery, 0)
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, &WebhookDelivery{
+			WebhookId:       webhook.Id,
+			EventId:         event.Id,
+			EventType:       event.Type,
+			Payload:         string(payload),
+			Status:          WebhookDeliveryPending,
+			NextAttemptTime: event.OccurredAt,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverJob 定期投递到期的webhook，失败后按指数退避重试
+func (r *WebhookUseCase) DeliverJob() *Job {
+	return &Job{
+		Name:     "webhook-deliver",
+		Interval: 5 * time.Second,
+		Run:      r.deliverDue,
+	}
+}
+
+func (r *WebhookUseCase) deliverDue(ctx context.Context) error {
+	now := time.Now()
+	deliveries, err := r.repo.ListDueDeliveries(ctx, now, webhookBatchSize)
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	webhooks, err := r.repo.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	webhookMap := make(map[int64]*Webhook, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookMap[webhook.Id] = webhook
+	}
+	for _, delivery := range deliveries {
+		// 多副本部署时通过租约抢占投递记录，避免重复投递
+		claimed, err := r.repo.ClaimDelivery(ctx, delivery.Id, delivery.NextAttemptTime, now.Add(webhookDeliveryLease))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		r.deliver(ctx, webhookMap[delivery.WebhookId], delivery)
+	}
+	return nil
+}
+
+func (r *WebhookUseCase) deliver(ctx context.Context, webhook *Webhook, delivery *WebhookDelivery) {
+	delivery.Attempts++
+	if webhook == nil {
+		delivery.Status = WebhookDeliveryFailed
+		delivery.LastError = "webhook deleted"
+	} else {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		header := map[string]string{
+			"Content-Type":        "application/json",
+			"X-Webhook-Id":        delivery.EventId,
+			"X-Webhook-Event":     delivery.EventType,
+			"X-Webhook-Timestamp": timestamp,
+			"X-Webhook-Signature": "sha256=" + SignWebhookPayload(webhook.Secret, timestamp, []byte(delivery.Payload)),
+		}
+		statusCode, err := r.sender.Send(ctx, webhook.Url, header, []byte(delivery.Payload))
+		delivery.LastStatusCode = int32(statusCode)
+		delivery.LastError = ""
+		if err == nil && (statusCode < 200 || statusCode >= 300) {
+			err = errors.Errorf("unexpected status code: %v", statusCode)
+		}
+		switch {
+		case err == nil:
+			delivery.Status = WebhookDeliverySucceeded
+		case delivery.Attempts >= webhookMaxAttempts:
+			delivery.Status = WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			delivery.LastError = err.Error()
+			delivery.NextAttemptTime = time.Now().Add(retryBackoff(delivery.Attempts, webhookBaseBackoff, webhookMaxBackoff))
+		}
+	}
+
+	err := r.repo.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		r.log.Errorf("fail to update webhook delivery: id(%v), error(%v)", delivery.Id, err)
+	}
+}
+
+// Subscribed 是否订阅了该事件
+func (w *Webhook) Subscribed(eventType string) bool {
+	for _, item := range strings.Split(w.Events, ",") {
+		if item == webhookAllEvents || item == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// SignWebhookPayload 使用HMAC-SHA256对"时间戳.请求体"签名
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (r *WebhookUseCase) generateSecret() (string, error) {
+	buf := make([]byte, 24)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", errors.Wrapf(err, "generate webhook secret error")
+	}
+	return hex.EncodeToString(buf), nil
+}
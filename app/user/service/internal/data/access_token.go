@@ -0,0 +1,118 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ biz.AccessTokenRepo = (*accessTokenRepo)(nil)
+
+type accessTokenRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewAccessTokenRepo(data *Data, logger log.Logger) biz.AccessTokenRepo {
+	return &accessTokenRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/accessToken")),
+	}
+}
+
+func (r *accessTokenRepo) CreateAccessToken(ctx context.Context, token *biz.AccessToken) error {
+	record := &UserAccessToken{}
+	util.StructAssign(record, token)
+	err := r.data.DB(ctx).WithContext(ctx).Select("userId", "name", "tokenHash", "tokenPrefix", "scopes", "expireTime", "createTime").Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create access token: userId(%v), name(%s)", token.UserId, token.Name))
+	}
+	token.Id = record.Id
+	token.CreateTime = record.CreateTime
+	return nil
+}
+
+func (r *accessTokenRepo) GetAccessToken(ctx context.Context, id int64) (*biz.AccessToken, error) {
+	record := &UserAccessToken{}
+	err := r.data.DB(ctx).WithContext(ctx).Where("id = ?", id).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("access token not found", fmt.Sprintf("id(%v)", id))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get access token: id(%v)", id))
+	}
+
+	token := &biz.AccessToken{}
+	util.StructAssign(token, record)
+	return token, nil
+}
+
+func (r *accessTokenRepo) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*biz.AccessToken, error) {
+	record := &UserAccessToken{}
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"tokenHash": tokenHash}).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("access token not found", "")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to get access token by hash")
+	}
+
+	token := &biz.AccessToken{}
+	util.StructAssign(token, record)
+	return token, nil
+}
+
+// ListAccessTokens 查询个人访问令牌，userId为0时查询全部，page为0时不分页
+func (r *accessTokenRepo) ListAccessTokens(ctx context.Context, userId, page, pageSize int32) ([]*biz.AccessToken, int64, error) {
+	list := make([]*UserAccessToken, 0)
+	var total int64
+	db := r.data.DB(ctx).WithContext(ctx).Model(&UserAccessToken{})
+	if userId != 0 {
+		db = db.Where(map[string]interface{}{"userId": userId})
+	}
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to count access tokens: userId(%v)", userId))
+	}
+	db = db.Order("id desc")
+	if page > 0 {
+		db = db.Offset(int((page - 1) * pageSize)).Limit(int(pageSize))
+	}
+	err = db.Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to list access tokens: userId(%v)", userId))
+	}
+
+	tokens := make([]*biz.AccessToken, 0, len(list))
+	for _, item := range list {
+		token := &biz.AccessToken{}
+		util.StructAssign(token, item)
+		tokens = append(tokens, token)
+	}
+	return tokens, total, nil
+}
+
+func (r *accessTokenRepo) RevokeAccessToken(ctx context.Context, id int64) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&UserAccessToken{}).Where(map[string]interface{}{"id": id, "revokeTime": nil}).Update("revokeTime", time.Now()).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to revoke access token: id(%v)", id))
+	}
+	return nil
+}
+
+func (r *accessTokenRepo) TouchAccessToken(ctx context.Context, id int64, ip string) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&UserAccessToken{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"lastUsedTime": time.Now(),
+		"lastUsedIp":   ip,
+	}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to touch access token: id(%v)", id))
+	}
+	return nil
+}
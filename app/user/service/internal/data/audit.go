@@ -0,0 +1,77 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm/clause"
+)
+
+var _ biz.AuditRepo = (*auditRepo)(nil)
+
+type auditRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewAuditRepo(data *Data, logger log.Logger) biz.AuditRepo {
+	return &auditRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/audit")),
+	}
+}
+
+func (r *auditRepo) CreateAuditLog(ctx context.Context, auditLog *biz.AuditLog) error {
+	record := &AuditLog{}
+	util.StructAssign(record, auditLog)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create audit log: action(%s)", auditLog.Action))
+	}
+	return nil
+}
+
+// ListAuditLogs 根据条件分页查询审计日志，按时间倒序
+func (r *auditRepo) ListAuditLogs(ctx context.Context, query *biz.AuditLogQuery) ([]*biz.AuditLog, int64, error) {
+	db := r.data.DB(ctx).WithContext(ctx).Model(&AuditLog{})
+	if query.ActorId != 0 {
+		db = db.Where(map[string]interface{}{"actorId": query.ActorId})
+	}
+	if query.TargetId != 0 {
+		db = db.Where(map[string]interface{}{"targetId": query.TargetId})
+	}
+	if query.Action != "" {
+		db = db.Where("action = ?", query.Action)
+	}
+	if query.Result != "" {
+		db = db.Where("result = ?", query.Result)
+	}
+	if !query.StartTime.IsZero() {
+		db = db.Where(clause.Gte{Column: "createTime", Value: query.StartTime})
+	}
+	if !query.EndTime.IsZero() {
+		db = db.Where(clause.Lt{Column: "createTime", Value: query.EndTime})
+	}
+
+	var total int64
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "fail to count audit logs")
+	}
+	list := make([]*AuditLog, 0)
+	err = db.Order("id desc").Offset(int((query.Page - 1) * query.PageSize)).Limit(int(query.PageSize)).Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "fail to list audit logs")
+	}
+
+	logs := make([]*biz.AuditLog, 0, len(list))
+	for _, item := range list {
+		auditLog := &biz.AuditLog{}
+		util.StructAssign(auditLog, item)
+		logs = append(logs, auditLog)
+	}
+	return logs, total, nil
+}
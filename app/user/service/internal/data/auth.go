@@ -2,8 +2,11 @@ package data
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
 	"github.com/pkg/errors"
 	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
 	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
@@ -18,7 +21,11 @@ type authRepo struct {
 	log  *log.Helper
 }
 
+// NewAuthRepo 内存模式下使用内存仓储
 func NewAuthRepo(data *Data, logger log.Logger) biz.AuthRepo {
+	if data.memory != nil {
+		return NewMemoryAuthRepo(data.memory, data.conf, logger)
+	}
 	return &authRepo{
 		data: data,
 		log:  log.NewHelper(log.With(logger, "module", "user/data/register")),
@@ -27,7 +34,7 @@ func NewAuthRepo(data *Data, logger log.Logger) biz.AuthRepo {
 
 func (r *authRepo) AccountExist(ctx context.Context, userAccount string) (bool, error) {
 	user := &User{}
-	err := r.data.db.WithContext(ctx).Select("id").Where("userAccount = ?", userAccount).First(user).Error
+	err := r.data.ReadDB(ctx).WithContext(ctx).Select("id").Where(map[string]interface{}{"userAccount": userAccount, "isDelete": 0}).First(user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return false, nil
 	}
@@ -37,24 +44,50 @@ func (r *authRepo) AccountExist(ctx context.Context, userAccount string) (bool,
 	return true, nil
 }
 
-func (r *authRepo) UserRegister(ctx context.Context, userAccount, passwordHash string) (int32, error) {
+func (r *authRepo) UserRegister(ctx context.Context, userAccount, passwordHash, email string, userStatus int32) (int32, error) {
 	user := &User{
 		UserAccount:  userAccount,
 		UserPassword: passwordHash,
+		Email:        email,
+		UserStatus:   userStatus,
 	}
-	err := r.data.db.WithContext(ctx).Select("userAccount", "userPassword").Create(user).Error
+	err := r.data.DB(ctx).WithContext(ctx).Select("userAccount", "userPassword", "email", "userStatus").Create(user).Error
 	if err != nil {
 		return 0, errors.Wrapf(err, fmt.Sprintf("fail to register user: userAccount(%s), userPassword(%s)", userAccount, passwordHash))
 	}
 	return user.Id, nil
 }
 
+// CreateUser 创建第三方登录、LDAP、SCIM等方式开通的用户和服务账号
+func (r *authRepo) CreateUser(ctx context.Context, user *biz.User) (int32, error) {
+	record := &User{
+		UserAccount:  user.UserAccount,
+		UserPassword: user.UserPassword,
+		UserName:     user.UserName,
+		AvatarUrl:    user.AvatarUrl,
+		Email:        user.Email,
+		Phone:        user.Phone,
+		UserStatus:   user.UserStatus,
+		Role:         user.Role,
+		UserType:     user.UserType,
+		OwnerId:      user.OwnerId,
+	}
+	err := r.data.DB(ctx).WithContext(ctx).Select("userAccount", "userPassword", "username", "avatarUrl", "email", "phone", "userStatus", "role", "userType", "ownerId").Create(record).Error
+	if err != nil {
+		return 0, errors.Wrapf(err, fmt.Sprintf("fail to create user: userAccount(%s)", user.UserAccount))
+	}
+	return record.Id, nil
+}
+
 func (r *authRepo) UserLogin(ctx context.Context, userAccount, passwordHash string) (*biz.User, error) {
 	user := &User{
 		UserAccount:  userAccount,
 		UserPassword: passwordHash,
 	}
-	err := r.data.db.WithContext(ctx).Where("userAccount = ? and userPassword = ? and isDelete = 0", userAccount, passwordHash).First(user).Error
+	err := r.data.db.WithContext(ctx).Where(map[string]interface{}{"userAccount": userAccount, "userPassword": passwordHash, "isDelete": 0}).First(user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("user not found", fmt.Sprintf("userAccount(%s)", userAccount))
+	}
 	if err != nil {
 		return nil, errors.Wrapf(err, fmt.Sprintf("user login failed: userAccount(%s), userPassword(%s)", userAccount, passwordHash))
 	}
@@ -65,9 +98,9 @@ func (r *authRepo) UserLogin(ctx context.Context, userAccount, passwordHash stri
 }
 
 func (r *authRepo) UserLogout(ctx context.Context, userId int32) error {
-	_, err := r.data.redisCli.Del(ctx, fmt.Sprintf("%s_%v", r.data.conf.UserLoginState, userId)).Result()
+	err := r.data.deleteSession(ctx, userId)
 	if err != nil {
-		return errors.Wrapf(err, fmt.Sprintf("user login failed: userId(%v)", userId))
+		return errors.Wrapf(err, fmt.Sprintf("user logout failed: userId(%v)", userId))
 	}
 	return nil
 }
@@ -75,12 +108,193 @@ func (r *authRepo) UserLogout(ctx context.Context, userId int32) error {
 func (r *authRepo) SetLoginSession(ctx context.Context, user *biz.User) error {
 	marshal, err := user.MarshalJSON()
 	if err != nil {
-		r.log.Errorf("fail to set user info to json: json.Marshal(%v), error(%v)", user, err)
-		return nil
+		return errors.Wrapf(err, fmt.Sprintf("fail to set user info to json: userId(%v)", user.Id))
+	}
+	err = r.data.setSession(ctx, user.Id, string(marshal))
+	if err != nil {
+		r.log.Errorf("fail to set user login session: userId(%v), error(%v)", user.Id, err)
+		return err
+	}
+	return nil
+}
+
+func (r *authRepo) SetLoginVerifyCode(ctx context.Context, userId int32, fingerprint, code string, timeout time.Duration) error {
+	err := r.data.redisCli.Set(ctx, r.loginVerifyCodeKey(userId, fingerprint), code, timeout).Err()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to set login verify code: userId(%v)", userId))
+	}
+	return nil
+}
+
+func (r *authRepo) GetLoginVerifyCode(ctx context.Context, userId int32, fingerprint string) (string, error) {
+	code, err := r.data.redisCli.Get(ctx, r.loginVerifyCodeKey(userId, fingerprint)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", kerrors.NotFound("login verify code not found", fmt.Sprintf("userId(%v)", userId))
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, fmt.Sprintf("fail to get login verify code: userId(%v)", userId))
+	}
+	return code, nil
+}
+
+func (r *authRepo) DeleteLoginVerifyCode(ctx context.Context, userId int32, fingerprint string) error {
+	err := r.data.redisCli.Del(ctx, r.loginVerifyCodeKey(userId, fingerprint)).Err()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to delete login verify code: userId(%v)", userId))
 	}
-	err = r.data.redisCli.Set(ctx, fmt.Sprintf("%s_%v", r.data.conf.UserLoginState, user.Id), string(marshal), time.Second*time.Duration(r.data.conf.SessionTimeout)).Err()
+	return nil
+}
+
+func (r *authRepo) loginVerifyCodeKey(userId int32, fingerprint string) string {
+	return r.data.redisKey(fmt.Sprintf("%s_verify_%v_%s", r.data.conf.UserLoginState, userId, fingerprint))
+}
+
+func (r *authRepo) SetImpersonation(ctx context.Context, tokenHash string, impersonation *biz.Impersonation, timeout time.Duration) error {
+	marshal, err := json.Marshal(impersonation)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("json marshal error: impersonation(%v)", impersonation))
+	}
+	err = r.data.redisCli.Set(ctx, r.impersonationKey(tokenHash), string(marshal), timeout).Err()
 	if err != nil {
-		r.log.Errorf("fail to set user session to cache: redis.Set(%v), error(%v)", user, err)
+		return errors.Wrapf(err, fmt.Sprintf("fail to set impersonation: adminId(%v), userId(%v)", impersonation.AdminId, impersonation.UserId))
 	}
 	return nil
 }
+
+func (r *authRepo) GetImpersonation(ctx context.Context, tokenHash string) (*biz.Impersonation, error) {
+	result, err := r.data.redisCli.Get(ctx, r.impersonationKey(tokenHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, kerrors.NotFound("impersonation not found", "")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to get impersonation")
+	}
+
+	impersonation := &biz.Impersonation{}
+	err = json.Unmarshal([]byte(result), impersonation)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("json unmarshal error: impersonation(%v)", result))
+	}
+	return impersonation, nil
+}
+
+func (r *authRepo) DeleteImpersonation(ctx context.Context, tokenHash string) error {
+	err := r.data.redisCli.Del(ctx, r.impersonationKey(tokenHash)).Err()
+	if err != nil {
+		return errors.Wrapf(err, "fail to delete impersonation")
+	}
+	return nil
+}
+
+func (r *authRepo) impersonationKey(tokenHash string) string {
+	return r.data.redisKey(fmt.Sprintf("%s_impersonation_%s", r.data.conf.UserLoginState, tokenHash))
+}
+
+func (r *authRepo) SetMagicLink(ctx context.Context, tokenHash string, link *biz.MagicLink, timeout time.Duration) error {
+	marshal, err := json.Marshal(link)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("json marshal error: link(%v)", link))
+	}
+	err = r.data.redisCli.Set(ctx, r.magicLinkKey(tokenHash), string(marshal), timeout).Err()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to set magic link: userId(%v)", link.UserId))
+	}
+	return nil
+}
+
+// TakeMagicLink 取出并删除免密登录链接，保证只能使用一次
+func (r *authRepo) TakeMagicLink(ctx context.Context, tokenHash string) (*biz.MagicLink, error) {
+	result, err := r.data.redisCli.GetDel(ctx, r.magicLinkKey(tokenHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, kerrors.NotFound("magic link not found", "")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to take magic link")
+	}
+
+	link := &biz.MagicLink{}
+	err = json.Unmarshal([]byte(result), link)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("json unmarshal error: link(%v)", result))
+	}
+	return link, nil
+}
+
+func (r *authRepo) magicLinkKey(tokenHash string) string {
+	return r.data.redisKey(fmt.Sprintf("%s_magic_link_%s", r.data.conf.UserLoginState, tokenHash))
+}
+
+// IncrMagicLinkRequest 免密登录链接请求计数加一，第一次计数时设置窗口过期时间
+func (r *authRepo) IncrMagicLinkRequest(ctx context.Context, key string, window time.Duration) (int64, error) {
+	limitKey := r.data.redisKey(fmt.Sprintf("%s_magic_link_limit_%s", r.data.conf.UserLoginState, key))
+	count, err := r.data.redisCli.Incr(ctx, limitKey).Result()
+	if err != nil {
+		return 0, errors.Wrapf(err, fmt.Sprintf("fail to count magic link request: key(%s)", key))
+	}
+	if count == 1 {
+		err = r.data.redisCli.Expire(ctx, limitKey, window).Err()
+		if err != nil {
+			r.data.redisCli.Del(ctx, limitKey)
+			return 0, errors.Wrapf(err, fmt.Sprintf("fail to set magic link request window: key(%s)", key))
+		}
+	}
+	return count, nil
+}
+
+func (r *authRepo) SetPasskeyChallenge(ctx context.Context, challengeId string, challenge *biz.PasskeyChallenge, timeout time.Duration) error {
+	marshal, err := json.Marshal(challenge)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("json marshal error: challenge(%v)", challenge))
+	}
+	err = r.data.redisCli.Set(ctx, r.passkeyChallengeKey(challengeId), string(marshal), timeout).Err()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to set passkey challenge: userId(%v), purpose(%s)", challenge.UserId, challenge.Purpose))
+	}
+	return nil
+}
+
+// TakePasskeyChallenge 取出并删除通行密钥挑战，保证每个挑战只能校验一次
+func (r *authRepo) TakePasskeyChallenge(ctx context.Context, challengeId string) (*biz.PasskeyChallenge, error) {
+	result, err := r.data.redisCli.GetDel(ctx, r.passkeyChallengeKey(challengeId)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, kerrors.NotFound("passkey challenge not found", "")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to take passkey challenge")
+	}
+
+	challenge := &biz.PasskeyChallenge{}
+	err = json.Unmarshal([]byte(result), challenge)
+	if err != nil {
+		return nil, errors.Wrapf(err, "json unmarshal error: passkey challenge")
+	}
+	return challenge, nil
+}
+
+func (r *authRepo) passkeyChallengeKey(challengeId string) string {
+	return r.data.redisKey(fmt.Sprintf("%s_passkey_challenge_%s", r.data.conf.UserLoginState, challengeId))
+}
+
+func (r *authRepo) SetPasskeyPending(ctx context.Context, userId int32, fingerprint string, timeout time.Duration) error {
+	err := r.data.redisCli.Set(ctx, r.passkeyPendingKey(userId, fingerprint), 1, timeout).Err()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to set passkey pending: userId(%v)", userId))
+	}
+	return nil
+}
+
+// TakePasskeyPending 取出并删除密码已验证、等待通行密钥二次验证的标记
+func (r *authRepo) TakePasskeyPending(ctx context.Context, userId int32, fingerprint string) error {
+	err := r.data.redisCli.GetDel(ctx, r.passkeyPendingKey(userId, fingerprint)).Err()
+	if errors.Is(err, redis.Nil) {
+		return kerrors.NotFound("passkey pending not found", fmt.Sprintf("userId(%v)", userId))
+	}
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to take passkey pending: userId(%v)", userId))
+	}
+	return nil
+}
+
+func (r *authRepo) passkeyPendingKey(userId int32, fingerprint string) string {
+	return r.data.redisKey(fmt.Sprintf("%s_passkey_pending_%v_%s", r.data.conf.UserLoginState, userId, fingerprint))
+}
@@ -0,0 +1,156 @@
+package data
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"sync"
+	"time"
+)
+
+// ErrRedisUnavailable 熔断期间直接返回，不再访问Redis
+var ErrRedisUnavailable = errors.New("redis unavailable: circuit breaker open")
+
+const (
+	defaultBreakerFailures = 5
+	defaultBreakerOpen     = 10 * time.Second
+)
+
+// 熔断器状态
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+var _ redis.Hook = (*RedisBreaker)(nil)
+
+// RedisBreaker Redis熔断器，作为go-redis的钩子在重试之后统计结果
+//1. 连续失败次数达到阈值后熔断，熔断期间的命令直接返回ErrRedisUnavailable
+//2. 熔断到期后进入半开状态，只放行一个命令探测，成功则恢复，失败则继续熔断
+//3. 只有网络错误和超时计为失败，key不存在、命令执行错误等Redis正常响应不计入
+type RedisBreaker struct {
+	mu        sync.Mutex
+	state     string
+	failures  int
+	threshold int
+	open      time.Duration
+	openUntil time.Time
+	probing   bool
+	lastError error
+	log       *log.Helper
+}
+
+func NewRedisBreaker(conf *conf.Data, logger log.Logger) *RedisBreaker {
+	threshold := int(conf.GetRedis().GetBreakerFailures())
+	if threshold <= 0 {
+		threshold = defaultBreakerFailures
+	}
+	open := conf.GetRedis().GetBreakerOpen().AsDuration()
+	if open <= 0 {
+		open = defaultBreakerOpen
+	}
+	return &RedisBreaker{
+		state:     BreakerClosed,
+		threshold: threshold,
+		open:      open,
+		log:       log.NewHelper(log.With(logger, "module", "user/data/redis-breaker")),
+	}
+}
+
+// State 当前状态和最近一次失败原因
+func (b *RedisBreaker) State() (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && !time.Now().Before(b.openUntil) {
+		return BreakerHalfOpen, b.lastError
+	}
+	return b.state, b.lastError
+}
+
+func (b *RedisBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Now().Before(b.openUntil) {
+			return ErrRedisUnavailable
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrRedisUnavailable
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record 记录命令结果，调用方取消的请求只释放探测名额，不改变状态
+func (b *RedisBreaker) record(err error) {
+	if errors.Is(err, ErrRedisUnavailable) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if errors.Is(err, context.Canceled) {
+		b.probing = false
+		return
+	}
+	if !isRedisFailure(err) {
+		if b.state != BreakerClosed {
+			b.log.Infof("redis recovered, circuit breaker closed")
+		}
+		b.state, b.failures, b.probing, b.lastError = BreakerClosed, 0, false, nil
+		return
+	}
+
+	b.failures++
+	b.lastError = err
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		if b.state != BreakerOpen {
+			b.log.Errorf("redis unavailable, circuit breaker open for %v: %v", b.open, err)
+		}
+		b.state = BreakerOpen
+		b.openUntil = time.Now().Add(b.open)
+		b.probing = false
+	}
+}
+
+// isRedisFailure Redis没有正常响应，key不存在和命令执行错误属于正常响应
+func isRedisFailure(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return false
+	}
+	var redisErr redis.Error
+	return !errors.As(err, &redisErr)
+}
+
+func (b *RedisBreaker) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	return ctx, b.allow()
+}
+
+func (b *RedisBreaker) AfterProcess(_ context.Context, cmd redis.Cmder) error {
+	b.record(cmd.Err())
+	return nil
+}
+
+func (b *RedisBreaker) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	return ctx, b.allow()
+}
+
+func (b *RedisBreaker) AfterProcessPipeline(_ context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if isRedisFailure(cmd.Err()) {
+			err = cmd.Err()
+			break
+		}
+	}
+	b.record(err)
+	return nil
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"testing"
+	"time"
+)
+
+const testBreakerOpen = 100 * time.Millisecond
+
+// newTestBreaker 连续失败2次后熔断
+func newTestBreaker(t *testing.T, maxRetries int32) (*RedisBreaker, redis.UniversalClient, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	dataConf := &conf.Data{Redis: &conf.Data_Redis{
+		Addr:            server.Addr(),
+		MaxRetries:      maxRetries,
+		BreakerFailures: 2,
+		BreakerOpen:     durationpb.New(testBreakerOpen),
+	}}
+	breaker := NewRedisBreaker(dataConf, testLogger)
+	client, cleanup := NewRedis(dataConf, breaker)
+	t.Cleanup(cleanup)
+	return breaker, client, server
+}
+
+func expectBreakerState(t *testing.T, breaker *RedisBreaker, want string) {
+	t.Helper()
+	state, err := breaker.State()
+	if state != want {
+		t.Fatalf("breaker state: want(%s), got(%s), last error(%v)", want, state, err)
+	}
+}
+
+func TestRedisBreakerOpenAndRecover(t *testing.T) {
+	ctx := context.Background()
+	breaker, client, server := newTestBreaker(t, -1)
+	if err := client.Set(ctx, "key", "value", 0).Err(); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	// key不存在和命令执行错误是Redis的正常响应，不计入失败
+	for i := 0; i < 3; i++ {
+		if err := client.Get(ctx, "missing").Err(); !errors.Is(err, redis.Nil) {
+			t.Fatalf("get missing key: %v", err)
+		}
+		if err := client.LPush(ctx, "key", "value").Err(); err == nil {
+			t.Fatalf("lpush on string key should fail")
+		}
+	}
+	expectBreakerState(t, breaker, BreakerClosed)
+
+	// 连续失败达到阈值后熔断，熔断期间直接失败，不再访问Redis
+	server.Close()
+	for i := 0; i < 2; i++ {
+		if err := client.Get(ctx, "key").Err(); err == nil || errors.Is(err, ErrRedisUnavailable) {
+			t.Fatalf("get during outage: %v", err)
+		}
+	}
+	expectBreakerState(t, breaker, BreakerOpen)
+	if err := client.Get(ctx, "key").Err(); !errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("get with breaker open: %v", err)
+	}
+	if _, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		return pipe.Get(ctx, "key").Err()
+	}); !errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("pipeline with breaker open: %v", err)
+	}
+
+	// 熔断到期后探测失败，重新熔断
+	time.Sleep(testBreakerOpen)
+	expectBreakerState(t, breaker, BreakerHalfOpen)
+	if err := client.Get(ctx, "key").Err(); err == nil || errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("probe during outage: %v", err)
+	}
+	expectBreakerState(t, breaker, BreakerOpen)
+
+	// Redis恢复后探测成功，熔断关闭
+	if err := server.Restart(); err != nil {
+		t.Fatalf("restart redis: %v", err)
+	}
+	time.Sleep(testBreakerOpen)
+	if value, err := client.Get(ctx, "key").Result(); err != nil || value != "value" {
+		t.Fatalf("get after recovered: value(%s), error(%v)", value, err)
+	}
+	expectBreakerState(t, breaker, BreakerClosed)
+}
+
+func TestRedisBreakerRetries(t *testing.T) {
+	ctx := context.Background()
+	breaker, client, server := newTestBreaker(t, 3)
+	if err := client.Set(ctx, "key", "value", 0).Err(); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	// 已建立的连接被断开，重试使用新连接后成功，不计入失败
+	for i := 0; i < 3; i++ {
+		server.Close()
+		if err := server.Restart(); err != nil {
+			t.Fatalf("restart redis: %v", err)
+		}
+		if value, err := client.Get(ctx, "key").Result(); err != nil || value != "value" {
+			t.Fatalf("get with retries: value(%s), error(%v)", value, err)
+		}
+	}
+	expectBreakerState(t, breaker, BreakerClosed)
+}
@@ -0,0 +1,344 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"os"
+	"testing"
+	"time"
+)
+
+// testMySQLSourceEnv 配置后仓储契约测试同时在MySQL上执行，需要一个可以执行迁移的空库
+const testMySQLSourceEnv = "USER_CENTER_TEST_MYSQL_DSN"
+
+type contractRepos struct {
+	auth biz.AuthRepo
+	user biz.UserRepo
+	tx   biz.Transaction
+	// record 直接读取存储中的用户记录，包括已删除的用户
+	record func(userId int32) (*User, error)
+}
+
+func newContractRepos(t *testing.T, driver, source string) *contractRepos {
+	d, _ := newTestData(t, driver, source)
+	return &contractRepos{
+		auth: NewAuthRepo(d, testLogger),
+		user: NewUserRepo(d, testLogger),
+		tx:   NewTransaction(d),
+		record: func(userId int32) (*User, error) {
+			if d.memory != nil {
+				list := d.memory.findUsers(func(user *User) bool {
+					return user.Id == userId
+				})
+				if len(list) == 0 {
+					return nil, fmt.Errorf("user not found: userId(%v)", userId)
+				}
+				return list[0], nil
+			}
+			user := &User{}
+			return user, d.db.Where("id = ?", userId).Take(user).Error
+		},
+	}
+}
+
+func TestRepoContractMemory(t *testing.T) {
+	testRepoContract(t, newContractRepos(t, DriverMemory, ""))
+}
+
+func TestRepoContractSQLite(t *testing.T) {
+	testRepoContract(t, newContractRepos(t, DriverSQLite, ""))
+}
+
+func TestRepoContractMySQL(t *testing.T) {
+	source := os.Getenv(testMySQLSourceEnv)
+	if source == "" {
+		t.Skipf("%s not set", testMySQLSourceEnv)
+	}
+	testRepoContract(t, newContractRepos(t, DriverMySQL, source))
+}
+
+// testRepoContract 内存仓储和数据库仓储对用例层表现一致，账号名带时间戳，可以在已有数据的库中重复执行
+func testRepoContract(t *testing.T, repos *contractRepos) {
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+	account := func(name string) string {
+		return fmt.Sprintf("%s_%d", name, suffix)
+	}
+	register := func(t *testing.T, name string) int32 {
+		t.Helper()
+		userId, err := repos.auth.UserRegister(ctx, account(name), biz.PasswordHash(name), "", biz.UserStatusNormal)
+		if err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+		return userId
+	}
+
+	t.Run("register and login", func(t *testing.T) {
+		exist, err := repos.auth.AccountExist(ctx, account("login"))
+		if err != nil || exist {
+			t.Fatalf("account exist before register: exist(%v), error(%v)", exist, err)
+		}
+		userId := register(t, "login")
+		exist, err = repos.auth.AccountExist(ctx, account("login"))
+		if err != nil || !exist {
+			t.Fatalf("account exist after register: exist(%v), error(%v)", exist, err)
+		}
+
+		user, err := repos.auth.UserLogin(ctx, account("login"), biz.PasswordHash("login"))
+		if err != nil {
+			t.Fatalf("login: %v", err)
+		}
+		if user.Id != userId || user.UserAccount != account("login") {
+			t.Fatalf("login user: got(%v, %s), want(%v, %s)", user.Id, user.UserAccount, userId, account("login"))
+		}
+		_, err = repos.auth.UserLogin(ctx, account("login"), biz.PasswordHash("wrong"))
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("login with wrong password: want NotFound, got %v", err)
+		}
+	})
+
+	t.Run("session", func(t *testing.T) {
+		userId := register(t, "session")
+		_, err := repos.user.GetUserSession(ctx, userId)
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("session before login: want NotFound, got %v", err)
+		}
+		err = repos.auth.SetLoginSession(ctx, &biz.User{Id: userId, UserAccount: account("session")})
+		if err != nil {
+			t.Fatalf("set session: %v", err)
+		}
+		session, err := repos.user.GetUserSession(ctx, userId)
+		if err != nil || session.UserAccount != account("session") {
+			t.Fatalf("session after login: session(%v), error(%v)", session, err)
+		}
+		err = repos.auth.UserLogout(ctx, userId)
+		if err != nil {
+			t.Fatalf("logout: %v", err)
+		}
+		_, err = repos.user.GetUserSession(ctx, userId)
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("session after logout: want NotFound, got %v", err)
+		}
+	})
+
+	t.Run("update role", func(t *testing.T) {
+		userId := register(t, "role")
+		err := repos.user.UpdateUserRole(ctx, userId, 1)
+		if err != nil {
+			t.Fatalf("update role: %v", err)
+		}
+		role, err := repos.user.GetUserRoleById(ctx, userId)
+		if err != nil || role != 1 {
+			t.Fatalf("role after update: role(%v), error(%v)", role, err)
+		}
+	})
+
+	t.Run("update status", func(t *testing.T) {
+		userId := register(t, "status")
+		err := repos.user.UpdateUserStatus(ctx, userId, biz.UserStatusPending, biz.UserStatusRejected)
+		if err == nil {
+			t.Fatalf("update status from wrong status: want error")
+		}
+		err = repos.user.UpdateUserStatus(ctx, userId, biz.UserStatusNormal, biz.UserStatusDisabled)
+		if err != nil {
+			t.Fatalf("update status: %v", err)
+		}
+		user, err := repos.user.GetCurrentUser(ctx, userId)
+		if err != nil || user.UserStatus != biz.UserStatusDisabled {
+			t.Fatalf("user after update status: user(%v), error(%v)", user, err)
+		}
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		rollback := errors.New("rollback")
+		err := repos.tx.ExecTx(ctx, func(ctx context.Context) error {
+			_, err := repos.auth.UserRegister(ctx, account("rollback"), "", "", biz.UserStatusNormal)
+			if err != nil {
+				return err
+			}
+			return rollback
+		})
+		if !errors.Is(err, rollback) {
+			t.Fatalf("transaction: want rollback error, got %v", err)
+		}
+		exist, err := repos.auth.AccountExist(ctx, account("rollback"))
+		if err != nil || exist {
+			t.Fatalf("account exist after rollback: exist(%v), error(%v)", exist, err)
+		}
+
+		err = repos.tx.ExecTx(ctx, func(ctx context.Context) error {
+			_, err := repos.auth.UserRegister(ctx, account("commit"), "", "", biz.UserStatusNormal)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("transaction commit: %v", err)
+		}
+		exist, err = repos.auth.AccountExist(ctx, account("commit"))
+		if err != nil || !exist {
+			t.Fatalf("account exist after commit: exist(%v), error(%v)", exist, err)
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		userName := account("search")
+		_, err := repos.auth.CreateUser(ctx, &biz.User{UserAccount: account("search"), UserName: userName, UserType: biz.UserTypeNormal})
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		_, err = repos.auth.CreateUser(ctx, &biz.User{UserAccount: account("search_service"), UserName: userName, UserType: biz.UserTypeService})
+		if err != nil {
+			t.Fatalf("create service account: %v", err)
+		}
+		users, err := repos.user.SearchUsers(ctx, userName, false)
+		if err != nil || len(users) != 1 || users[0].UserName != userName || users[0].UserType != biz.UserTypeNormal {
+			t.Fatalf("search users: users(%v), error(%v)", users, err)
+		}
+		users, err = repos.user.SearchUsers(ctx, userName, true)
+		if err != nil || len(users) != 2 {
+			t.Fatalf("search users with service accounts: users(%v), error(%v)", users, err)
+		}
+	})
+
+	t.Run("verify code", func(t *testing.T) {
+		userId := register(t, "verify")
+		err := repos.auth.SetLoginVerifyCode(ctx, userId, "device", "123456", time.Minute)
+		if err != nil {
+			t.Fatalf("set verify code: %v", err)
+		}
+		code, err := repos.auth.GetLoginVerifyCode(ctx, userId, "device")
+		if err != nil || code != "123456" {
+			t.Fatalf("get verify code: code(%s), error(%v)", code, err)
+		}
+		_, err = repos.auth.GetLoginVerifyCode(ctx, userId, "other")
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("verify code of other device: want NotFound, got %v", err)
+		}
+		err = repos.auth.DeleteLoginVerifyCode(ctx, userId, "device")
+		if err != nil {
+			t.Fatalf("delete verify code: %v", err)
+		}
+		_, err = repos.auth.GetLoginVerifyCode(ctx, userId, "device")
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("verify code after delete: want NotFound, got %v", err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		userId := register(t, "delete")
+		// 先读一次，删除后不能再从用户信息缓存中读到
+		_, err := repos.user.GetCurrentUser(ctx, userId)
+		if err != nil {
+			t.Fatalf("user before delete: %v", err)
+		}
+		err = repos.user.DeleteUser(ctx, userId)
+		if err != nil {
+			t.Fatalf("delete user: %v", err)
+		}
+		_, err = repos.user.GetCurrentUser(ctx, userId)
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("user after delete: want NotFound, got %v", err)
+		}
+		_, err = repos.user.GetUserByAccount(ctx, account("delete"))
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("user by account after delete: want NotFound, got %v", err)
+		}
+		_, err = repos.auth.UserLogin(ctx, account("delete"), biz.PasswordHash("delete"))
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("login after delete: want NotFound, got %v", err)
+		}
+		users, _, err := repos.user.ListUsers(ctx, []*biz.FilterCondition{{Attribute: biz.FilterAttributeUserAccount, Operator: biz.FilterOperatorEq, Value: account("delete")}}, 0, 10)
+		if err != nil || len(users) != 0 {
+			t.Fatalf("list users after delete: users(%v), error(%v)", users, err)
+		}
+
+		// 逻辑删除保留记录，账号可以重新注册
+		record, err := repos.record(userId)
+		if err != nil || record.IsDelete != 1 {
+			t.Fatalf("record after delete: record(%v), error(%v)", record, err)
+		}
+		exist, err := repos.auth.AccountExist(ctx, account("delete"))
+		if err != nil || exist {
+			t.Fatalf("account exist after delete: exist(%v), error(%v)", exist, err)
+		}
+		if register(t, "delete") == userId {
+			t.Fatalf("register deleted account: reused userId(%v)", userId)
+		}
+	})
+}
+
+// TestMemoryDriverWiring 内存模式下注入内存仓储，并创建初始管理员
+func TestMemoryDriverWiring(t *testing.T) {
+	d, _ := newTestData(t, DriverMemory, "")
+	if _, ok := NewAuthRepo(d, testLogger).(*memoryAuthRepo); !ok {
+		t.Fatalf("memory driver: want memory auth repo")
+	}
+	if _, ok := NewUserRepo(d, testLogger).(*memoryUserRepo); !ok {
+		t.Fatalf("memory driver: want memory user repo")
+	}
+	exist, err := NewAuthRepo(d, testLogger).AccountExist(context.Background(), "admin")
+	if err != nil || !exist {
+		t.Fatalf("memory driver admin: exist(%v), error(%v)", exist, err)
+	}
+	if !NewHealthRepo(d, testLogger).SessionFallback() {
+		t.Fatalf("memory driver: sessions do not depend on redis")
+	}
+}
+
+// TestMemoryDriverWithoutRedis 内存模式下授权码、第三方登录state和SAML断言不依赖Redis，也不写入磁盘
+func TestMemoryDriverWithoutRedis(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	d, server := newTestData(t, DriverMemory, "")
+	server.Close()
+
+	oauthRepo := NewOAuthRepo(d, testLogger)
+	err := oauthRepo.CreateOAuthClient(ctx, &biz.OAuthClient{ClientId: "memory-client", Name: "memory", RedirectUris: "https://app.example/callback"})
+	if err != nil {
+		t.Fatalf("create oauth client: %v", err)
+	}
+	client, err := oauthRepo.GetOAuthClient(ctx, "memory-client")
+	if err != nil || client.Name != "memory" {
+		t.Fatalf("get oauth client: client(%+v), error(%v)", client, err)
+	}
+	err = oauthRepo.SetAuthorizationCode(ctx, "code-hash", &biz.OAuthAuthorizationCode{ClientId: "memory-client", UserId: 1}, time.Minute)
+	if err != nil {
+		t.Fatalf("set authorization code: %v", err)
+	}
+	code, err := oauthRepo.TakeAuthorizationCode(ctx, "code-hash")
+	if err != nil || code.ClientId != "memory-client" || code.UserId != 1 {
+		t.Fatalf("take authorization code: code(%+v), error(%v)", code, err)
+	}
+	_, err = oauthRepo.TakeAuthorizationCode(ctx, "code-hash")
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("take authorization code twice: %v", err)
+	}
+
+	identityRepo := NewIdentityRepo(d, testLogger)
+	err = identityRepo.SetExternalLoginState(ctx, "state-hash", &biz.ExternalLoginState{Provider: "github", Nonce: "nonce"}, time.Minute)
+	if err != nil {
+		t.Fatalf("set external login state: %v", err)
+	}
+	state, err := identityRepo.TakeExternalLoginState(ctx, "state-hash")
+	if err != nil || state.Provider != "github" || state.Nonce != "nonce" {
+		t.Fatalf("take external login state: state(%+v), error(%v)", state, err)
+	}
+	_, err = identityRepo.TakeExternalLoginState(ctx, "state-hash")
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("take external login state twice: %v", err)
+	}
+	for i, want := range []bool{true, false} {
+		ok, err := identityRepo.UseSAMLAssertion(ctx, "assertion", time.Minute)
+		if err != nil || ok != want {
+			t.Fatalf("use saml assertion(%d): ok(%v), error(%v)", i, ok, err)
+		}
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("memory driver wrote to disk: entries(%v), error(%v)", entries, err)
+	}
+}
@@ -2,34 +2,70 @@ package data
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/glebarez/sqlite"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
+	"github.com/pkg/errors"
 	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
 	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
+	"os"
 	"runtime"
+	"sync/atomic"
 	"time"
 )
 
-var ProviderSet = wire.NewSet(NewData, NewDB, NewTransaction, NewRedis, NewRecovery, NewUserRepo, NewAuthRepo)
+var ProviderSet = wire.NewSet(NewData, NewDB, NewReplicas, NewTransaction, NewRedis, NewRedisBreaker, NewRecovery, NewHealthRepo, NewUserRepo, NewAuthRepo, NewMailer, NewAuditRepo, NewLoginHistoryRepo, NewGeoLocator, NewWebhookRepo, NewWebhookSender, NewOutboxRepo, NewEventPublisher, NewUserChangeRepo, NewOAuthRepo, NewTokenSigner, NewIdentityRepo, NewExternalProviders, NewDirectory, NewSAMLProviders, NewGroupRepo, NewAccessTokenRepo, NewPasskeyRepo, NewPasskeyRelyingParty)
 
 type Data struct {
-	log      *log.Helper
-	db       *gorm.DB
-	redisCli redis.Cmdable
-	conf     *conf.UserConstant
+	log             *log.Helper
+	db              *gorm.DB
+	replicas        *Replicas
+	redisCli        redis.UniversalClient
+	breaker         *RedisBreaker
+	conf            *conf.UserConstant
+	sessionFallback bool
+	keyPrefix       string
+	profiles        *profileCache
+	memory          *MemoryStore
 }
 
 type contextTxKey struct{}
 
+type contextAfterCommitKey struct{}
+
+// ExecTx 在事务中执行，事务提交后执行事务中通过afterCommit登记的操作
 func (d *Data) ExecTx(ctx context.Context, fn func(ctx context.Context) error) error {
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		ctx = context.WithValue(ctx, contextTxKey{}, tx)
-		return fn(ctx)
+	var hooks []func(ctx context.Context)
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(ctx, contextTxKey{}, tx)
+		txCtx = context.WithValue(txCtx, contextAfterCommitKey{}, &hooks)
+		return fn(txCtx)
 	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+	return nil
+}
+
+// afterCommit 在事务中时登记到事务提交后执行，不在事务中时立即执行
+func (d *Data) afterCommit(ctx context.Context, hook func(ctx context.Context)) {
+	hooks, ok := ctx.Value(contextAfterCommitKey{}).(*[]func(ctx context.Context))
+	if ok {
+		*hooks = append(*hooks, hook)
+		return
+	}
+	hook(ctx)
 }
 
 func (d *Data) DB(ctx context.Context) *gorm.DB {
@@ -40,7 +76,11 @@ func (d *Data) DB(ctx context.Context) *gorm.DB {
 	return d.db
 }
 
+// NewTransaction 内存模式下用户保存在内存仓储中，事务同时覆盖内存仓储和临时库
 func NewTransaction(d *Data) biz.Transaction {
+	if d.memory != nil {
+		return &memoryTransaction{store: d.memory, data: d}
+	}
 	return d
 }
 
@@ -76,48 +116,248 @@ func NewRecovery(d *Data) biz.Recovery {
 	return d
 }
 
-func NewDB(conf *conf.Data) *gorm.DB {
-	l := log.NewHelper(log.With(log.GetLogger(), "module", "user/data/mysql"))
+// 支持的数据库驱动
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	// DriverMemory 用户和登录态保存在进程内存中，其他数据使用进程内的SQLite内存库，不依赖MySQL和Redis，用于演示
+	DriverMemory = "memory"
+)
+
+// NewDB 按配置的驱动连接数据库，未配置驱动时默认为MySQL
+func NewDB(conf *conf.Data) (*gorm.DB, func()) {
+	l := log.NewHelper(log.With(log.GetLogger(), "module", "user/data/db"))
+
+	cleanup := func() {}
+	source := conf.Database.Source
+	driver := conf.Database.Driver
+	if driver == DriverMemory {
+		driver = DriverSQLite
+		source = fmt.Sprintf("file:/user_center_%d.db?vfs=memdb&_pragma=busy_timeout(5000)&_txlock=immediate", atomic.AddUint32(&memoryDBSeq, 1))
+	}
+	db, err := openDB(driver, source, conf.Database)
+	if err != nil {
+		l.Fatalf("failed opening connection to db: %v", err)
+	}
+	if conf.Database.Driver == DriverMemory {
+		cleanup = pinMemoryDB(db, l)
+		migrateMemoryDB(db, l)
+	}
+	return db, cleanup
+}
 
-	db, err := gorm.Open(mysql.Open(conf.Database.Source), &gorm.Config{
+// openDB 打开数据库连接并设置连接池，主库和只读副本使用相同的配置
+func openDB(driver, source string, conf *conf.Data_Database) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch driver {
+	case "", DriverMySQL:
+		dialector = mysql.Open(source)
+	case DriverPostgres:
+		dialector = postgres.Open(source)
+	case DriverSQLite:
+		dialector = sqlite.Open(source)
+	default:
+		return nil, errors.Errorf("unsupported database driver: %s", driver)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{
 		DisableForeignKeyConstraintWhenMigrating: true,
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true,
 		},
 	})
 	if err != nil {
-		l.Fatalf("failed opening connection to db: %v", err)
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if conf.GetMaxOpenConns() > 0 {
+		sqlDB.SetMaxOpenConns(int(conf.GetMaxOpenConns()))
+	}
+	if conf.GetMaxIdleConns() > 0 {
+		sqlDB.SetMaxIdleConns(int(conf.GetMaxIdleConns()))
+	}
+	if conf.GetConnMaxLifetime().AsDuration() > 0 {
+		sqlDB.SetConnMaxLifetime(conf.GetConnMaxLifetime().AsDuration())
+	}
+	if conf.GetConnMaxIdleTime().AsDuration() > 0 {
+		sqlDB.SetConnMaxIdleTime(conf.GetConnMaxIdleTime().AsDuration())
+	}
+	return db, nil
+}
+
+// memoryDBSeq 同一进程中的多个内存库使用不同的库名
+var memoryDBSeq uint32
+
+// pinMemoryDB memdb在最后一个连接关闭时释放，保持一个连接直到退出
+func pinMemoryDB(db *gorm.DB, l *log.Helper) func() {
+	sqlDB, err := db.DB()
+	if err != nil {
+		l.Fatalf("fail to open memory database: %v", err)
+	}
+	conn, err := sqlDB.Conn(context.Background())
+	if err != nil {
+		l.Fatalf("fail to open memory database: %v", err)
+	}
+	return func() {
+		err := conn.Close()
+		if err != nil {
+			l.Errorf("fail to close memory database: %v", err)
+		}
 	}
-	return db
 }
 
-func NewRedis(conf *conf.Data) redis.Cmdable {
+// migrateMemoryDB 临时库每次启动都是空库，直接执行迁移，初始管理员由内存仓储创建
+func migrateMemoryDB(db *gorm.DB, l *log.Helper) {
+	migrator, err := NewMigrator(db, nil, log.GetLogger())
+	if err != nil {
+		l.Fatalf("fail to load migrations: %v", err)
+	}
+	_, err = migrator.Up(context.Background())
+	if err != nil {
+		l.Fatalf("fail to migrate memory database: %v", err)
+	}
+}
+
+// Redis部署模式
+const (
+	RedisStandalone = "standalone"
+	RedisSentinel   = "sentinel"
+	RedisCluster    = "cluster"
+)
+
+const defaultRedisPoolSize = 10
+
+// NewRedis 按配置的模式连接Redis
+//1. 单节点、哨兵、集群模式统一为UniversalClient，哨兵模式自动跟随主节点切换
+//2. 网络错误按配置重试，重试后仍失败时计入熔断器
+//3. 启动时Redis不可用不退出，由熔断器和健康检查反映Redis状态，恢复后自动可用
+func NewRedis(conf *conf.Data, breaker *RedisBreaker) (redis.UniversalClient, func()) {
 	l := log.NewHelper(log.With(log.GetLogger(), "module", "user/data/redis"))
-	client := redis.NewClient(&redis.Options{
-		Addr:         conf.Redis.Addr,
-		ReadTimeout:  conf.Redis.ReadTimeout.AsDuration(),
-		WriteTimeout: conf.Redis.WriteTimeout.AsDuration(),
-		DialTimeout:  time.Second * 2,
-		PoolSize:     10,
-		Password:     conf.Redis.Password,
-	})
+	c := conf.Redis
+	mode := c.GetMode()
+	addrs := c.GetAddrs()
+	if len(addrs) == 0 {
+		addrs = []string{c.GetAddr()}
+	}
+
+	tlsConfig, err := redisTLSConfig(c.GetTls())
+	if err != nil {
+		l.Fatalf("fail to load redis tls config: %v", err)
+	}
+	poolSize := int(c.GetPoolSize())
+	if poolSize <= 0 {
+		poolSize = defaultRedisPoolSize
+	}
+	dialTimeout := c.GetDialTimeout().AsDuration()
+	if dialTimeout <= 0 {
+		dialTimeout = time.Second * 2
+	}
+	options := &redis.UniversalOptions{
+		Addrs:            addrs,
+		DB:               int(c.GetDb()),
+		Username:         c.GetUsername(),
+		Password:         c.GetPassword(),
+		SentinelUsername: c.GetSentinelUsername(),
+		SentinelPassword: c.GetSentinelPassword(),
+		MasterName:       c.GetMasterName(),
+		MaxRetries:       int(c.GetMaxRetries()),
+		DialTimeout:      dialTimeout,
+		ReadTimeout:      c.GetReadTimeout().AsDuration(),
+		WriteTimeout:     c.GetWriteTimeout().AsDuration(),
+		PoolSize:         poolSize,
+		MinIdleConns:     int(c.GetMinIdleConns()),
+		PoolTimeout:      c.GetPoolTimeout().AsDuration(),
+		IdleTimeout:      c.GetIdleTimeout().AsDuration(),
+		TLSConfig:        tlsConfig,
+	}
+
+	var client redis.UniversalClient
+	switch mode {
+	case "", RedisStandalone:
+		simple := options.Simple()
+		simple.Network = c.GetNetwork()
+		client = redis.NewClient(simple)
+	case RedisSentinel:
+		if options.MasterName == "" {
+			l.Fatalf("redis master_name is required in sentinel mode")
+		}
+		client = redis.NewFailoverClient(options.Failover())
+	case RedisCluster:
+		if options.DB != 0 {
+			l.Fatalf("redis db must be 0 in cluster mode: db(%v)", options.DB)
+		}
+		client = redis.NewClusterClient(options.Cluster())
+	default:
+		l.Fatalf("unsupported redis mode: %s", mode)
+	}
+	client.AddHook(breaker)
 	timeout, cancelFunc := context.WithTimeout(context.Background(), time.Second*2)
 	defer cancelFunc()
-	err := client.Ping(timeout).Err()
+	err = client.Ping(timeout).Err()
 	if err != nil {
-		l.Fatalf("redis connect error: %v", err)
+		l.Errorf("redis connect error, running degraded until redis recovers: %v", err)
+	}
+	return client, func() {}
+}
+
+// redisTLSConfig 未开启TLS时返回nil
+func redisTLSConfig(c *conf.Data_Redis_TLS) (*tls.Config, error) {
+	if !c.GetEnable() {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.GetServerName(),
+		InsecureSkipVerify: c.GetInsecureSkipVerify(),
+	}
+	if c.GetCaFile() != "" {
+		ca, err := os.ReadFile(c.GetCaFile())
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to read redis ca file: %s", c.GetCaFile()))
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.Errorf("fail to parse redis ca file: %s", c.GetCaFile())
+		}
 	}
-	return client
+	if c.GetCertFile() != "" || c.GetKeyFile() != "" {
+		pair, err := tls.LoadX509KeyPair(c.GetCertFile(), c.GetKeyFile())
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to load redis client certificate: %s", c.GetCertFile()))
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	return tlsConfig, nil
 }
 
-func NewData(db *gorm.DB, redisCmd redis.Cmdable, logger log.Logger, conf *conf.UserConstant) (*Data, func(), error) {
+// redisKey 为键加上配置的前缀
+func (d *Data) redisKey(key string) string {
+	return d.keyPrefix + key
+}
+
+func NewData(db *gorm.DB, replicas *Replicas, redisCmd redis.UniversalClient, breaker *RedisBreaker, logger log.Logger, conf *conf.UserConstant, dataConf *conf.Data) (*Data, func(), error) {
 	l := log.NewHelper(log.With(log.GetLogger(), "module", "user/data/new-data"))
 
 	d := &Data{
-		log:      log.NewHelper(log.With(logger, "module", "creation/data")),
-		db:       db,
-		redisCli: redisCmd,
-		conf:     conf,
+		log:             log.NewHelper(log.With(logger, "module", "creation/data")),
+		db:              db,
+		replicas:        replicas,
+		redisCli:        redisCmd,
+		breaker:         breaker,
+		conf:            conf,
+		sessionFallback: dataConf.GetRedis().GetSessionFallback(),
+		keyPrefix:       dataConf.GetRedis().GetKeyPrefix(),
+		profiles:        newProfileCache(dataConf.GetProfileCache()),
+	}
+	if dataConf.GetDatabase().GetDriver() == DriverMemory {
+		d.memory = NewMemoryStore()
+		err := d.memory.seedAdmin(conf, l)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	return d, func() {
 		l.Info("closing the data resources")
@@ -132,7 +372,7 @@ func NewData(db *gorm.DB, redisCmd redis.Cmdable, logger log.Logger, conf *conf.
 			l.Errorf("close db err: %v", err.Error())
 		}
 
-		err = redisCmd.(*redis.Client).Close()
+		err = redisCmd.Close()
 		if err != nil {
 			l.Errorf("close redis err: %v", err.Error())
 		}
@@ -0,0 +1,152 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testLogger 测试中不输出日志
+var testLogger = log.NewStdLogger(io.Discard)
+
+func testUserConstant() *conf.UserConstant {
+	return &conf.UserConstant{
+		UserLoginState: "user_login_state",
+		SessionTimeout: 3600,
+		DefaultRole:    0,
+		AdminRole:      1,
+	}
+}
+
+// newTestData 按驱动创建数据层，Redis使用进程内的miniredis
+//1. sqlite未指定连接串时使用测试临时目录中的数据库文件
+//2. 非内存模式执行迁移
+//3. 测试结束时关闭连接
+func newTestData(t *testing.T, driver, source string, setup ...func(c *conf.Data)) (*Data, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	if driver == DriverSQLite && source == "" {
+		source = fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_txlock=immediate", filepath.Join(t.TempDir(), "user_center.db"))
+	}
+	dataConf := &conf.Data{
+		Database:     &conf.Data_Database{Driver: driver, Source: source},
+		Redis:        &conf.Data_Redis{Addr: server.Addr(), MaxRetries: -1},
+		ProfileCache: &conf.Data_ProfileCache{},
+	}
+	for _, fn := range setup {
+		fn(dataConf)
+	}
+
+	db, cleanupDB := NewDB(dataConf)
+	if driver != DriverMemory {
+		migrator, err := NewMigrator(db, nil, testLogger)
+		if err != nil {
+			t.Fatalf("fail to load migrations: %v", err)
+		}
+		_, err = migrator.Up(context.Background())
+		if err != nil {
+			t.Fatalf("fail to migrate: %v", err)
+		}
+	}
+	breaker := NewRedisBreaker(dataConf, testLogger)
+	redisCli, cleanupRedis := NewRedis(dataConf, breaker)
+	replicas, cleanupReplicas := NewReplicas(dataConf, testLogger)
+	d, cleanup, err := NewData(db, replicas, redisCli, breaker, testLogger, testUserConstant(), dataConf)
+	if err != nil {
+		t.Fatalf("fail to create data: %v", err)
+	}
+	t.Cleanup(func() {
+		cleanup()
+		cleanupReplicas()
+		cleanupRedis()
+		cleanupDB()
+	})
+	return d, server
+}
+
+// TestNewRedisModes 按模式创建对应的客户端，哨兵模式使用主节点名称发现主节点
+func TestNewRedisModes(t *testing.T) {
+	server := miniredis.RunT(t)
+	newRedis := func(redisConf *conf.Data_Redis) redis.UniversalClient {
+		t.Helper()
+		dataConf := &conf.Data{Redis: redisConf}
+		client, cleanup := NewRedis(dataConf, NewRedisBreaker(dataConf, testLogger))
+		t.Cleanup(func() {
+			cleanup()
+			_ = client.Close()
+		})
+		return client
+	}
+
+	standalone, ok := newRedis(&conf.Data_Redis{Addr: server.Addr(), Db: 2}).(*redis.Client)
+	if !ok || standalone.Options().Addr != server.Addr() || standalone.Options().DB != 2 {
+		t.Fatalf("standalone client: %T", standalone)
+	}
+
+	// 哨兵不可用时不退出，启动后降级运行
+	sentinel, ok := newRedis(&conf.Data_Redis{Mode: RedisSentinel, Addrs: []string{"127.0.0.1:1"}, MasterName: "mymaster", MaxRetries: -1}).(*redis.Client)
+	if !ok || sentinel.Options().Addr != "FailoverClient" {
+		t.Fatalf("sentinel client: %T", sentinel)
+	}
+
+	cluster, ok := newRedis(&conf.Data_Redis{Mode: RedisCluster, Addrs: []string{server.Addr()}}).(*redis.ClusterClient)
+	if !ok || len(cluster.Options().Addrs) != 1 || cluster.Options().Addrs[0] != server.Addr() {
+		t.Fatalf("cluster client: %T", cluster)
+	}
+	ctx := context.Background()
+	err := cluster.Set(ctx, "cluster_key", "value", time.Minute).Err()
+	if err != nil {
+		t.Fatalf("set through cluster client: %v", err)
+	}
+	if got, _ := server.Get("cluster_key"); got != "value" {
+		t.Fatalf("cluster key: %q", got)
+	}
+}
+
+// TestRedisKeyPrefix 配置前缀后所有键都带前缀
+func TestRedisKeyPrefix(t *testing.T) {
+	ctx := context.Background()
+	d, server := newTestData(t, DriverSQLite, "", func(c *conf.Data) {
+		c.Redis.KeyPrefix = "{uc}:"
+	})
+	authRepo := NewAuthRepo(d, testLogger)
+	userId, err := authRepo.CreateUser(ctx, &biz.User{UserAccount: "prefix_user"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	user, err := NewUserRepo(d, testLogger).GetCurrentUser(ctx, userId)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	err = authRepo.SetLoginSession(ctx, user)
+	if err != nil {
+		t.Fatalf("set login session: %v", err)
+	}
+	err = authRepo.SetLoginVerifyCode(ctx, userId, "device", "123456", time.Minute)
+	if err != nil {
+		t.Fatalf("set verify code: %v", err)
+	}
+	_, err = authRepo.IncrMagicLinkRequest(ctx, "prefix_user", time.Minute)
+	if err != nil {
+		t.Fatalf("incr magic link request: %v", err)
+	}
+
+	keys := server.Keys()
+	if !server.Exists(fmt.Sprintf("{uc}:user_login_state_%v", userId)) || len(keys) < 3 {
+		t.Fatalf("redis keys: %v", keys)
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, "{uc}:") {
+			t.Fatalf("redis key without prefix: %s, keys(%v)", key, keys)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/coreos/go-oidc/v3/oidc"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"golang.org/x/oauth2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var _ biz.ExternalProviders = (*externalProviders)(nil)
+
+// 请求第三方接口的超时时间
+const externalProviderTimeout = 10 * time.Second
+
+type externalProviders struct {
+	providers map[string]*externalProvider
+}
+
+// NewExternalProviders 按配置创建第三方登录提供方，未配置client_id的忽略
+func NewExternalProviders(conf *conf.OAuth, logger log.Logger) biz.ExternalProviders {
+	l := log.NewHelper(log.With(logger, "module", "user/data/externalProvider"))
+	client := &http.Client{Timeout: externalProviderTimeout}
+	providers := make(map[string]*externalProvider)
+	for _, item := range conf.GetExternalProviders() {
+		if item.GetName() == "" || item.GetClientId() == "" {
+			continue
+		}
+		if item.GetIssuer() == "" && (item.GetAuthUrl() == "" || item.GetTokenUrl() == "" || item.GetUserinfoUrl() == "") {
+			l.Warnf("external provider endpoints not configured: name(%s)", item.GetName())
+			continue
+		}
+		providers[item.GetName()] = &externalProvider{conf: item, client: client}
+	}
+	return &externalProviders{providers: providers}
+}
+
+func (p *externalProviders) Get(name string) (biz.ExternalProvider, error) {
+	provider, ok := p.providers[name]
+	if !ok {
+		return nil, kerrors.NotFound("external provider not found", fmt.Sprintf("name(%s)", name))
+	}
+	return provider, nil
+}
+
+// externalProvider 配置issuer时按OIDC处理，首次使用时通过discovery获取端点，失败时下次重试
+type externalProvider struct {
+	conf     *conf.ExternalProvider
+	client   *http.Client
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+	provider *oidc.Provider
+}
+
+func (p *externalProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	config, err := p.config(ctx)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(codeVerifier))
+	options := []oauth2.AuthCodeOption{
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(sum[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", biz.PKCEMethodS256),
+	}
+	if p.provider != nil {
+		options = append(options, oidc.Nonce(nonce))
+	}
+	return config.AuthCodeURL(state, options...), nil
+}
+
+// Exchange 使用授权码换取令牌并获取用户身份
+//1. OIDC提供方校验id_token签名、签发者、受众和nonce，id_token中没有邮箱时从用户信息接口补充
+//2. 其它提供方从用户信息接口获取身份，邮箱是否已验证由trust_email决定
+func (p *externalProvider) Exchange(ctx context.Context, code, nonce, codeVerifier string) (*biz.ExternalIdentity, error) {
+	config, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = oidc.ClientContext(ctx, p.client)
+	token, err := config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to exchange code: provider(%s)", p.conf.GetName()))
+	}
+
+	if p.provider == nil {
+		claims, err := p.userInfo(ctx, config, token)
+		if err != nil {
+			return nil, err
+		}
+		return p.identity(claims, p.subjectField()), nil
+	}
+
+	rawIdToken, _ := token.Extra("id_token").(string)
+	if rawIdToken == "" {
+		return nil, errors.Errorf("id_token missing: provider(%s)", p.conf.GetName())
+	}
+	idToken, err := p.verifier.Verify(ctx, rawIdToken)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to verify id_token: provider(%s)", p.conf.GetName()))
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.Errorf("id_token nonce mismatch: provider(%s)", p.conf.GetName())
+	}
+	claims := make(map[string]interface{})
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to parse id_token claims: provider(%s)", p.conf.GetName()))
+	}
+	if _, ok := claims["email"]; !ok && p.provider.UserInfoEndpoint() != "" {
+		info, err := p.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to get userinfo: provider(%s)", p.conf.GetName()))
+		}
+		// 用户信息接口返回的sub必须与id_token一致
+		if info.Subject == idToken.Subject {
+			_ = info.Claims(&claims)
+		}
+	}
+	claims["sub"] = idToken.Subject
+	return p.identity(claims, "sub"), nil
+}
+
+// config 获取OAuth2配置，OIDC提供方首次调用时执行discovery
+func (p *externalProvider) config(ctx context.Context) (*oauth2.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth != nil {
+		return p.oauth, nil
+	}
+
+	config := &oauth2.Config{
+		ClientID:     p.conf.GetClientId(),
+		ClientSecret: p.conf.GetClientSecret(),
+		RedirectURL:  p.conf.GetRedirectUrl(),
+		Scopes:       p.conf.GetScopes(),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.conf.GetAuthUrl(),
+			TokenURL: p.conf.GetTokenUrl(),
+		},
+	}
+	if p.conf.GetIssuer() != "" {
+		provider, err := oidc.NewProvider(oidc.ClientContext(ctx, p.client), p.conf.GetIssuer())
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to discover provider: issuer(%s)", p.conf.GetIssuer()))
+		}
+		config.Endpoint = provider.Endpoint()
+		if len(config.Scopes) == 0 {
+			config.Scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+		}
+		p.provider = provider
+		p.verifier = provider.Verifier(&oidc.Config{ClientID: p.conf.GetClientId()})
+	}
+	p.oauth = config
+	return config, nil
+}
+
+func (p *externalProvider) userInfo(ctx context.Context, config *oauth2.Config, token *oauth2.Token) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.conf.GetUserinfoUrl(), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to create userinfo request: provider(%s)", p.conf.GetName()))
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := config.Client(ctx, token).Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get userinfo: provider(%s)", p.conf.GetName()))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fail to get userinfo: provider(%s), status(%v)", p.conf.GetName(), resp.StatusCode)
+	}
+
+	claims := make(map[string]interface{})
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	err = decoder.Decode(&claims)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to decode userinfo: provider(%s)", p.conf.GetName()))
+	}
+	return claims, nil
+}
+
+// identity 从声明中提取身份，未返回email_verified时按trust_email处理
+func (p *externalProvider) identity(claims map[string]interface{}, subjectField string) *biz.ExternalIdentity {
+	identity := &biz.ExternalIdentity{
+		Subject:       claimString(claims, subjectField),
+		Email:         claimString(claims, "email"),
+		EmailVerified: p.conf.GetTrustEmail(),
+		Name:          claimString(claims, "name"),
+		AvatarUrl:     claimString(claims, "picture"),
+	}
+	if verified, ok := claims["email_verified"].(bool); ok {
+		identity.EmailVerified = verified
+	}
+	if identity.AvatarUrl == "" {
+		identity.AvatarUrl = claimString(claims, "avatar_url")
+	}
+	return identity
+}
+
+func (p *externalProvider) subjectField() string {
+	if p.conf.GetSubjectField() == "" {
+		return "sub"
+	}
+	return p.conf.GetSubjectField()
+}
+
+func claimString(claims map[string]interface{}, name string) string {
+	switch value := claims[name].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	}
+	return ""
+}
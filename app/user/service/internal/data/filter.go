@@ -0,0 +1,57 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+// 过滤条件中可使用的属性及对应的列
+var (
+	userFilterColumns = map[string]string{
+		biz.FilterAttributeId:          "id",
+		biz.FilterAttributeUserAccount: "userAccount",
+		biz.FilterAttributeUserName:    "username",
+		biz.FilterAttributeEmail:       "email",
+		biz.FilterAttributePhone:       "phone",
+		biz.FilterAttributeUserStatus:  "userStatus",
+	}
+	groupFilterColumns = map[string]string{
+		biz.FilterAttributeId:          "id",
+		biz.FilterAttributeDisplayName: "displayName",
+		biz.FilterAttributeExternalId:  "externalId",
+	}
+)
+
+// like的转义字符，SQLite没有默认转义字符，PostgreSQL的默认转义字符与MySQL的字符串转义冲突，统一显式指定
+var likeEscaper = strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+
+// applyFilter 将过滤条件转换为查询条件，多个条件之间为and关系，列名按数据库方言加引号
+func applyFilter(db *gorm.DB, columns map[string]string, conditions []*biz.FilterCondition) (*gorm.DB, error) {
+	for _, condition := range conditions {
+		name, ok := columns[condition.Attribute]
+		if !ok {
+			return nil, errors.Errorf("unsupported filter attribute: %s", condition.Attribute)
+		}
+		column := clause.Column{Name: name}
+		switch condition.Operator {
+		case biz.FilterOperatorEq:
+			db = db.Where("? = ?", column, condition.Value)
+		case biz.FilterOperatorNe:
+			db = db.Where("? <> ?", column, condition.Value)
+		case biz.FilterOperatorCo:
+			db = db.Where("? like ? escape '!'", column, "%"+likeEscaper.Replace(condition.Value)+"%")
+		case biz.FilterOperatorSw:
+			db = db.Where("? like ? escape '!'", column, likeEscaper.Replace(condition.Value)+"%")
+		case biz.FilterOperatorEw:
+			db = db.Where("? like ? escape '!'", column, "%"+likeEscaper.Replace(condition.Value))
+		case biz.FilterOperatorPr:
+			db = db.Where("? is not null and ? <> ''", column, column)
+		default:
+			return nil, errors.Errorf("unsupported filter operator: %s", condition.Operator)
+		}
+	}
+	return db, nil
+}
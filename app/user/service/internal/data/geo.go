@@ -0,0 +1,120 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+)
+
+var _ biz.GeoLocator = (*geoLocator)(nil)
+
+// geoRange ip段，起止地址统一为16字节
+type geoRange struct {
+	start    net.IP
+	end      net.IP
+	location string
+}
+
+// geoLocator 启动时将本地CSV地址库加载到内存，按起始地址排序后二分查找
+type geoLocator struct {
+	ranges []*geoRange
+}
+
+// NewGeoLocator 加载配置的地址库，未配置时不识别地理位置
+func NewGeoLocator(conf *conf.Data, logger log.Logger) (biz.GeoLocator, error) {
+	l := log.NewHelper(log.With(logger, "module", "user/data/geo"))
+	g := &geoLocator{}
+	for _, file := range conf.GetGeo().GetFiles() {
+		ranges, err := loadGeoFile(file)
+		if err != nil {
+			return nil, err
+		}
+		g.ranges = append(g.ranges, ranges...)
+		l.Infof("geo database loaded: file(%s), ranges(%v)", file, len(ranges))
+	}
+	sort.Slice(g.ranges, func(i, j int) bool {
+		return bytes.Compare(g.ranges[i].start, g.ranges[j].start) < 0
+	})
+	return g, nil
+}
+
+// loadGeoFile 读取CSV地址库
+//1. 第一列为CIDR，之后第一个非空列为地理位置，GeoLite2 Blocks CSV中依次为geoname_id、registered_country_geoname_id
+//2. 第一行不是CIDR时视为表头跳过，空行和#开头的行忽略
+func loadGeoFile(file string) ([]*geoRange, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to open geo database: file(%s)", file))
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	ranges := make([]*geoRange, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to read geo database: file(%s)", file))
+		}
+		_, network, err := net.ParseCIDR(strings.TrimSpace(record[0]))
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, errors.Errorf("invalid network in geo database: file(%s), line(%v), network(%s)", file, line, record[0])
+		}
+		var location string
+		for _, field := range record[1:] {
+			if location = strings.TrimSpace(field); location != "" {
+				break
+			}
+		}
+		if location == "" {
+			continue
+		}
+		start := network.IP.To16()
+		end := make(net.IP, net.IPv6len)
+		mask := network.Mask
+		if len(mask) == net.IPv4len {
+			mask = append(net.CIDRMask(96, 128)[:12], mask...)
+		}
+		for i := range end {
+			end[i] = start[i] | ^mask[i]
+		}
+		ranges = append(ranges, &geoRange{start: start, end: end, location: location})
+	}
+	return ranges, nil
+}
+
+// Locate 查找ip所在的地址段，地址段不重叠，找不到时返回空字符串
+func (g *geoLocator) Locate(_ context.Context, ip string) (string, error) {
+	parsed := net.ParseIP(ip).To16()
+	if parsed == nil || len(g.ranges) == 0 {
+		return "", nil
+	}
+	i := sort.Search(len(g.ranges), func(i int) bool {
+		return bytes.Compare(g.ranges[i].start, parsed) > 0
+	})
+	if i == 0 {
+		return "", nil
+	}
+	r := g.ranges[i-1]
+	if bytes.Compare(parsed, r.end) > 0 {
+		return "", nil
+	}
+	return r.location, nil
+}
@@ -0,0 +1,70 @@
+package data
+
+import (
+	"context"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeGeoFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "geo.csv")
+	err := os.WriteFile(file, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("write geo file: %v", err)
+	}
+	return file
+}
+
+func TestGeoLocator(t *testing.T) {
+	ctx := context.Background()
+	// GeoLite2 Blocks CSV格式，geoname_id为空时使用registered_country_geoname_id
+	blocks := writeGeoFile(t, "network,geoname_id,registered_country_geoname_id,represented_country_geoname_id,is_anonymous_proxy,is_satellite_provider\n"+
+		"203.0.113.0/24,1814991,1814991,,0,0\n"+
+		"198.51.100.0/25,,6252001,,0,0\n"+
+		"2001:db8::/32,2921044,2921044,,0,0\n")
+	custom := writeGeoFile(t, "# 自定义地址段\n10.0.0.0/8,office\n")
+	g, err := NewGeoLocator(&conf.Data{Geo: &conf.Data_Geo{Files: []string{blocks, custom}}}, testLogger)
+	if err != nil {
+		t.Fatalf("load geo database: %v", err)
+	}
+
+	for ip, want := range map[string]string{
+		"203.0.113.0":    "1814991",
+		"203.0.113.255":  "1814991",
+		"198.51.100.127": "6252001",
+		"198.51.100.128": "",
+		"2001:db8::1":    "2921044",
+		"2001:db9::1":    "",
+		"10.1.2.3":       "office",
+		"192.0.2.1":      "",
+		"invalid":        "",
+	} {
+		location, err := g.Locate(ctx, ip)
+		if err != nil || location != want {
+			t.Fatalf("locate %s: want(%s), got(%s), error(%v)", ip, want, location, err)
+		}
+	}
+}
+
+func TestGeoLocatorInvalidFile(t *testing.T) {
+	// 未配置地址库时不识别地理位置
+	g, err := NewGeoLocator(&conf.Data{}, testLogger)
+	if err != nil {
+		t.Fatalf("geo locator without database: %v", err)
+	}
+	if location, _ := g.Locate(context.Background(), "203.0.113.7"); location != "" {
+		t.Fatalf("locate without database: %s", location)
+	}
+
+	for _, files := range [][]string{
+		{filepath.Join(t.TempDir(), "missing.csv")},
+		{writeGeoFile(t, "203.0.113.0/24,CN\nnot-a-network,US\n")},
+	} {
+		if _, err = NewGeoLocator(&conf.Data{Geo: &conf.Data_Geo{Files: files}}, testLogger); err == nil {
+			t.Fatalf("invalid geo database loaded: %v", files)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ biz.GroupRepo = (*groupRepo)(nil)
+
+type groupRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewGroupRepo(data *Data, logger log.Logger) biz.GroupRepo {
+	return &groupRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/group")),
+	}
+}
+
+func (r *groupRepo) CreateGroup(ctx context.Context, group *biz.Group) error {
+	record := &UserGroup{}
+	util.StructAssign(record, group)
+	err := r.data.DB(ctx).WithContext(ctx).Select("displayName", "externalId").Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create group: displayName(%s)", group.DisplayName))
+	}
+	group.Id = record.Id
+	return nil
+}
+
+func (r *groupRepo) GetGroup(ctx context.Context, id int64) (*biz.Group, error) {
+	record := &UserGroup{}
+	err := r.data.DB(ctx).WithContext(ctx).Where("id = ?", id).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("group not found", fmt.Sprintf("id(%v)", id))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get group: id(%v)", id))
+	}
+
+	group := &biz.Group{}
+	util.StructAssign(group, record)
+	return group, nil
+}
+
+// ListGroups 按过滤条件分页查询组，成员条件转换为子查询
+func (r *groupRepo) ListGroups(ctx context.Context, conditions []*biz.FilterCondition, offset, limit int32) ([]*biz.Group, int64, error) {
+	list := make([]*UserGroup, 0)
+	var total int64
+	db := r.data.DB(ctx).WithContext(ctx).Model(&UserGroup{})
+	columnConditions := make([]*biz.FilterCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		if condition.Attribute != biz.FilterAttributeMember {
+			columnConditions = append(columnConditions, condition)
+			continue
+		}
+		if condition.Operator != biz.FilterOperatorEq {
+			return nil, 0, errors.Errorf("unsupported filter operator: %s", condition.Operator)
+		}
+		db = db.Where("id in (?)", r.data.DB(ctx).Model(&UserGroupMember{}).Select("groupId").Where(map[string]interface{}{"userId": condition.Value}))
+	}
+	db, err := applyFilter(db, groupFilterColumns, columnConditions)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "fail to count groups")
+	}
+	if limit == 0 {
+		return []*biz.Group{}, total, nil
+	}
+	err = db.Order("id").Offset(int(offset)).Limit(int(limit)).Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "fail to list groups")
+	}
+
+	groups := make([]*biz.Group, 0, len(list))
+	for _, item := range list {
+		group := &biz.Group{}
+		util.StructAssign(group, item)
+		groups = append(groups, group)
+	}
+	return groups, total, nil
+}
+
+func (r *groupRepo) UpdateGroup(ctx context.Context, group *biz.Group) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&UserGroup{}).Where("id = ?", group.Id).Updates(map[string]interface{}{
+		"displayName": group.DisplayName,
+		"externalId":  group.ExternalId,
+	}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to update group: id(%v)", group.Id))
+	}
+	return nil
+}
+
+// DeleteGroup 删除组及其成员关系
+func (r *groupRepo) DeleteGroup(ctx context.Context, id int64) error {
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"groupId": id}).Delete(&UserGroupMember{}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to delete group members: groupId(%v)", id))
+	}
+	err = r.data.DB(ctx).WithContext(ctx).Where("id = ?", id).Delete(&UserGroup{}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to delete group: id(%v)", id))
+	}
+	return nil
+}
+
+// ListGroupMembers 查询组成员，已删除的用户不返回，联表查询的表名和列名按数据库方言加引号
+func (r *groupRepo) ListGroupMembers(ctx context.Context, groupIds []int64) ([]*biz.GroupMember, error) {
+	members := make([]*biz.GroupMember, 0)
+	if len(groupIds) == 0 {
+		return members, nil
+	}
+	if r.data.memory != nil {
+		return r.listMemoryGroupMembers(ctx, groupIds)
+	}
+	groupId := clause.Column{Table: "m", Name: "groupId"}
+	userId := clause.Column{Table: "m", Name: "userId"}
+	err := r.data.DB(ctx).WithContext(ctx).Table("user_group_member m").
+		Select("? as group_id, ? as user_id, ? as user_account, ? as user_name", groupId, userId, clause.Column{Table: "u", Name: "userAccount"}, clause.Column{Table: "u", Name: "username"}).
+		Joins("join ? u on ? = ? and ? = 0", clause.Table{Name: "user"}, clause.Column{Table: "u", Name: "id"}, userId, clause.Column{Table: "u", Name: "isDelete"}).
+		Where("? in ?", groupId, groupIds).Order("m.id").Scan(&members).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list group members: groupIds(%v)", groupIds))
+	}
+	return members, nil
+}
+
+// listMemoryGroupMembers 内存模式下用户不在临时库中，先查询成员关系再从内存仓储中补充用户信息
+func (r *groupRepo) listMemoryGroupMembers(ctx context.Context, groupIds []int64) ([]*biz.GroupMember, error) {
+	records := make([]*UserGroupMember, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"groupId": groupIds}).Order("id").Find(&records).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list group members: groupIds(%v)", groupIds))
+	}
+	userIds := make([]int32, 0, len(records))
+	for _, record := range records {
+		userIds = append(userIds, record.UserId)
+	}
+	users := r.data.memory.usersById(userIds)
+	members := make([]*biz.GroupMember, 0, len(records))
+	for _, record := range records {
+		user, ok := users[record.UserId]
+		if !ok {
+			continue
+		}
+		members = append(members, &biz.GroupMember{GroupId: record.GroupId, UserId: record.UserId, UserAccount: user.UserAccount, UserName: user.UserName})
+	}
+	return members, nil
+}
+
+// AddGroupMembers 添加组成员，已是成员的忽略，用户不存在时返回NotFound
+func (r *groupRepo) AddGroupMembers(ctx context.Context, groupId int64, userIds []int32) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+	userIds = uniqueUserIds(userIds)
+	var count int64
+	if r.data.memory != nil {
+		count = int64(len(r.data.memory.usersById(userIds)))
+	} else {
+		err := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"id": userIds, "isDelete": 0}).Count(&count).Error
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("fail to count users: userIds(%v)", userIds))
+		}
+	}
+	if count != int64(len(userIds)) {
+		return kerrors.NotFound("user not found", fmt.Sprintf("userIds(%v)", userIds))
+	}
+
+	records := make([]*UserGroupMember, 0, len(userIds))
+	for _, userId := range userIds {
+		records = append(records, &UserGroupMember{GroupId: groupId, UserId: userId})
+	}
+	err := r.data.DB(ctx).WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Select("groupId", "userId").Create(&records).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to add group members: groupId(%v), userIds(%v)", groupId, userIds))
+	}
+	return nil
+}
+
+func (r *groupRepo) RemoveGroupMembers(ctx context.Context, groupId int64, userIds []int32) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"groupId": groupId, "userId": userIds}).Delete(&UserGroupMember{}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to remove group members: groupId(%v), userIds(%v)", groupId, userIds))
+	}
+	return nil
+}
+
+// SetGroupMembers 将组成员替换为指定用户
+func (r *groupRepo) SetGroupMembers(ctx context.Context, groupId int64, userIds []int32) error {
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"groupId": groupId}).Delete(&UserGroupMember{}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to clear group members: groupId(%v)", groupId))
+	}
+	return r.AddGroupMembers(ctx, groupId, userIds)
+}
+
+func (r *groupRepo) DeleteUserMemberships(ctx context.Context, userId int32) error {
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"userId": userId}).Delete(&UserGroupMember{}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to delete group memberships: userId(%v)", userId))
+	}
+	return nil
+}
+
+func uniqueUserIds(userIds []int32) []int32 {
+	seen := make(map[int32]bool, len(userIds))
+	result := make([]int32, 0, len(userIds))
+	for _, userId := range userIds {
+		if !seen[userId] {
+			seen[userId] = true
+			result = append(result, userId)
+		}
+	}
+	return result
+}
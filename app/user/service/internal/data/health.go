@@ -0,0 +1,73 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"time"
+)
+
+var _ biz.HealthRepo = (*healthRepo)(nil)
+
+const healthCheckTimeout = time.Second * 2
+
+type healthRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewHealthRepo(data *Data, logger log.Logger) biz.HealthRepo {
+	return &healthRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/health")),
+	}
+}
+
+func (r *healthRepo) CheckDB(ctx context.Context) *biz.ComponentHealth {
+	health := &biz.ComponentHealth{Name: "db", Status: biz.HealthOk}
+	sqlDB, err := r.data.db.DB()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		defer cancel()
+		err = sqlDB.PingContext(ctx)
+	}
+	if err != nil {
+		health.Status = biz.HealthUnavailable
+		health.Detail = err.Error()
+	}
+	return health
+}
+
+// CheckRedis 熔断期间不访问Redis，直接返回熔断器状态和最近一次失败原因
+func (r *healthRepo) CheckRedis(ctx context.Context) *biz.ComponentHealth {
+	health := &biz.ComponentHealth{Name: "redis", Status: biz.HealthOk}
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	err := r.data.redisCli.Ping(ctx).Err()
+	if err == nil {
+		return health
+	}
+	health.Status = biz.HealthUnavailable
+	state, lastErr := r.data.breaker.State()
+	if lastErr != nil {
+		health.Detail = fmt.Sprintf("circuit breaker %s: %v", state, lastErr)
+	} else {
+		health.Detail = fmt.Sprintf("circuit breaker %s: %v", state, err)
+	}
+	return health
+}
+
+// CheckReplicas 返回定时检查的结果，不额外访问副本
+func (r *healthRepo) CheckReplicas(_ context.Context) []*biz.ComponentHealth {
+	return r.data.replicas.Health()
+}
+
+// SessionFallback 内存模式下登录态保存在内存中，不依赖Redis
+func (r *healthRepo) SessionFallback() bool {
+	return r.data.sessionFallback || r.data.memory != nil
+}
+
+func (r *healthRepo) CacheStats() []*biz.CacheStats {
+	return []*biz.CacheStats{r.data.profiles.Stats()}
+}
@@ -0,0 +1,164 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var _ biz.IdentityRepo = (*identityRepo)(nil)
+
+type identityRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewIdentityRepo 内存模式下登录state和SAML断言保存在内存中
+func NewIdentityRepo(data *Data, logger log.Logger) biz.IdentityRepo {
+	repo := &identityRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/identity")),
+	}
+	if data.memory != nil {
+		return &memoryIdentityRepo{identityRepo: repo, store: data.memory}
+	}
+	return repo
+}
+
+func (r *identityRepo) CreateIdentity(ctx context.Context, identity *biz.Identity) error {
+	record := &UserIdentity{}
+	util.StructAssign(record, identity)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create identity: userId(%v), provider(%s)", identity.UserId, identity.Provider))
+	}
+	identity.Id = record.Id
+	return nil
+}
+
+func (r *identityRepo) GetIdentity(ctx context.Context, provider, subject string) (*biz.Identity, error) {
+	return r.getIdentity(ctx, fmt.Sprintf("provider(%s), subject(%s)", provider, subject), "provider = ? and subject = ?", provider, subject)
+}
+
+func (r *identityRepo) GetIdentityById(ctx context.Context, id int64) (*biz.Identity, error) {
+	return r.getIdentity(ctx, fmt.Sprintf("id(%v)", id), "id = ?", id)
+}
+
+func (r *identityRepo) getIdentity(ctx context.Context, detail string, query string, args ...interface{}) (*biz.Identity, error) {
+	record := &UserIdentity{}
+	err := r.data.DB(ctx).WithContext(ctx).Where(query, args...).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("identity not found", detail)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get identity: %s", detail))
+	}
+
+	identity := &biz.Identity{}
+	util.StructAssign(identity, record)
+	return identity, nil
+}
+
+func (r *identityRepo) ListIdentities(ctx context.Context, userId int32) ([]*biz.Identity, error) {
+	list := make([]*UserIdentity, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"userId": userId}).Order("id").Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list identities: userId(%v)", userId))
+	}
+
+	identities := make([]*biz.Identity, 0, len(list))
+	for _, item := range list {
+		identity := &biz.Identity{}
+		util.StructAssign(identity, item)
+		identities = append(identities, identity)
+	}
+	return identities, nil
+}
+
+// CountIdentities 统计用户绑定的第三方账号，事务中调用时锁定这些记录
+// PostgreSQL不允许聚合查询加FOR UPDATE，锁定时查询记录id后计数
+func (r *identityRepo) CountIdentities(ctx context.Context, userId int32) (int64, error) {
+	ids := make([]int64, 0)
+	db := r.data.DB(ctx).WithContext(ctx).Model(&UserIdentity{}).Where(map[string]interface{}{"userId": userId})
+	if _, ok := ctx.Value(contextTxKey{}).(*gorm.DB); ok {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	err := db.Pluck("id", &ids).Error
+	if err != nil {
+		return 0, errors.Wrapf(err, fmt.Sprintf("fail to count identities: userId(%v)", userId))
+	}
+	return int64(len(ids)), nil
+}
+
+func (r *identityRepo) DeleteIdentity(ctx context.Context, id int64) error {
+	err := r.data.DB(ctx).WithContext(ctx).Where("id = ?", id).Delete(&UserIdentity{}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to delete identity: id(%v)", id))
+	}
+	return nil
+}
+
+// UpdateIdentityLogin 更新最近登录时间，第三方返回的邮箱有变化时一并更新
+func (r *identityRepo) UpdateIdentityLogin(ctx context.Context, id int64, email string) error {
+	updates := map[string]interface{}{"lastLoginTime": time.Now()}
+	if email != "" {
+		updates["email"] = email
+	}
+	err := r.data.DB(ctx).WithContext(ctx).Model(&UserIdentity{}).Where("id = ?", id).Updates(updates).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to update identity login: id(%v)", id))
+	}
+	return nil
+}
+
+// UseSAMLAssertion 记录已使用的断言，断言已使用过时返回false，防止重放
+func (r *identityRepo) UseSAMLAssertion(ctx context.Context, assertionId string, timeout time.Duration) (bool, error) {
+	ok, err := r.data.redisCli.SetNX(ctx, r.data.redisKey(fmt.Sprintf("%s_saml_assertion_%s", r.data.conf.UserLoginState, assertionId)), 1, timeout).Result()
+	if err != nil {
+		return false, errors.Wrapf(err, fmt.Sprintf("fail to use saml assertion: assertionId(%s)", assertionId))
+	}
+	return ok, nil
+}
+
+func (r *identityRepo) SetExternalLoginState(ctx context.Context, stateHash string, state *biz.ExternalLoginState, timeout time.Duration) error {
+	marshal, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("json marshal error: state(%v)", state))
+	}
+	err = r.data.redisCli.Set(ctx, r.externalLoginStateKey(stateHash), string(marshal), timeout).Err()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to set external login state: provider(%s)", state.Provider))
+	}
+	return nil
+}
+
+// TakeExternalLoginState 读取并删除state，保证只能回调一次
+func (r *identityRepo) TakeExternalLoginState(ctx context.Context, stateHash string) (*biz.ExternalLoginState, error) {
+	result, err := r.data.redisCli.GetDel(ctx, r.externalLoginStateKey(stateHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, kerrors.NotFound("external login state not found", "")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to take external login state")
+	}
+
+	state := &biz.ExternalLoginState{}
+	err = json.Unmarshal([]byte(result), state)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("json unmarshal error: state(%v)", result))
+	}
+	return state, nil
+}
+
+func (r *identityRepo) externalLoginStateKey(stateHash string) string {
+	return r.data.redisKey(fmt.Sprintf("%s_external_state_%s", r.data.conf.UserLoginState, stateHash))
+}
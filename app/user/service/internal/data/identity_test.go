@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPostgresSourceEnv 配置后第三方账号仓储测试同时在PostgreSQL上执行，需要一个可以执行迁移的空库
+const testPostgresSourceEnv = "USER_CENTER_TEST_POSTGRES_DSN"
+
+func TestIdentityRepoSQLite(t *testing.T) {
+	d, _ := newTestData(t, DriverSQLite, "")
+	testIdentityRepo(t, d)
+}
+
+func TestIdentityRepoPostgres(t *testing.T) {
+	source := os.Getenv(testPostgresSourceEnv)
+	if source == "" {
+		t.Skipf("%s not set", testPostgresSourceEnv)
+	}
+	d, _ := newTestData(t, DriverPostgres, source)
+	testIdentityRepo(t, d)
+}
+
+// testIdentityRepo 事务中锁定并统计用户的第三方账号，subject带时间戳，可以在已有数据的库中重复执行
+func testIdentityRepo(t *testing.T, d *Data) {
+	ctx := context.Background()
+	repo := NewIdentityRepo(d, testLogger)
+	suffix := time.Now().UnixNano()
+	userId := int32(suffix % 1000000000)
+	for _, provider := range []string{"github", "google"} {
+		err := repo.CreateIdentity(ctx, &biz.Identity{UserId: userId, Provider: provider, Subject: fmt.Sprintf("sub_%d", suffix), LastLoginTime: time.Now()})
+		if err != nil {
+			t.Fatalf("create identity: %v", err)
+		}
+	}
+
+	count, err := repo.CountIdentities(ctx, userId)
+	if err != nil || count != 2 {
+		t.Fatalf("count identities: count(%v), error(%v)", count, err)
+	}
+	identities, err := repo.ListIdentities(ctx, userId)
+	if err != nil || len(identities) != 2 {
+		t.Fatalf("list identities: identities(%v), error(%v)", identities, err)
+	}
+
+	err = NewTransaction(d).ExecTx(ctx, func(ctx context.Context) error {
+		count, err := repo.CountIdentities(ctx, userId)
+		if err != nil {
+			return err
+		}
+		if count != 2 {
+			return fmt.Errorf("count identities in transaction: %v", count)
+		}
+		return repo.DeleteIdentity(ctx, identities[0].Id)
+	})
+	if err != nil {
+		t.Fatalf("count identities in transaction: %v", err)
+	}
+	count, err = repo.CountIdentities(ctx, userId)
+	if err != nil || count != 1 {
+		t.Fatalf("count identities after delete: count(%v), error(%v)", count, err)
+	}
+}
+
+// TestIdentityCountLockingSQL 事务中的计数按PostgreSQL方言生成，锁定查询不能是聚合查询
+func TestIdentityCountLockingSQL(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 user=user_center dbname=user_center"), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("fail to open postgres dialect: %v", err)
+	}
+	var statements []string
+	err = db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+		statements = append(statements, db.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("fail to register callback: %v", err)
+	}
+
+	repo := NewIdentityRepo(&Data{db: db}, testLogger)
+	ctx := context.WithValue(context.Background(), contextTxKey{}, db)
+	_, err = repo.CountIdentities(ctx, 1)
+	if err != nil {
+		t.Fatalf("count identities: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("statements: %v", statements)
+	}
+	statement := strings.ToLower(statements[0])
+	if !strings.Contains(statement, "for update") || strings.Contains(statement, "count(") {
+		t.Fatalf("locking count statement: %s", statements[0])
+	}
+}
@@ -0,0 +1,143 @@
+package data
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net"
+	"net/url"
+	"time"
+)
+
+var _ biz.Directory = (*ldapDirectory)(nil)
+
+const defaultLDAPTimeout = 5 * time.Second
+
+type ldapDirectory struct {
+	conf *conf.LDAP
+	log  *log.Helper
+}
+
+func NewDirectory(conf *conf.LDAP, logger log.Logger) biz.Directory {
+	return &ldapDirectory{
+		conf: conf,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/ldap")),
+	}
+}
+
+// Authenticate 先搜索再绑定
+//1. 使用服务账号搜索登录账号对应的用户，没有找到时返回NotFound
+//2. 使用用户的DN和密码绑定，绑定成功即认证通过
+func (d *ldapDirectory) Authenticate(ctx context.Context, userAccount, userPassword string) (*biz.DirectoryEntry, error) {
+	// 空密码会被目录服务视为匿名绑定而成功
+	if userPassword == "" {
+		return nil, errors.Errorf("empty ldap password: userAccount(%s)", userAccount)
+	}
+
+	conn, err := d.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if d.conf.GetBindDn() != "" {
+		err = conn.Bind(d.conf.GetBindDn(), d.conf.GetBindPassword())
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to bind ldap service account: bindDn(%s)", d.conf.GetBindDn()))
+		}
+	}
+
+	filter := d.conf.GetUserFilter()
+	if filter == "" {
+		filter = "(uid=%s)"
+	}
+	nameAttribute := attributeOr(d.conf.GetNameAttribute(), "cn")
+	emailAttribute := attributeOr(d.conf.GetEmailAttribute(), "mail")
+	phoneAttribute := attributeOr(d.conf.GetPhoneAttribute(), "telephoneNumber")
+	groupAttribute := attributeOr(d.conf.GetGroupAttribute(), "memberOf")
+	result, err := conn.Search(ldap.NewSearchRequest(
+		d.conf.GetBaseDn(), ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(d.timeout().Seconds()), false,
+		fmt.Sprintf(filter, ldap.EscapeFilter(userAccount)),
+		[]string{"entryUUID", "objectGUID", nameAttribute, emailAttribute, phoneAttribute, groupAttribute},
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to search ldap user: userAccount(%s)", userAccount))
+	}
+	switch {
+	case result == nil || len(result.Entries) == 0:
+		return nil, kerrors.NotFound("ldap user not found", fmt.Sprintf("userAccount(%s)", userAccount))
+	case len(result.Entries) > 1:
+		return nil, errors.Errorf("ldap user not unique: userAccount(%s)", userAccount)
+	}
+
+	entry := result.Entries[0]
+	err = conn.Bind(entry.DN, userPassword)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, errors.Errorf("ldap invalid credentials: userAccount(%s)", userAccount)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to bind ldap user: dn(%s)", entry.DN))
+	}
+
+	id := entry.GetAttributeValue("entryUUID")
+	if guid := entry.GetRawAttributeValue("objectGUID"); id == "" && len(guid) > 0 {
+		id = hex.EncodeToString(guid)
+	}
+	if id == "" {
+		id = entry.DN
+	}
+	return &biz.DirectoryEntry{
+		Id:       id,
+		Dn:       entry.DN,
+		UserName: entry.GetAttributeValue(nameAttribute),
+		Email:    entry.GetAttributeValue(emailAttribute),
+		Phone:    entry.GetAttributeValue(phoneAttribute),
+		Groups:   entry.GetAttributeValues(groupAttribute),
+	}, nil
+}
+
+func (d *ldapDirectory) dial() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: d.conf.GetInsecureSkipVerify()}
+	conn, err := ldap.DialURL(d.conf.GetUrl(),
+		ldap.DialWithDialer(&net.Dialer{Timeout: d.timeout()}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to dial ldap: url(%s)", d.conf.GetUrl()))
+	}
+	conn.SetTimeout(d.timeout())
+
+	if d.conf.GetStartTls() {
+		if location, err := url.Parse(d.conf.GetUrl()); err == nil {
+			tlsConfig.ServerName = location.Hostname()
+		}
+		err = conn.StartTLS(tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to start tls: url(%s)", d.conf.GetUrl()))
+		}
+	}
+	return conn, nil
+}
+
+func (d *ldapDirectory) timeout() time.Duration {
+	if d.conf.GetTimeout() == nil {
+		return defaultLDAPTimeout
+	}
+	return d.conf.GetTimeout().AsDuration()
+}
+
+func attributeOr(attribute, defaultAttribute string) string {
+	if attribute == "" {
+		return defaultAttribute
+	}
+	return attribute
+}
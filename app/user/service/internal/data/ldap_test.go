@@ -0,0 +1,438 @@
+package data
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testLDAPBaseDn          = "dc=example,dc=com"
+	testLDAPServiceDn       = "cn=service,dc=example,dc=com"
+	testLDAPServicePassword = "service-secret"
+	testLDAPAdminGroup      = "cn=admins,ou=groups,dc=example,dc=com"
+)
+
+// testLDAPServer 进程内的LDAPv3目录服务，只实现简单绑定和搜索
+//1. 绑定：条目DN和密码匹配时成功，DN和密码都为空时为匿名绑定
+//2. 搜索：必须先以服务账号绑定，过滤条件支持与、或、等值和存在判断，超过sizeLimit时返回sizeLimitExceeded
+type testLDAPServer struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	entries []*testLDAPEntry
+	binds   []string
+}
+
+type testLDAPEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+func newTestLDAPServer(t *testing.T, entries ...*testLDAPEntry) *testLDAPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("fail to listen: %v", err)
+	}
+	server := &testLDAPServer{listener: listener}
+	server.entries = append(server.entries, &testLDAPEntry{dn: testLDAPServiceDn, password: testLDAPServicePassword})
+	server.entries = append(server.entries, entries...)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { _ = listener.Close() })
+	return server
+}
+
+func (s *testLDAPServer) url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// setAttribute 修改目录中的条目
+func (s *testLDAPServer) setAttribute(dn, name string, values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if entry.dn == dn {
+			entry.attributes[name] = values
+		}
+	}
+}
+
+// bindCount 以dn绑定的次数
+func (s *testLDAPServer) bindCount(dn string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, bound := range s.binds {
+		if bound == dn {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *testLDAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	bound := ""
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageId := packet.Children[0].Value
+		request := packet.Children[1]
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, password := request.Children[1].Data.String(), request.Children[2].Data.String()
+			code := s.bind(dn, password)
+			if code == ldap.LDAPResultSuccess {
+				bound = dn
+			}
+			_, _ = conn.Write(ldapResponse(messageId, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationSearchRequest:
+			if bound != testLDAPServiceDn {
+				_, _ = conn.Write(ldapResponse(messageId, ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights).Bytes())
+				continue
+			}
+			for _, response := range s.search(messageId, request) {
+				_, _ = conn.Write(response.Bytes())
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (s *testLDAPServer) bind(dn, password string) uint16 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.binds = append(s.binds, dn)
+	if dn == "" && password == "" {
+		return ldap.LDAPResultSuccess
+	}
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.dn, dn) && entry.password != "" && entry.password == password {
+			return ldap.LDAPResultSuccess
+		}
+	}
+	return ldap.LDAPResultInvalidCredentials
+}
+
+func (s *testLDAPServer) search(messageId interface{}, request *ber.Packet) []*ber.Packet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	baseDn := strings.ToLower(request.Children[0].Data.String())
+	sizeLimit := request.Children[3].Value.(int64)
+	filter := request.Children[6]
+	var attributes []string
+	for _, child := range request.Children[7].Children {
+		attributes = append(attributes, child.Data.String())
+	}
+
+	var responses []*ber.Packet
+	code := uint16(ldap.LDAPResultSuccess)
+	for _, entry := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.dn), baseDn) || !entry.match(filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(responses)) >= sizeLimit {
+			code = ldap.LDAPResultSizeLimitExceeded
+			break
+		}
+		responses = append(responses, entry.packet(messageId, attributes))
+	}
+	return append(responses, ldapResponse(messageId, ldap.ApplicationSearchResultDone, code))
+}
+
+func (e *testLDAPEntry) match(filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !e.match(child) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if e.match(child) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterEqualityMatch:
+		for _, value := range e.values(filter.Children[0].Data.String()) {
+			if strings.EqualFold(value, filter.Children[1].Data.String()) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(e.values(filter.Data.String())) > 0
+	}
+	return false
+}
+
+func (e *testLDAPEntry) values(name string) []string {
+	for attribute, values := range e.attributes {
+		if strings.EqualFold(attribute, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+func (e *testLDAPEntry) packet(messageId interface{}, attributes []string) *ber.Packet {
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, ""))
+	list := ber.NewSequence("")
+	for _, name := range attributes {
+		values := e.values(name)
+		if len(values) == 0 {
+			continue
+		}
+		attribute := ber.NewSequence("")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+		attribute.AppendChild(set)
+		list.AppendChild(attribute)
+	}
+	entry.AppendChild(list)
+	return ldapMessage(messageId, entry)
+}
+
+func ldapResponse(messageId interface{}, tag ber.Tag, code uint16) *ber.Packet {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	return ldapMessage(messageId, response)
+}
+
+func ldapMessage(messageId interface{}, op *ber.Packet) *ber.Packet {
+	message := ber.NewSequence("")
+	message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageId, ""))
+	message.AppendChild(op)
+	return message
+}
+
+func testLDAPUser(uid, uuid, password string, groups ...string) *testLDAPEntry {
+	return &testLDAPEntry{
+		dn:       fmt.Sprintf("uid=%s,ou=people,%s", uid, testLDAPBaseDn),
+		password: password,
+		attributes: map[string][]string{
+			"objectClass":     {"inetOrgPerson"},
+			"uid":             {uid},
+			"entryUUID":       {uuid},
+			"cn":              {strings.ToUpper(uid)},
+			"mail":            {uid + "@example.com"},
+			"telephoneNumber": {"13800000000"},
+			"memberOf":        groups,
+		},
+	}
+}
+
+func testLDAPConf(server *testLDAPServer) *conf.LDAP {
+	return &conf.LDAP{
+		Url:          server.url(),
+		BindDn:       testLDAPServiceDn,
+		BindPassword: testLDAPServicePassword,
+		BaseDn:       testLDAPBaseDn,
+		GroupRoles:   map[string]int32{testLDAPAdminGroup: 1},
+	}
+}
+
+func TestLDAPDirectoryAuthenticate(t *testing.T) {
+	twin := testLDAPUser("twin", "uuid-twin-2", "twin-secret")
+	twin.dn = "uid=twin,ou=staff," + testLDAPBaseDn
+	server := newTestLDAPServer(t,
+		testLDAPUser("alice", "uuid-alice", "alice-secret", testLDAPAdminGroup, "cn=staff,ou=groups,dc=example,dc=com"),
+		testLDAPUser("twin", "uuid-twin-1", "twin-secret"),
+		twin,
+		&testLDAPEntry{dn: "uid=other,ou=people,dc=other,dc=com", password: "other-secret", attributes: map[string][]string{"uid": {"other"}}},
+	)
+	directory := NewDirectory(testLDAPConf(server), testLogger)
+	ctx := context.Background()
+
+	entry, err := directory.Authenticate(ctx, "alice", "alice-secret")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if entry.Id != "uuid-alice" || entry.Dn != "uid=alice,ou=people,"+testLDAPBaseDn || entry.UserName != "ALICE" ||
+		entry.Email != "alice@example.com" || entry.Phone != "13800000000" || len(entry.Groups) != 2 {
+		t.Fatalf("directory entry: %+v", entry)
+	}
+
+	_, err = directory.Authenticate(ctx, "alice", "wrong")
+	if err == nil || kerrors.IsNotFound(err) || !strings.Contains(err.Error(), "invalid credentials") {
+		t.Fatalf("wrong password: %v", err)
+	}
+
+	// 目录中没有的用户返回NotFound，由下一个认证后端继续认证
+	_, err = directory.Authenticate(ctx, "nobody", "secret")
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("unknown user: %v", err)
+	}
+	_, err = directory.Authenticate(ctx, "other", "other-secret")
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("user outside base dn: %v", err)
+	}
+
+	// 登录账号中的过滤条件特殊字符被转义，不能匹配任意用户
+	for _, account := range []string{"*", "alice)(uid=*", "*)(|(uid=*"} {
+		_, err = directory.Authenticate(ctx, account, "alice-secret")
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("filter injection %q: %v", account, err)
+		}
+	}
+
+	_, err = directory.Authenticate(ctx, "twin", "twin-secret")
+	if err == nil || !strings.Contains(err.Error(), "not unique") {
+		t.Fatalf("duplicate user: %v", err)
+	}
+}
+
+func TestLDAPDirectoryRejectsEmptyPassword(t *testing.T) {
+	server := newTestLDAPServer(t, testLDAPUser("alice", "uuid-alice", "alice-secret"))
+	c := testLDAPConf(server)
+	c.BindDn, c.BindPassword = "", ""
+	directory := NewDirectory(c, testLogger)
+
+	// 目录服务把空密码视为匿名绑定，不能发出绑定请求
+	_, err := directory.Authenticate(context.Background(), "alice", "")
+	if err == nil {
+		t.Fatalf("empty password accepted")
+	}
+	if server.bindCount("uid=alice,ou=people,"+testLDAPBaseDn) != 0 || server.bindCount("") != 0 {
+		t.Fatalf("empty password sent to directory")
+	}
+}
+
+func TestLDAPDirectoryServiceAccount(t *testing.T) {
+	server := newTestLDAPServer(t, testLDAPUser("alice", "uuid-alice", "alice-secret"))
+	c := testLDAPConf(server)
+	c.BindPassword = "wrong"
+	_, err := NewDirectory(c, testLogger).Authenticate(context.Background(), "alice", "alice-secret")
+	if err == nil || kerrors.IsNotFound(err) || !strings.Contains(err.Error(), "service account") {
+		t.Fatalf("wrong service password: %v", err)
+	}
+	if server.bindCount("uid=alice,ou=people,"+testLDAPBaseDn) != 0 {
+		t.Fatalf("user bound without search")
+	}
+}
+
+func TestLDAPDirectoryActiveDirectory(t *testing.T) {
+	guid := []byte{0x10, 0x32, 0x54, 0x76, 0x98, 0xba, 0xdc, 0xfe, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+	server := newTestLDAPServer(t, &testLDAPEntry{
+		dn:       "CN=Bob,OU=Users," + testLDAPBaseDn,
+		password: "bob-secret",
+		attributes: map[string][]string{
+			"objectClass":       {"user"},
+			"sAMAccountName":    {"bob"},
+			"objectGUID":        {string(guid)},
+			"displayName":       {"Bob"},
+			"userPrincipalName": {"bob@example.com"},
+		},
+	})
+	c := testLDAPConf(server)
+	c.UserFilter = "(&(objectClass=user)(sAMAccountName=%s))"
+	c.NameAttribute = "displayName"
+	c.EmailAttribute = "userPrincipalName"
+	entry, err := NewDirectory(c, testLogger).Authenticate(context.Background(), "bob", "bob-secret")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if entry.Id != hex.EncodeToString(guid) || entry.UserName != "Bob" || entry.Email != "bob@example.com" {
+		t.Fatalf("directory entry: %+v", entry)
+	}
+}
+
+// newTestLDAPAuthenticator 目录认证后端，本地用户保存在SQLite中
+func newTestLDAPAuthenticator(t *testing.T, server *testLDAPServer) (*biz.LDAPAuthenticator, *Data) {
+	d, _ := newTestData(t, DriverSQLite, "")
+	uconf := testUserConstant()
+	userRepo := NewUserRepo(d, testLogger)
+	audit := biz.NewAuditRecorder(NewAuditRepo(d, testLogger), testLogger)
+	webhook := biz.NewWebhookUseCase(NewWebhookRepo(d, testLogger), userRepo, NewWebhookSender(), audit, testLogger, uconf)
+	event := biz.NewEventUseCase(NewOutboxRepo(d, testLogger), NewUserChangeRepo(d, testLogger), webhook, testLogger)
+	ldapConf := testLDAPConf(server)
+	return biz.NewLDAPAuthenticator(NewDirectory(ldapConf, testLogger), NewIdentityRepo(d, testLogger), NewAuthRepo(d, testLogger),
+		userRepo, NewTransaction(d), event, audit, ldapConf, uconf, testLogger), d
+}
+
+func TestLDAPAuthenticatorProvisioning(t *testing.T) {
+	server := newTestLDAPServer(t, testLDAPUser("alice", "uuid-alice", "alice-secret", testLDAPAdminGroup))
+	authenticator, d := newTestLDAPAuthenticator(t, server)
+	ctx := context.Background()
+
+	// 首次登录开通本地用户，角色按所属组映射
+	user, err := authenticator.Authenticate(ctx, "alice", "alice-secret")
+	if err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	if user.UserAccount != "alice" || user.Email != "alice@example.com" || user.Role != 1 || user.UserPassword != "" {
+		t.Fatalf("provisioned user: %+v", user)
+	}
+	identity, err := NewIdentityRepo(d, testLogger).GetIdentity(ctx, biz.AuthenticatorLDAP, "uuid-alice")
+	if err != nil || identity.UserId != user.Id {
+		t.Fatalf("ldap identity: identity(%+v), error(%v)", identity, err)
+	}
+
+	// 离开管理员组后再次登录时同步角色
+	server.setAttribute("uid=alice,ou=people,"+testLDAPBaseDn, "memberOf")
+	again, err := authenticator.Authenticate(ctx, "alice", "alice-secret")
+	if err != nil || again.Id != user.Id || again.Role != 0 {
+		t.Fatalf("role sync: user(%+v), error(%v)", again, err)
+	}
+	stored, err := NewUserRepo(d, testLogger).GetCurrentUser(ctx, user.Id)
+	if err != nil || stored.Role != 0 {
+		t.Fatalf("role after sync: user(%+v), error(%v)", stored, err)
+	}
+
+	// 目录中改名后按entryUUID找到同一个本地用户
+	server.setAttribute("uid=alice,ou=people,"+testLDAPBaseDn, "uid", "alice2")
+	renamed, err := authenticator.Authenticate(ctx, "alice2", "alice-secret")
+	if err != nil || renamed.Id != user.Id {
+		t.Fatalf("renamed user: user(%+v), error(%v)", renamed, err)
+	}
+}
+
+func TestLDAPAuthenticatorAccountConflict(t *testing.T) {
+	server := newTestLDAPServer(t, testLDAPUser("carol", "uuid-carol", "carol-secret"))
+	authenticator, d := newTestLDAPAuthenticator(t, server)
+	ctx := context.Background()
+	_, err := NewAuthRepo(d, testLogger).CreateUser(ctx, &biz.User{UserAccount: "carol", UserPassword: biz.PasswordHash("local")})
+	if err != nil {
+		t.Fatalf("create local user: %v", err)
+	}
+
+	// 目录用户不能接管同名的本地账号
+	_, err = authenticator.Authenticate(ctx, "carol", "carol-secret")
+	if err == nil || !strings.Contains(err.Error(), "not linked to ldap") {
+		t.Fatalf("account conflict: %v", err)
+	}
+	_, err = NewIdentityRepo(d, testLogger).GetIdentity(ctx, biz.AuthenticatorLDAP, "uuid-carol")
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("identity created for conflicting account: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var _ biz.LoginHistoryRepo = (*loginHistoryRepo)(nil)
+
+type loginHistoryRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewLoginHistoryRepo(data *Data, logger log.Logger) biz.LoginHistoryRepo {
+	return &loginHistoryRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/login-history")),
+	}
+}
+
+func (r *loginHistoryRepo) CreateLoginHistory(ctx context.Context, history *biz.LoginHistory) error {
+	record := &LoginHistory{}
+	util.StructAssign(record, history)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create login history: userAccount(%s)", history.UserAccount))
+	}
+	return nil
+}
+
+// ListLoginHistory 分页查询登录历史，userId为0时查询全部用户
+func (r *loginHistoryRepo) ListLoginHistory(ctx context.Context, userId, page, pageSize int32) ([]*biz.LoginHistory, int64, error) {
+	db := r.data.DB(ctx).WithContext(ctx).Model(&LoginHistory{})
+	if userId != 0 {
+		db = db.Where(map[string]interface{}{"userId": userId})
+	}
+
+	var total int64
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to count login history: userId(%v)", userId))
+	}
+	list := make([]*LoginHistory, 0)
+	err = db.Order("id desc").Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to list login history: userId(%v)", userId))
+	}
+
+	result := make([]*biz.LoginHistory, 0, len(list))
+	for _, item := range list {
+		history := &biz.LoginHistory{}
+		util.StructAssign(history, item)
+		result = append(result, history)
+	}
+	return result, total, nil
+}
+
+// ListSuccessLoginHistory 查询用户最近的成功登录
+func (r *loginHistoryRepo) ListSuccessLoginHistory(ctx context.Context, userId int32, limit int) ([]*biz.LoginHistory, error) {
+	list := make([]*LoginHistory, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"userId": userId, "success": true}).Order("id desc").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list success login history: userId(%v)", userId))
+	}
+
+	result := make([]*biz.LoginHistory, 0, len(list))
+	for _, item := range list {
+		history := &biz.LoginHistory{}
+		util.StructAssign(history, item)
+		result = append(result, history)
+	}
+	return result, nil
+}
+
+func (r *loginHistoryRepo) PruneLoginHistory(ctx context.Context, before time.Time) (int64, error) {
+	result := r.data.DB(ctx).WithContext(ctx).Where(clause.Lt{Column: "createTime", Value: before}).Delete(&LoginHistory{})
+	if result.Error != nil {
+		return 0, errors.Wrapf(result.Error, fmt.Sprintf("fail to prune login history: before(%v)", before))
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"testing"
+	"time"
+)
+
+// TestLoginHistoryPruneJob 清理任务按保留时间删除过期的登录历史，未配置保留时间时不删除
+func TestLoginHistoryPruneJob(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestData(t, DriverSQLite, "")
+	repo := NewLoginHistoryRepo(d, testLogger)
+	create := func(userAccount string, createTime time.Time) {
+		t.Helper()
+		err := repo.CreateLoginHistory(ctx, &biz.LoginHistory{UserId: 1, UserAccount: userAccount, Method: biz.LoginMethodPassword, Success: true, CreateTime: createTime})
+		if err != nil {
+			t.Fatalf("create login history: %v", err)
+		}
+	}
+	accounts := func() []string {
+		t.Helper()
+		list, _, err := repo.ListLoginHistory(ctx, 0, 1, 10)
+		if err != nil {
+			t.Fatalf("list login history: %v", err)
+		}
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			result = append(result, item.UserAccount)
+		}
+		return result
+	}
+	create("expired", time.Now().Add(-48*time.Hour))
+	create("recent", time.Now().Add(-time.Hour))
+	create("now", time.Time{})
+
+	uconf := testUserConstant()
+	job := biz.NewLoginHistoryUseCase(repo, testLogger, uconf).PruneJob()
+	err := job.Run(ctx)
+	if err != nil {
+		t.Fatalf("prune without retention: %v", err)
+	}
+	if got := accounts(); len(got) != 3 {
+		t.Fatalf("login history without retention: %v", got)
+	}
+
+	uconf.LoginHistoryRetention = int64((24 * time.Hour).Seconds())
+	err = job.Run(ctx)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if got := accounts(); len(got) != 2 || got[0] != "now" || got[1] != "recent" {
+		t.Fatalf("login history after prune: %v", got)
+	}
+}
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+var _ biz.Mailer = (*mailer)(nil)
+
+type mailer struct {
+	conf *conf.Data_Mail
+	log  *log.Helper
+}
+
+func NewMailer(conf *conf.Data, logger log.Logger) biz.Mailer {
+	return &mailer{
+		conf: conf.Mail,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/mail")),
+	}
+}
+
+// Send 发送邮件，未配置smtp服务时仅打印日志
+func (m *mailer) Send(_ context.Context, to, subject, body string) error {
+	if m.conf == nil || m.conf.Host == "" {
+		m.log.Infof("smtp not configured, skip mail: to(%s), subject(%s)", to, subject)
+		return nil
+	}
+
+	var auth smtp.Auth
+	if m.conf.Username != "" {
+		auth = smtp.PlainAuth("", m.conf.Username, m.conf.Password, m.conf.Host)
+	}
+	msg := strings.Join([]string{
+		fmt.Sprintf("From: %s", m.conf.From),
+		fmt.Sprintf("To: %s", to),
+		fmt.Sprintf("Subject: %s", subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+	addr := net.JoinHostPort(m.conf.Host, strconv.Itoa(int(m.conf.Port)))
+	err := smtp.SendMail(addr, auth, m.conf.From, []string{to}, []byte(msg))
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to send mail: to(%s), subject(%s)", to, subject))
+	}
+	return nil
+}
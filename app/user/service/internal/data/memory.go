@@ -0,0 +1,763 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ biz.AuthRepo     = (*memoryAuthRepo)(nil)
+	_ biz.UserRepo     = (*memoryUserRepo)(nil)
+	_ biz.IdentityRepo = (*memoryIdentityRepo)(nil)
+	_ biz.OAuthRepo    = (*memoryOAuthRepo)(nil)
+	_ biz.Transaction  = (*MemoryStore)(nil)
+	_ biz.Transaction  = (*memoryTransaction)(nil)
+)
+
+type memoryTxKey struct{}
+
+// MemoryStore 内存仓储共享的数据
+//1. 用户和审核记录对应数据库，在事务中修改，事务失败时回滚
+//2. 登录态、验证码等对应Redis，带过期时间，与数据库一样不随事务回滚
+//3. 写数据库的操作和事务串行执行，事务中可以读到其他事务未提交的修改
+type MemoryStore struct {
+	mu             sync.Mutex
+	txMu           sync.Mutex
+	users          map[int32]*User
+	approvals      []*UserApproval
+	nextUserId     int32
+	nextApprovalId int32
+	cache          map[string]*memoryCacheItem
+}
+
+type memoryCacheItem struct {
+	value      interface{}
+	expireTime time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users: make(map[int32]*User),
+		cache: make(map[string]*memoryCacheItem),
+	}
+}
+
+// ExecTx 事务串行执行，fn返回错误或panic时恢复到事务开始时的数据，嵌套事务相当于保存点
+func (s *MemoryStore) ExecTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if ctx.Value(memoryTxKey{}) == nil {
+		s.txMu.Lock()
+		defer s.txMu.Unlock()
+		ctx = context.WithValue(ctx, memoryTxKey{}, true)
+	}
+
+	s.mu.Lock()
+	users, approvals, nextUserId, nextApprovalId := s.snapshot()
+	s.mu.Unlock()
+	rollback := func() {
+		s.mu.Lock()
+		s.users, s.approvals, s.nextUserId, s.nextApprovalId = users, approvals, nextUserId, nextApprovalId
+		s.mu.Unlock()
+	}
+	defer func() {
+		if rerr := recover(); rerr != nil {
+			rollback()
+			panic(rerr)
+		}
+	}()
+
+	err = fn(ctx)
+	if err != nil {
+		rollback()
+	}
+	return err
+}
+
+func (s *MemoryStore) snapshot() (map[int32]*User, []*UserApproval, int32, int32) {
+	users := make(map[int32]*User, len(s.users))
+	for id, user := range s.users {
+		record := *user
+		users[id] = &record
+	}
+	approvals := make([]*UserApproval, 0, len(s.approvals))
+	for _, approval := range s.approvals {
+		record := *approval
+		approvals = append(approvals, &record)
+	}
+	return users, approvals, s.nextUserId, s.nextApprovalId
+}
+
+// write 修改数据库部分的数据，事务外的修改等待正在执行的事务结束
+func (s *MemoryStore) write(ctx context.Context, fn func()) {
+	if ctx.Value(memoryTxKey{}) == nil {
+		s.txMu.Lock()
+		defer s.txMu.Unlock()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+func (s *MemoryStore) locked(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+// findUsers 按id顺序返回满足条件的用户副本
+func (s *MemoryStore) findUsers(match func(user *User) bool) []*User {
+	list := make([]*User, 0)
+	s.locked(func() {
+		for _, user := range s.users {
+			if match(user) {
+				record := *user
+				list = append(list, &record)
+			}
+		}
+	})
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Id < list[j].Id
+	})
+	return list
+}
+
+func (s *MemoryStore) createUser(ctx context.Context, record *User) int32 {
+	s.write(ctx, func() {
+		s.nextUserId++
+		record.Id = s.nextUserId
+		record.CreateTime = time.Now()
+		record.UpdateTime = record.CreateTime
+		s.users[record.Id] = record
+	})
+	return record.Id
+}
+
+// updateUser 修改满足条件的用户，返回修改的行数
+func (s *MemoryStore) updateUser(ctx context.Context, match func(user *User) bool, update func(user *User)) int64 {
+	var rows int64
+	s.write(ctx, func() {
+		for _, user := range s.users {
+			if match(user) {
+				update(user)
+				user.UpdateTime = time.Now()
+				rows++
+			}
+		}
+	})
+	return rows
+}
+
+func (s *MemoryStore) setCache(key string, value interface{}, timeout time.Duration) {
+	item := &memoryCacheItem{value: value}
+	if timeout > 0 {
+		item.expireTime = time.Now().Add(timeout)
+	}
+	s.locked(func() {
+		s.cache[key] = item
+	})
+}
+
+// setCacheNX 键不存在或已过期时写入，返回是否写入成功
+func (s *MemoryStore) setCacheNX(key string, value interface{}, timeout time.Duration) bool {
+	var ok bool
+	s.locked(func() {
+		item, exist := s.cache[key]
+		if exist && (item.expireTime.IsZero() || time.Now().Before(item.expireTime)) {
+			return
+		}
+		item = &memoryCacheItem{value: value}
+		if timeout > 0 {
+			item.expireTime = time.Now().Add(timeout)
+		}
+		s.cache[key] = item
+		ok = true
+	})
+	return ok
+}
+
+// getCache 读取未过期的缓存，take为true时读取后删除
+func (s *MemoryStore) getCache(key string, take bool) (interface{}, bool) {
+	var value interface{}
+	var ok bool
+	s.locked(func() {
+		item, exist := s.cache[key]
+		if !exist {
+			return
+		}
+		if !item.expireTime.IsZero() && !time.Now().Before(item.expireTime) {
+			delete(s.cache, key)
+			return
+		}
+		if take {
+			delete(s.cache, key)
+		}
+		value, ok = item.value, true
+	})
+	return value, ok
+}
+
+// incrCache 计数加一，不存在或已过期时从1开始并设置过期时间
+func (s *MemoryStore) incrCache(key string, timeout time.Duration) int64 {
+	var count int64
+	s.locked(func() {
+		item, ok := s.cache[key]
+		if ok && (item.expireTime.IsZero() || time.Now().Before(item.expireTime)) {
+			count = item.value.(int64) + 1
+			item.value = count
+			return
+		}
+		count = 1
+		item = &memoryCacheItem{value: count}
+		if timeout > 0 {
+			item.expireTime = time.Now().Add(timeout)
+		}
+		s.cache[key] = item
+	})
+	return count
+}
+
+func (s *MemoryStore) deleteCache(key string) {
+	s.locked(func() {
+		delete(s.cache, key)
+	})
+}
+
+// seedAdmin 创建初始管理员，内存数据每次启动都是空的，随机密码只在启动日志中输出一次
+func (s *MemoryStore) seedAdmin(conf *conf.UserConstant, l *log.Helper) error {
+	password, err := randomPassword(16)
+	if err != nil {
+		return err
+	}
+	s.createUser(context.Background(), &User{
+		UserAccount:  "admin",
+		UserPassword: biz.PasswordHash(password),
+		UserName:     "admin",
+		Role:         conf.GetAdminRole(),
+	})
+	l.Infof("initial admin password: %s, please change it after first login, it will not be shown again", password)
+	return nil
+}
+
+// usersById 按id查询未删除的用户，不存在的id不返回
+func (s *MemoryStore) usersById(userIds []int32) map[int32]*User {
+	ids := make(map[int32]bool, len(userIds))
+	for _, userId := range userIds {
+		ids[userId] = true
+	}
+	users := make(map[int32]*User, len(userIds))
+	for _, user := range s.findUsers(func(user *User) bool {
+		return ids[user.Id] && user.IsDelete == 0
+	}) {
+		users[user.Id] = user
+	}
+	return users
+}
+
+func sessionKey(userId int32) string {
+	return fmt.Sprintf("session_%v", userId)
+}
+
+type memoryAuthRepo struct {
+	store *MemoryStore
+	conf  *conf.UserConstant
+	log   *log.Helper
+}
+
+func NewMemoryAuthRepo(store *MemoryStore, conf *conf.UserConstant, logger log.Logger) biz.AuthRepo {
+	return &memoryAuthRepo{
+		store: store,
+		conf:  conf,
+		log:   log.NewHelper(log.With(logger, "module", "user/data/memory-auth")),
+	}
+}
+
+func (r *memoryAuthRepo) AccountExist(ctx context.Context, userAccount string) (bool, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		return user.UserAccount == userAccount && user.IsDelete == 0
+	})
+	return len(list) > 0, nil
+}
+
+func (r *memoryAuthRepo) UserRegister(ctx context.Context, userAccount, passwordHash, email string, userStatus int32) (int32, error) {
+	return r.store.createUser(ctx, &User{
+		UserAccount:  userAccount,
+		UserPassword: passwordHash,
+		Email:        email,
+		UserStatus:   userStatus,
+	}), nil
+}
+
+// CreateUser 创建第三方登录、LDAP、SCIM等方式开通的用户和服务账号
+func (r *memoryAuthRepo) CreateUser(ctx context.Context, user *biz.User) (int32, error) {
+	return r.store.createUser(ctx, &User{
+		UserAccount:  user.UserAccount,
+		UserPassword: user.UserPassword,
+		UserName:     user.UserName,
+		AvatarUrl:    user.AvatarUrl,
+		Email:        user.Email,
+		Phone:        user.Phone,
+		UserStatus:   user.UserStatus,
+		Role:         user.Role,
+		UserType:     user.UserType,
+		OwnerId:      user.OwnerId,
+	}), nil
+}
+
+func (r *memoryAuthRepo) UserLogin(ctx context.Context, userAccount, passwordHash string) (*biz.User, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		return user.UserAccount == userAccount && user.UserPassword == passwordHash && user.IsDelete == 0
+	})
+	if len(list) == 0 {
+		return nil, kerrors.NotFound("user not found", fmt.Sprintf("userAccount(%s)", userAccount))
+	}
+	result := &biz.User{}
+	util.StructAssign(result, list[0])
+	return result, nil
+}
+
+func (r *memoryAuthRepo) UserLogout(ctx context.Context, userId int32) error {
+	r.store.deleteCache(sessionKey(userId))
+	return nil
+}
+
+func (r *memoryAuthRepo) SetLoginSession(ctx context.Context, user *biz.User) error {
+	session := &User{}
+	util.StructAssign(session, user)
+	r.store.setCache(sessionKey(user.Id), session, time.Second*time.Duration(r.conf.SessionTimeout))
+	return nil
+}
+
+func (r *memoryAuthRepo) SetLoginVerifyCode(ctx context.Context, userId int32, fingerprint, code string, timeout time.Duration) error {
+	r.store.setCache(fmt.Sprintf("verify_%v_%s", userId, fingerprint), code, timeout)
+	return nil
+}
+
+func (r *memoryAuthRepo) GetLoginVerifyCode(ctx context.Context, userId int32, fingerprint string) (string, error) {
+	code, ok := r.store.getCache(fmt.Sprintf("verify_%v_%s", userId, fingerprint), false)
+	if !ok {
+		return "", kerrors.NotFound("login verify code not found", fmt.Sprintf("userId(%v)", userId))
+	}
+	return code.(string), nil
+}
+
+func (r *memoryAuthRepo) DeleteLoginVerifyCode(ctx context.Context, userId int32, fingerprint string) error {
+	r.store.deleteCache(fmt.Sprintf("verify_%v_%s", userId, fingerprint))
+	return nil
+}
+
+func (r *memoryAuthRepo) SetMagicLink(ctx context.Context, tokenHash string, link *biz.MagicLink, timeout time.Duration) error {
+	record := *link
+	r.store.setCache("magic_link_"+tokenHash, &record, timeout)
+	return nil
+}
+
+// TakeMagicLink 取出并删除免密登录链接，保证只能使用一次
+func (r *memoryAuthRepo) TakeMagicLink(ctx context.Context, tokenHash string) (*biz.MagicLink, error) {
+	link, ok := r.store.getCache("magic_link_"+tokenHash, true)
+	if !ok {
+		return nil, kerrors.NotFound("magic link not found", "")
+	}
+	return link.(*biz.MagicLink), nil
+}
+
+// IncrMagicLinkRequest 免密登录链接请求计数加一，第一次计数时设置窗口过期时间
+func (r *memoryAuthRepo) IncrMagicLinkRequest(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return r.store.incrCache("magic_link_limit_"+key, window), nil
+}
+
+func (r *memoryAuthRepo) SetImpersonation(ctx context.Context, tokenHash string, impersonation *biz.Impersonation, timeout time.Duration) error {
+	record := *impersonation
+	r.store.setCache("impersonation_"+tokenHash, &record, timeout)
+	return nil
+}
+
+func (r *memoryAuthRepo) GetImpersonation(ctx context.Context, tokenHash string) (*biz.Impersonation, error) {
+	impersonation, ok := r.store.getCache("impersonation_"+tokenHash, false)
+	if !ok {
+		return nil, kerrors.NotFound("impersonation not found", "")
+	}
+	record := *impersonation.(*biz.Impersonation)
+	return &record, nil
+}
+
+func (r *memoryAuthRepo) DeleteImpersonation(ctx context.Context, tokenHash string) error {
+	r.store.deleteCache("impersonation_" + tokenHash)
+	return nil
+}
+
+func (r *memoryAuthRepo) SetPasskeyChallenge(ctx context.Context, challengeId string, challenge *biz.PasskeyChallenge, timeout time.Duration) error {
+	record := *challenge
+	r.store.setCache("passkey_challenge_"+challengeId, &record, timeout)
+	return nil
+}
+
+// TakePasskeyChallenge 取出并删除通行密钥挑战，保证每个挑战只能校验一次
+func (r *memoryAuthRepo) TakePasskeyChallenge(ctx context.Context, challengeId string) (*biz.PasskeyChallenge, error) {
+	challenge, ok := r.store.getCache("passkey_challenge_"+challengeId, true)
+	if !ok {
+		return nil, kerrors.NotFound("passkey challenge not found", "")
+	}
+	return challenge.(*biz.PasskeyChallenge), nil
+}
+
+func (r *memoryAuthRepo) SetPasskeyPending(ctx context.Context, userId int32, fingerprint string, timeout time.Duration) error {
+	r.store.setCache(fmt.Sprintf("passkey_pending_%v_%s", userId, fingerprint), true, timeout)
+	return nil
+}
+
+// TakePasskeyPending 取出并删除密码已验证、等待通行密钥二次验证的标记
+func (r *memoryAuthRepo) TakePasskeyPending(ctx context.Context, userId int32, fingerprint string) error {
+	_, ok := r.store.getCache(fmt.Sprintf("passkey_pending_%v_%s", userId, fingerprint), true)
+	if !ok {
+		return kerrors.NotFound("passkey pending not found", fmt.Sprintf("userId(%v)", userId))
+	}
+	return nil
+}
+
+// memoryIdentityRepo 内存模式的第三方账号仓储，绑定关系保存在临时库中，登录state和已使用的SAML断言保存在内存中
+type memoryIdentityRepo struct {
+	*identityRepo
+	store *MemoryStore
+}
+
+func (r *memoryIdentityRepo) UseSAMLAssertion(ctx context.Context, assertionId string, timeout time.Duration) (bool, error) {
+	return r.store.setCacheNX("saml_assertion_"+assertionId, true, timeout), nil
+}
+
+func (r *memoryIdentityRepo) SetExternalLoginState(ctx context.Context, stateHash string, state *biz.ExternalLoginState, timeout time.Duration) error {
+	record := *state
+	r.store.setCache("external_state_"+stateHash, &record, timeout)
+	return nil
+}
+
+// TakeExternalLoginState 取出并删除state，保证只能回调一次
+func (r *memoryIdentityRepo) TakeExternalLoginState(ctx context.Context, stateHash string) (*biz.ExternalLoginState, error) {
+	state, ok := r.store.getCache("external_state_"+stateHash, true)
+	if !ok {
+		return nil, kerrors.NotFound("external login state not found", "")
+	}
+	return state.(*biz.ExternalLoginState), nil
+}
+
+// memoryOAuthRepo 内存模式的OAuth仓储，应用、授权记录和刷新令牌保存在临时库中，授权码保存在内存中
+type memoryOAuthRepo struct {
+	*oauthRepo
+	store *MemoryStore
+}
+
+func (r *memoryOAuthRepo) SetAuthorizationCode(ctx context.Context, codeHash string, code *biz.OAuthAuthorizationCode, timeout time.Duration) error {
+	record := *code
+	r.store.setCache("authorization_code_"+codeHash, &record, timeout)
+	return nil
+}
+
+// TakeAuthorizationCode 取出并删除授权码，保证只能兑换一次
+func (r *memoryOAuthRepo) TakeAuthorizationCode(ctx context.Context, codeHash string) (*biz.OAuthAuthorizationCode, error) {
+	code, ok := r.store.getCache("authorization_code_"+codeHash, true)
+	if !ok {
+		return nil, kerrors.NotFound("authorization code not found", "")
+	}
+	return code.(*biz.OAuthAuthorizationCode), nil
+}
+
+type memoryUserRepo struct {
+	store *MemoryStore
+	log   *log.Helper
+}
+
+func NewMemoryUserRepo(store *MemoryStore, logger log.Logger) biz.UserRepo {
+	return &memoryUserRepo{
+		store: store,
+		log:   log.NewHelper(log.With(logger, "module", "user/data/memory-user")),
+	}
+}
+
+func (r *memoryUserRepo) GetUserRoleById(ctx context.Context, userId int32) (int32, error) {
+	user, err := r.GetCurrentUser(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+	return user.Role, nil
+}
+
+func (r *memoryUserRepo) GetUserSession(ctx context.Context, userId int32) (*biz.User, error) {
+	session, ok := r.store.getCache(sessionKey(userId), false)
+	if !ok {
+		return nil, kerrors.NotFound("user not found from cache", fmt.Sprintf("userId(%v)", userId))
+	}
+	result := &biz.User{}
+	util.StructAssign(result, session.(*User))
+	return result, nil
+}
+
+func (r *memoryUserRepo) GetCurrentUser(ctx context.Context, userId int32) (*biz.User, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		return user.Id == userId && user.IsDelete == 0
+	})
+	if len(list) == 0 {
+		return nil, kerrors.NotFound("user not found", fmt.Sprintf("userId(%v)", userId))
+	}
+	return toBizUsers(list)[0], nil
+}
+
+func (r *memoryUserRepo) GetUserByAccount(ctx context.Context, userAccount string) (*biz.User, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		return user.UserAccount == userAccount && user.IsDelete == 0
+	})
+	if len(list) == 0 {
+		return nil, kerrors.NotFound("user not found", fmt.Sprintf("userAccount(%s)", userAccount))
+	}
+	return toBizUsers(list)[0], nil
+}
+
+// SearchUsers 查询用户，userName按like语义匹配，与MySQL默认排序规则一样不区分大小写
+func (r *memoryUserRepo) SearchUsers(ctx context.Context, userName string, includeServiceAccounts bool) ([]*biz.User, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		if user.IsDelete != 0 || (!includeServiceAccounts && user.UserType != biz.UserTypeNormal) {
+			return false
+		}
+		return userName == "" || likeMatch([]rune(strings.ToLower(userName)), []rune(strings.ToLower(user.UserName)))
+	})
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return toBizUsers(list), nil
+}
+
+// DeleteUser 逻辑删除用户，所有查询都会过滤已删除的用户
+func (r *memoryUserRepo) DeleteUser(ctx context.Context, userId int32) error {
+	r.store.updateUser(ctx, func(user *User) bool {
+		return user.Id == userId && user.IsDelete == 0
+	}, func(user *User) {
+		user.IsDelete = 1
+	})
+	return nil
+}
+
+// ListUsersByStatus 根据用户状态分页查询用户
+func (r *memoryUserRepo) ListUsersByStatus(ctx context.Context, userStatus, page, pageSize int32) ([]*biz.User, int64, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		return user.UserStatus == userStatus && user.IsDelete == 0
+	})
+	return toBizUsers(paginate(list, (page-1)*pageSize, pageSize)), int64(len(list)), nil
+}
+
+// UpdateUserStatus 更新用户状态，仅当用户当前状态为fromStatus时更新
+func (r *memoryUserRepo) UpdateUserStatus(ctx context.Context, userId, fromStatus, toStatus int32) error {
+	rows := r.store.updateUser(ctx, func(user *User) bool {
+		return user.Id == userId && user.UserStatus == fromStatus && user.IsDelete == 0
+	}, func(user *User) {
+		user.UserStatus = toStatus
+	})
+	if rows == 0 {
+		return errors.Errorf("user status changed: userId(%v), userStatus(%v)", userId, fromStatus)
+	}
+	return nil
+}
+
+// UpdateUserRole 更新用户角色
+func (r *memoryUserRepo) UpdateUserRole(ctx context.Context, userId, role int32) error {
+	r.store.updateUser(ctx, func(user *User) bool {
+		return user.Id == userId && user.IsDelete == 0
+	}, func(user *User) {
+		user.Role = role
+	})
+	return nil
+}
+
+func (r *memoryUserRepo) CreateUserApproval(ctx context.Context, approval *biz.UserApproval) error {
+	record := &UserApproval{}
+	util.StructAssign(record, approval)
+	r.store.write(ctx, func() {
+		r.store.nextApprovalId++
+		record.Id = r.store.nextApprovalId
+		record.CreateTime = time.Now()
+		r.store.approvals = append(r.store.approvals, record)
+	})
+	return nil
+}
+
+// ListUsersByEmail 根据邮箱查询用户
+func (r *memoryUserRepo) ListUsersByEmail(ctx context.Context, email string) ([]*biz.User, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		return user.Email == email && user.IsDelete == 0
+	})
+	return toBizUsers(list), nil
+}
+
+// ListUsers 按过滤条件分页查询用户
+func (r *memoryUserRepo) ListUsers(ctx context.Context, conditions []*biz.FilterCondition, offset, limit int32) ([]*biz.User, int64, error) {
+	var matchErr error
+	list := r.store.findUsers(func(user *User) bool {
+		if user.IsDelete != 0 || matchErr != nil {
+			return false
+		}
+		ok, err := matchFilter(user, conditions)
+		if err != nil {
+			matchErr = err
+		}
+		return ok
+	})
+	if matchErr != nil {
+		return nil, 0, matchErr
+	}
+	if limit == 0 {
+		return []*biz.User{}, int64(len(list)), nil
+	}
+	return toBizUsers(paginate(list, offset, limit)), int64(len(list)), nil
+}
+
+// UpdateUser 更新用户资料和状态，密码为空时不修改密码
+func (r *memoryUserRepo) UpdateUser(ctx context.Context, user *biz.User) error {
+	r.store.updateUser(ctx, func(record *User) bool {
+		return record.Id == user.Id && record.IsDelete == 0
+	}, func(record *User) {
+		record.UserAccount = user.UserAccount
+		record.UserName = user.UserName
+		record.AvatarUrl = user.AvatarUrl
+		record.Email = user.Email
+		record.Phone = user.Phone
+		record.UserStatus = user.UserStatus
+		if user.UserPassword != "" {
+			record.UserPassword = user.UserPassword
+		}
+	})
+	return nil
+}
+
+// ListServiceAccounts 分页查询服务账号，ownerId为0时查询全部
+func (r *memoryUserRepo) ListServiceAccounts(ctx context.Context, ownerId, page, pageSize int32) ([]*biz.User, int64, error) {
+	list := r.store.findUsers(func(user *User) bool {
+		return user.UserType == biz.UserTypeService && user.IsDelete == 0 && (ownerId == 0 || user.OwnerId == ownerId)
+	})
+	return toBizUsers(paginate(list, (page-1)*pageSize, pageSize)), int64(len(list)), nil
+}
+
+// UpdateServiceAccount 更新服务账号名称、角色、负责人和状态
+func (r *memoryUserRepo) UpdateServiceAccount(ctx context.Context, account *biz.User) error {
+	r.store.updateUser(ctx, func(user *User) bool {
+		return user.Id == account.Id && user.UserType == biz.UserTypeService && user.IsDelete == 0
+	}, func(user *User) {
+		user.UserName = account.UserName
+		user.Role = account.Role
+		user.OwnerId = account.OwnerId
+		user.UserStatus = account.UserStatus
+	})
+	return nil
+}
+
+func toBizUsers(list []*User) []*biz.User {
+	users := make([]*biz.User, 0, len(list))
+	for _, item := range list {
+		user := &biz.User{}
+		util.StructAssign(user, item)
+		users = append(users, user)
+	}
+	return users
+}
+
+// paginate 与SQL的offset、limit一致，limit为负数时不限制条数
+func paginate(list []*User, offset, limit int32) []*User {
+	if offset > 0 {
+		if int(offset) >= len(list) {
+			return []*User{}
+		}
+		list = list[offset:]
+	}
+	if limit >= 0 && int(limit) < len(list) {
+		list = list[:limit]
+	}
+	return list
+}
+
+// matchFilter 与applyFilter的语义一致，多个条件之间为and关系
+func matchFilter(user *User, conditions []*biz.FilterCondition) (bool, error) {
+	for _, condition := range conditions {
+		var value string
+		switch condition.Attribute {
+		case biz.FilterAttributeId:
+			value = fmt.Sprintf("%v", user.Id)
+		case biz.FilterAttributeUserAccount:
+			value = user.UserAccount
+		case biz.FilterAttributeUserName:
+			value = user.UserName
+		case biz.FilterAttributeEmail:
+			value = user.Email
+		case biz.FilterAttributePhone:
+			value = user.Phone
+		case biz.FilterAttributeUserStatus:
+			value = fmt.Sprintf("%v", user.UserStatus)
+		default:
+			return false, errors.Errorf("unsupported filter attribute: %s", condition.Attribute)
+		}
+
+		value, expected := strings.ToLower(value), strings.ToLower(condition.Value)
+		var ok bool
+		switch condition.Operator {
+		case biz.FilterOperatorEq:
+			ok = value == expected
+		case biz.FilterOperatorNe:
+			ok = value != expected
+		case biz.FilterOperatorCo:
+			ok = strings.Contains(value, expected)
+		case biz.FilterOperatorSw:
+			ok = strings.HasPrefix(value, expected)
+		case biz.FilterOperatorEw:
+			ok = strings.HasSuffix(value, expected)
+		case biz.FilterOperatorPr:
+			ok = value != ""
+		default:
+			return false, errors.Errorf("unsupported filter operator: %s", condition.Operator)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// likeMatch 按SQL like语义匹配，%匹配任意个字符，_匹配单个字符，不支持转义
+func likeMatch(pattern, value []rune) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '%':
+			for i := 0; i <= len(value); i++ {
+				if likeMatch(pattern[1:], value[i:]) {
+					return true
+				}
+			}
+			return false
+		case '_':
+			if len(value) == 0 {
+				return false
+			}
+		default:
+			if len(value) == 0 || value[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, value = pattern[1:], value[1:]
+	}
+	return len(value) == 0
+}
+
+// memoryTransaction 内存模式的事务，内存仓储的事务中再开启临时库的事务，任一失败时两者都回滚
+type memoryTransaction struct {
+	store *MemoryStore
+	data  *Data
+}
+
+func (t *memoryTransaction) ExecTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.store.ExecTx(ctx, func(ctx context.Context) error {
+		return t.data.ExecTx(ctx, fn)
+	})
+}
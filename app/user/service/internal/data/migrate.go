@@ -0,0 +1,547 @@
+package data
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"gorm.io/gorm"
+	"io/fs"
+	"math/big"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// 每种数据库一个迁移目录，各目录的迁移版本必须一一对应
+var migrationDialects = []string{DriverMySQL, DriverPostgres, DriverSQLite}
+
+// 迁移文件名格式：4位版本号_名称.sql
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// 旧版建表脚本中初始管理员的默认密码摘要
+const legacyAdminPasswordHash = "25d55ad283aa400af464c76d713c07ad"
+
+const (
+	// 迁移锁名称，多个副本同时执行迁移时只有一个能拿到锁
+	migrationLockName = "user_center_migration"
+	// PostgreSQL咨询锁只能用整数作为键
+	migrationLockKey = 7301202404
+	// 等待迁移锁的超时时间，单位秒
+	migrationLockTimeout = 60
+)
+
+// Migration 版本化的数据库迁移，只能向前执行
+type Migration struct {
+	Version  int64
+	Name     string
+	SQL      string
+	Checksum string
+}
+
+// MigrationHook 迁移执行成功后在同一事务中调用，用于不能用SQL表达的数据初始化
+type MigrationHook func(ctx context.Context, tx *gorm.DB) error
+
+// MigrationStatus 迁移状态，未执行的迁移AppliedTime为空，Executed为已提交的语句数
+type MigrationStatus struct {
+	Migration   *Migration
+	AppliedTime time.Time
+	Modified    bool
+	Executed    int
+	Statements  int
+}
+
+type SchemaMigration struct {
+	Version     int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name        string    `gorm:"column:name;size:256;not null"`
+	Checksum    string    `gorm:"column:checksum;size:64;not null"`
+	AppliedTime time.Time `gorm:"column:appliedTime;not null"`
+}
+
+// SchemaMigrationProgress DDL不支持事务的数据库中部分执行的迁移，记录已提交的语句数和这些语句的摘要
+type SchemaMigrationProgress struct {
+	Version    int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Executed   int       `gorm:"column:executed;not null"`
+	Checksum   string    `gorm:"column:checksum;size:64;not null"`
+	UpdateTime time.Time `gorm:"column:updateTime;not null"`
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []*Migration
+	hooks      map[int64]MigrationHook
+	// MySQL的DDL会隐式提交事务，迁移失败时已执行的语句无法回滚
+	transactionalDDL bool
+	log              *log.Helper
+}
+
+// NewMigrator 按数据库类型加载内嵌在程序中的迁移文件
+func NewMigrator(db *gorm.DB, hooks map[int64]MigrationHook, logger log.Logger) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	all, err := loadDialectMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	migrations, ok := all[dialect]
+	if !ok {
+		return nil, errors.Errorf("unsupported migration dialect: %s", dialect)
+	}
+	return &Migrator{
+		db:               db,
+		dialect:          dialect,
+		migrations:       migrations,
+		hooks:            hooks,
+		transactionalDDL: dialect != DriverMySQL,
+		log:              log.NewHelper(log.With(logger, "module", "user/data/migrator")),
+	}, nil
+}
+
+// Up 执行所有未执行的迁移
+//1. 获取迁移锁，同一时间只有一个进程执行迁移
+//2. 已执行的迁移文件被修改，或者未执行的迁移版本低于已执行的最新版本时拒绝执行
+//3. 按版本顺序逐个执行，每个迁移的语句、迁移记录和钩子在同一事务中
+//4. MySQL的DDL会隐式提交，无法整体回滚，逐条语句记录进度，失败后再次执行时从失败的语句继续，
+//   已执行的语句不能再修改；语句执行成功但记录进度前进程退出时该语句会重复执行，
+//   因此MySQL迁移中的语句需要可以重复执行（如create table if not exists），不能做到时每个迁移文件只写一条DDL
+func (m *Migrator) Up(ctx context.Context) (applied []*Migration, err error) {
+	err = m.withLock(ctx, func(db *gorm.DB) error {
+		statuses, err := m.status(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		var latest int64
+		pending := make([]*Migration, 0)
+		for _, status := range statuses {
+			if status.Modified {
+				return errors.Errorf("applied migration modified: version(%v), name(%s)", status.Migration.Version, status.Migration.Name)
+			}
+			if !status.AppliedTime.IsZero() {
+				latest = status.Migration.Version
+				continue
+			}
+			pending = append(pending, status.Migration)
+		}
+		for _, migration := range pending {
+			if migration.Version < latest {
+				return errors.Errorf("migration out of order: version(%v) is lower than applied version(%v)", migration.Version, latest)
+			}
+		}
+
+		for _, migration := range pending {
+			err = m.apply(ctx, db, migration)
+			if err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+			m.log.Infof("migration applied: version(%v), name(%s)", migration.Version, migration.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// Status 所有迁移的执行状态，按版本排序
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	var statuses []*MigrationStatus
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		var err error
+		statuses, err = m.status(ctx, db)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) status(ctx context.Context, db *gorm.DB) ([]*MigrationStatus, error) {
+	if !db.WithContext(ctx).Migrator().HasTable(&SchemaMigration{}) {
+		err := db.WithContext(ctx).Migrator().CreateTable(&SchemaMigration{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to create migration history table")
+		}
+	}
+	progresses, err := m.progresses(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*SchemaMigration, 0)
+	err = db.WithContext(ctx).Order("version").Find(&records).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list applied migrations")
+	}
+
+	applied := make(map[int64]*SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	statuses := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statements := splitStatements(migration.SQL)
+		status := &MigrationStatus{Migration: migration, Statements: len(statements)}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedTime = record.AppliedTime
+			status.Modified = record.Checksum != migration.Checksum
+			status.Executed = len(statements)
+			delete(applied, migration.Version)
+		} else if progress, ok := progresses[migration.Version]; ok {
+			status.Executed = progress.Executed
+			status.Modified = !progress.matches(statements)
+		}
+		statuses = append(statuses, status)
+	}
+	for version := range applied {
+		return nil, errors.Errorf("applied migration missing from binary: version(%v)", version)
+	}
+	return statuses, nil
+}
+
+// progresses 部分执行的迁移，DDL支持事务的数据库中迁移不会部分执行
+func (m *Migrator) progresses(ctx context.Context, db *gorm.DB) (map[int64]*SchemaMigrationProgress, error) {
+	result := make(map[int64]*SchemaMigrationProgress)
+	if m.transactionalDDL {
+		return result, nil
+	}
+	if !db.WithContext(ctx).Migrator().HasTable(&SchemaMigrationProgress{}) {
+		err := db.WithContext(ctx).Migrator().CreateTable(&SchemaMigrationProgress{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to create migration progress table")
+		}
+	}
+	records := make([]*SchemaMigrationProgress, 0)
+	err := db.WithContext(ctx).Find(&records).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list migration progress")
+	}
+	for _, record := range records {
+		result[record.Version] = record
+	}
+	return result, nil
+}
+
+// matches 已提交的语句没有被修改
+func (p *SchemaMigrationProgress) matches(statements []string) bool {
+	return p.Executed <= len(statements) && p.Checksum == statementsChecksum(statements[:p.Executed])
+}
+
+// apply 执行迁移
+//1. DDL支持事务时所有语句在同一事务中执行，失败时整体回滚
+//2. 否则逐条执行语句，每条语句提交后记录进度，再次执行时跳过已提交的语句
+//3. 钩子、迁移记录和删除进度在同一事务中
+func (m *Migrator) apply(ctx context.Context, db *gorm.DB, migration *Migration) error {
+	if !m.transactionalDDL {
+		err := m.execStatements(ctx, db, migration)
+		if err != nil {
+			return err
+		}
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if m.transactionalDDL {
+			for _, statement := range splitStatements(migration.SQL) {
+				err := tx.Exec(statement).Error
+				if err != nil {
+					return errors.Wrapf(err, fmt.Sprintf("fail to apply migration: version(%v), name(%s)", migration.Version, migration.Name))
+				}
+			}
+		}
+		if hook, ok := m.hooks[migration.Version]; ok {
+			err := hook(ctx, tx)
+			if err != nil {
+				return errors.Wrapf(err, fmt.Sprintf("fail to run migration hook: version(%v), name(%s)", migration.Version, migration.Name))
+			}
+		}
+		err := tx.Create(&SchemaMigration{
+			Version:     migration.Version,
+			Name:        migration.Name,
+			Checksum:    migration.Checksum,
+			AppliedTime: time.Now(),
+		}).Error
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("fail to record migration: version(%v), name(%s)", migration.Version, migration.Name))
+		}
+		if !m.transactionalDDL {
+			err = tx.Where(map[string]interface{}{"version": migration.Version}).Delete(&SchemaMigrationProgress{}).Error
+			if err != nil {
+				return errors.Wrapf(err, fmt.Sprintf("fail to delete migration progress: version(%v), name(%s)", migration.Version, migration.Name))
+			}
+		}
+		return nil
+	})
+}
+
+// execStatements 从上次失败的语句开始逐条执行，已提交的语句被修改时拒绝执行，需要人工处理
+func (m *Migrator) execStatements(ctx context.Context, db *gorm.DB, migration *Migration) error {
+	statements := splitStatements(migration.SQL)
+	progress := &SchemaMigrationProgress{Version: migration.Version}
+	err := db.WithContext(ctx).Where(map[string]interface{}{"version": migration.Version}).Limit(1).Find(progress).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to get migration progress: version(%v), name(%s)", migration.Version, migration.Name))
+	}
+	if !progress.matches(statements) {
+		return errors.Errorf("committed statements of partially applied migration modified: version(%v), name(%s), executed(%v)", migration.Version, migration.Name, progress.Executed)
+	}
+	if progress.Executed > 0 {
+		m.log.Warnf("resume partially applied migration: version(%v), name(%s), executed(%v/%v)", migration.Version, migration.Name, progress.Executed, len(statements))
+	}
+
+	for i := progress.Executed; i < len(statements); i++ {
+		err = db.WithContext(ctx).Exec(statements[i]).Error
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("fail to apply migration: version(%v), name(%s), statement(%v/%v), %v statement(s) committed",
+				migration.Version, migration.Name, i+1, len(statements), i))
+		}
+		progress.Executed = i + 1
+		progress.Checksum = statementsChecksum(statements[:i+1])
+		progress.UpdateTime = time.Now()
+		err = db.WithContext(ctx).Save(progress).Error
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("fail to record migration progress: version(%v), name(%s), statement(%v/%v)", migration.Version, migration.Name, i+1, len(statements)))
+		}
+	}
+	return nil
+}
+
+// statementsChecksum 已提交语句的摘要
+func statementsChecksum(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+	checksum := sha256.Sum256([]byte(strings.Join(statements, ";\n")))
+	return hex.EncodeToString(checksum[:])
+}
+
+// withLock 在同一个连接上获取和释放数据库锁，进程退出时连接断开，锁自动释放
+//1. MySQL使用命名锁，PostgreSQL使用会话级咨询锁
+//2. SQLite没有跨进程的命名锁，由数据库文件的写锁保证事务串行执行
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if m.dialect == DriverSQLite {
+		return fn(m.db.WithContext(ctx))
+	}
+	return m.db.WithContext(ctx).Connection(func(db *gorm.DB) error {
+		var err error
+		switch m.dialect {
+		case DriverPostgres:
+			err = m.lockPostgres(ctx, db)
+		default:
+			err = m.lockMySQL(db)
+		}
+		if err != nil {
+			return err
+		}
+		defer func() {
+			var err error
+			switch m.dialect {
+			case DriverPostgres:
+				err = db.Exec("select pg_advisory_unlock(?)", migrationLockKey).Error
+			default:
+				err = db.Exec("select release_lock(?)", migrationLockName).Error
+			}
+			if err != nil {
+				m.log.Errorf("fail to release migration lock: %v", err)
+			}
+		}()
+		return fn(db)
+	})
+}
+
+func (m *Migrator) lockMySQL(db *gorm.DB) error {
+	var locked int
+	err := db.Raw("select get_lock(?, ?)", migrationLockName, migrationLockTimeout).Scan(&locked).Error
+	if err != nil {
+		return errors.Wrapf(err, "fail to acquire migration lock")
+	}
+	if locked != 1 {
+		return errors.Errorf("migration lock timeout: another migration is running")
+	}
+	return nil
+}
+
+// lockPostgres 轮询获取咨询锁，阻塞的pg_advisory_lock无法按超时时间放弃
+func (m *Migrator) lockPostgres(ctx context.Context, db *gorm.DB) error {
+	deadline := time.Now().Add(migrationLockTimeout * time.Second)
+	for {
+		var locked bool
+		err := db.Raw("select pg_try_advisory_lock(?)", migrationLockKey).Scan(&locked).Error
+		if err != nil {
+			return errors.Wrapf(err, "fail to acquire migration lock")
+		}
+		if locked {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("migration lock timeout: another migration is running")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// SeedAdminPassword 初始管理员没有密码或仍是旧版默认密码时生成随机密码，返回生成的密码，没有更新时返回空字符串
+// 密码由调用方在迁移提交后输出，数据层不直接输出
+func SeedAdminPassword(ctx context.Context, tx *gorm.DB) (string, error) {
+	password, err := randomPassword(16)
+	if err != nil {
+		return "", err
+	}
+	result := tx.WithContext(ctx).Model(&User{}).
+		Where(map[string]interface{}{"userAccount": "admin", "userPassword": []string{"", legacyAdminPasswordHash}}).
+		Update("userPassword", biz.PasswordHash(password))
+	if result.Error != nil {
+		return "", errors.Wrapf(result.Error, "fail to seed admin password")
+	}
+	if result.RowsAffected == 0 {
+		return "", nil
+	}
+	return password, nil
+}
+
+func randomPassword(length int) (string, error) {
+	const letters = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		if err != nil {
+			return "", errors.Wrapf(err, "fail to generate password")
+		}
+		password[i] = letters[n.Int64()]
+	}
+	return string(password), nil
+}
+
+// CreateMigration 在每种数据库的迁移目录中创建下一个版本的空迁移文件，返回文件路径
+func CreateMigration(dir, name string) ([]string, error) {
+	if !migrationFileRegexp.MatchString("0_" + name + ".sql") {
+		return nil, errors.Errorf("invalid migration name: %s, only lowercase letters, digits and underscores are allowed", name)
+	}
+	all, err := loadDialectMigrations(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, err
+	}
+	var version int64 = 1
+	if migrations := all[migrationDialects[0]]; len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	files := make([]string, 0, len(migrationDialects))
+	for _, dialect := range migrationDialects {
+		file := filepath.Join(dir, dialect, fmt.Sprintf("%04d_%s.sql", version, name))
+		content := fmt.Sprintf("-- %s\n-- 迁移只能向前执行，已执行的迁移不能修改，语句以行尾分号结束\n-- 其他数据库目录中同版本的迁移需要保持一致\n\n", name)
+		err = os.WriteFile(file, []byte(content), 0644)
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to create migration: path(%s)", file))
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// loadDialectMigrations 加载每种数据库的迁移，各目录的版本和名称不一致时报错，避免某种数据库漏写迁移
+func loadDialectMigrations(fsys fs.FS, dir string) (map[string][]*Migration, error) {
+	all := make(map[string][]*Migration, len(migrationDialects))
+	for _, dialect := range migrationDialects {
+		migrations, err := loadMigrations(fsys, path.Join(dir, dialect))
+		if err != nil {
+			return nil, err
+		}
+		all[dialect] = migrations
+	}
+
+	expected := all[migrationDialects[0]]
+	for _, dialect := range migrationDialects[1:] {
+		migrations := all[dialect]
+		if len(migrations) != len(expected) {
+			return nil, errors.Errorf("migrations mismatch: %s has %v migration(s), %s has %v", migrationDialects[0], len(expected), dialect, len(migrations))
+		}
+		for i, migration := range migrations {
+			if migration.Version != expected[i].Version || migration.Name != expected[i].Name {
+				return nil, errors.Errorf("migrations mismatch: %s has %04d_%s, %s has %04d_%s", migrationDialects[0], expected[i].Version, expected[i].Name, dialect, migration.Version, migration.Name)
+			}
+		}
+	}
+	return all, nil
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to read migrations: dir(%s)", dir))
+	}
+
+	migrations := make([]*Migration, 0, len(entries))
+	versions := make(map[int64]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, errors.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		if exist, ok := versions[version]; ok {
+			return nil, errors.Errorf("duplicate migration version: %s, %s", exist, entry.Name())
+		}
+		versions[version] = entry.Name()
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to read migration: %s", entry.Name()))
+		}
+		checksum := sha256.Sum256(content)
+		migrations = append(migrations, &Migration{
+			Version:  version,
+			Name:     match[2],
+			SQL:      string(content),
+			Checksum: hex.EncodeToString(checksum[:]),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// splitStatements 按行尾分号拆分语句，忽略注释行，语句中间的分号不拆分
+func splitStatements(content string) []string {
+	statements := make([]string, 0)
+	var builder strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "--") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		builder.WriteString(scanner.Text())
+		builder.WriteString("\n")
+		if strings.HasSuffix(line, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(builder.String()), ";"))
+			builder.Reset()
+		}
+	}
+	if strings.TrimSpace(builder.String()) != "" {
+		statements = append(statements, strings.TrimSpace(builder.String()))
+	}
+	return statements
+}
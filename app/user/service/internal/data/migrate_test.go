@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"gorm.io/gorm"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestMigrationDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	source := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_txlock=immediate", filepath.Join(t.TempDir(), "migrate.db"))
+	db, cleanup := NewDB(&conf.Data{Database: &conf.Data_Database{Driver: DriverSQLite, Source: source}})
+	t.Cleanup(cleanup)
+	return db
+}
+
+func newTestMigration(version int64, statements ...string) *Migration {
+	content := strings.Join(statements, ";\n") + ";\n"
+	checksum := sha256.Sum256([]byte(content))
+	return &Migration{Version: version, Name: fmt.Sprintf("test_%v", version), SQL: content, Checksum: hex.EncodeToString(checksum[:])}
+}
+
+func newTestMigrator(db *gorm.DB, transactionalDDL bool, migrations ...*Migration) *Migrator {
+	return &Migrator{
+		db:               db,
+		dialect:          DriverSQLite,
+		migrations:       migrations,
+		transactionalDDL: transactionalDDL,
+		log:              log.NewHelper(testLogger),
+	}
+}
+
+func expectTables(t *testing.T, db *gorm.DB, want map[string]bool) {
+	t.Helper()
+	for table, exist := range want {
+		if db.Migrator().HasTable(table) != exist {
+			t.Fatalf("table %s exist: want(%v)", table, exist)
+		}
+	}
+}
+
+func TestMigratorTransactionalDDL(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMigrationDB(t)
+	m := newTestMigrator(db, true, newTestMigration(1, "create table a (id integer)", "create table b (id integer)", "insert into missing values (1)"))
+
+	// 迁移失败时整体回滚，修正后重新执行全部语句
+	_, err := m.Up(ctx)
+	if err == nil {
+		t.Fatalf("migration with invalid statement applied")
+	}
+	expectTables(t, db, map[string]bool{"a": false, "b": false})
+
+	m.migrations = []*Migration{newTestMigration(1, "create table a (id integer)", "create table b (id integer)", "insert into a values (1)")}
+	applied, err := m.Up(ctx)
+	if err != nil || len(applied) != 1 {
+		t.Fatalf("apply fixed migration: applied(%v), error(%v)", len(applied), err)
+	}
+	expectTables(t, db, map[string]bool{"a": true, "b": true})
+}
+
+func TestMigratorResumePartiallyApplied(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMigrationDB(t)
+	m := newTestMigrator(db, false,
+		newTestMigration(1, "create table a (id integer)"),
+		newTestMigration(2, "create table b (id integer)", "create table c (id integer)", "insert into missing values (1)", "create table d (id integer)"))
+
+	// DDL不支持事务时已执行的语句无法回滚，记录已提交的语句数
+	_, err := m.Up(ctx)
+	if err == nil || !strings.Contains(err.Error(), "statement(3/4), 2 statement(s) committed") {
+		t.Fatalf("migration with invalid statement: %v", err)
+	}
+	expectTables(t, db, map[string]bool{"a": true, "b": true, "c": true, "d": false})
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("migration status: %v", err)
+	}
+	if statuses[0].AppliedTime.IsZero() || !statuses[1].AppliedTime.IsZero() || statuses[1].Executed != 2 || statuses[1].Statements != 4 || statuses[1].Modified {
+		t.Fatalf("partially applied status: %+v", statuses[1])
+	}
+
+	// 已提交的语句被修改时拒绝继续执行
+	m.migrations[1] = newTestMigration(2, "create table b (id integer, name text)", "create table c (id integer)", "insert into c values (1)", "create table d (id integer)")
+	_, err = m.Up(ctx)
+	if err == nil || !strings.Contains(err.Error(), "modified") {
+		t.Fatalf("modified committed statements: %v", err)
+	}
+
+	// 修正失败的语句后从失败处继续，已提交的语句不重复执行
+	m.migrations[1] = newTestMigration(2, "create table b (id integer)", "create table c (id integer)", "insert into c values (1)", "create table d (id integer)")
+	applied, err := m.Up(ctx)
+	if err != nil || len(applied) != 1 || applied[0].Version != 2 {
+		t.Fatalf("resume migration: applied(%v), error(%v)", len(applied), err)
+	}
+	expectTables(t, db, map[string]bool{"d": true})
+	var progresses int64
+	db.Model(&SchemaMigrationProgress{}).Count(&progresses)
+	statuses, _ = m.Status(ctx)
+	if progresses != 0 || statuses[1].AppliedTime.IsZero() || statuses[1].Executed != 4 {
+		t.Fatalf("migration progress after applied: progresses(%v), status(%+v)", progresses, statuses[1])
+	}
+}
+
+func TestSeedAdminPassword(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMigrationDB(t)
+	migrator, err := NewMigrator(db, nil, testLogger)
+	if err != nil {
+		t.Fatalf("fail to load migrations: %v", err)
+	}
+	_, err = migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("fail to migrate: %v", err)
+	}
+	db.Model(&User{}).Where(map[string]interface{}{"userAccount": "admin"}).Update("userPassword", legacyAdminPasswordHash)
+
+	password, err := SeedAdminPassword(ctx, db)
+	if err != nil || len(password) != 16 {
+		t.Fatalf("seed admin password: password(%s), error(%v)", password, err)
+	}
+	admin := &User{}
+	db.Where(map[string]interface{}{"userAccount": "admin"}).Take(admin)
+	if admin.UserPassword != biz.PasswordHash(password) {
+		t.Fatalf("admin password not updated")
+	}
+
+	// 已修改过的密码不再覆盖
+	password, err = SeedAdminPassword(ctx, db)
+	if err != nil || password != "" {
+		t.Fatalf("seed changed admin password: password(%s), error(%v)", password, err)
+	}
+}
@@ -0,0 +1,270 @@
+-- 初始表结构，由原 sql/data.sql 整理，不再删除已有的库和表
+
+create table if not exists user
+(
+    id           bigint auto_increment comment 'id'
+        primary key,
+    username     varchar(256)                       null comment '用户昵称',
+    userAccount  varchar(256)                       null comment '账号',
+    avatarUrl    varchar(1024)                      null comment '用户头像',
+    gender       tinyint                            null comment '性别',
+    userPassword varchar(512)                       not null comment '密码',
+    phone        varchar(128)                       null comment '电话',
+    email        varchar(512)                       null comment '邮箱',
+    userStatus   int      default 0                 not null comment '用户状态 0-正常',
+    createTime   datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    updateTime   datetime default CURRENT_TIMESTAMP null on update CURRENT_TIMESTAMP comment '更新时间',
+    isDelete     tinyint  default 0                 not null comment '是否删除',
+    role         int      default 0                 not null comment '用户角色 0-普通用户 1-管理员',
+    userType     int      default 0                 not null comment '用户类型 0-普通用户 1-服务账号',
+    ownerId      bigint                             null comment '服务账号负责人id'
+)
+    comment '用户';
+
+-- 初始管理员，密码为空不能登录，执行本迁移时生成随机密码；已有管理员账号时不重复创建
+insert into user (username, userAccount, avatarUrl, gender, userPassword, userStatus, role, userType)
+select 'Tom', 'admin', 'http://cdn.u2.huluxia.com/g3/M00/36/56/wKgBOVwPmcmAB2cnAACcXKrjLlw989.jpg', 0, '', 0, 1, 0
+from dual
+where not exists(select 1 from user where userAccount = 'admin');
+
+create table if not exists user_approval
+(
+    id         bigint auto_increment comment 'id'
+        primary key,
+    userId     bigint                             not null comment '被审核用户id',
+    reviewerId bigint                             not null comment '审核管理员id',
+    userStatus int                                not null comment '审核结果 0-通过 2-拒绝',
+    reason     varchar(256)                       null comment '审核原因',
+    createTime datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    index idx_userId (userId)
+)
+    comment '用户审核记录';
+
+create table if not exists audit_log
+(
+    id         bigint auto_increment comment 'id'
+        primary key,
+    actorId    bigint   default 0                 not null comment '操作者id 0-匿名',
+    targetId   bigint   default 0                 not null comment '目标用户id',
+    action     varchar(64)                        not null comment '操作',
+    ip         varchar(64)                        null comment '客户端ip',
+    userAgent  varchar(512)                       null comment '客户端user agent',
+    result     varchar(16)                        not null comment '结果 success/failure',
+    reason     varchar(128)                       null comment '失败原因',
+    detail     varchar(1024)                      null comment '详情',
+    createTime datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    index idx_actorId (actorId),
+    index idx_targetId (targetId),
+    index idx_action_createTime (action, createTime)
+)
+    comment '审计日志';
+
+create table if not exists login_history
+(
+    id           bigint auto_increment comment 'id'
+        primary key,
+    userId       bigint   default 0                 not null comment '用户id 0-账号不存在',
+    userAccount  varchar(256)                       null comment '登录账号',
+    ip           varchar(64)                        null comment '客户端ip',
+    userAgent    varchar(512)                       null comment '客户端user agent',
+    fingerprint  varchar(64)                        null comment '设备指纹',
+    method       varchar(32)                        not null comment '登录方式',
+    success      tinyint                            not null comment '是否成功',
+    unrecognized tinyint  default 0                 not null comment '是否为未识别的设备或地点',
+    reason       varchar(128)                       null comment '失败原因',
+    createTime   datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    index idx_userId_createTime (userId, createTime),
+    index idx_createTime (createTime)
+)
+    comment '登录历史';
+
+create table if not exists webhook
+(
+    id         bigint auto_increment comment 'id'
+        primary key,
+    url        varchar(1024)                      not null comment '回调地址',
+    secret     varchar(128)                       not null comment '签名密钥',
+    events     varchar(512)                       not null comment '订阅事件，逗号分隔',
+    creatorId  bigint                             not null comment '创建人id',
+    createTime datetime default CURRENT_TIMESTAMP null comment '创建时间'
+)
+    comment 'webhook订阅';
+
+create table if not exists webhook_delivery
+(
+    id              bigint auto_increment comment 'id'
+        primary key,
+    webhookId       bigint                             not null comment 'webhook id',
+    eventId         varchar(64)                        not null comment '事件id',
+    eventType       varchar(64)                        not null comment '事件类型',
+    payload         text                               not null comment '投递内容',
+    status          varchar(16)                        not null comment '状态 pending/succeeded/failed',
+    attempts        int      default 0                 not null comment '已投递次数',
+    nextAttemptTime datetime                           not null comment '下次投递时间',
+    lastStatusCode  int      default 0                 not null comment '最近一次响应状态码',
+    lastError       varchar(512)                       null comment '最近一次错误',
+    createTime      datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    updateTime      datetime default CURRENT_TIMESTAMP null on update CURRENT_TIMESTAMP comment '更新时间',
+    index idx_webhookId_createTime (webhookId, createTime),
+    index idx_status_nextAttemptTime (status, nextAttemptTime)
+)
+    comment 'webhook投递记录';
+
+create table if not exists outbox_event
+(
+    id              bigint auto_increment comment 'id'
+        primary key,
+    eventId         varchar(64)                        not null comment '事件id，用于消费方去重',
+    eventType       varchar(64)                        not null comment '事件类型',
+    userId          bigint   default 0                 not null comment '用户id',
+    payload         text                               not null comment '事件内容',
+    status          varchar(16)                        not null comment '状态 pending/published',
+    attempts        int      default 0                 not null comment '已发布次数',
+    nextAttemptTime datetime                           not null comment '下次发布时间',
+    lastError       varchar(512)                       null comment '最近一次错误',
+    createTime      datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    updateTime      datetime default CURRENT_TIMESTAMP null on update CURRENT_TIMESTAMP comment '更新时间',
+    unique index uk_eventId (eventId),
+    index idx_status_nextAttemptTime (status, nextAttemptTime),
+    index idx_status_updateTime (status, updateTime)
+)
+    comment '事务发件箱';
+
+create table if not exists user_change_log
+(
+    seq        bigint auto_increment comment '变更序号'
+        primary key,
+    eventId    varchar(64)                        not null comment '事件id',
+    changeType varchar(32)                        not null comment '变更类型 create/update/delete/status_change',
+    userId     bigint                             not null comment '用户id',
+    payload    text                               not null comment '事件内容',
+    createTime datetime default CURRENT_TIMESTAMP null comment '创建时间'
+)
+    comment '用户变更日志';
+
+create table if not exists oauth_client
+(
+    id                     bigint auto_increment comment 'id'
+        primary key,
+    clientId               varchar(64)                        not null comment '应用id',
+    clientSecretHash       varchar(64)                        null comment '应用密钥摘要，公开客户端为空',
+    name                   varchar(64)                        not null comment '应用名称',
+    redirectUris           varchar(2048)                      null comment '回调地址，空格分隔',
+    postLogoutRedirectUris varchar(2048)                      null comment '退出登录回调地址，空格分隔',
+    grantTypes             varchar(128)                       not null comment '授权类型，空格分隔',
+    scopes                 varchar(512)                       null comment '权限范围，空格分隔',
+    public                 tinyint  default 0                 not null comment '是否为公开客户端',
+    creatorId              bigint                             not null comment '创建人id',
+    createTime             datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    unique index uk_clientId (clientId)
+)
+    comment 'OAuth应用';
+
+create table if not exists oauth_consent
+(
+    id         bigint auto_increment comment 'id'
+        primary key,
+    userId     bigint                             not null comment '用户id',
+    clientId   varchar(64)                        not null comment '应用id',
+    scopes     varchar(512)                       null comment '已授予的权限范围，空格分隔',
+    createTime datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    updateTime datetime default CURRENT_TIMESTAMP null on update CURRENT_TIMESTAMP comment '更新时间',
+    unique index uk_userId_clientId (userId, clientId)
+)
+    comment 'OAuth用户授权';
+
+create table if not exists oauth_refresh_token
+(
+    id         bigint auto_increment comment 'id'
+        primary key,
+    tokenHash  varchar(64)                        not null comment '刷新令牌摘要',
+    clientId   varchar(64)                        not null comment '应用id',
+    userId     bigint                             not null comment '用户id',
+    scope      varchar(512)                       null comment '权限范围',
+    expireTime datetime                           not null comment '过期时间',
+    revoked    tinyint  default 0                 not null comment '是否已吊销',
+    createTime datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    unique index uk_tokenHash (tokenHash),
+    index idx_clientId (clientId),
+    index idx_userId (userId)
+)
+    comment 'OAuth刷新令牌';
+
+create table if not exists user_identity
+(
+    id            bigint auto_increment comment 'id'
+        primary key,
+    userId        bigint                             not null comment '用户id',
+    provider      varchar(64)                        not null comment '第三方登录提供方',
+    subject       varchar(256)                       not null comment '第三方账号唯一标识',
+    email         varchar(512)                       null comment '第三方账号邮箱',
+    createTime    datetime default CURRENT_TIMESTAMP null comment '绑定时间',
+    lastLoginTime datetime                           null comment '最近登录时间',
+    unique index uk_provider_subject (provider, subject),
+    index idx_userId (userId)
+)
+    comment '用户第三方账号';
+
+create table if not exists user_group
+(
+    id          bigint auto_increment comment 'id'
+        primary key,
+    displayName varchar(256)                       not null comment '组名',
+    externalId  varchar(256)                       null comment '身份提供方中的组标识',
+    createTime  datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    updateTime  datetime default CURRENT_TIMESTAMP null on update CURRENT_TIMESTAMP comment '更新时间',
+    unique index uk_displayName (displayName)
+)
+    comment '用户组';
+
+create table if not exists user_group_member
+(
+    id         bigint auto_increment comment 'id'
+        primary key,
+    groupId    bigint                             not null comment '组id',
+    userId     bigint                             not null comment '用户id',
+    createTime datetime default CURRENT_TIMESTAMP null comment '加入时间',
+    unique index uk_group_user (groupId, userId),
+    index idx_userId (userId)
+)
+    comment '用户组成员';
+
+create table if not exists user_access_token
+(
+    id           bigint auto_increment comment 'id'
+        primary key,
+    userId       bigint                             not null comment '用户id',
+    name         varchar(64)                        not null comment '令牌名称',
+    tokenHash    char(64)                           not null comment '令牌摘要',
+    tokenPrefix  varchar(16)                        not null comment '令牌前缀，用于识别令牌',
+    scopes       varchar(256)                       not null comment '权限范围，空格分隔',
+    expireTime   datetime                           not null comment '过期时间',
+    lastUsedTime datetime                           null comment '最近使用时间',
+    lastUsedIp   varchar(64)                        null comment '最近使用ip',
+    revokeTime   datetime                           null comment '吊销时间',
+    createTime   datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    unique index uk_tokenHash (tokenHash),
+    index idx_userId (userId)
+)
+    comment '个人访问令牌';
+
+create table if not exists user_passkey
+(
+    id              bigint auto_increment comment 'id'
+        primary key,
+    userId          bigint                             not null comment '用户id',
+    name            varchar(64)                        not null comment '通行密钥名称',
+    credentialId    varchar(512)                       not null comment '凭证id，base64url编码',
+    publicKey       blob                               not null comment '凭证公钥，COSE编码',
+    attestationType varchar(64)                        null comment '证明格式',
+    transports      varchar(128)                       null comment '传输方式，空格分隔',
+    aaguid          varchar(64)                        null comment '认证器型号',
+    signCount       bigint   default 0                 not null comment '签名计数',
+    backupEligible  tinyint  default 0                 not null comment '是否可备份',
+    backupState     tinyint  default 0                 not null comment '是否已备份',
+    lastUsedTime    datetime                           null comment '最近使用时间',
+    createTime      datetime default CURRENT_TIMESTAMP null comment '创建时间',
+    unique index uk_credentialId (credentialId),
+    index idx_userId (userId)
+)
+    comment '通行密钥';
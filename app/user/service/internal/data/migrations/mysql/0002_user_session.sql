@@ -0,0 +1,12 @@
+-- user_session
+-- Redis不可用且开启 session_fallback 时保存登录态，每个用户一条
+
+create table if not exists user_session
+(
+    userId     bigint                             not null comment '用户id'
+        primary key,
+    session    text                               not null comment '登录态，与Redis中的内容一致',
+    expireTime datetime                           not null comment '过期时间',
+    updateTime datetime default CURRENT_TIMESTAMP null on update CURRENT_TIMESTAMP comment '更新时间'
+)
+    comment '登录态（Redis不可用时的备用存储）';
@@ -0,0 +1,10 @@
+-- user_session
+-- Redis不可用且开启 session_fallback 时保存登录态，每个用户一条
+
+create table if not exists "user_session"
+(
+    "userId"     bigint primary key,
+    "session"    text                                  not null,
+    "expireTime" timestamptz                           not null,
+    "updateTime" timestamptz default CURRENT_TIMESTAMP
+);
@@ -0,0 +1,239 @@
+-- 初始表结构，与 mysql/0001_init.sql 保持一致，字段说明见该文件
+-- 时间列以文本保存，数据库默认值 CURRENT_TIMESTAMP 为UTC时间
+-- 索引名在同一个库中唯一，统一加表名前缀
+
+create table if not exists user
+(
+    id           integer primary key autoincrement,
+    username     varchar(256),
+    userAccount  varchar(256),
+    avatarUrl    varchar(1024),
+    gender       smallint,
+    userPassword varchar(512)                        not null,
+    phone        varchar(128),
+    email        varchar(512),
+    userStatus   int         default 0                 not null,
+    createTime   datetime    default CURRENT_TIMESTAMP,
+    updateTime   datetime    default CURRENT_TIMESTAMP,
+    isDelete     smallint    default 0                 not null,
+    role         int         default 0                 not null,
+    userType     int         default 0                 not null,
+    ownerId      bigint
+);
+
+-- 初始管理员，密码为空不能登录，执行本迁移时生成随机密码；已有管理员账号时不重复创建
+insert into user (username, userAccount, avatarUrl, gender, userPassword, userStatus, role, userType)
+select 'Tom', 'admin', 'http://cdn.u2.huluxia.com/g3/M00/36/56/wKgBOVwPmcmAB2cnAACcXKrjLlw989.jpg', 0, '', 0, 1, 0
+where not exists(select 1 from user where userAccount = 'admin');
+
+create table if not exists user_approval
+(
+    id         integer primary key autoincrement,
+    userId     bigint                                not null,
+    reviewerId bigint                                not null,
+    userStatus int                                   not null,
+    reason     varchar(256),
+    createTime datetime    default CURRENT_TIMESTAMP
+);
+create index if not exists user_approval_idx_userId on user_approval (userId);
+
+create table if not exists audit_log
+(
+    id         integer primary key autoincrement,
+    actorId    bigint      default 0                 not null,
+    targetId   bigint      default 0                 not null,
+    action     varchar(64)                           not null,
+    ip         varchar(64),
+    userAgent  varchar(512),
+    result     varchar(16)                           not null,
+    reason     varchar(128),
+    detail     varchar(1024),
+    createTime datetime    default CURRENT_TIMESTAMP
+);
+create index if not exists audit_log_idx_actorId on audit_log (actorId);
+create index if not exists audit_log_idx_targetId on audit_log (targetId);
+create index if not exists audit_log_idx_action_createTime on audit_log (action, createTime);
+
+create table if not exists login_history
+(
+    id           integer primary key autoincrement,
+    userId       bigint      default 0                 not null,
+    userAccount  varchar(256),
+    ip           varchar(64),
+    userAgent    varchar(512),
+    fingerprint  varchar(64),
+    method       varchar(32)                           not null,
+    success      boolean                               not null,
+    unrecognized boolean     default 0                 not null,
+    reason       varchar(128),
+    createTime   datetime    default CURRENT_TIMESTAMP
+);
+create index if not exists login_history_idx_userId_createTime on login_history (userId, createTime);
+create index if not exists login_history_idx_createTime on login_history (createTime);
+
+create table if not exists webhook
+(
+    id         integer primary key autoincrement,
+    url        varchar(1024)                         not null,
+    secret     varchar(128)                          not null,
+    events     varchar(512)                          not null,
+    creatorId  bigint                                not null,
+    createTime datetime    default CURRENT_TIMESTAMP
+);
+
+create table if not exists webhook_delivery
+(
+    id              integer primary key autoincrement,
+    webhookId       bigint                                not null,
+    eventId         varchar(64)                           not null,
+    eventType       varchar(64)                           not null,
+    payload         text                                  not null,
+    status          varchar(16)                           not null,
+    attempts        int         default 0                 not null,
+    nextAttemptTime datetime                              not null,
+    lastStatusCode  int         default 0                 not null,
+    lastError       varchar(512),
+    createTime      datetime    default CURRENT_TIMESTAMP,
+    updateTime      datetime    default CURRENT_TIMESTAMP
+);
+create index if not exists webhook_delivery_idx_webhookId_createTime on webhook_delivery (webhookId, createTime);
+create index if not exists webhook_delivery_idx_status_nextAttemptTime on webhook_delivery (status, nextAttemptTime);
+
+create table if not exists outbox_event
+(
+    id              integer primary key autoincrement,
+    eventId         varchar(64)                           not null,
+    eventType       varchar(64)                           not null,
+    userId          bigint      default 0                 not null,
+    payload         text                                  not null,
+    status          varchar(16)                           not null,
+    attempts        int         default 0                 not null,
+    nextAttemptTime datetime                              not null,
+    lastError       varchar(512),
+    createTime      datetime    default CURRENT_TIMESTAMP,
+    updateTime      datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists outbox_event_uk_eventId on outbox_event (eventId);
+create index if not exists outbox_event_idx_status_nextAttemptTime on outbox_event (status, nextAttemptTime);
+create index if not exists outbox_event_idx_status_updateTime on outbox_event (status, updateTime);
+
+create table if not exists user_change_log
+(
+    seq        integer primary key autoincrement,
+    eventId    varchar(64)                           not null,
+    changeType varchar(32)                           not null,
+    userId     bigint                                not null,
+    payload    text                                  not null,
+    createTime datetime    default CURRENT_TIMESTAMP
+);
+
+create table if not exists oauth_client
+(
+    id                     integer primary key autoincrement,
+    clientId               varchar(64)                           not null,
+    clientSecretHash       varchar(64),
+    name                   varchar(64)                           not null,
+    redirectUris           varchar(2048),
+    postLogoutRedirectUris varchar(2048),
+    grantTypes             varchar(128)                          not null,
+    scopes                 varchar(512),
+    public                 boolean     default 0                 not null,
+    creatorId              bigint                                not null,
+    createTime             datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists oauth_client_uk_clientId on oauth_client (clientId);
+
+create table if not exists oauth_consent
+(
+    id         integer primary key autoincrement,
+    userId     bigint                                not null,
+    clientId   varchar(64)                           not null,
+    scopes     varchar(512),
+    createTime datetime    default CURRENT_TIMESTAMP,
+    updateTime datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists oauth_consent_uk_userId_clientId on oauth_consent (userId, clientId);
+
+create table if not exists oauth_refresh_token
+(
+    id         integer primary key autoincrement,
+    tokenHash  varchar(64)                           not null,
+    clientId   varchar(64)                           not null,
+    userId     bigint                                not null,
+    scope      varchar(512),
+    expireTime datetime                              not null,
+    revoked    boolean     default 0                 not null,
+    createTime datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists oauth_refresh_token_uk_tokenHash on oauth_refresh_token (tokenHash);
+create index if not exists oauth_refresh_token_idx_clientId on oauth_refresh_token (clientId);
+create index if not exists oauth_refresh_token_idx_userId on oauth_refresh_token (userId);
+
+create table if not exists user_identity
+(
+    id            integer primary key autoincrement,
+    userId        bigint                                not null,
+    provider      varchar(64)                           not null,
+    subject       varchar(256)                          not null,
+    email         varchar(512),
+    createTime    datetime    default CURRENT_TIMESTAMP,
+    lastLoginTime datetime   
+);
+create unique index if not exists user_identity_uk_provider_subject on user_identity (provider, subject);
+create index if not exists user_identity_idx_userId on user_identity (userId);
+
+create table if not exists user_group
+(
+    id          integer primary key autoincrement,
+    displayName varchar(256)                          not null,
+    externalId  varchar(256),
+    createTime  datetime    default CURRENT_TIMESTAMP,
+    updateTime  datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists user_group_uk_displayName on user_group (displayName);
+
+create table if not exists user_group_member
+(
+    id         integer primary key autoincrement,
+    groupId    bigint                                not null,
+    userId     bigint                                not null,
+    createTime datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists user_group_member_uk_group_user on user_group_member (groupId, userId);
+create index if not exists user_group_member_idx_userId on user_group_member (userId);
+
+create table if not exists user_access_token
+(
+    id           integer primary key autoincrement,
+    userId       bigint                                not null,
+    name         varchar(64)                           not null,
+    tokenHash    char(64)                              not null,
+    tokenPrefix  varchar(16)                           not null,
+    scopes       varchar(256)                          not null,
+    expireTime   datetime                              not null,
+    lastUsedTime datetime   ,
+    lastUsedIp   varchar(64),
+    revokeTime   datetime   ,
+    createTime   datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists user_access_token_uk_tokenHash on user_access_token (tokenHash);
+create index if not exists user_access_token_idx_userId on user_access_token (userId);
+
+create table if not exists user_passkey
+(
+    id              integer primary key autoincrement,
+    userId          bigint                                not null,
+    name            varchar(64)                           not null,
+    credentialId    varchar(512)                          not null,
+    publicKey       blob                                  not null,
+    attestationType varchar(64),
+    transports      varchar(128),
+    aaguid          varchar(64),
+    signCount       bigint      default 0                 not null,
+    backupEligible  boolean     default 0                 not null,
+    backupState     boolean     default 0                 not null,
+    lastUsedTime    datetime   ,
+    createTime      datetime    default CURRENT_TIMESTAMP
+);
+create unique index if not exists user_passkey_uk_credentialId on user_passkey (credentialId);
+create index if not exists user_passkey_idx_userId on user_passkey (userId);
@@ -14,9 +14,128 @@ type User struct {
 	Email        string
 	UserStatus   int32     `gorm:"column:userStatus"`
 	CreateTime   time.Time `gorm:"column:createTime"`
-	UpdateTime   time.Time `gorm:"column:updateTime"`
+	UpdateTime   time.Time `gorm:"column:updateTime;autoUpdateTime"`
 	IsDelete     int32     `gorm:"column:isDelete"`
 	Role         int32
+	UserType     int32 `gorm:"column:userType"`
+	OwnerId      int32 `gorm:"column:ownerId"`
+}
+
+type UserApproval struct {
+	Id         int32
+	UserId     int32 `gorm:"column:userId"`
+	ReviewerId int32 `gorm:"column:reviewerId"`
+	UserStatus int32 `gorm:"column:userStatus"`
+	Reason     string
+	CreateTime time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type AuditLog struct {
+	Id         int64
+	ActorId    int32 `gorm:"column:actorId"`
+	TargetId   int32 `gorm:"column:targetId"`
+	Action     string
+	Ip         string
+	UserAgent  string `gorm:"column:userAgent"`
+	Result     string
+	Reason     string
+	Detail     string
+	CreateTime time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type LoginHistory struct {
+	Id           int64
+	UserId       int32  `gorm:"column:userId"`
+	UserAccount  string `gorm:"column:userAccount"`
+	Ip           string
+	UserAgent    string `gorm:"column:userAgent"`
+	Fingerprint  string
+	Method       string
+	Success      bool
+	Unrecognized bool
+	Reason       string
+	CreateTime   time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type Webhook struct {
+	Id         int64
+	Url        string
+	Secret     string
+	Events     string
+	CreatorId  int32     `gorm:"column:creatorId"`
+	CreateTime time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type WebhookDelivery struct {
+	Id              int64
+	WebhookId       int64  `gorm:"column:webhookId"`
+	EventId         string `gorm:"column:eventId"`
+	EventType       string `gorm:"column:eventType"`
+	Payload         string
+	Status          string
+	Attempts        int32
+	NextAttemptTime time.Time `gorm:"column:nextAttemptTime"`
+	LastStatusCode  int32     `gorm:"column:lastStatusCode"`
+	LastError       string    `gorm:"column:lastError"`
+	CreateTime      time.Time `gorm:"column:createTime;autoCreateTime"`
+	UpdateTime      time.Time `gorm:"column:updateTime;autoUpdateTime"`
+}
+
+type OutboxEvent struct {
+	Id              int64
+	EventId         string `gorm:"column:eventId"`
+	EventType       string `gorm:"column:eventType"`
+	UserId          int32  `gorm:"column:userId"`
+	Payload         string
+	Status          string
+	Attempts        int32
+	NextAttemptTime time.Time `gorm:"column:nextAttemptTime"`
+	LastError       string    `gorm:"column:lastError"`
+	CreateTime      time.Time `gorm:"column:createTime;autoCreateTime"`
+	UpdateTime      time.Time `gorm:"column:updateTime;autoUpdateTime"`
+}
+
+type UserChangeLog struct {
+	Seq        int64  `gorm:"primaryKey"`
+	EventId    string `gorm:"column:eventId"`
+	ChangeType string `gorm:"column:changeType"`
+	UserId     int32  `gorm:"column:userId"`
+	Payload    string
+	CreateTime time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type OauthClient struct {
+	Id                     int64
+	ClientId               string `gorm:"column:clientId"`
+	ClientSecretHash       string `gorm:"column:clientSecretHash"`
+	Name                   string
+	RedirectUris           string    `gorm:"column:redirectUris"`
+	PostLogoutRedirectUris string    `gorm:"column:postLogoutRedirectUris"`
+	GrantTypes             string    `gorm:"column:grantTypes"`
+	Scopes                 string    `gorm:"column:scopes"`
+	Public                 bool      `gorm:"column:public"`
+	CreatorId              int32     `gorm:"column:creatorId"`
+	CreateTime             time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type OauthConsent struct {
+	Id         int64
+	UserId     int32     `gorm:"column:userId"`
+	ClientId   string    `gorm:"column:clientId"`
+	Scopes     string    `gorm:"column:scopes"`
+	CreateTime time.Time `gorm:"column:createTime;autoCreateTime"`
+	UpdateTime time.Time `gorm:"column:updateTime;autoUpdateTime"`
+}
+
+type OauthRefreshToken struct {
+	Id         int64
+	TokenHash  string    `gorm:"column:tokenHash"`
+	ClientId   string    `gorm:"column:clientId"`
+	UserId     int32     `gorm:"column:userId"`
+	Scope      string    `gorm:"column:scope"`
+	ExpireTime time.Time `gorm:"column:expireTime"`
+	Revoked    bool      `gorm:"column:revoked"`
+	CreateTime time.Time `gorm:"column:createTime;autoCreateTime"`
 }
 
 ////easyjson:json
@@ -70,3 +189,65 @@ type User struct {
 //	Followed string `gorm:"uniqueIndex:idx_follow;index;size:20"`
 //	Status   int32
 //}
+
+type UserIdentity struct {
+	Id            int64
+	UserId        int32     `gorm:"column:userId"`
+	Provider      string    `gorm:"column:provider"`
+	Subject       string    `gorm:"column:subject"`
+	Email         string    `gorm:"column:email"`
+	CreateTime    time.Time `gorm:"column:createTime;autoCreateTime"`
+	LastLoginTime time.Time `gorm:"column:lastLoginTime"`
+}
+
+type UserGroup struct {
+	Id          int64
+	DisplayName string    `gorm:"column:displayName"`
+	ExternalId  string    `gorm:"column:externalId"`
+	CreateTime  time.Time `gorm:"column:createTime;autoCreateTime"`
+	UpdateTime  time.Time `gorm:"column:updateTime;autoUpdateTime"`
+}
+
+type UserGroupMember struct {
+	Id         int64
+	GroupId    int64     `gorm:"column:groupId"`
+	UserId     int32     `gorm:"column:userId"`
+	CreateTime time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type UserAccessToken struct {
+	Id           int64
+	UserId       int32     `gorm:"column:userId"`
+	Name         string    `gorm:"column:name"`
+	TokenHash    string    `gorm:"column:tokenHash"`
+	TokenPrefix  string    `gorm:"column:tokenPrefix"`
+	Scopes       string    `gorm:"column:scopes"`
+	ExpireTime   time.Time `gorm:"column:expireTime"`
+	LastUsedTime time.Time `gorm:"column:lastUsedTime"`
+	LastUsedIp   string    `gorm:"column:lastUsedIp"`
+	RevokeTime   time.Time `gorm:"column:revokeTime"`
+	CreateTime   time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type UserPasskey struct {
+	Id              int64
+	UserId          int32     `gorm:"column:userId"`
+	Name            string    `gorm:"column:name"`
+	CredentialId    string    `gorm:"column:credentialId"`
+	PublicKey       []byte    `gorm:"column:publicKey"`
+	AttestationType string    `gorm:"column:attestationType"`
+	Transports      string    `gorm:"column:transports"`
+	Aaguid          string    `gorm:"column:aaguid"`
+	SignCount       int64     `gorm:"column:signCount"`
+	BackupEligible  bool      `gorm:"column:backupEligible"`
+	BackupState     bool      `gorm:"column:backupState"`
+	LastUsedTime    time.Time `gorm:"column:lastUsedTime"`
+	CreateTime      time.Time `gorm:"column:createTime;autoCreateTime"`
+}
+
+type UserSession struct {
+	UserId     int32     `gorm:"column:userId;primaryKey;autoIncrement:false"`
+	Session    string    `gorm:"column:session"`
+	ExpireTime time.Time `gorm:"column:expireTime"`
+	UpdateTime time.Time `gorm:"column:updateTime;autoUpdateTime"`
+}
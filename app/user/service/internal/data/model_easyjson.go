@@ -66,6 +66,10 @@ func easyjsonC80ae7adDecodeGithubComUserCenterUserCenterBackendAppUserServiceInt
 			out.IsDelete = int32(in.Int32())
 		case "role":
 			out.Role = int32(in.Int32())
+		case "userType":
+			out.UserType = int32(in.Int32())
+		case "ownerId":
+			out.OwnerId = int32(in.Int32())
 		default:
 			in.SkipRecursive()
 		}
@@ -145,6 +149,16 @@ func easyjsonC80ae7adEncodeGithubComUserCenterUserCenterBackendAppUserServiceInt
 		out.RawString(prefix)
 		out.Int32(int32(in.Role))
 	}
+	{
+		const prefix string = ",\"userType\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.UserType))
+	}
+	{
+		const prefix string = ",\"ownerId\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.OwnerId))
+	}
 	out.RawByte('}')
 }
 
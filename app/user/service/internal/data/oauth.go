@@ -0,0 +1,198 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ biz.OAuthRepo = (*oauthRepo)(nil)
+
+type oauthRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewOAuthRepo 内存模式下授权码保存在内存中
+func NewOAuthRepo(data *Data, logger log.Logger) biz.OAuthRepo {
+	repo := &oauthRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/oauth")),
+	}
+	if data.memory != nil {
+		return &memoryOAuthRepo{oauthRepo: repo, store: data.memory}
+	}
+	return repo
+}
+
+func (r *oauthRepo) CreateOAuthClient(ctx context.Context, client *biz.OAuthClient) error {
+	record := &OauthClient{}
+	util.StructAssign(record, client)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create oauth client: name(%s)", client.Name))
+	}
+	client.Id = record.Id
+	client.CreateTime = record.CreateTime
+	return nil
+}
+
+func (r *oauthRepo) GetOAuthClient(ctx context.Context, clientId string) (*biz.OAuthClient, error) {
+	record := &OauthClient{}
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"clientId": clientId}).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("oauth client not found", fmt.Sprintf("clientId(%s)", clientId))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get oauth client: clientId(%s)", clientId))
+	}
+
+	client := &biz.OAuthClient{}
+	util.StructAssign(client, record)
+	return client, nil
+}
+
+func (r *oauthRepo) ListOAuthClients(ctx context.Context) ([]*biz.OAuthClient, error) {
+	list := make([]*OauthClient, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Order("id").Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list oauth clients")
+	}
+
+	clients := make([]*biz.OAuthClient, 0, len(list))
+	for _, item := range list {
+		client := &biz.OAuthClient{}
+		util.StructAssign(client, item)
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+// DeleteOAuthClient 删除应用及其授权记录和刷新令牌
+func (r *oauthRepo) DeleteOAuthClient(ctx context.Context, clientId string) error {
+	return r.data.ExecTx(ctx, func(ctx context.Context) error {
+		db := r.data.DB(ctx).WithContext(ctx)
+		err := db.Where(map[string]interface{}{"clientId": clientId}).Delete(&OauthRefreshToken{}).Error
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("fail to delete oauth refresh tokens: clientId(%s)", clientId))
+		}
+		err = db.Where(map[string]interface{}{"clientId": clientId}).Delete(&OauthConsent{}).Error
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("fail to delete oauth consents: clientId(%s)", clientId))
+		}
+		err = db.Where(map[string]interface{}{"clientId": clientId}).Delete(&OauthClient{}).Error
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("fail to delete oauth client: clientId(%s)", clientId))
+		}
+		return nil
+	})
+}
+
+func (r *oauthRepo) GetOAuthConsent(ctx context.Context, userId int32, clientId string) (*biz.OAuthConsent, error) {
+	record := &OauthConsent{}
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"userId": userId, "clientId": clientId}).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("oauth consent not found", fmt.Sprintf("userId(%v), clientId(%s)", userId, clientId))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get oauth consent: userId(%v), clientId(%s)", userId, clientId))
+	}
+
+	consent := &biz.OAuthConsent{}
+	util.StructAssign(consent, record)
+	return consent, nil
+}
+
+func (r *oauthRepo) SaveOAuthConsent(ctx context.Context, consent *biz.OAuthConsent) error {
+	record := &OauthConsent{}
+	util.StructAssign(record, consent)
+	var err error
+	if record.Id == 0 {
+		err = r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	} else {
+		err = r.data.DB(ctx).WithContext(ctx).Model(&OauthConsent{}).Where("id = ?", record.Id).Update("scopes", record.Scopes).Error
+	}
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to save oauth consent: userId(%v), clientId(%s)", consent.UserId, consent.ClientId))
+	}
+	consent.Id = record.Id
+	return nil
+}
+
+func (r *oauthRepo) SetAuthorizationCode(ctx context.Context, codeHash string, code *biz.OAuthAuthorizationCode, timeout time.Duration) error {
+	marshal, err := json.Marshal(code)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("json marshal error: code(%v)", code))
+	}
+	err = r.data.redisCli.Set(ctx, r.authorizationCodeKey(codeHash), string(marshal), timeout).Err()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to set authorization code: clientId(%s), userId(%v)", code.ClientId, code.UserId))
+	}
+	return nil
+}
+
+// TakeAuthorizationCode 读取并删除授权码，保证只能兑换一次
+func (r *oauthRepo) TakeAuthorizationCode(ctx context.Context, codeHash string) (*biz.OAuthAuthorizationCode, error) {
+	result, err := r.data.redisCli.GetDel(ctx, r.authorizationCodeKey(codeHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, kerrors.NotFound("authorization code not found", "")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to take authorization code")
+	}
+
+	code := &biz.OAuthAuthorizationCode{}
+	err = json.Unmarshal([]byte(result), code)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("json unmarshal error: code(%v)", result))
+	}
+	return code, nil
+}
+
+func (r *oauthRepo) CreateRefreshToken(ctx context.Context, token *biz.OAuthRefreshToken) error {
+	record := &OauthRefreshToken{}
+	util.StructAssign(record, token)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create refresh token: clientId(%s), userId(%v)", token.ClientId, token.UserId))
+	}
+	token.Id = record.Id
+	return nil
+}
+
+func (r *oauthRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*biz.OAuthRefreshToken, error) {
+	record := &OauthRefreshToken{}
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"tokenHash": tokenHash}).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("refresh token not found", "")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to get refresh token")
+	}
+
+	token := &biz.OAuthRefreshToken{}
+	util.StructAssign(token, record)
+	return token, nil
+}
+
+// RevokeRefreshToken 吊销刷新令牌，返回false表示令牌已被吊销
+func (r *oauthRepo) RevokeRefreshToken(ctx context.Context, tokenHash string) (bool, error) {
+	result := r.data.DB(ctx).WithContext(ctx).Model(&OauthRefreshToken{}).
+		Where(map[string]interface{}{"tokenHash": tokenHash, "revoked": false}).Update("revoked", true)
+	if result.Error != nil {
+		return false, errors.Wrapf(result.Error, "fail to revoke refresh token")
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *oauthRepo) authorizationCodeKey(codeHash string) string {
+	return r.data.redisKey(fmt.Sprintf("%s_oauth_code_%s", r.data.conf.UserLoginState, codeHash))
+}
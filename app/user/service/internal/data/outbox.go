@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var _ biz.OutboxRepo = (*outboxRepo)(nil)
+
+type outboxRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewOutboxRepo(data *Data, logger log.Logger) biz.OutboxRepo {
+	return &outboxRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/outbox")),
+	}
+}
+
+// CreateOutboxEvent 写入发件箱，ctx携带事务时与业务变更一同提交
+func (r *outboxRepo) CreateOutboxEvent(ctx context.Context, event *biz.OutboxEvent) error {
+	record := &OutboxEvent{}
+	util.StructAssign(record, event)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create outbox event: eventId(%s), eventType(%s)", event.EventId, event.EventType))
+	}
+	event.Id = record.Id
+	return nil
+}
+
+// ListDueOutboxEvents 查询到期待发布的事件
+func (r *outboxRepo) ListDueOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*biz.OutboxEvent, error) {
+	list := make([]*OutboxEvent, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"status": biz.OutboxEventPending}).Where(clause.Lte{Column: "nextAttemptTime", Value: now}).
+		Order("id").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list due outbox events")
+	}
+
+	events := make([]*biz.OutboxEvent, 0, len(list))
+	for _, item := range list {
+		event := &biz.OutboxEvent{}
+		util.StructAssign(event, item)
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// ClaimOutboxEvent 将发布时间推迟到租约结束，更新成功表示抢占到该事件
+func (r *outboxRepo) ClaimOutboxEvent(ctx context.Context, id int64, nextAttemptTime, leaseUntil time.Time) (bool, error) {
+	result := r.data.DB(ctx).WithContext(ctx).Model(&OutboxEvent{}).
+		Where(map[string]interface{}{"id": id, "status": biz.OutboxEventPending, "nextAttemptTime": nextAttemptTime}).
+		Update("nextAttemptTime", leaseUntil)
+	if result.Error != nil {
+		return false, errors.Wrapf(result.Error, fmt.Sprintf("fail to claim outbox event: id(%v)", id))
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *outboxRepo) UpdateOutboxEvent(ctx context.Context, event *biz.OutboxEvent) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&OutboxEvent{}).Where("id = ?", event.Id).Updates(map[string]interface{}{
+		"status":          event.Status,
+		"attempts":        event.Attempts,
+		"nextAttemptTime": event.NextAttemptTime,
+		"lastError":       event.LastError,
+	}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to update outbox event: id(%v)", event.Id))
+	}
+	return nil
+}
+
+// PrunePublishedOutboxEvents 删除发布时间早于before的事件
+func (r *outboxRepo) PrunePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	result := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"status": biz.OutboxEventPublished}).Where(clause.Lt{Column: "updateTime", Value: before}).Delete(&OutboxEvent{})
+	if result.Error != nil {
+		return 0, errors.Wrapf(result.Error, fmt.Sprintf("fail to prune outbox events: before(%v)", before))
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,156 @@
+package data
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"testing"
+	"time"
+)
+
+type failingPublisher struct {
+	calls int
+}
+
+func (p *failingPublisher) Publish(context.Context, *biz.OutboxEvent) error {
+	p.calls++
+	return errors.New("bus unavailable")
+}
+
+func createOutboxEvent(t *testing.T, repo biz.OutboxRepo, eventId string) *biz.OutboxEvent {
+	t.Helper()
+	event := &biz.OutboxEvent{EventId: eventId, EventType: "user.updated", UserId: 1, Payload: `{"id":1}`,
+		Status: biz.OutboxEventPending, NextAttemptTime: time.Now().Add(-time.Second)}
+	err := repo.CreateOutboxEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("create outbox event: %v", err)
+	}
+	return event
+}
+
+func getOutboxEvent(t *testing.T, d *Data, id int64) *OutboxEvent {
+	t.Helper()
+	record := &OutboxEvent{}
+	err := d.db.Where("id = ?", id).First(record).Error
+	if err != nil {
+		t.Fatalf("get outbox event: %v", err)
+	}
+	return record
+}
+
+// TestOutboxClaim 同一事件只能被一个relay抢占
+func TestOutboxClaim(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestData(t, DriverSQLite, "")
+	repo := NewOutboxRepo(d, testLogger)
+	createOutboxEvent(t, repo, "evt-claim")
+
+	events, err := repo.ListDueOutboxEvents(ctx, time.Now(), 10)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("list due events: %v, %v", events, err)
+	}
+	event := events[0]
+	leaseUntil := time.Now().Add(30 * time.Second)
+	claimed, err := repo.ClaimOutboxEvent(ctx, event.Id, event.NextAttemptTime, leaseUntil)
+	if err != nil || !claimed {
+		t.Fatalf("first claim: %v, %v", claimed, err)
+	}
+	claimed, err = repo.ClaimOutboxEvent(ctx, event.Id, event.NextAttemptTime, leaseUntil)
+	if err != nil || claimed {
+		t.Fatalf("second claim: %v, %v", claimed, err)
+	}
+	// 租约内不再到期
+	events, err = repo.ListDueOutboxEvents(ctx, time.Now(), 10)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("list due events during lease: %v, %v", events, err)
+	}
+}
+
+// TestOutboxRelay 发布失败后推迟重试，发布成功后标记为已发布且不再重复发布
+func TestOutboxRelay(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestData(t, DriverSQLite, "")
+	repo := NewOutboxRepo(d, testLogger)
+	event := createOutboxEvent(t, repo, "evt-relay")
+
+	failing := &failingPublisher{}
+	err := biz.NewOutboxRelay(repo, failing, testUserConstant(), testLogger).RelayJob().Run(ctx)
+	if err != nil {
+		t.Fatalf("relay with failing publisher: %v", err)
+	}
+	record := getOutboxEvent(t, d, event.Id)
+	if failing.calls != 1 || record.Status != biz.OutboxEventPending || record.Attempts != 1 || record.LastError == "" || !record.NextAttemptTime.After(time.Now()) {
+		t.Fatalf("event after failed publish: calls(%v), %+v", failing.calls, record)
+	}
+
+	// 退避期间不会再次发布
+	err = biz.NewOutboxRelay(repo, failing, testUserConstant(), testLogger).RelayJob().Run(ctx)
+	if err != nil || failing.calls != 1 {
+		t.Fatalf("relay during backoff: calls(%v), %v", failing.calls, err)
+	}
+
+	err = d.db.Model(&OutboxEvent{}).Where("id = ?", event.Id).Update("nextAttemptTime", time.Now().Add(-time.Second)).Error
+	if err != nil {
+		t.Fatalf("expire backoff: %v", err)
+	}
+	publisher := NewMemoryEventPublisher()
+	relay := biz.NewOutboxRelay(repo, publisher, testUserConstant(), testLogger)
+	for i := 0; i < 2; i++ {
+		err = relay.RelayJob().Run(ctx)
+		if err != nil {
+			t.Fatalf("relay: %v", err)
+		}
+	}
+	published := publisher.Events()
+	if len(published) != 1 || published[0].EventId != "evt-relay" || published[0].Attempts != 2 {
+		t.Fatalf("published events: %+v", published)
+	}
+	record = getOutboxEvent(t, d, event.Id)
+	if record.Status != biz.OutboxEventPublished || record.Attempts != 2 || record.LastError != "" {
+		t.Fatalf("event after publish: %+v", record)
+	}
+}
+
+// TestRedisStreamPublisher 事件写入带前缀的stream，同一EventId只写入一次
+func TestRedisStreamPublisher(t *testing.T) {
+	ctx := context.Background()
+	d, server := newTestData(t, DriverSQLite, "", func(c *conf.Data) {
+		c.Redis.KeyPrefix = "{test}:"
+	})
+	publisher := NewEventPublisher(d, &conf.Data{EventBus: &conf.Data_EventBus{Stream: "events", MaxLen: 100}}, testLogger)
+
+	event := &biz.OutboxEvent{EventId: "evt-stream", EventType: "user.created", UserId: 7, Payload: `{"id":7}`}
+	for i := 0; i < 2; i++ {
+		err := publisher.Publish(ctx, event)
+		if err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	entries, err := d.redisCli.XRange(ctx, "{test}:events", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("stream entries: %+v", entries)
+	}
+	values := entries[0].Values
+	if values["id"] != "evt-stream" || values["type"] != "user.created" || values["userId"] != "7" || values["payload"] != `{"id":7}` {
+		t.Fatalf("stream entry: %+v", values)
+	}
+	if !server.Exists("{test}:events:dedup:evt-stream") {
+		t.Fatalf("dedup key is missing: %v", server.Keys())
+	}
+
+	// 去重键过期后视为新事件
+	server.FastForward(25 * time.Hour)
+	err = publisher.Publish(ctx, event)
+	if err != nil {
+		t.Fatalf("publish after dedup ttl: %v", err)
+	}
+	length, err := d.redisCli.XLen(ctx, "{test}:events").Result()
+	if err != nil || length != 2 {
+		t.Fatalf("stream length after dedup ttl: %v, %v", length, err)
+	}
+}
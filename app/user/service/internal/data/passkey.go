@@ -0,0 +1,78 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"time"
+)
+
+var _ biz.PasskeyRepo = (*passkeyRepo)(nil)
+
+type passkeyRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewPasskeyRepo(data *Data, logger log.Logger) biz.PasskeyRepo {
+	return &passkeyRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/passkey")),
+	}
+}
+
+func (r *passkeyRepo) CreatePasskey(ctx context.Context, passkey *biz.Passkey) error {
+	record := &UserPasskey{}
+	util.StructAssign(record, passkey)
+	err := r.data.DB(ctx).WithContext(ctx).Select("userId", "name", "credentialId", "publicKey", "attestationType", "transports", "aaguid", "signCount", "backupEligible", "backupState", "createTime").Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create passkey: userId(%v), name(%s)", passkey.UserId, passkey.Name))
+	}
+	passkey.Id = record.Id
+	passkey.CreateTime = record.CreateTime
+	return nil
+}
+
+func (r *passkeyRepo) ListPasskeys(ctx context.Context, userId int32) ([]*biz.Passkey, error) {
+	list := make([]*UserPasskey, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"userId": userId}).Order("id desc").Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list passkeys: userId(%v)", userId))
+	}
+
+	passkeys := make([]*biz.Passkey, 0, len(list))
+	for _, item := range list {
+		passkey := &biz.Passkey{}
+		util.StructAssign(passkey, item)
+		passkeys = append(passkeys, passkey)
+	}
+	return passkeys, nil
+}
+
+// UpdatePasskeyUsage 登录成功后更新签名计数、备份状态和最近使用时间
+func (r *passkeyRepo) UpdatePasskeyUsage(ctx context.Context, passkey *biz.Passkey) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&UserPasskey{}).Where("id = ?", passkey.Id).Updates(map[string]interface{}{
+		"signCount":    passkey.SignCount,
+		"backupState":  passkey.BackupState,
+		"lastUsedTime": time.Now(),
+	}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to update passkey usage: id(%v)", passkey.Id))
+	}
+	return nil
+}
+
+func (r *passkeyRepo) DeletePasskey(ctx context.Context, id int64, userId int32) error {
+	result := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"id": id, "userId": userId}).Delete(&UserPasskey{})
+	if result.Error != nil {
+		return errors.Wrapf(result.Error, fmt.Sprintf("fail to delete passkey: id(%v), userId(%v)", id, userId))
+	}
+	if result.RowsAffected == 0 {
+		return kerrors.NotFound("passkey not found", fmt.Sprintf("id(%v), userId(%v)", id, userId))
+	}
+	return nil
+}
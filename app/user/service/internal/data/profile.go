@@ -0,0 +1,262 @@
+package data
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// userProfileSchema 缓存内容的格式版本，User结构变化时修改，旧格式的缓存不再读取
+const userProfileSchema = "v2"
+
+const (
+	defaultProfileCacheTTL = 10 * time.Minute
+	defaultProfileLocalTTL = 5 * time.Second
+)
+
+// profileCache 用户信息缓存
+//1. Redis中按用户保存版本号，缓存键包含版本号，修改用户后版本号加一，旧版本的缓存不再读取，等待过期
+//2. 缓存未命中时同一个用户的并发请求只查询一次数据库，查询主库，避免把副本上的旧数据写入新版本的缓存
+//3. 可选的进程内LRU缓存，有效期较短，本进程修改用户时立即删除，其他实例修改时到期后失效
+//4. 缓存中不保存密码，需要密码时使用biz.ReadPrimary直接查询数据库
+type profileCache struct {
+	disable bool
+	ttl     time.Duration
+	local   *lruCache
+	group   singleflight.Group
+
+	localHits     uint64
+	redisHits     uint64
+	misses        uint64
+	errors        uint64
+	invalidations uint64
+}
+
+func newProfileCache(c *conf.Data_ProfileCache) *profileCache {
+	ttl := c.GetTtl().AsDuration()
+	if ttl <= 0 {
+		ttl = defaultProfileCacheTTL
+	}
+	cache := &profileCache{
+		disable: c.GetDisable(),
+		ttl:     ttl,
+	}
+	if c.GetLocalSize() > 0 {
+		localTTL := c.GetLocalTtl().AsDuration()
+		if localTTL <= 0 {
+			localTTL = defaultProfileLocalTTL
+		}
+		cache.local = newLRUCache(int(c.GetLocalSize()), localTTL)
+	}
+	return cache
+}
+
+// Stats 缓存命中统计
+func (c *profileCache) Stats() *biz.CacheStats {
+	return &biz.CacheStats{
+		Name:          "user_profile",
+		LocalHits:     atomic.LoadUint64(&c.localHits),
+		RedisHits:     atomic.LoadUint64(&c.redisHits),
+		Misses:        atomic.LoadUint64(&c.misses),
+		Errors:        atomic.LoadUint64(&c.errors),
+		Invalidations: atomic.LoadUint64(&c.invalidations),
+	}
+}
+
+func (d *Data) profileVersionKey(userId int32) string {
+	return d.redisKey(fmt.Sprintf("%s_profile_version_%v", d.conf.UserLoginState, userId))
+}
+
+func (d *Data) profileKey(userId int32, version string) string {
+	return d.redisKey(fmt.Sprintf("%s_profile_%s_%v_%s", d.conf.UserLoginState, userProfileSchema, userId, version))
+}
+
+// getUserProfile 读取用户信息
+//1. 事务中或要求读主库时直接查询数据库，不读写缓存
+//2. 依次读取进程内缓存、Redis缓存，都未命中时查询主库并写入缓存
+//3. Redis不可用时查询数据库，不影响读取
+func (d *Data) getUserProfile(ctx context.Context, userId int32) (*User, error) {
+	_, inTx := ctx.Value(contextTxKey{}).(*gorm.DB)
+	if d.profiles.disable || inTx || biz.IsReadPrimary(ctx) {
+		return d.loadUser(d.ReadDB(ctx).WithContext(ctx), userId)
+	}
+	if user, ok := d.profiles.local.get(userId); ok {
+		atomic.AddUint64(&d.profiles.localHits, 1)
+		return user, nil
+	}
+
+	result, err, _ := d.profiles.group.Do(strconv.Itoa(int(userId)), func() (interface{}, error) {
+		// 共享的查询不随某一个请求取消
+		return d.loadUserProfile(context.WithoutCancel(ctx), userId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	user := *result.(*User)
+	return &user, nil
+}
+
+func (d *Data) loadUserProfile(ctx context.Context, userId int32) (*User, error) {
+	version, err := d.redisCli.Get(ctx, d.profileVersionKey(userId)).Result()
+	if errors.Is(err, redis.Nil) {
+		version, err = "0", nil
+	}
+	if err != nil {
+		atomic.AddUint64(&d.profiles.errors, 1)
+		d.log.Warnf("fail to get user profile version from redis, load from db: userId(%v), error(%v)", userId, err)
+		return d.loadUser(d.ReadDB(ctx).WithContext(ctx), userId)
+	}
+
+	key := d.profileKey(userId, version)
+	result, err := d.redisCli.Get(ctx, key).Result()
+	if err == nil {
+		user := &User{}
+		err = user.UnmarshalJSON([]byte(result))
+		if err == nil {
+			atomic.AddUint64(&d.profiles.redisHits, 1)
+			d.profiles.local.set(userId, user)
+			return user, nil
+		}
+	}
+	if err != nil && !errors.Is(err, redis.Nil) {
+		atomic.AddUint64(&d.profiles.errors, 1)
+		d.log.Warnf("fail to get user profile from redis: userId(%v), error(%v)", userId, err)
+	}
+
+	atomic.AddUint64(&d.profiles.misses, 1)
+	user, err := d.loadUser(d.db.WithContext(ctx), userId)
+	if err != nil {
+		return nil, err
+	}
+	user.UserPassword = ""
+	marshal, err := user.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to set user profile to json: userId(%v)", userId))
+	}
+	err = d.redisCli.Set(ctx, key, string(marshal), d.profiles.ttl).Err()
+	if err != nil {
+		atomic.AddUint64(&d.profiles.errors, 1)
+		d.log.Warnf("fail to set user profile to redis: userId(%v), error(%v)", userId, err)
+	}
+	d.profiles.local.set(userId, user)
+	return user, nil
+}
+
+func (d *Data) loadUser(db *gorm.DB, userId int32) (*User, error) {
+	user := &User{}
+	err := db.Where(map[string]interface{}{"id": userId, "isDelete": 0}).First(user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("user not found", fmt.Sprintf("userId(%v)", userId))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("get user failed: userId(%v)", userId))
+	}
+	return user, nil
+}
+
+// invalidateUserProfile 修改用户后使缓存失效，在事务中时等到事务提交后执行，避免其他请求在提交前把旧数据写入新版本的缓存
+func (d *Data) invalidateUserProfile(ctx context.Context, userIds ...int32) {
+	if d.profiles.disable {
+		return
+	}
+	d.afterCommit(ctx, func(ctx context.Context) {
+		for _, userId := range userIds {
+			atomic.AddUint64(&d.profiles.invalidations, 1)
+			d.profiles.local.remove(userId)
+			d.profiles.group.Forget(strconv.Itoa(int(userId)))
+			err := d.redisCli.Incr(ctx, d.profileVersionKey(userId)).Err()
+			if err != nil {
+				atomic.AddUint64(&d.profiles.errors, 1)
+				d.log.Errorf("fail to invalidate user profile cache, it expires in %v: userId(%v), error(%v)", d.profiles.ttl, userId, err)
+			}
+		}
+	})
+}
+
+// lruCache 进程内的LRU缓存，nil表示不使用
+type lruCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	list  *list.List
+	items map[int32]*list.Element
+}
+
+type lruEntry struct {
+	userId     int32
+	user       User
+	expireTime time.Time
+}
+
+func newLRUCache(size int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		size:  size,
+		ttl:   ttl,
+		list:  list.New(),
+		items: make(map[int32]*list.Element),
+	}
+}
+
+func (c *lruCache) get(userId int32) (*User, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.items[userId]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expireTime) {
+		c.list.Remove(element)
+		delete(c.items, userId)
+		return nil, false
+	}
+	c.list.MoveToFront(element)
+	user := entry.user
+	return &user, true
+}
+
+func (c *lruCache) set(userId int32, user *User) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &lruEntry{userId: userId, user: *user, expireTime: time.Now().Add(c.ttl)}
+	if element, ok := c.items[userId]; ok {
+		element.Value = entry
+		c.list.MoveToFront(element)
+		return
+	}
+	c.items[userId] = c.list.PushFront(entry)
+	if c.list.Len() > c.size {
+		oldest := c.list.Back()
+		c.list.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).userId)
+	}
+}
+
+func (c *lruCache) remove(userId int32) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.items[userId]; ok {
+		c.list.Remove(element)
+		delete(c.items, userId)
+	}
+}
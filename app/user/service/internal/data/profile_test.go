@@ -0,0 +1,147 @@
+package data
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newProfileTestData(t *testing.T) (*Data, biz.UserRepo, int32) {
+	d, _ := newTestData(t, DriverSQLite, "", func(c *conf.Data) {
+		c.ProfileCache = &conf.Data_ProfileCache{LocalSize: 10}
+	})
+	userId, err := NewAuthRepo(d, testLogger).UserRegister(context.Background(), "profile", biz.PasswordHash("profile"), "", biz.UserStatusNormal)
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return d, NewUserRepo(d, testLogger), userId
+}
+
+func TestProfileCacheWithoutPassword(t *testing.T) {
+	d, repo, userId := newProfileTestData(t)
+	ctx := context.Background()
+
+	user, err := repo.GetCurrentUser(ctx, userId)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if user.UserPassword != "" {
+		t.Fatalf("cached profile contains password")
+	}
+	keys, err := d.redisCli.Keys(ctx, "*profile_v*").Result()
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("profile keys: keys(%v), error(%v)", keys, err)
+	}
+	value, err := d.redisCli.Get(ctx, keys[0]).Result()
+	if err != nil {
+		t.Fatalf("get profile from redis: %v", err)
+	}
+	if strings.Contains(value, biz.PasswordHash("profile")) {
+		t.Fatalf("profile in redis contains password: %s", value)
+	}
+	local, ok := d.profiles.local.get(userId)
+	if !ok {
+		t.Fatalf("profile not in local cache")
+	}
+	if local.UserPassword != "" {
+		t.Fatalf("local profile contains password")
+	}
+
+	user, err = repo.GetCurrentUser(biz.ReadPrimary(ctx), userId)
+	if err != nil || user.UserPassword != biz.PasswordHash("profile") {
+		t.Fatalf("read primary should load password from db: user(%v), error(%v)", user, err)
+	}
+}
+
+func TestProfileCacheInvalidation(t *testing.T) {
+	d, repo, userId := newProfileTestData(t)
+	ctx := context.Background()
+
+	role, err := repo.GetUserRoleById(ctx, userId)
+	if err != nil || role != 0 {
+		t.Fatalf("role before update: role(%v), error(%v)", role, err)
+	}
+	err = repo.UpdateUserRole(ctx, userId, 1)
+	if err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	version, err := d.redisCli.Get(ctx, d.profileVersionKey(userId)).Result()
+	if err != nil || version != "1" {
+		t.Fatalf("version after update: version(%s), error(%v)", version, err)
+	}
+	if _, ok := d.profiles.local.get(userId); ok {
+		t.Fatalf("local profile not removed after update")
+	}
+	role, err = repo.GetUserRoleById(ctx, userId)
+	if err != nil || role != 1 {
+		t.Fatalf("role after update: role(%v), error(%v)", role, err)
+	}
+	if stats := d.profiles.Stats(); stats.Invalidations != 1 || stats.Misses != 2 {
+		t.Fatalf("stats: %+v", stats)
+	}
+}
+
+func TestProfileCacheInvalidationAfterCommit(t *testing.T) {
+	d, repo, userId := newProfileTestData(t)
+	ctx := context.Background()
+
+	err := d.ExecTx(ctx, func(ctx context.Context) error {
+		err := repo.UpdateUserRole(ctx, userId, 1)
+		if err != nil {
+			return err
+		}
+		exist, err := d.redisCli.Exists(ctx, d.profileVersionKey(userId)).Result()
+		if err != nil || exist != 0 {
+			t.Errorf("version bumped before commit: exist(%v), error(%v)", exist, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transaction: %v", err)
+	}
+	version, err := d.redisCli.Get(ctx, d.profileVersionKey(userId)).Result()
+	if err != nil || version != "1" {
+		t.Fatalf("version after commit: version(%s), error(%v)", version, err)
+	}
+
+	rollback := errors.New("rollback")
+	err = d.ExecTx(ctx, func(ctx context.Context) error {
+		err := repo.UpdateUserRole(ctx, userId, 0)
+		if err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("transaction: want rollback error, got %v", err)
+	}
+	version, err = d.redisCli.Get(ctx, d.profileVersionKey(userId)).Result()
+	if err != nil || version != "1" {
+		t.Fatalf("version bumped after rollback: version(%s), error(%v)", version, err)
+	}
+}
+
+func TestProfileCacheSingleLoad(t *testing.T) {
+	d, repo, userId := newProfileTestData(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.GetCurrentUser(ctx, userId)
+			if err != nil {
+				t.Errorf("get user: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if stats := d.profiles.Stats(); stats.Misses != 1 {
+		t.Fatalf("concurrent reads of a cold cache should load once: %+v", stats)
+	}
+}
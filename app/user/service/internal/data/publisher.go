@@ -0,0 +1,140 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"sync"
+	"time"
+)
+
+var _ biz.EventPublisher = (*redisStreamPublisher)(nil)
+var _ biz.EventPublisher = (*MemoryEventPublisher)(nil)
+var _ biz.EventPublisher = (*logEventPublisher)(nil)
+
+const (
+	defaultEventStream   = "user_center:events"
+	defaultEventDedupTTL = 24 * time.Hour
+)
+
+// NewEventPublisher 根据配置选择消息总线，默认使用redis stream
+func NewEventPublisher(data *Data, conf *conf.Data, logger log.Logger) biz.EventPublisher {
+	bus := conf.EventBus
+	driver := bus.GetDriver()
+	if driver == "" && data.memory != nil {
+		driver = "memory"
+	}
+	switch driver {
+	case "memory":
+		return NewMemoryEventPublisher()
+	case "log":
+		return &logEventPublisher{
+			log: log.NewHelper(log.With(logger, "module", "user/data/eventPublisher")),
+		}
+	default:
+		stream := bus.GetStream()
+		if stream == "" {
+			stream = defaultEventStream
+		}
+		dedupTTL := defaultEventDedupTTL
+		if bus.GetDedupTtl().AsDuration() > 0 {
+			dedupTTL = bus.GetDedupTtl().AsDuration()
+		}
+		return &redisStreamPublisher{
+			redisCli: data.redisCli,
+			stream:   data.redisKey(stream),
+			maxLen:   bus.GetMaxLen(),
+			dedupTTL: dedupTTL,
+		}
+	}
+}
+
+// redisStreamPublisher 使用XADD将事件写入redis stream
+type redisStreamPublisher struct {
+	redisCli redis.Cmdable
+	stream   string
+	maxLen   int64
+	dedupTTL time.Duration
+}
+
+// Publish 发布事件
+//1. 去重键存在说明事件已写入stream，直接返回成功，避免relay重试造成重复消息
+//2. XADD与去重键在同一个MULTI中提交
+func (p *redisStreamPublisher) Publish(ctx context.Context, event *biz.OutboxEvent) error {
+	dedupKey := fmt.Sprintf("%s:dedup:%s", p.stream, event.EventId)
+	exists, err := p.redisCli.Exists(ctx, dedupKey).Result()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to check event dedup key: eventId(%s)", event.EventId))
+	}
+	if exists > 0 {
+		return nil
+	}
+
+	_, err = p.redisCli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: p.stream,
+			MaxLen: p.maxLen,
+			Approx: p.maxLen > 0,
+			Values: map[string]interface{}{
+				"id":      event.EventId,
+				"type":    event.EventType,
+				"userId":  event.UserId,
+				"payload": event.Payload,
+			},
+		})
+		pipe.Set(ctx, dedupKey, 1, p.dedupTTL)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to publish event to redis stream: stream(%s), eventId(%s)", p.stream, event.EventId))
+	}
+	return nil
+}
+
+// MemoryEventPublisher 内存消息总线，按EventId去重，用于测试和本地开发
+type MemoryEventPublisher struct {
+	mu     sync.Mutex
+	seen   map[string]struct{}
+	events []*biz.OutboxEvent
+}
+
+func NewMemoryEventPublisher() *MemoryEventPublisher {
+	return &MemoryEventPublisher{
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (p *MemoryEventPublisher) Publish(_ context.Context, event *biz.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.seen[event.EventId]; ok {
+		return nil
+	}
+	p.seen[event.EventId] = struct{}{}
+	published := *event
+	p.events = append(p.events, &published)
+	return nil
+}
+
+// Events 已发布的事件
+func (p *MemoryEventPublisher) Events() []*biz.OutboxEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	events := make([]*biz.OutboxEvent, len(p.events))
+	copy(events, p.events)
+	return events
+}
+
+// logEventPublisher 仅打印日志
+type logEventPublisher struct {
+	log *log.Helper
+}
+
+func (p *logEventPublisher) Publish(_ context.Context, event *biz.OutboxEvent) error {
+	p.log.Infof("publish event: eventId(%s), eventType(%s), userId(%v), payload(%s)", event.EventId, event.EventType, event.UserId, event.Payload)
+	return nil
+}
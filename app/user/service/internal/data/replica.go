@@ -0,0 +1,175 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"gorm.io/gorm"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultReplicaCheckInterval = 10 * time.Second
+
+// Replicas 只读副本
+//1. 读请求在可用的副本间轮询
+//2. 定时检查副本，不可用的副本不再接收读请求，恢复后重新加入
+//3. 没有可用副本时读主库
+type Replicas struct {
+	replicas []*replica
+	next     uint32
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	log      *log.Helper
+}
+
+type replica struct {
+	name string
+	db   *gorm.DB
+
+	mu        sync.RWMutex
+	healthy   bool
+	lastError error
+}
+
+func NewReplicas(conf *conf.Data, logger log.Logger) (*Replicas, func()) {
+	l := log.NewHelper(log.With(logger, "module", "user/data/replicas"))
+	interval := conf.Database.GetReplicaCheckInterval().AsDuration()
+	if interval <= 0 {
+		interval = defaultReplicaCheckInterval
+	}
+	r := &Replicas{
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		log:      l,
+	}
+	if conf.Database.Driver == DriverMemory || len(conf.Database.GetReplicas()) == 0 {
+		close(r.done)
+		return r, func() {}
+	}
+
+	for i, source := range conf.Database.GetReplicas() {
+		db, err := openDB(conf.Database.Driver, source, conf.Database)
+		if err != nil {
+			l.Fatalf("failed opening connection to db replica(%d): %v", i, err)
+		}
+		r.replicas = append(r.replicas, &replica{name: fmt.Sprintf("db_replica_%d", i), db: db})
+	}
+	r.check()
+	go r.run()
+	return r, func() {
+		close(r.stop)
+		<-r.done
+		for _, item := range r.replicas {
+			sqlDB, err := item.db.DB()
+			if err == nil {
+				err = sqlDB.Close()
+			}
+			if err != nil {
+				l.Errorf("close %s err: %v", item.name, err.Error())
+			}
+		}
+	}
+}
+
+func (r *Replicas) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+// check 检查所有副本，状态变化时输出日志
+func (r *Replicas) check() {
+	for _, item := range r.replicas {
+		err := item.ping()
+		item.mu.Lock()
+		healthy := err == nil
+		if healthy != item.healthy {
+			if healthy {
+				r.log.Infof("%s available", item.name)
+			} else {
+				r.log.Errorf("%s unavailable, reads fail over to other replicas or primary: %v", item.name, err)
+			}
+		}
+		item.healthy, item.lastError = healthy, err
+		item.mu.Unlock()
+	}
+}
+
+func (item *replica) ping() error {
+	sqlDB, err := item.db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+// pick 轮询选择可用的副本，没有可用副本时返回nil
+func (r *Replicas) pick() *gorm.DB {
+	n := len(r.replicas)
+	if n == 0 {
+		return nil
+	}
+	start := atomic.AddUint32(&r.next, 1)
+	for i := 0; i < n; i++ {
+		item := r.replicas[(int(start)+i)%n]
+		item.mu.RLock()
+		healthy := item.healthy
+		item.mu.RUnlock()
+		if healthy {
+			return item.db
+		}
+	}
+	return nil
+}
+
+// Health 各副本最近一次检查的结果
+func (r *Replicas) Health() []*biz.ComponentHealth {
+	list := make([]*biz.ComponentHealth, 0, len(r.replicas))
+	for _, item := range r.replicas {
+		health := &biz.ComponentHealth{Name: item.name, Status: biz.HealthOk}
+		item.mu.RLock()
+		if !item.healthy {
+			health.Status = biz.HealthUnavailable
+			if item.lastError != nil {
+				health.Detail = item.lastError.Error()
+			}
+		}
+		item.mu.RUnlock()
+		list = append(list, health)
+	}
+	return list
+}
+
+// ReadDB 只读查询使用的连接
+//1. 事务中使用事务连接，保证读到事务内的修改
+//2. 要求读主库时使用主库，保证读到刚写入的数据
+//3. 其他情况使用可用的副本，没有配置副本或副本都不可用时使用主库
+func (d *Data) ReadDB(ctx context.Context) *gorm.DB {
+	tx, ok := ctx.Value(contextTxKey{}).(*gorm.DB)
+	if ok {
+		return tx
+	}
+	if biz.IsReadPrimary(ctx) {
+		return d.db
+	}
+	if db := d.replicas.pick(); db != nil {
+		return db
+	}
+	return d.db
+}
@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"path/filepath"
+	"testing"
+)
+
+// newTestReplica 创建已迁移的sqlite副本，副本与主库是独立的文件，用于区分读到的是哪个库
+func newTestReplica(t *testing.T) string {
+	t.Helper()
+	source := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)", filepath.Join(t.TempDir(), "replica.db"))
+	db, err := openDB(DriverSQLite, source, &conf.Data_Database{})
+	if err != nil {
+		t.Fatalf("open replica: %v", err)
+	}
+	migrator, err := NewMigrator(db, nil, testLogger)
+	if err != nil {
+		t.Fatalf("fail to load migrations: %v", err)
+	}
+	_, err = migrator.Up(context.Background())
+	if err != nil {
+		t.Fatalf("migrate replica: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err == nil {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		t.Fatalf("close replica: %v", err)
+	}
+	return source
+}
+
+// TestReadDBRouting 普通读走副本，要求读主库和事务中的读走主库，副本不可用时读主库
+func TestReadDBRouting(t *testing.T) {
+	ctx := context.Background()
+	replicaSource := newTestReplica(t)
+	d, _ := newTestData(t, DriverSQLite, "", func(c *conf.Data) {
+		c.Database.Replicas = []string{replicaSource}
+	})
+	userRepo := NewUserRepo(d, testLogger)
+	// 只写入主库，模拟复制延迟
+	userId, err := NewAuthRepo(d, testLogger).CreateUser(ctx, &biz.User{UserAccount: "replica_lag", UserName: "lagging"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	found := func(ctx context.Context) bool {
+		t.Helper()
+		users, err := userRepo.SearchUsers(ctx, "lagging", false)
+		if err != nil {
+			t.Fatalf("search users: %v", err)
+		}
+		return len(users) == 1 && users[0].Id == userId
+	}
+
+	if found(ctx) {
+		t.Fatalf("read from primary without ReadPrimary")
+	}
+	if !found(biz.ReadPrimary(ctx)) {
+		t.Fatalf("ReadPrimary did not read from primary")
+	}
+	err = d.ExecTx(ctx, func(ctx context.Context) error {
+		if !found(ctx) {
+			t.Errorf("read in transaction did not use the transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("exec tx: %v", err)
+	}
+
+	// 副本不可用后读主库，健康检查反映副本状态
+	sqlDB, err := d.replicas.replicas[0].db.DB()
+	if err != nil {
+		t.Fatalf("replica db: %v", err)
+	}
+	err = sqlDB.Close()
+	if err != nil {
+		t.Fatalf("close replica: %v", err)
+	}
+	d.replicas.check()
+	if !found(ctx) {
+		t.Fatalf("read did not fail over to primary")
+	}
+	health := d.replicas.Health()
+	if len(health) != 1 || health[0].Status != biz.HealthUnavailable {
+		t.Fatalf("replica health: %+v", health)
+	}
+}
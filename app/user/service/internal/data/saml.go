@@ -0,0 +1,277 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"github.com/crewjam/saml"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+var _ biz.SAMLProviders = (*samlProviders)(nil)
+
+// IdP元数据最大长度
+const samlMetadataMaxSize = 1 << 20
+
+type samlProviders struct {
+	providers map[string]*samlProvider
+}
+
+// NewSAMLProviders 按配置创建SP，所有IdP共用同一个SP实体id和证书，ACS地址按IdP区分
+func NewSAMLProviders(conf *conf.SAML, logger log.Logger) (biz.SAMLProviders, error) {
+	l := log.NewHelper(log.With(logger, "module", "user/data/saml"))
+	providers := make(map[string]*samlProvider)
+	if len(conf.GetProviders()) == 0 {
+		return &samlProviders{providers: providers}, nil
+	}
+
+	rootUrl, err := url.Parse(conf.GetRootUrl())
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to parse saml root url: rootUrl(%s)", conf.GetRootUrl()))
+	}
+	key, certificate, err := samlKeyPair(conf, l)
+	if err != nil {
+		return nil, err
+	}
+	entityId := conf.GetEntityId()
+	if entityId == "" {
+		entityId = rootUrl.ResolveReference(&url.URL{Path: "/saml/metadata"}).String()
+	}
+
+	client := &http.Client{Timeout: externalProviderTimeout}
+	for _, item := range conf.GetProviders() {
+		if item.GetName() == "" || (item.GetIdpMetadataFile() == "" && item.GetIdpMetadataUrl() == "") {
+			continue
+		}
+		sp := &saml.ServiceProvider{
+			EntityID:          entityId,
+			Key:               key,
+			Certificate:       certificate,
+			HTTPClient:        client,
+			MetadataURL:       *rootUrl.ResolveReference(&url.URL{Path: "/saml/metadata", RawQuery: url.Values{"provider": {item.GetName()}}.Encode()}),
+			AcsURL:            *rootUrl.ResolveReference(&url.URL{Path: fmt.Sprintf("/api/user/saml/%s/acs", item.GetName())}),
+			AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+			AllowIDPInitiated: item.GetAllowIdpInitiated(),
+		}
+		if conf.GetSignAuthnRequest() {
+			sp.SignatureMethod = dsig.RSASHA256SignatureMethod
+		}
+		provider := &samlProvider{conf: item, sp: sp, client: client}
+		if item.GetIdpMetadataFile() != "" {
+			content, err := os.ReadFile(item.GetIdpMetadataFile())
+			if err != nil {
+				return nil, errors.Wrapf(err, fmt.Sprintf("fail to read idp metadata: file(%s)", item.GetIdpMetadataFile()))
+			}
+			sp.IDPMetadata, err = parseIdPMetadata(content)
+			if err != nil {
+				return nil, errors.Wrapf(err, fmt.Sprintf("fail to parse idp metadata: file(%s)", item.GetIdpMetadataFile()))
+			}
+		}
+		providers[item.GetName()] = provider
+	}
+	return &samlProviders{providers: providers}, nil
+}
+
+func (p *samlProviders) Get(name string) (biz.SAMLProvider, error) {
+	provider, ok := p.providers[name]
+	if !ok {
+		return nil, kerrors.NotFound("saml provider not found", fmt.Sprintf("name(%s)", name))
+	}
+	return provider, nil
+}
+
+// samlProvider 只配置了元数据地址的IdP，首次使用时获取元数据，失败时下次重试
+type samlProvider struct {
+	conf   *conf.SAMLProvider
+	sp     *saml.ServiceProvider
+	client *http.Client
+	mu     sync.Mutex
+}
+
+func (p *samlProvider) Metadata() ([]byte, error) {
+	metadata, err := xml.MarshalIndent(p.sp.Metadata(), "", "  ")
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to marshal sp metadata: provider(%s)", p.conf.GetName()))
+	}
+	return metadata, nil
+}
+
+// AuthnRequestURL 使用HTTP-Redirect绑定发起AuthnRequest，返回登录地址和请求id
+func (p *samlProvider) AuthnRequestURL(ctx context.Context, relayState string) (string, string, error) {
+	sp, err := p.serviceProvider(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	req, err := sp.MakeAuthenticationRequest(sp.GetSSOBindingLocation(saml.HTTPRedirectBinding), saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return "", "", errors.Wrapf(err, fmt.Sprintf("fail to make authn request: provider(%s)", p.conf.GetName()))
+	}
+	loginUrl, err := req.Redirect(relayState, sp)
+	if err != nil {
+		return "", "", errors.Wrapf(err, fmt.Sprintf("fail to make authn request: provider(%s)", p.conf.GetName()))
+	}
+	return loginUrl.String(), req.ID, nil
+}
+
+// ParseResponse 解析HTTP-POST绑定的SAMLResponse并校验
+func (p *samlProvider) ParseResponse(ctx context.Context, samlResponse string, requestIds []string) (*biz.SAMLAssertion, error) {
+	sp, err := p.serviceProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to decode saml response: provider(%s)", p.conf.GetName()))
+	}
+	assertion, err := sp.ParseXMLResponse(decoded, requestIds)
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) && invalid.PrivateErr != nil {
+			err = invalid.PrivateErr
+		}
+		return nil, errors.Wrapf(err, fmt.Sprintf("invalid saml response: provider(%s)", p.conf.GetName()))
+	}
+
+	result := &biz.SAMLAssertion{
+		Id:         assertion.ID,
+		Attributes: make(map[string][]string),
+	}
+	if assertion.Subject != nil {
+		if assertion.Subject.NameID != nil {
+			result.NameId = assertion.Subject.NameID.Value
+		}
+		for _, confirmation := range assertion.Subject.SubjectConfirmations {
+			if confirmation.SubjectConfirmationData != nil {
+				result.InResponseTo = confirmation.SubjectConfirmationData.InResponseTo
+			}
+		}
+	}
+	if assertion.Conditions != nil {
+		result.ExpireTime = assertion.Conditions.NotOnOrAfter
+	}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			values := make([]string, 0, len(attribute.Values))
+			for _, value := range attribute.Values {
+				values = append(values, value.Value)
+			}
+			result.Attributes[attribute.Name] = append(result.Attributes[attribute.Name], values...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				result.Attributes[attribute.FriendlyName] = append(result.Attributes[attribute.FriendlyName], values...)
+			}
+		}
+	}
+	return result, nil
+}
+
+// serviceProvider 返回已加载IdP元数据的SP
+func (p *samlProvider) serviceProvider(ctx context.Context) (*saml.ServiceProvider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.sp.IDPMetadata != nil {
+		return p.sp, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.conf.GetIdpMetadataUrl(), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to create idp metadata request: provider(%s)", p.conf.GetName()))
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get idp metadata: provider(%s)", p.conf.GetName()))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fail to get idp metadata: provider(%s), status(%v)", p.conf.GetName(), resp.StatusCode)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, samlMetadataMaxSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to read idp metadata: provider(%s)", p.conf.GetName()))
+	}
+	p.sp.IDPMetadata, err = parseIdPMetadata(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to parse idp metadata: provider(%s)", p.conf.GetName()))
+	}
+	return p.sp, nil
+}
+
+// parseIdPMetadata 解析IdP元数据，EntitiesDescriptor时取第一个包含IdP描述的实体
+func parseIdPMetadata(content []byte) (*saml.EntityDescriptor, error) {
+	entity := &saml.EntityDescriptor{}
+	err := xml.Unmarshal(content, entity)
+	if err == nil && len(entity.IDPSSODescriptors) > 0 {
+		return entity, nil
+	}
+
+	entities := &saml.EntitiesDescriptor{}
+	if xml.Unmarshal(content, entities) == nil {
+		for i := range entities.EntityDescriptors {
+			if len(entities.EntityDescriptors[i].IDPSSODescriptors) > 0 {
+				return &entities.EntityDescriptors[i], nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, errors.New("no idp sso descriptor found")
+}
+
+// samlKeyPair 加载SP证书和私钥，未配置时生成临时的自签名证书
+func samlKeyPair(conf *conf.SAML, l *log.Helper) (*rsa.PrivateKey, *x509.Certificate, error) {
+	if conf.GetCertificateFile() != "" && conf.GetKeyFile() != "" {
+		pair, err := tls.LoadX509KeyPair(conf.GetCertificateFile(), conf.GetKeyFile())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, fmt.Sprintf("fail to load saml key pair: certificate(%s)", conf.GetCertificateFile()))
+		}
+		key, ok := pair.PrivateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, nil, errors.Errorf("saml private key must be rsa: key(%s)", conf.GetKeyFile())
+		}
+		certificate, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, fmt.Sprintf("fail to parse saml certificate: certificate(%s)", conf.GetCertificateFile()))
+		}
+		return key, certificate, nil
+	}
+
+	l.Warn("saml certificate not configured, generate a temporary certificate")
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fail to generate saml key")
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "user-center saml sp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fail to create saml certificate")
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fail to parse saml certificate")
+	}
+	return key, certificate, nil
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func (d *Data) sessionKey(userId int32) string {
+	return d.redisKey(fmt.Sprintf("%s_%v", d.conf.UserLoginState, userId))
+}
+
+// setSession 保存登录态
+//1. 优先写入Redis
+//2. Redis不可用且开启了数据库兜底时写入user_session表
+//3. 都失败时返回ErrSessionUnavailable，登录明确失败
+func (d *Data) setSession(ctx context.Context, userId int32, session string) error {
+	timeout := time.Second * time.Duration(d.conf.SessionTimeout)
+	err := d.redisCli.Set(ctx, d.sessionKey(userId), session, timeout).Err()
+	if err == nil {
+		return nil
+	}
+	if !d.sessionFallback {
+		return errors.Wrapf(biz.ErrSessionUnavailable, "fail to set user session to redis: userId(%v), error(%v)", userId, err)
+	}
+
+	d.log.Warnf("redis unavailable, set user session to db: userId(%v), error(%v)", userId, err)
+	dbErr := d.DB(ctx).WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "userId"}},
+		DoUpdates: clause.AssignmentColumns([]string{"session", "expireTime", "updateTime"}),
+	}).Create(&UserSession{
+		UserId:     userId,
+		Session:    session,
+		ExpireTime: time.Now().Add(timeout),
+	}).Error
+	if dbErr != nil {
+		return errors.Wrapf(biz.ErrSessionUnavailable, "fail to set user session to db: userId(%v), redis error(%v), db error(%v)", userId, err, dbErr)
+	}
+	return nil
+}
+
+// getSession 读取登录态，返回redis.Nil表示未登录
+//1. 优先读取Redis
+//2. 开启了数据库兜底时，Redis中不存在或Redis不可用都会再读取user_session表，兜底期间写入的登录态在Redis恢复后仍然有效
+//3. Redis不可用且没有兜底时返回ErrSessionUnavailable，不能当作未登录
+func (d *Data) getSession(ctx context.Context, userId int32) (string, error) {
+	result, err := d.redisCli.Get(ctx, d.sessionKey(userId)).Result()
+	if err == nil {
+		return result, nil
+	}
+	if !errors.Is(err, redis.Nil) && !d.sessionFallback {
+		return "", errors.Wrapf(biz.ErrSessionUnavailable, "fail to get user session from redis: userId(%v), error(%v)", userId, err)
+	}
+	if !d.sessionFallback {
+		return "", redis.Nil
+	}
+
+	var session UserSession
+	dbErr := d.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"userId": userId}).
+		Where(clause.Gt{Column: "expireTime", Value: time.Now()}).Take(&session).Error
+	if errors.Is(dbErr, gorm.ErrRecordNotFound) {
+		return "", redis.Nil
+	}
+	if dbErr != nil {
+		return "", errors.Wrapf(biz.ErrSessionUnavailable, "fail to get user session from db: userId(%v), redis error(%v), db error(%v)", userId, err, dbErr)
+	}
+	return session.Session, nil
+}
+
+// deleteSession 删除登录态，Redis不可用时返回ErrSessionUnavailable，避免退出登录后登录态仍然有效
+func (d *Data) deleteSession(ctx context.Context, userId int32) error {
+	if d.sessionFallback {
+		err := d.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"userId": userId}).Delete(&UserSession{}).Error
+		if err != nil {
+			return errors.Wrapf(biz.ErrSessionUnavailable, "fail to delete user session from db: userId(%v), error(%v)", userId, err)
+		}
+	}
+	err := d.redisCli.Del(ctx, d.sessionKey(userId)).Err()
+	if err != nil {
+		return errors.Wrapf(biz.ErrSessionUnavailable, "fail to delete user session from redis: userId(%v), error(%v)", userId, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package data
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"math/big"
+	"os"
+)
+
+var _ biz.TokenSigner = (*tokenSigner)(nil)
+
+// tokenSigner 使用RS256签名，kid为公钥摘要
+type tokenSigner struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+func NewTokenSigner(conf *conf.OAuth, logger log.Logger) (biz.TokenSigner, error) {
+	l := log.NewHelper(log.With(logger, "module", "user/data/tokenSigner"))
+
+	var key *rsa.PrivateKey
+	if conf.GetSigningKeyFile() == "" {
+		l.Warn("oauth signing key not configured, generate a temporary key")
+		generated, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to generate signing key")
+		}
+		key = generated
+	} else {
+		content, err := os.ReadFile(conf.GetSigningKeyFile())
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to read signing key: file(%s)", conf.GetSigningKeyFile()))
+		}
+		key, err = jwt.ParseRSAPrivateKeyFromPEM(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to parse signing key: file(%s)", conf.GetSigningKeyFile()))
+		}
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to marshal public key")
+	}
+	sum := sha256.Sum256(der)
+	return &tokenSigner{
+		key: key,
+		kid: base64.RawURLEncoding.EncodeToString(sum[:12]),
+	}, nil
+}
+
+func (s *tokenSigner) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", errors.Wrapf(err, "fail to sign token")
+	}
+	return signed, nil
+}
+
+func (s *tokenSigner) JWKS() []*biz.JSONWebKey {
+	return []*biz.JSONWebKey{
+		{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: s.kid,
+			N:   base64.RawURLEncoding.EncodeToString(s.key.PublicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.PublicKey.E)).Bytes()),
+		},
+	}
+}
+
+func (s *tokenSigner) Parse(token string, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, errors.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return &s.key.PublicKey, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "fail to parse token")
+	}
+	return nil
+}
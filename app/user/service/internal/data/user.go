@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
 	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm"
 )
 
 var _ biz.UserRepo = (*userRepo)(nil)
@@ -18,15 +19,20 @@ type userRepo struct {
 	log  *log.Helper
 }
 
+// NewUserRepo 内存模式下使用内存仓储
 func NewUserRepo(data *Data, logger log.Logger) biz.UserRepo {
+	if data.memory != nil {
+		return NewMemoryUserRepo(data.memory, logger)
+	}
 	return &userRepo{
 		data: data,
 		log:  log.NewHelper(log.With(logger, "module", "user/data/user")),
 	}
 }
 
+// GetUserRoleById 最新的用户角色，读取用户信息缓存，登录态中的角色在修改后可能已经过期
 func (r *userRepo) GetUserRoleById(ctx context.Context, userId int32) (int32, error) {
-	user, err := r.getUserFromCache(ctx, userId)
+	user, err := r.data.getUserProfile(ctx, userId)
 	if err != nil {
 		return 0, err
 	}
@@ -44,12 +50,24 @@ func (r *userRepo) GetUserSession(ctx context.Context, userId int32) (*biz.User,
 }
 
 func (r *userRepo) GetCurrentUser(ctx context.Context, userId int32) (*biz.User, error) {
-	user := &User{
-		Id: userId,
+	user, err := r.data.getUserProfile(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &biz.User{}
+	util.StructAssign(result, user)
+	return result, nil
+}
+
+func (r *userRepo) GetUserByAccount(ctx context.Context, userAccount string) (*biz.User, error) {
+	user := &User{}
+	err := r.data.db.WithContext(ctx).Where(map[string]interface{}{"userAccount": userAccount, "isDelete": 0}).First(user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, kerrors.NotFound("user not found", fmt.Sprintf("userAccount(%s)", userAccount))
 	}
-	err := r.data.db.WithContext(ctx).Where("id = ?", userId).First(user).Error
 	if err != nil {
-		return nil, errors.Wrapf(err, fmt.Sprintf("get user failed: userId(%v)", userId))
+		return nil, errors.Wrapf(err, fmt.Sprintf("get user failed: userAccount(%s)", userAccount))
 	}
 
 	result := &biz.User{}
@@ -57,15 +75,19 @@ func (r *userRepo) GetCurrentUser(ctx context.Context, userId int32) (*biz.User,
 	return result, nil
 }
 
-// SearchUsers 查询用户（允许根据用户名查询，仅管理员可查询）
-func (r *userRepo) SearchUsers(ctx context.Context, userName string) ([]*biz.User, error) {
+// SearchUsers 查询用户（允许根据用户名查询，仅管理员可查询），includeServiceAccounts为false时不返回服务账号
+func (r *userRepo) SearchUsers(ctx context.Context, userName string, includeServiceAccounts bool) ([]*biz.User, error) {
 	list := make([]*User, 0)
 	var err error
+	db := r.data.ReadDB(ctx).WithContext(ctx)
+	if !includeServiceAccounts {
+		db = db.Where(map[string]interface{}{"userType": biz.UserTypeNormal})
+	}
 	switch userName {
 	case "":
-		err = r.data.db.WithContext(ctx).Where("isDelete = 0").Find(&list).Error
+		err = db.Where(map[string]interface{}{"isDelete": 0}).Find(&list).Error
 	default:
-		err = r.data.db.WithContext(ctx).Where("userName like ? and isDelete = 0", userName).Find(&list).Error
+		err = db.Where(map[string]interface{}{"isDelete": 0}).Where("username like ?", userName).Find(&list).Error
 	}
 	if err != nil {
 		return nil, errors.Wrapf(err, fmt.Sprintf("fail to search users: userName(%s)", userName))
@@ -80,20 +102,182 @@ func (r *userRepo) SearchUsers(ctx context.Context, userName string) ([]*biz.Use
 	return search, nil
 }
 
-// DeleteUser 删除用户
+// DeleteUser 逻辑删除用户，所有查询都会过滤已删除的用户
 func (r *userRepo) DeleteUser(ctx context.Context, userId int32) error {
-	user := &User{}
-	user.Id = userId
-	user.IsDelete = 1
-	err := r.data.db.WithContext(ctx).Where("id = ? and isDelete = 0", userId).Delete(user).Error
+	err := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"id": userId, "isDelete": 0}).Update("isDelete", 1).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to delete user: userId(%v)", userId))
+	}
+	r.data.invalidateUserProfile(ctx, userId)
+	return nil
+}
+
+// ListUsersByStatus 根据用户状态分页查询用户
+func (r *userRepo) ListUsersByStatus(ctx context.Context, userStatus, page, pageSize int32) ([]*biz.User, int64, error) {
+	list := make([]*User, 0)
+	var total int64
+	db := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"userStatus": userStatus, "isDelete": 0})
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to count users: userStatus(%v)", userStatus))
+	}
+	err = db.Order("id").Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to list users: userStatus(%v)", userStatus))
+	}
+
+	users := make([]*biz.User, 0, len(list))
+	for _, item := range list {
+		user := &biz.User{}
+		util.StructAssign(user, item)
+		users = append(users, user)
+	}
+	return users, total, nil
+}
+
+// UpdateUserStatus 更新用户状态，仅当用户当前状态为fromStatus时更新
+func (r *userRepo) UpdateUserStatus(ctx context.Context, userId, fromStatus, toStatus int32) error {
+	result := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"id": userId, "userStatus": fromStatus, "isDelete": 0}).Update("userStatus", toStatus)
+	if result.Error != nil {
+		return errors.Wrapf(result.Error, fmt.Sprintf("fail to update user status: userId(%v), userStatus(%v)", userId, toStatus))
+	}
+	if result.RowsAffected == 0 {
+		return errors.Errorf("user status changed: userId(%v), userStatus(%v)", userId, fromStatus)
+	}
+	r.data.invalidateUserProfile(ctx, userId)
+	return nil
+}
+
+// UpdateUserRole 更新用户角色
+func (r *userRepo) UpdateUserRole(ctx context.Context, userId, role int32) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"id": userId, "isDelete": 0}).Update("role", role).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to update user role: userId(%v), role(%v)", userId, role))
+	}
+	r.data.invalidateUserProfile(ctx, userId)
+	return nil
+}
+
+func (r *userRepo) CreateUserApproval(ctx context.Context, approval *biz.UserApproval) error {
+	record := &UserApproval{}
+	util.StructAssign(record, approval)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create user approval: userId(%v)", approval.UserId))
+	}
+	return nil
+}
+
+// ListUsersByEmail 根据邮箱查询用户
+func (r *userRepo) ListUsersByEmail(ctx context.Context, email string) ([]*biz.User, error) {
+	list := make([]*User, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"email": email, "isDelete": 0}).Order("id").Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list users: email(%s)", email))
+	}
+
+	users := make([]*biz.User, 0, len(list))
+	for _, item := range list {
+		user := &biz.User{}
+		util.StructAssign(user, item)
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// ListUsers 按过滤条件分页查询用户
+func (r *userRepo) ListUsers(ctx context.Context, conditions []*biz.FilterCondition, offset, limit int32) ([]*biz.User, int64, error) {
+	list := make([]*User, 0)
+	var total int64
+	db, err := applyFilter(r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"isDelete": 0}), userFilterColumns, conditions)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "fail to count users")
+	}
+	if limit == 0 {
+		return []*biz.User{}, total, nil
+	}
+	err = db.Order("id").Offset(int(offset)).Limit(int(limit)).Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "fail to list users")
+	}
+
+	users := make([]*biz.User, 0, len(list))
+	for _, item := range list {
+		user := &biz.User{}
+		util.StructAssign(user, item)
+		users = append(users, user)
+	}
+	return users, total, nil
+}
+
+// UpdateUser 更新用户资料和状态，密码为空时不修改密码
+func (r *userRepo) UpdateUser(ctx context.Context, user *biz.User) error {
+	updates := map[string]interface{}{
+		"userAccount": user.UserAccount,
+		"username":    user.UserName,
+		"avatarUrl":   user.AvatarUrl,
+		"email":       user.Email,
+		"phone":       user.Phone,
+		"userStatus":  user.UserStatus,
+	}
+	if user.UserPassword != "" {
+		updates["userPassword"] = user.UserPassword
+	}
+	err := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"id": user.Id, "isDelete": 0}).Updates(updates).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to update user: userId(%v)", user.Id))
+	}
+	r.data.invalidateUserProfile(ctx, user.Id)
+	return nil
+}
+
+// ListServiceAccounts 分页查询服务账号，ownerId为0时查询全部
+func (r *userRepo) ListServiceAccounts(ctx context.Context, ownerId, page, pageSize int32) ([]*biz.User, int64, error) {
+	list := make([]*User, 0)
+	var total int64
+	db := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"userType": biz.UserTypeService, "isDelete": 0})
+	if ownerId != 0 {
+		db = db.Where(map[string]interface{}{"ownerId": ownerId})
+	}
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to count service accounts: ownerId(%v)", ownerId))
+	}
+	err = db.Order("id").Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to list service accounts: ownerId(%v)", ownerId))
+	}
+
+	accounts := make([]*biz.User, 0, len(list))
+	for _, item := range list {
+		account := &biz.User{}
+		util.StructAssign(account, item)
+		accounts = append(accounts, account)
+	}
+	return accounts, total, nil
+}
+
+// UpdateServiceAccount 更新服务账号名称、角色、负责人和状态
+func (r *userRepo) UpdateServiceAccount(ctx context.Context, account *biz.User) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&User{}).Where(map[string]interface{}{"id": account.Id, "userType": biz.UserTypeService, "isDelete": 0}).Updates(map[string]interface{}{
+		"username":   account.UserName,
+		"role":       account.Role,
+		"ownerId":    account.OwnerId,
+		"userStatus": account.UserStatus,
+	}).Error
 	if err != nil {
-		return errors.Wrapf(err, fmt.Sprintf("fail to delete user: userId(%s)", userId))
+		return errors.Wrapf(err, fmt.Sprintf("fail to update service account: userId(%v)", account.Id))
 	}
+	r.data.invalidateUserProfile(ctx, account.Id)
 	return nil
 }
 
 func (r *userRepo) getUserFromCache(ctx context.Context, userId int32) (*User, error) {
-	result, err := r.data.redisCli.Get(ctx, fmt.Sprintf("%s_%v", r.data.conf.UserLoginState, userId)).Result()
+	result, err := r.data.getSession(ctx, userId)
 	if errors.Is(err, redis.Nil) {
 		return nil, kerrors.NotFound("user not found from cache", fmt.Sprintf("userId(%v)", userId))
 	}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+)
+
+var _ biz.UserChangeRepo = (*userChangeRepo)(nil)
+
+type userChangeRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewUserChangeRepo(data *Data, logger log.Logger) biz.UserChangeRepo {
+	return &userChangeRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/userChange")),
+	}
+}
+
+func (r *userChangeRepo) AppendUserChange(ctx context.Context, change *biz.UserChange) error {
+	record := &UserChangeLog{}
+	util.StructAssign(record, change)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to append user change: eventId(%s), userId(%v)", change.EventId, change.UserId))
+	}
+	change.Seq = record.Seq
+	return nil
+}
+
+func (r *userChangeRepo) ListUserChanges(ctx context.Context, afterSeq int64, limit int) ([]*biz.UserChange, error) {
+	list := make([]*UserChangeLog, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where("seq > ?", afterSeq).Order("seq").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list user changes: afterSeq(%v)", afterSeq))
+	}
+
+	changes := make([]*biz.UserChange, 0, len(list))
+	for _, item := range list {
+		change := &biz.UserChange{}
+		util.StructAssign(change, item)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// ListUserChangesBySeq 按序号查询变更，不存在的序号忽略
+func (r *userChangeRepo) ListUserChangesBySeq(ctx context.Context, seqs []int64) ([]*biz.UserChange, error) {
+	list := make([]*UserChangeLog, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where("seq in ?", seqs).Order("seq").Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to list user changes: seqs(%v)", seqs))
+	}
+
+	changes := make([]*biz.UserChange, 0, len(list))
+	for _, item := range list {
+		change := &biz.UserChange{}
+		util.StructAssign(change, item)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// GetUserChangeSeqRange 查询保留的最小和最大序号，没有变更时均为0
+func (r *userChangeRepo) GetUserChangeSeqRange(ctx context.Context) (int64, int64, error) {
+	var seqRange struct {
+		MinSeq int64
+		MaxSeq int64
+	}
+	err := r.data.DB(ctx).WithContext(ctx).Model(&UserChangeLog{}).
+		Select("coalesce(min(seq), 0) as min_seq, coalesce(max(seq), 0) as max_seq").Scan(&seqRange).Error
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "fail to get user change seq range")
+	}
+	return seqRange.MinSeq, seqRange.MaxSeq, nil
+}
+
+// PruneUserChanges 删除序号小于beforeSeq的变更
+func (r *userChangeRepo) PruneUserChanges(ctx context.Context, beforeSeq int64) (int64, error) {
+	result := r.data.DB(ctx).WithContext(ctx).Where("seq < ?", beforeSeq).Delete(&UserChangeLog{})
+	if result.Error != nil {
+		return 0, errors.Wrapf(result.Error, fmt.Sprintf("fail to prune user changes: beforeSeq(%v)", beforeSeq))
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"testing"
+	"time"
+)
+
+// appendTestChange 以指定序号写入变更，模拟乱序提交的事务
+func appendTestChange(t *testing.T, d *Data, seq int64, createTime time.Time) {
+	t.Helper()
+	err := d.db.Create(&UserChangeLog{Seq: seq, EventId: fmt.Sprint(seq), ChangeType: biz.UserChangeUpdate, Payload: "{}", CreateTime: createTime}).Error
+	if err != nil {
+		t.Fatalf("append user change: %v", err)
+	}
+}
+
+func TestUserChangeFeedRescanGap(t *testing.T) {
+	d, _ := newTestData(t, DriverSQLite, "")
+	uconf := testUserConstant()
+	uconf.ChangeGapRescan = 1
+	userRepo := NewUserRepo(d, testLogger)
+	admin := &User{UserAccount: "admin", Role: uconf.AdminRole}
+	d.db.Create(admin)
+	err := NewAuthRepo(d, testLogger).SetLoginSession(context.Background(), &biz.User{Id: admin.Id, UserAccount: "admin", Role: uconf.AdminRole})
+	if err != nil {
+		t.Fatalf("set login session: %v", err)
+	}
+	feed := biz.NewUserChangeFeed(NewUserChangeRepo(d, testLogger), userRepo, uconf, testLogger)
+
+	// 序号2、4、5的事务还未提交，超过等待时间后先推送3和6
+	old := time.Now().Add(-time.Minute)
+	appendTestChange(t, d, 1, old)
+	appendTestChange(t, d, 3, old)
+	appendTestChange(t, d, 6, old)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), "userId", admin.Id))
+	defer cancel()
+	received := make(chan int64, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- feed.Watch(ctx, 1, func(change *biz.UserChange) error {
+			received <- change.Seq
+			return nil
+		})
+	}()
+	expectSeq := func(want ...int64) {
+		t.Helper()
+		for _, seq := range want {
+			select {
+			case got := <-received:
+				if got != seq {
+					t.Fatalf("user change seq: want(%v), got(%v)", seq, got)
+				}
+			case err := <-done:
+				t.Fatalf("watch stopped: %v", err)
+			case <-time.After(3 * time.Second):
+				t.Fatalf("user change seq %v not received", seq)
+			}
+		}
+	}
+	expectSeq(3, 6)
+
+	// 补查期间提交的变更补发
+	appendTestChange(t, d, 4, old)
+	appendTestChange(t, d, 2, old)
+	expectSeq(2, 4)
+
+	// 超过补查时间后不再推送，后续变更正常推送
+	time.Sleep(1500 * time.Millisecond)
+	appendTestChange(t, d, 5, old)
+	appendTestChange(t, d, 7, time.Now())
+	expectSeq(7)
+	select {
+	case seq := <-received:
+		t.Fatalf("user change seq %v received after rescan window", seq)
+	case <-time.After(time.Second):
+	}
+	cancel()
+	<-done
+}
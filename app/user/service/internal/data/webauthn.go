@@ -0,0 +1,302 @@
+package data
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"strings"
+)
+
+var _ biz.PasskeyRelyingParty = (*passkeyRelyingParty)(nil)
+
+type passkeyRelyingParty struct {
+	webauthn *webauthn.WebAuthn
+	log      *log.Helper
+}
+
+// NewPasskeyRelyingParty 按配置创建WebAuthn依赖方，未配置rp_id时不开启通行密钥
+func NewPasskeyRelyingParty(conf *conf.WebAuthn, logger log.Logger) (biz.PasskeyRelyingParty, error) {
+	l := log.NewHelper(log.With(logger, "module", "user/data/webauthn"))
+	if conf.GetRpId() == "" {
+		return &passkeyRelyingParty{log: l}, nil
+	}
+
+	timeout := biz.PasskeyChallengeTimeout(conf)
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:                  conf.GetRpId(),
+		RPDisplayName:         conf.GetRpDisplayName(),
+		RPOrigins:             conf.GetRpOrigins(),
+		AttestationPreference: protocol.PreferNoAttestation,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        webauthn.TimeoutConfig{Enforce: true, Timeout: timeout, TimeoutUVD: timeout},
+			Registration: webauthn.TimeoutConfig{Enforce: true, Timeout: timeout, TimeoutUVD: timeout},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to create webauthn relying party: rpId(%s)", conf.GetRpId()))
+	}
+	return &passkeyRelyingParty{webauthn: w, log: l}, nil
+}
+
+// BeginRegistration 生成注册选项，排除已注册的凭证，优先创建可发现凭证以支持免账号登录
+func (r *passkeyRelyingParty) BeginRegistration(user *biz.User, passkeys []*biz.Passkey) ([]byte, []byte, error) {
+	if r.webauthn == nil {
+		return nil, nil, errors.New("webauthn not configured")
+	}
+	wu, err := newWebauthnUser(user, passkeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(wu.credentials))
+	for _, credential := range wu.credentials {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	creation, session, err := r.webauthn.BeginRegistration(wu,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementPreferred,
+			UserVerification: protocol.VerificationPreferred,
+		}),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, fmt.Sprintf("fail to begin passkey registration: userId(%v)", user.Id))
+	}
+	return marshalCeremony(creation, session)
+}
+
+// FinishRegistration 校验证明，返回待保存的通行密钥
+func (r *passkeyRelyingParty) FinishRegistration(user *biz.User, passkeys []*biz.Passkey, session []byte, credential string) (*biz.Passkey, error) {
+	if r.webauthn == nil {
+		return nil, errors.New("webauthn not configured")
+	}
+	sessionData, err := unmarshalSession(session)
+	if err != nil {
+		return nil, err
+	}
+	wu, err := newWebauthnUser(user, passkeys)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBody(strings.NewReader(credential))
+	if err != nil {
+		return nil, webauthnError(err, "fail to parse passkey registration")
+	}
+	created, err := r.webauthn.CreateCredential(wu, *sessionData, parsed)
+	if err != nil {
+		return nil, webauthnError(err, "fail to verify passkey registration")
+	}
+
+	passkey := &biz.Passkey{
+		UserId:          user.Id,
+		CredentialId:    base64.RawURLEncoding.EncodeToString(created.ID),
+		PublicKey:       created.PublicKey,
+		AttestationType: created.AttestationType,
+		Aaguid:          formatAaguid(created.Authenticator.AAGUID),
+		SignCount:       int64(created.Authenticator.SignCount),
+		BackupEligible:  created.Flags.BackupEligible,
+		BackupState:     created.Flags.BackupState,
+	}
+	transports := make([]string, 0, len(created.Transport))
+	for _, transport := range created.Transport {
+		transports = append(transports, string(transport))
+	}
+	passkey.Transports = strings.Join(transports, " ")
+	return passkey, nil
+}
+
+// BeginLogin 生成登录选项，user为空时发起可发现凭证登录，由认证器选择账号
+func (r *passkeyRelyingParty) BeginLogin(user *biz.User, passkeys []*biz.Passkey, userVerification bool) ([]byte, []byte, error) {
+	if r.webauthn == nil {
+		return nil, nil, errors.New("webauthn not configured")
+	}
+	verification := protocol.VerificationDiscouraged
+	if userVerification {
+		verification = protocol.VerificationRequired
+	}
+
+	if user == nil {
+		assertion, session, err := r.webauthn.BeginDiscoverableLogin(webauthn.WithUserVerification(verification))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "fail to begin discoverable passkey login")
+		}
+		return marshalCeremony(assertion, session)
+	}
+	wu, err := newWebauthnUser(user, passkeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	assertion, session, err := r.webauthn.BeginLogin(wu, webauthn.WithUserVerification(verification))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, fmt.Sprintf("fail to begin passkey login: userId(%v)", user.Id))
+	}
+	return marshalCeremony(assertion, session)
+}
+
+// FinishLogin 校验断言，用户由发起登录时的用户句柄或断言中的用户句柄确定
+func (r *passkeyRelyingParty) FinishLogin(session []byte, credential string, lookup biz.PasskeyLookup) (*biz.PasskeyAssertion, error) {
+	if r.webauthn == nil {
+		return nil, errors.New("webauthn not configured")
+	}
+	sessionData, err := unmarshalSession(session)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBody(strings.NewReader(credential))
+	if err != nil {
+		return nil, webauthnError(err, "fail to parse passkey assertion")
+	}
+
+	var wu *webauthnUser
+	handler := func(_, userHandle []byte) (webauthn.User, error) {
+		user, passkeys, err := lookup(userHandle)
+		if err != nil {
+			return nil, err
+		}
+		wu, err = newWebauthnUser(user, passkeys)
+		if err != nil {
+			return nil, err
+		}
+		return wu, nil
+	}
+
+	var validated *webauthn.Credential
+	if len(sessionData.UserID) > 0 {
+		var u webauthn.User
+		u, err = handler(nil, sessionData.UserID)
+		if err != nil {
+			return nil, err
+		}
+		validated, err = r.webauthn.ValidateLogin(u, *sessionData, parsed)
+	} else {
+		validated, err = r.webauthn.ValidateDiscoverableLogin(handler, *sessionData, parsed)
+	}
+	if err != nil {
+		return nil, webauthnError(err, "fail to verify passkey assertion")
+	}
+
+	for i, item := range wu.credentials {
+		if !bytes.Equal(item.ID, validated.ID) {
+			continue
+		}
+		passkey := wu.passkeys[i]
+		passkey.SignCount = int64(validated.Authenticator.SignCount)
+		passkey.BackupState = validated.Flags.BackupState
+		return &biz.PasskeyAssertion{
+			User:         wu.user,
+			Passkey:      passkey,
+			CloneWarning: validated.Authenticator.CloneWarning,
+		}, nil
+	}
+	return nil, errors.Errorf("passkey not found: userId(%v)", wu.user.Id)
+}
+
+// webauthnUser 适配webauthn.User，凭证与通行密钥按下标一一对应
+type webauthnUser struct {
+	user        *biz.User
+	passkeys    []*biz.Passkey
+	credentials []webauthn.Credential
+}
+
+func newWebauthnUser(user *biz.User, passkeys []*biz.Passkey) (*webauthnUser, error) {
+	credentials := make([]webauthn.Credential, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		id, err := base64.RawURLEncoding.DecodeString(passkey.CredentialId)
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to decode passkey credential id: id(%v)", passkey.Id))
+		}
+		aaguid, err := hex.DecodeString(strings.ReplaceAll(passkey.Aaguid, "-", ""))
+		if err != nil {
+			return nil, errors.Wrapf(err, fmt.Sprintf("fail to decode passkey aaguid: id(%v)", passkey.Id))
+		}
+		transports := make([]protocol.AuthenticatorTransport, 0)
+		for _, transport := range strings.Fields(passkey.Transports) {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+		credentials = append(credentials, webauthn.Credential{
+			ID:              id,
+			PublicKey:       passkey.PublicKey,
+			AttestationType: passkey.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: passkey.BackupEligible,
+				BackupState:    passkey.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    aaguid,
+				SignCount: uint32(passkey.SignCount),
+			},
+		})
+	}
+	return &webauthnUser{user: user, passkeys: passkeys, credentials: credentials}, nil
+}
+
+func (u *webauthnUser) WebAuthnID() []byte {
+	return biz.PasskeyUserHandle(u.user.Id)
+}
+
+func (u *webauthnUser) WebAuthnName() string {
+	return u.user.UserAccount
+}
+
+func (u *webauthnUser) WebAuthnDisplayName() string {
+	if u.user.UserName != "" {
+		return u.user.UserName
+	}
+	return u.user.UserAccount
+}
+
+func (u *webauthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *webauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+func marshalCeremony(options interface{}, session *webauthn.SessionData) ([]byte, []byte, error) {
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "json marshal error: webauthn options")
+	}
+	sessionJson, err := json.Marshal(session)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "json marshal error: webauthn session")
+	}
+	return optionsJson, sessionJson, nil
+}
+
+func unmarshalSession(session []byte) (*webauthn.SessionData, error) {
+	sessionData := &webauthn.SessionData{}
+	err := json.Unmarshal(session, sessionData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "json unmarshal error: webauthn session")
+	}
+	return sessionData, nil
+}
+
+// webauthnError 校验失败原因在DevInfo中，一并带上便于排查
+func webauthnError(err error, message string) error {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) && protocolErr.DevInfo != "" {
+		return errors.Errorf("%s: %s(%s)", message, protocolErr.Details, protocolErr.DevInfo)
+	}
+	return errors.Wrapf(err, message)
+}
+
+// formatAaguid 按UUID格式展示认证器型号
+func formatAaguid(aaguid []byte) string {
+	if len(aaguid) != 16 {
+		return hex.EncodeToString(aaguid)
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", aaguid[0:4], aaguid[4:6], aaguid[6:8], aaguid[8:10], aaguid[10:16])
+}
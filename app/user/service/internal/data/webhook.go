@@ -0,0 +1,192 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/pkg/util"
+	"gorm.io/gorm/clause"
+	"io"
+	"net/http"
+	"time"
+)
+
+var _ biz.WebhookRepo = (*webhookRepo)(nil)
+var _ biz.WebhookSender = (*webhookSender)(nil)
+
+type webhookRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewWebhookRepo(data *Data, logger log.Logger) biz.WebhookRepo {
+	return &webhookRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "user/data/webhook")),
+	}
+}
+
+func (r *webhookRepo) CreateWebhook(ctx context.Context, webhook *biz.Webhook) (int64, error) {
+	record := &Webhook{}
+	util.StructAssign(record, webhook)
+	err := r.data.DB(ctx).WithContext(ctx).Create(record).Error
+	if err != nil {
+		return 0, errors.Wrapf(err, fmt.Sprintf("fail to create webhook: url(%s)", webhook.Url))
+	}
+	return record.Id, nil
+}
+
+func (r *webhookRepo) DeleteWebhook(ctx context.Context, id int64) error {
+	err := r.data.DB(ctx).WithContext(ctx).Where("id = ?", id).Delete(&Webhook{}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to delete webhook: id(%v)", id))
+	}
+	return nil
+}
+
+func (r *webhookRepo) ListWebhooks(ctx context.Context) ([]*biz.Webhook, error) {
+	list := make([]*Webhook, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Order("id").Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list webhooks")
+	}
+
+	webhooks := make([]*biz.Webhook, 0, len(list))
+	for _, item := range list {
+		webhook := &biz.Webhook{}
+		util.StructAssign(webhook, item)
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepo) CreateDeliveries(ctx context.Context, deliveries []*biz.WebhookDelivery) error {
+	records := make([]*WebhookDelivery, 0, len(deliveries))
+	for _, item := range deliveries {
+		record := &WebhookDelivery{}
+		util.StructAssign(record, item)
+		records = append(records, record)
+	}
+	err := r.data.DB(ctx).WithContext(ctx).Create(&records).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to create webhook deliveries: count(%v)", len(records)))
+	}
+	for i, record := range records {
+		deliveries[i].Id = record.Id
+	}
+	return nil
+}
+
+func (r *webhookRepo) GetDelivery(ctx context.Context, id int64) (*biz.WebhookDelivery, error) {
+	record := &WebhookDelivery{}
+	err := r.data.DB(ctx).WithContext(ctx).Where("id = ?", id).First(record).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, fmt.Sprintf("fail to get webhook delivery: id(%v)", id))
+	}
+
+	delivery := &biz.WebhookDelivery{}
+	util.StructAssign(delivery, record)
+	return delivery, nil
+}
+
+func (r *webhookRepo) ListDeliveries(ctx context.Context, webhookId int64, status string, page, pageSize int32) ([]*biz.WebhookDelivery, int64, error) {
+	db := r.data.DB(ctx).WithContext(ctx).Model(&WebhookDelivery{})
+	if webhookId != 0 {
+		db = db.Where(map[string]interface{}{"webhookId": webhookId})
+	}
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	var total int64
+	err := db.Count(&total).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to count webhook deliveries: webhookId(%v)", webhookId))
+	}
+	list := make([]*WebhookDelivery, 0)
+	err = db.Order("id desc").Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).Find(&list).Error
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, fmt.Sprintf("fail to list webhook deliveries: webhookId(%v)", webhookId))
+	}
+
+	deliveries := make([]*biz.WebhookDelivery, 0, len(list))
+	for _, item := range list {
+		delivery := &biz.WebhookDelivery{}
+		util.StructAssign(delivery, item)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, total, nil
+}
+
+// ListDueDeliveries 查询到期待投递的记录
+func (r *webhookRepo) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*biz.WebhookDelivery, error) {
+	list := make([]*WebhookDelivery, 0)
+	err := r.data.DB(ctx).WithContext(ctx).Where(map[string]interface{}{"status": biz.WebhookDeliveryPending}).Where(clause.Lte{Column: "nextAttemptTime", Value: now}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "nextAttemptTime"}}).Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list due webhook deliveries")
+	}
+
+	deliveries := make([]*biz.WebhookDelivery, 0, len(list))
+	for _, item := range list {
+		delivery := &biz.WebhookDelivery{}
+		util.StructAssign(delivery, item)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery 将投递时间推迟到租约结束，更新成功表示抢占到该记录
+func (r *webhookRepo) ClaimDelivery(ctx context.Context, id int64, nextAttemptTime, leaseUntil time.Time) (bool, error) {
+	result := r.data.DB(ctx).WithContext(ctx).Model(&WebhookDelivery{}).
+		Where(map[string]interface{}{"id": id, "status": biz.WebhookDeliveryPending, "nextAttemptTime": nextAttemptTime}).
+		Update("nextAttemptTime", leaseUntil)
+	if result.Error != nil {
+		return false, errors.Wrapf(result.Error, fmt.Sprintf("fail to claim webhook delivery: id(%v)", id))
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *webhookRepo) UpdateDelivery(ctx context.Context, delivery *biz.WebhookDelivery) error {
+	err := r.data.DB(ctx).WithContext(ctx).Model(&WebhookDelivery{}).Where("id = ?", delivery.Id).Updates(map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"nextAttemptTime": delivery.NextAttemptTime,
+		"lastStatusCode":  delivery.LastStatusCode,
+		"lastError":       delivery.LastError,
+	}).Error
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("fail to update webhook delivery: id(%v)", delivery.Id))
+	}
+	return nil
+}
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender() biz.WebhookSender {
+	return &webhookSender{
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *webhookSender) Send(ctx context.Context, url string, header map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrapf(err, fmt.Sprintf("fail to create webhook request: url(%s)", url))
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, fmt.Sprintf("fail to send webhook: url(%s)", url))
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,184 @@
+package server_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/data"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type createAccessTokenReply struct {
+	Token string `json:"token"`
+	Data  struct {
+		Id          string `json:"id"`
+		TokenPrefix string `json:"tokenPrefix"`
+	} `json:"data"`
+}
+
+func bearerHeader(token string) http.Header {
+	return http.Header{"Authorization": []string{"Bearer " + token}}
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// createAccessToken 通过会话创建令牌，返回令牌明文和id
+func createAccessToken(t *testing.T, s *testServer, userId int32, scope string) (string, int64) {
+	t.Helper()
+	reply := &createAccessTokenReply{}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/token/create", userHeader(userId),
+		map[string]interface{}{"name": scope + " token", "scopes": []string{scope}, "expireDays": 30}, reply)
+	if status != http.StatusOK {
+		t.Fatalf("create %s token: status(%v), body(%s)", scope, status, body)
+	}
+	id, err := strconv.ParseInt(reply.Data.Id, 10, 64)
+	if err != nil {
+		t.Fatalf("token id: %v, body(%s)", err, body)
+	}
+	if !strings.HasPrefix(reply.Token, biz.AccessTokenPrefix) || !strings.HasPrefix(reply.Token, reply.Data.TokenPrefix) || len(reply.Data.TokenPrefix) >= len(reply.Token) {
+		t.Fatalf("token format: %s", body)
+	}
+	return reply.Token, id
+}
+
+// TestAccessTokenStorage 数据库中只保存令牌摘要，列表中不返回明文
+func TestAccessTokenStorage(t *testing.T) {
+	s := newTestServer(t)
+	userId := s.createUser(t, &biz.User{UserAccount: "token_storage"})
+	s.login(t, userId)
+	secret, id := createAccessToken(t, s, userId, biz.AccessTokenScopeRead)
+
+	token, err := data.NewAccessTokenRepo(s.data, testLogger).GetAccessToken(context.Background(), id)
+	if err != nil {
+		t.Fatalf("get token: %v", err)
+	}
+	if token.TokenHash != sha256Hex(secret) || strings.Contains(token.TokenHash, secret) {
+		t.Fatalf("stored token hash: %+v", token)
+	}
+
+	status, body := s.call(t, s.newClient(t), http.MethodGet, "/api/user/token/list", userHeader(userId), nil, nil)
+	if status != http.StatusOK || strings.Contains(string(body), secret) || strings.Contains(string(body), token.TokenHash) {
+		t.Fatalf("list tokens: status(%v), body(%s)", status, body)
+	}
+}
+
+// TestAccessTokenScope 令牌只能调用权限范围内的接口，write包含read，会话专用接口不接受令牌
+func TestAccessTokenScope(t *testing.T) {
+	s := newTestServer(t)
+	adminId := s.createUser(t, &biz.User{UserAccount: "token_admin", Role: 1})
+	s.login(t, adminId)
+	victimId := s.createUser(t, &biz.User{UserAccount: "token_victim"})
+	readToken, _ := createAccessToken(t, s, adminId, biz.AccessTokenScopeRead)
+	writeToken, _ := createAccessToken(t, s, adminId, biz.AccessTokenScopeWrite)
+
+	var current struct {
+		Data struct {
+			Id int32 `json:"id"`
+		} `json:"data"`
+	}
+	for _, token := range []string{readToken, writeToken} {
+		status, body := s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", bearerHeader(token), nil, &current)
+		if status != http.StatusOK || current.Data.Id != adminId {
+			t.Fatalf("read with token: status(%v), body(%s)", status, body)
+		}
+	}
+
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/delete", bearerHeader(readToken), map[string]int32{"id": victimId}, nil)
+	if status == http.StatusOK || errorReason(body) != "PERMISSION_DENY" {
+		t.Fatalf("write with read token: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/token/create", bearerHeader(writeToken),
+		map[string]interface{}{"name": "nested", "scopes": []string{"read"}, "expireDays": 1}, nil)
+	if status == http.StatusOK || errorReason(body) != "PERMISSION_DENY" {
+		t.Fatalf("create token with token: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/delete", bearerHeader(writeToken), map[string]int32{"id": victimId}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("write with write token: status(%v), body(%s)", status, body)
+	}
+}
+
+// TestAccessTokenRevokeAndExpiry 已吊销、已过期和不存在的令牌都无效
+func TestAccessTokenRevokeAndExpiry(t *testing.T) {
+	s := newTestServer(t)
+	userId := s.createUser(t, &biz.User{UserAccount: "token_revoke"})
+	s.login(t, userId)
+	secret, id := createAccessToken(t, s, userId, biz.AccessTokenScopeRead)
+
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/token/revoke", userHeader(userId), map[string]int64{"id": id}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("revoke: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", bearerHeader(secret), nil, nil)
+	if status == http.StatusOK || errorReason(body) != "ACCESS_TOKEN_INVALID" {
+		t.Fatalf("revoked token: status(%v), body(%s)", status, body)
+	}
+
+	expired := biz.AccessTokenPrefix + "expired-token-secret"
+	err := data.NewAccessTokenRepo(s.data, testLogger).CreateAccessToken(context.Background(), &biz.AccessToken{
+		UserId: userId, Name: "expired", TokenHash: sha256Hex(expired), TokenPrefix: expired[:12], Scopes: biz.AccessTokenScopeRead,
+		ExpireTime: time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("create expired token: %v", err)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", bearerHeader(expired), nil, nil)
+	if status == http.StatusOK || errorReason(body) != "ACCESS_TOKEN_INVALID" {
+		t.Fatalf("expired token: status(%v), body(%s)", status, body)
+	}
+
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", bearerHeader(biz.AccessTokenPrefix+"unknown"), nil, nil)
+	if status == http.StatusOK || errorReason(body) != "ACCESS_TOKEN_INVALID" {
+		t.Fatalf("unknown token: status(%v), body(%s)", status, body)
+	}
+}
+
+// TestAccessTokenTouch 最近使用时间按最小间隔更新
+func TestAccessTokenTouch(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t)
+	userId := s.createUser(t, &biz.User{UserAccount: "token_touch"})
+	repo := data.NewAccessTokenRepo(s.data, testLogger)
+	secret := biz.AccessTokenPrefix + "touch-token-secret"
+	token := &biz.AccessToken{
+		UserId: userId, Name: "touch", TokenHash: sha256Hex(secret), TokenPrefix: secret[:12], Scopes: biz.AccessTokenScopeRead,
+		ExpireTime: time.Now().Add(time.Hour),
+	}
+	err := repo.CreateAccessToken(ctx, token)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+	lastUsed := func() time.Time {
+		t.Helper()
+		record, err := repo.GetAccessToken(ctx, token.Id)
+		if err != nil {
+			t.Fatalf("get token: %v", err)
+		}
+		return record.LastUsedTime
+	}
+	use := func() {
+		t.Helper()
+		status, body := s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", bearerHeader(secret), nil, nil)
+		if status != http.StatusOK {
+			t.Fatalf("use token: status(%v), body(%s)", status, body)
+		}
+	}
+
+	use()
+	touched := lastUsed()
+	if time.Since(touched) > time.Minute {
+		t.Fatalf("last used time not updated: %v", touched)
+	}
+	use()
+	if got := lastUsed(); !got.Equal(touched) {
+		t.Fatalf("last used time updated within interval: %v, %v", touched, got)
+	}
+}
@@ -0,0 +1,112 @@
+package server_test
+
+import (
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type pendingUsersReply struct {
+	Data []struct {
+		Id          int32  `json:"id"`
+		UserAccount string `json:"userAccount"`
+	} `json:"data"`
+	Total string `json:"total"`
+}
+
+// registerPending 开启注册审核时注册用户，返回用户id
+func registerPending(t *testing.T, s *testServer, account, email string) int32 {
+	t.Helper()
+	var reply struct {
+		Data struct {
+			Id         int32 `json:"id"`
+			UserStatus int32 `json:"userStatus"`
+		} `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/register", nil,
+		map[string]string{"userAccount": account, "userPassword": account + "-password", "checkPassword": account + "-password", "email": email}, &reply)
+	if status != http.StatusOK || reply.Data.Id == 0 || reply.Data.UserStatus != biz.UserStatusPending {
+		t.Fatalf("register %s: status(%v), body(%s)", account, status, body)
+	}
+	return reply.Data.Id
+}
+
+func passwordLogin(t *testing.T, s *testServer, account string) (int, []byte) {
+	t.Helper()
+	return s.call(t, s.newClient(t), http.MethodPost, "/api/user/login", nil,
+		map[string]string{"userAccount": account, "userPassword": account + "-password"}, nil)
+}
+
+// TestRegisterApproval 开启注册审核时新用户审核通过前不能登录，审核结果邮件通知用户
+func TestRegisterApproval(t *testing.T) {
+	s := newTestServer(t, func(c *conf.Config) {
+		c.Constant.RegisterApproval = true
+	})
+	adminId := s.createUser(t, &biz.User{UserAccount: "approval_admin", Role: 1})
+	s.login(t, adminId)
+	memberId := s.createUser(t, &biz.User{UserAccount: "approval_member"})
+	s.login(t, memberId)
+
+	samId := registerPending(t, s, "sam_pending", "sam@example.com")
+	tinaId := registerPending(t, s, "tina_pending", "tina@example.com")
+
+	status, body := passwordLogin(t, s, "sam_pending")
+	if status == http.StatusOK || errorReason(body) != "ACCOUNT_PENDING_APPROVAL" {
+		t.Fatalf("login before approval: status(%v), body(%s)", status, body)
+	}
+
+	// 只有管理员可以查看和审核
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/pending/list", userHeader(memberId), map[string]int32{"page": 1}, nil)
+	if status == http.StatusOK {
+		t.Fatalf("member lists pending users: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/approve", userHeader(memberId), map[string]int32{"id": samId}, nil)
+	if status == http.StatusOK {
+		t.Fatalf("member approves user: status(%v), body(%s)", status, body)
+	}
+
+	pending := &pendingUsersReply{}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/pending/list", userHeader(adminId), map[string]int32{"page": 1, "pageSize": 10}, pending)
+	if status != http.StatusOK || pending.Total != "2" || len(pending.Data) != 2 || pending.Data[0].Id != samId || pending.Data[1].Id != tinaId {
+		t.Fatalf("list pending users: status(%v), body(%s)", status, body)
+	}
+
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/approve", userHeader(adminId), map[string]int32{"id": samId}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("approve: status(%v), body(%s)", status, body)
+	}
+	mail, ok := s.mailer.last("sam@example.com")
+	if !ok || mail.Subject != "账号审核通过" {
+		t.Fatalf("approval mail: %+v", mail)
+	}
+	status, body = passwordLogin(t, s, "sam_pending")
+	if status != http.StatusOK {
+		t.Fatalf("login after approval: status(%v), body(%s)", status, body)
+	}
+	// 已审核的用户不能再次审核
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/reject", userHeader(adminId), map[string]interface{}{"id": samId, "reason": "late"}, nil)
+	if status == http.StatusOK || errorReason(body) != "USER_APPROVE_FAILED" {
+		t.Fatalf("review approved user: status(%v), body(%s)", status, body)
+	}
+
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/reject", userHeader(adminId), map[string]interface{}{"id": tinaId, "reason": "unknown applicant"}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("reject: status(%v), body(%s)", status, body)
+	}
+	mail, ok = s.mailer.last("tina@example.com")
+	if !ok || mail.Subject != "账号审核未通过" || !strings.Contains(mail.Body, "unknown applicant") {
+		t.Fatalf("rejection mail: %+v", mail)
+	}
+	status, body = passwordLogin(t, s, "tina_pending")
+	if status == http.StatusOK || errorReason(body) != "ACCOUNT_REJECTED" {
+		t.Fatalf("login after rejection: status(%v), body(%s)", status, body)
+	}
+
+	pending = &pendingUsersReply{}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/pending/list", userHeader(adminId), map[string]int32{"page": 1}, pending)
+	if status != http.StatusOK || len(pending.Data) != 0 {
+		t.Fatalf("pending users after review: status(%v), body(%s)", status, body)
+	}
+}
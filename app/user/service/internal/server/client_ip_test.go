@@ -0,0 +1,81 @@
+package server_test
+
+import (
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"testing"
+)
+
+// loginIp 以指定的代理请求头登录失败一次，返回审计日志中记录的客户端ip
+func loginIp(t *testing.T, s *testServer, adminId int32, header http.Header) string {
+	t.Helper()
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/login", header,
+		map[string]string{"userAccount": "alice", "userPassword": "wrong-password"}, nil)
+	if status == http.StatusOK {
+		t.Fatalf("login with wrong password: body(%s)", body)
+	}
+	logs := s.listAuditLogs(t, adminId, biz.AuditActionUserLogin)
+	if len(logs) == 0 {
+		t.Fatalf("login audit log not recorded")
+	}
+	return logs[0].Ip
+}
+
+func newClientIpServer(t *testing.T, proxies ...string) (*testServer, int32) {
+	s := newTestServer(t, func(c *conf.Config) {
+		c.Server.TrustedProxies = proxies
+	})
+	adminId := s.createUser(t, &biz.User{UserAccount: "admin", Role: 1})
+	s.createUser(t, &biz.User{UserAccount: "alice", UserPassword: biz.PasswordHash("alice-password")})
+	s.login(t, adminId)
+	return s, adminId
+}
+
+func TestClientIpUntrustedPeer(t *testing.T) {
+	s, adminId := newClientIpServer(t)
+
+	// 没有配置可信代理时，客户端伪造的代理请求头不生效
+	for _, header := range []http.Header{
+		{"X-Forwarded-For": {"203.0.113.7"}},
+		{"X-Real-Ip": {"203.0.113.7"}},
+	} {
+		if ip := loginIp(t, s, adminId, header); ip != "127.0.0.1" {
+			t.Fatalf("spoofed header trusted: header(%v), ip(%s)", header, ip)
+		}
+	}
+}
+
+func TestClientIpTrustedProxy(t *testing.T) {
+	s, adminId := newClientIpServer(t, "127.0.0.1", "10.0.0.0/8")
+
+	for _, c := range []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{"forwarded", http.Header{"X-Forwarded-For": {"203.0.113.7"}}, "203.0.113.7"},
+		// 客户端自带的X-Forwarded-For在最左边，取最右边的不可信地址
+		{"spoofed", http.Header{"X-Forwarded-For": {"198.51.100.1, 203.0.113.7"}}, "203.0.113.7"},
+		{"proxy chain", http.Header{"X-Forwarded-For": {"203.0.113.7, 10.0.0.2, 10.0.0.3"}}, "203.0.113.7"},
+		{"all trusted", http.Header{"X-Forwarded-For": {"10.0.0.2, 10.0.0.3"}}, "10.0.0.2"},
+		{"malformed", http.Header{"X-Forwarded-For": {"unknown"}}, "127.0.0.1"},
+		{"real ip", http.Header{"X-Real-Ip": {"203.0.113.8"}}, "203.0.113.8"},
+		{"no header", nil, "127.0.0.1"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if ip := loginIp(t, s, adminId, c.header); ip != c.want {
+				t.Fatalf("client ip: want(%s), got(%s)", c.want, ip)
+			}
+		})
+	}
+}
+
+func TestClientIpProxyNotTrusted(t *testing.T) {
+	s, adminId := newClientIpServer(t, "10.0.0.0/8")
+
+	// 对端不是可信代理时，即使转发的地址中有可信代理也不生效
+	if ip := loginIp(t, s, adminId, http.Header{"X-Forwarded-For": {"203.0.113.7, 10.0.0.2"}}); ip != "127.0.0.1" {
+		t.Fatalf("header from untrusted peer: ip(%s)", ip)
+	}
+}
@@ -0,0 +1,577 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"github.com/user-center/user-center-backend/app/user/service/internal/data"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	mockIdPClientId     = "user-center"
+	mockIdPClientSecret = "idp-secret"
+)
+
+// mockIdP 本地的OIDC提供方，提供discovery、JWKS、令牌和用户信息端点
+//1. 授权端点由approve代替，模拟用户在提供方完成登录，按授权请求签发授权码
+//2. 令牌端点校验客户端凭证、redirect_uri和PKCE code_verifier，授权码一次有效
+//3. tamper可以修改签发的id_token声明，signer为nil时使用提供方自己的密钥
+type mockIdP struct {
+	*httptest.Server
+	key biz.TokenSigner
+
+	mu       sync.Mutex
+	codes    map[string]*mockIdPCode
+	tokens   map[string]map[string]interface{}
+	tamper   func(claims jwt.MapClaims)
+	signer   biz.TokenSigner
+	userinfo map[string]interface{}
+}
+
+type mockIdPCode struct {
+	challenge   string
+	redirectUri string
+	nonce       string
+	claims      map[string]interface{}
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := data.NewTokenSigner(&conf.OAuth{}, testLogger)
+	if err != nil {
+		t.Fatalf("fail to create idp key: %v", err)
+	}
+	idp := &mockIdP{
+		key:    key,
+		codes:  make(map[string]*mockIdPCode),
+		tokens: make(map[string]map[string]interface{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"keys": idp.key.JWKS()})
+	})
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/userinfo", idp.userInfo)
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (p *mockIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.URL,
+		"authorization_endpoint":                p.URL + "/authorize",
+		"token_endpoint":                        p.URL + "/token",
+		"userinfo_endpoint":                     p.URL + "/userinfo",
+		"jwks_uri":                              p.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+// approve 用户在提供方登录并同意授权，校验授权请求后返回回调地址
+func (p *mockIdP) approve(t *testing.T, authorizeUrl string, claims map[string]interface{}) *url.URL {
+	t.Helper()
+	location, err := url.Parse(authorizeUrl)
+	if err != nil || !strings.HasPrefix(authorizeUrl, p.URL+"/authorize?") {
+		t.Fatalf("authorize url: %s", authorizeUrl)
+	}
+	query := location.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != mockIdPClientId {
+		t.Fatalf("authorize request: %s", authorizeUrl)
+	}
+	if query.Get("code_challenge_method") != biz.PKCEMethodS256 || query.Get("code_challenge") == "" {
+		t.Fatalf("authorize request without PKCE: %s", authorizeUrl)
+	}
+	if query.Get("state") == "" || query.Get("nonce") == "" || !strings.Contains(query.Get("scope"), "openid") {
+		t.Fatalf("authorize request without state, nonce or openid scope: %s", authorizeUrl)
+	}
+
+	code := fmt.Sprintf("code-%v", time.Now().UnixNano())
+	p.mu.Lock()
+	p.codes[code] = &mockIdPCode{
+		challenge:   query.Get("code_challenge"),
+		redirectUri: query.Get("redirect_uri"),
+		nonce:       query.Get("nonce"),
+		claims:      claims,
+	}
+	p.mu.Unlock()
+
+	callback, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		t.Fatalf("redirect uri: %v", err)
+	}
+	callback.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+	return callback
+}
+
+func (p *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientId, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if clientId != mockIdPClientId || clientSecret != mockIdPClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	code, ok := p.codes[r.PostFormValue("code")]
+	delete(p.codes, r.PostFormValue("code"))
+	if !ok || r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("redirect_uri") != code.redirectUri ||
+		pkceChallenge(r.PostFormValue("code_verifier")) != code.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   p.URL,
+		"aud":   mockIdPClientId,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Minute).Unix(),
+		"nonce": code.nonce,
+	}
+	for key, value := range code.claims {
+		claims[key] = value
+	}
+	if p.tamper != nil {
+		p.tamper(claims)
+	}
+	signer := p.signer
+	if signer == nil {
+		signer = p.key
+	}
+	idToken, err := signer.Sign(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	accessToken := fmt.Sprintf("access-%v", now.UnixNano())
+	p.tokens[accessToken] = code.claims
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     idToken,
+	})
+}
+
+func (p *mockIdP) userInfo(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	claims, ok := p.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+	info := map[string]interface{}{"sub": claims["sub"]}
+	for key, value := range p.userinfo {
+		info[key] = value
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// newExternalLoginServer 配置名为idp的OIDC提供方，回调地址指向测试服务
+func newExternalLoginServer(t *testing.T, setup ...func(c *conf.Config)) (*testServer, *mockIdP) {
+	idp := newMockIdP(t)
+	provider := &conf.ExternalProvider{
+		Name:         "idp",
+		Issuer:       idp.URL,
+		ClientId:     mockIdPClientId,
+		ClientSecret: mockIdPClientSecret,
+	}
+	s := newTestServer(t, append([]func(c *conf.Config){func(c *conf.Config) {
+		c.Oauth.ExternalProviders = []*conf.ExternalProvider{provider}
+	}}, setup...)...)
+	// 首次登录时才读取端点配置，服务启动后补充回调地址
+	provider.RedirectUrl = s.URL + "/api/user/external/idp/callback"
+	return s, idp
+}
+
+type externalLoginReply struct {
+	Data struct {
+		Id          int32  `json:"id"`
+		UserAccount string `json:"userAccount"`
+		Email       string `json:"email"`
+	} `json:"data"`
+}
+
+// startExternalLogin 发起第三方登录或绑定，返回提供方的授权地址
+func startExternalLogin(t *testing.T, s *testServer, path string, header http.Header) string {
+	t.Helper()
+	var reply struct {
+		AuthorizeUrl string `json:"authorizeUrl"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, path, header, map[string]string{"provider": "idp"}, &reply)
+	if status != http.StatusOK {
+		t.Fatalf("start external login: status(%v), body(%s)", status, body)
+	}
+	return reply.AuthorizeUrl
+}
+
+// callback 浏览器从提供方跳转回服务的回调地址
+func callback(t *testing.T, s *testServer, location *url.URL, header http.Header, reply interface{}) (int, []byte) {
+	t.Helper()
+	return s.call(t, s.newClient(t), http.MethodGet, location.RequestURI(), header, nil, reply)
+}
+
+// externalLogin 完成一次第三方登录，claims为提供方返回的身份
+func externalLogin(t *testing.T, s *testServer, idp *mockIdP, claims map[string]interface{}) (int, []byte, *externalLoginReply) {
+	t.Helper()
+	reply := &externalLoginReply{}
+	location := idp.approve(t, startExternalLogin(t, s, "/api/user/external/login", nil), claims)
+	status, body := callback(t, s, location, nil, reply)
+	return status, body, reply
+}
+
+// deviceExternalLogin 从指定设备完成一次第三方登录，verifyCode为发起登录时携带的二次验证码
+func deviceExternalLogin(t *testing.T, s *testServer, idp *mockIdP, device, verifyCode string, claims map[string]interface{}) (int, []byte, *externalLoginReply) {
+	t.Helper()
+	header := http.Header{"X-Device-Id": {device}}
+	var start struct {
+		AuthorizeUrl string `json:"authorizeUrl"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/external/login", header, map[string]string{"provider": "idp", "verifyCode": verifyCode}, &start)
+	if status != http.StatusOK {
+		t.Fatalf("start external login: status(%v), body(%s)", status, body)
+	}
+	reply := &externalLoginReply{}
+	status, body = callback(t, s, idp.approve(t, start.AuthorizeUrl, claims), header, reply)
+	return status, body, reply
+}
+
+func TestExternalLoginProvisioning(t *testing.T) {
+	s, idp := newExternalLoginServer(t)
+	bobId := s.createUser(t, &biz.User{UserAccount: "bob", Email: "bob@example.com"})
+	carolId := s.createUser(t, &biz.User{UserAccount: "carol", Email: "carol@example.com"})
+
+	// 新的第三方身份开通用户，已验证的邮箱写入用户
+	status, body, alice := externalLogin(t, s, idp, map[string]interface{}{"sub": "alice-sub", "email": "alice@example.com", "email_verified": true})
+	if status != http.StatusOK || !strings.HasPrefix(alice.Data.UserAccount, "idp_") || alice.Data.Email != "alice@example.com" {
+		t.Fatalf("provision user: status(%v), body(%s)", status, body)
+	}
+	status, body, again := externalLogin(t, s, idp, map[string]interface{}{"sub": "alice-sub", "email": "alice@example.com", "email_verified": true})
+	if status != http.StatusOK || again.Data.Id != alice.Data.Id {
+		t.Fatalf("login with linked identity: status(%v), body(%s)", status, body)
+	}
+
+	// 已验证的邮箱对应唯一的本地用户时自动绑定
+	status, body, bob := externalLogin(t, s, idp, map[string]interface{}{"sub": "bob-sub", "email": "bob@example.com", "email_verified": true})
+	if status != http.StatusOK || bob.Data.Id != bobId {
+		t.Fatalf("link by verified email: status(%v), body(%s)", status, body)
+	}
+
+	// 未验证的邮箱不能绑定已有用户，也不写入新用户
+	status, body, carol := externalLogin(t, s, idp, map[string]interface{}{"sub": "carol-sub", "email": "carol@example.com", "email_verified": false})
+	if status != http.StatusOK || carol.Data.Id == carolId || carol.Data.Email != "" {
+		t.Fatalf("login with unverified email: status(%v), body(%s)", status, body)
+	}
+
+	var identities struct {
+		Data []struct {
+			Provider string `json:"provider"`
+			Subject  string `json:"subject"`
+		} `json:"data"`
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/identity/list", userHeader(bobId), nil, &identities)
+	if status != http.StatusOK || len(identities.Data) != 1 || identities.Data[0].Provider != "idp" || identities.Data[0].Subject != "bob-sub" {
+		t.Fatalf("list identities: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestExternalLoginUserinfoFallback(t *testing.T) {
+	s, idp := newExternalLoginServer(t)
+
+	// id_token中没有邮箱时从用户信息接口补充
+	idp.userinfo = map[string]interface{}{"email": "dave@example.com", "email_verified": true}
+	status, body, dave := externalLogin(t, s, idp, map[string]interface{}{"sub": "dave-sub"})
+	if status != http.StatusOK || dave.Data.Email != "dave@example.com" {
+		t.Fatalf("userinfo fallback: status(%v), body(%s)", status, body)
+	}
+
+	// 用户信息接口返回的sub与id_token不一致时忽略
+	idp.userinfo = map[string]interface{}{"sub": "someone-else", "email": "erin@example.com", "email_verified": true}
+	status, body, erin := externalLogin(t, s, idp, map[string]interface{}{"sub": "erin-sub"})
+	if status != http.StatusOK || erin.Data.Email != "" {
+		t.Fatalf("userinfo with another subject: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestExternalLoginRejectsInvalidIdToken(t *testing.T) {
+	s, idp := newExternalLoginServer(t)
+	rogue, err := data.NewTokenSigner(&conf.OAuth{}, testLogger)
+	if err != nil {
+		t.Fatalf("fail to create rogue key: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(claims jwt.MapClaims)
+		signer biz.TokenSigner
+	}{
+		{name: "nonce", tamper: func(claims jwt.MapClaims) { claims["nonce"] = "replayed" }},
+		{name: "audience", tamper: func(claims jwt.MapClaims) { claims["aud"] = "another-client" }},
+		{name: "issuer", tamper: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example" }},
+		{name: "expired", tamper: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "signature", signer: rogue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp.tamper, idp.signer = tt.tamper, tt.signer
+			defer func() { idp.tamper, idp.signer = nil, nil }()
+
+			subject := "mallory-" + tt.name
+			status, body, _ := externalLogin(t, s, idp, map[string]interface{}{"sub": subject, "email": "mallory@example.com", "email_verified": true})
+			if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+				t.Fatalf("invalid id_token accepted: status(%v), body(%s)", status, body)
+			}
+			_, err := data.NewIdentityRepo(s.data, testLogger).GetIdentity(context.Background(), "idp", subject)
+			if !kerrors.IsNotFound(err) {
+				t.Fatalf("identity created for invalid id_token: %v", err)
+			}
+		})
+	}
+}
+
+func TestExternalLoginStateSingleUse(t *testing.T) {
+	s, idp := newExternalLoginServer(t)
+	location := idp.approve(t, startExternalLogin(t, s, "/api/user/external/login", nil), map[string]interface{}{"sub": "frank-sub"})
+	status, body := callback(t, s, location, nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("external login: status(%v), body(%s)", status, body)
+	}
+	status, body = callback(t, s, location, nil, nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("replayed callback accepted: status(%v), body(%s)", status, body)
+	}
+
+	// 伪造的state不能登录
+	forged := *location
+	forged.RawQuery = url.Values{"code": {"forged"}, "state": {"forged"}}.Encode()
+	status, body = callback(t, s, &forged, nil, nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("forged state accepted: status(%v), body(%s)", status, body)
+	}
+
+	// 提供方返回的错误
+	denied := *location
+	denied.RawQuery = url.Values{"error": {"access_denied"}, "state": {"whatever"}}.Encode()
+	status, body = callback(t, s, &denied, nil, nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("provider error accepted: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestLinkIdentity(t *testing.T) {
+	s, idp := newExternalLoginServer(t)
+	graceId := s.createUser(t, &biz.User{UserAccount: "grace"})
+	heidiId := s.createUser(t, &biz.User{UserAccount: "heidi"})
+	s.login(t, graceId)
+	s.login(t, heidiId)
+
+	// 回调必须由发起绑定的用户完成
+	location := idp.approve(t, startExternalLogin(t, s, "/api/user/identity/link", userHeader(graceId)), map[string]interface{}{"sub": "grace-sub"})
+	status, body := callback(t, s, location, userHeader(heidiId), nil)
+	if status == http.StatusOK || errorReason(body) != "PERMISSION_DENY" {
+		t.Fatalf("link completed by another user: status(%v), body(%s)", status, body)
+	}
+
+	location = idp.approve(t, startExternalLogin(t, s, "/api/user/identity/link", userHeader(graceId)), map[string]interface{}{"sub": "grace-sub"})
+	reply := &externalLoginReply{}
+	status, body = callback(t, s, location, userHeader(graceId), reply)
+	if status != http.StatusOK || reply.Data.Id != graceId {
+		t.Fatalf("link identity: status(%v), body(%s)", status, body)
+	}
+
+	// 已绑定其他用户的第三方账号不能再绑定
+	location = idp.approve(t, startExternalLogin(t, s, "/api/user/identity/link", userHeader(heidiId)), map[string]interface{}{"sub": "grace-sub"})
+	status, body = callback(t, s, location, userHeader(heidiId), nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("identity linked twice: status(%v), body(%s)", status, body)
+	}
+
+	status, body, login := externalLogin(t, s, idp, map[string]interface{}{"sub": "grace-sub"})
+	if status != http.StatusOK || login.Data.Id != graceId {
+		t.Fatalf("login with linked identity: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestIdentityRequiresSession(t *testing.T) {
+	s, idp := newExternalLoginServer(t)
+	ivanId := s.createUser(t, &biz.User{UserAccount: "ivan"})
+	status, body, login := externalLogin(t, s, idp, map[string]interface{}{"sub": "judy-sub"})
+	if status != http.StatusOK {
+		t.Fatalf("external login: status(%v), body(%s)", status, body)
+	}
+	judyId := login.Data.Id
+
+	// 没有登录会话时伪造的用户id不能管理第三方账号
+	forged := []struct {
+		method, path string
+		body         interface{}
+	}{
+		{http.MethodPost, "/api/user/identity/link", map[string]string{"provider": "idp"}},
+		{http.MethodGet, "/api/user/identity/list", nil},
+		{http.MethodPost, "/api/user/identity/unlink", map[string]int64{"id": 1}},
+	}
+	for _, c := range forged {
+		status, body = s.call(t, s.newClient(t), c.method, c.path, userHeader(ivanId), c.body, nil)
+		if status == http.StatusOK || errorReason(body) != "LOGIN_STATE_TIMEOUT" {
+			t.Fatalf("%s without session: status(%v), body(%s)", c.path, status, body)
+		}
+	}
+
+	// 发起绑定后会话已退出的，回调时拒绝绑定
+	s.login(t, ivanId)
+	location := idp.approve(t, startExternalLogin(t, s, "/api/user/identity/link", userHeader(ivanId)), map[string]interface{}{"sub": "ivan-sub"})
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/logout", userHeader(ivanId), struct{}{}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("logout: status(%v), body(%s)", status, body)
+	}
+	status, body = callback(t, s, location, userHeader(ivanId), nil)
+	if status == http.StatusOK || errorReason(body) != "LOGIN_STATE_TIMEOUT" {
+		t.Fatalf("link callback without session: status(%v), body(%s)", status, body)
+	}
+
+	// 第三方登录用户的会话仍可以查看绑定的账号
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/identity/list", userHeader(judyId), nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("list identities in session: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestExternalLoginStepUp(t *testing.T) {
+	s, idp := newExternalLoginServer(t, func(c *conf.Config) {
+		c.Constant.LoginStepUp = true
+	})
+	claims := map[string]interface{}{"sub": "kim-sub", "email": "kim@example.com", "email_verified": true}
+	status, body, login := deviceExternalLogin(t, s, idp, "laptop", "", claims)
+	if status != http.StatusOK {
+		t.Fatalf("first external login: status(%v), body(%s)", status, body)
+	}
+
+	// 第三方登录与账号密码登录一样，新设备需要邮箱验证码
+	status, body, _ = deviceExternalLogin(t, s, idp, "phone", "", claims)
+	if status == http.StatusOK || errorReason(body) != "LOGIN_VERIFY_REQUIRED" {
+		t.Fatalf("external login from new device: status(%v), body(%s)", status, body)
+	}
+	mail, ok := s.mailer.last("kim@example.com")
+	match := verifyCodeRegexp.FindStringSubmatch(mail.Body)
+	if !ok || match == nil {
+		t.Fatalf("verify code not sent: %+v", mail)
+	}
+	status, body, again := deviceExternalLogin(t, s, idp, "phone", match[1], claims)
+	if status != http.StatusOK || again.Data.Id != login.Data.Id {
+		t.Fatalf("external login with verify code: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestExternalLoginPasskeySecondFactor(t *testing.T) {
+	s, idp := newExternalLoginServer(t, func(c *conf.Config) {
+		c.Webauthn = &conf.WebAuthn{RpId: testRpId, RpDisplayName: "User Center", RpOrigins: []string{testOrigin}, SecondFactor: true}
+	})
+	claims := map[string]interface{}{"sub": "leo-sub"}
+	status, body, login := externalLogin(t, s, idp, claims)
+	if status != http.StatusOK {
+		t.Fatalf("first external login: status(%v), body(%s)", status, body)
+	}
+	registerPasskey(t, s, &softAuthenticator{origin: testOrigin}, login.Data.Id)
+
+	// 已注册通行密钥的用户第三方登录后同样需要通行密钥二次验证
+	status, body, _ = externalLogin(t, s, idp, claims)
+	if status == http.StatusOK || errorReason(body) != "PASSKEY_REQUIRED" {
+		t.Fatalf("external login should require passkey: status(%v), body(%s)", status, body)
+	}
+}
+
+// unlinkOnlyIdentity 解绑用户唯一的第三方账号，返回状态码和响应
+func unlinkOnlyIdentity(t *testing.T, s *testServer, userId int32) (int, []byte) {
+	t.Helper()
+	var identities struct {
+		Data []struct {
+			Id int64 `json:"id,string"`
+		} `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodGet, "/api/user/identity/list", userHeader(userId), nil, &identities)
+	if status != http.StatusOK || len(identities.Data) != 1 {
+		t.Fatalf("list identities: status(%v), body(%s)", status, body)
+	}
+	return s.call(t, s.newClient(t), http.MethodPost, "/api/user/identity/unlink", userHeader(userId), map[string]int64{"id": identities.Data[0].Id}, nil)
+}
+
+// TestUnlinkLastLoginMethod 解绑时密码、第三方账号、通行密钥和登录链接都算作登录方式，不能解绑最后一种
+func TestUnlinkLastLoginMethod(t *testing.T) {
+	t.Run("only identity is kept", func(t *testing.T) {
+		s, idp := newExternalLoginServer(t)
+		status, body, login := externalLogin(t, s, idp, map[string]interface{}{"sub": "mia-sub", "email": "mia@example.com", "email_verified": true})
+		if status != http.StatusOK {
+			t.Fatalf("external login: status(%v), body(%s)", status, body)
+		}
+		// 没有开启免密登录时邮箱不算登录方式
+		status, body = unlinkOnlyIdentity(t, s, login.Data.Id)
+		if status == http.StatusOK || errorReason(body) != "IDENTITY_UNLINK_DENIED" {
+			t.Fatalf("unlink last login method: status(%v), body(%s)", status, body)
+		}
+	})
+
+	t.Run("passkey is a login method", func(t *testing.T) {
+		s, idp := newExternalLoginServer(t, func(c *conf.Config) {
+			c.Webauthn = &conf.WebAuthn{RpId: testRpId, RpDisplayName: "User Center", RpOrigins: []string{testOrigin}}
+		})
+		status, body, login := externalLogin(t, s, idp, map[string]interface{}{"sub": "noah-sub"})
+		if status != http.StatusOK {
+			t.Fatalf("external login: status(%v), body(%s)", status, body)
+		}
+		registerPasskey(t, s, &softAuthenticator{origin: testOrigin}, login.Data.Id)
+		status, body = unlinkOnlyIdentity(t, s, login.Data.Id)
+		if status != http.StatusOK {
+			t.Fatalf("unlink with passkey registered: status(%v), body(%s)", status, body)
+		}
+	})
+
+	t.Run("magic link is a login method", func(t *testing.T) {
+		s, idp := newExternalLoginServer(t, func(c *conf.Config) {
+			c.Constant.MagicLinkLogin = true
+			c.Constant.MagicLinkUrl = "https://front.example/login/magic"
+		})
+		status, body, login := externalLogin(t, s, idp, map[string]interface{}{"sub": "olga-sub"})
+		if status != http.StatusOK {
+			t.Fatalf("external login: status(%v), body(%s)", status, body)
+		}
+		// 没有邮箱时收不到登录链接
+		status, body = unlinkOnlyIdentity(t, s, login.Data.Id)
+		if status == http.StatusOK || errorReason(body) != "IDENTITY_UNLINK_DENIED" {
+			t.Fatalf("unlink without email: status(%v), body(%s)", status, body)
+		}
+
+		status, body, login = externalLogin(t, s, idp, map[string]interface{}{"sub": "pia-sub", "email": "pia@example.com", "email_verified": true})
+		if status != http.StatusOK || login.Data.Email == "" {
+			t.Fatalf("external login: status(%v), body(%s)", status, body)
+		}
+		status, body = unlinkOnlyIdentity(t, s, login.Data.Id)
+		if status != http.StatusOK {
+			t.Fatalf("unlink with magic link login: status(%v), body(%s)", status, body)
+		}
+	})
+}
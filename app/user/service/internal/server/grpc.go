@@ -15,6 +15,10 @@ import (
 
 // NewGRPCServer new a gRPC user.
 func NewGRPCServer(c *conf.Server, userService *service.UserService, logger log.Logger) *grpc.Server {
+	proxies, err := newTrustedProxies(c)
+	if err != nil {
+		log.NewHelper(logger).Fatalf("fail to load server config: %v", err)
+	}
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(recovery.WithHandler(func(ctx context.Context, req, err interface{}) error {
@@ -24,9 +28,11 @@ func NewGRPCServer(c *conf.Server, userService *service.UserService, logger log.
 			})),
 			ratelimit.Server(),
 			responseServer(),
+			requestServer(proxies, userService.CredentialContext),
 			logging.Server(log.NewFilter(logger, log.FilterLevel(log.LevelError))),
 			validate.Validator(),
 		),
+		grpc.StreamInterceptor(streamServer(proxies, userService.CredentialContext)),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -0,0 +1,266 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"github.com/user-center/user-center-backend/app/user/service/internal/data"
+	"github.com/user-center/user-center-backend/app/user/service/internal/server"
+	"github.com/user-center/user-center-backend/app/user/service/internal/service"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+var testLogger = log.NewStdLogger(io.Discard)
+
+// testMailer 记录发送的邮件，代替SMTP
+type testMailer struct {
+	mu    sync.Mutex
+	mails []testMail
+}
+
+type testMail struct {
+	To, Subject, Body string
+}
+
+func (m *testMailer) Send(_ context.Context, to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mails = append(m.mails, testMail{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+// last 最后一封发给to的邮件
+func (m *testMailer) last(to string) (testMail, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.mails) - 1; i >= 0; i-- {
+		if m.mails[i].To == to {
+			return m.mails[i], true
+		}
+	}
+	return testMail{}, false
+}
+
+// testServer 按wire_gen.go的依赖关系组装的HTTP服务，数据库为临时SQLite库，Redis为miniredis，邮件记录在内存中
+type testServer struct {
+	*httptest.Server
+	conf   *conf.Config
+	data   *data.Data
+	redis  *miniredis.Miniredis
+	mailer *testMailer
+	users  biz.UserRepo
+}
+
+func testConfig(t *testing.T) *conf.Config {
+	return &conf.Config{
+		Server: &conf.Server{Http: &conf.Server_HTTP{}, Grpc: &conf.Server_GRPC{}},
+		Data: &conf.Data{
+			Database: &conf.Data_Database{
+				Driver: data.DriverSQLite,
+				Source: fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_txlock=immediate", filepath.Join(t.TempDir(), "user_center.db")),
+			},
+			Redis:        &conf.Data_Redis{MaxRetries: -1},
+			EventBus:     &conf.Data_EventBus{Driver: "memory"},
+			ProfileCache: &conf.Data_ProfileCache{},
+		},
+		Constant: &conf.UserConstant{
+			UserLoginState: "user_login_state",
+			SessionTimeout: 3600,
+			AdminRole:      1,
+		},
+		Oauth:    &conf.OAuth{Issuer: "http://127.0.0.1"},
+		Ldap:     &conf.LDAP{},
+		Saml:     &conf.SAML{},
+		Webauthn: &conf.WebAuthn{},
+	}
+}
+
+// newTestServer 启动测试服务，setup可以修改默认配置，测试结束时关闭
+func newTestServer(t *testing.T, setup ...func(c *conf.Config)) *testServer {
+	t.Helper()
+	redisServer := miniredis.RunT(t)
+	c := testConfig(t)
+	c.Data.Redis.Addr = redisServer.Addr()
+	for _, fn := range setup {
+		fn(c)
+	}
+
+	db, cleanupDB := data.NewDB(c.Data)
+	t.Cleanup(cleanupDB)
+	if c.Data.Database.Driver != data.DriverMemory {
+		migrator, err := data.NewMigrator(db, nil, testLogger)
+		if err != nil {
+			t.Fatalf("fail to load migrations: %v", err)
+		}
+		_, err = migrator.Up(context.Background())
+		if err != nil {
+			t.Fatalf("fail to migrate: %v", err)
+		}
+	}
+	replicas, cleanupReplicas := data.NewReplicas(c.Data, testLogger)
+	t.Cleanup(cleanupReplicas)
+	breaker := data.NewRedisBreaker(c.Data, testLogger)
+	redisCli, cleanupRedis := data.NewRedis(c.Data, breaker)
+	t.Cleanup(cleanupRedis)
+	d, cleanupData, err := data.NewData(db, replicas, redisCli, breaker, testLogger, c.Constant, c.Data)
+	if err != nil {
+		t.Fatalf("fail to create data: %v", err)
+	}
+	t.Cleanup(cleanupData)
+
+	mailer := &testMailer{}
+	userRepo := data.NewUserRepo(d, testLogger)
+	recovery := data.NewRecovery(d)
+	transaction := data.NewTransaction(d)
+	auditRecorder := biz.NewAuditRecorder(data.NewAuditRepo(d, testLogger), testLogger)
+	loginHistoryRepo := data.NewLoginHistoryRepo(d, testLogger)
+	loginHistoryUseCase := biz.NewLoginHistoryUseCase(loginHistoryRepo, testLogger, c.Constant)
+	outboxRepo := data.NewOutboxRepo(d, testLogger)
+	userChangeRepo := data.NewUserChangeRepo(d, testLogger)
+	webhookUseCase := biz.NewWebhookUseCase(data.NewWebhookRepo(d, testLogger), userRepo, data.NewWebhookSender(), auditRecorder, testLogger, c.Constant)
+	eventUseCase := biz.NewEventUseCase(outboxRepo, userChangeRepo, webhookUseCase, testLogger)
+	userUseCase := biz.NewUserUseCase(userRepo, recovery, transaction, testLogger, c.Constant, mailer, auditRecorder, loginHistoryUseCase, eventUseCase)
+	authRepo := data.NewAuthRepo(d, testLogger)
+	geoLocator, err := data.NewGeoLocator(c.Data, testLogger)
+	if err != nil {
+		t.Fatalf("fail to load geo database: %v", err)
+	}
+	loginRiskDetector := biz.NewLoginRiskDetector(loginHistoryRepo, biz.NewLoginRiskRules(geoLocator, c.Constant, testLogger), mailer, testLogger)
+	identityRepo := data.NewIdentityRepo(d, testLogger)
+	ldapAuthenticator := biz.NewLDAPAuthenticator(data.NewDirectory(c.Ldap, testLogger), identityRepo, authRepo, userRepo, transaction, eventUseCase, auditRecorder, c.Ldap, c.Constant, testLogger)
+	authenticators := biz.NewAuthenticators(authRepo, ldapAuthenticator, c.Constant, testLogger)
+	passkeyRepo := data.NewPasskeyRepo(d, testLogger)
+	authRepoUseCase := biz.NewAuthRepoUseCase(authRepo, userRepo, recovery, transaction, testLogger, c.Constant, auditRecorder, loginHistoryUseCase, loginRiskDetector, mailer, eventUseCase, authenticators, passkeyRepo, identityRepo, c.Webauthn)
+	userChangeFeed := biz.NewUserChangeFeed(userChangeRepo, userRepo, c.Constant, testLogger)
+	tokenSigner, err := data.NewTokenSigner(c.Oauth, testLogger)
+	if err != nil {
+		t.Fatalf("fail to create token signer: %v", err)
+	}
+	oauthUseCase := biz.NewOAuthUseCase(data.NewOAuthRepo(d, testLogger), userRepo, authRepo, tokenSigner, transaction, c.Oauth, c.Constant, auditRecorder, testLogger)
+	identityUseCase := biz.NewIdentityUseCase(identityRepo, data.NewExternalProviders(c.Oauth, testLogger), authRepo, userRepo, authRepoUseCase, transaction, c.Constant, auditRecorder, loginHistoryUseCase, eventUseCase, testLogger)
+	samlProviders, err := data.NewSAMLProviders(c.Saml, testLogger)
+	if err != nil {
+		t.Fatalf("fail to create saml providers: %v", err)
+	}
+	samlUseCase := biz.NewSAMLUseCase(samlProviders, identityRepo, identityUseCase, c.Saml, testLogger)
+	scimUseCase := biz.NewScimUseCase(userRepo, authRepo, data.NewGroupRepo(d, testLogger), transaction, eventUseCase, auditRecorder, c.Constant, testLogger)
+	accessTokenRepo := data.NewAccessTokenRepo(d, testLogger)
+	accessTokenUseCase := biz.NewAccessTokenUseCase(accessTokenRepo, userRepo, auditRecorder, c.Constant, testLogger)
+	serviceAccountUseCase := biz.NewServiceAccountUseCase(userRepo, authRepo, accessTokenRepo, transaction, eventUseCase, auditRecorder, c.Constant, testLogger)
+	impersonationUseCase := biz.NewImpersonationUseCase(authRepo, userRepo, auditRecorder, c.Constant, testLogger)
+	relyingParty, err := data.NewPasskeyRelyingParty(c.Webauthn, testLogger)
+	if err != nil {
+		t.Fatalf("fail to create passkey relying party: %v", err)
+	}
+	passkeyUseCase := biz.NewPasskeyUseCase(passkeyRepo, authRepo, userRepo, relyingParty, authRepoUseCase, auditRecorder, loginHistoryUseCase, c.Webauthn, testLogger)
+	healthUseCase := biz.NewHealthUseCase(data.NewHealthRepo(d, testLogger), testLogger)
+	userService := service.NewUserService(userUseCase, authRepoUseCase, biz.NewValidateUseCase(), webhookUseCase, userChangeFeed, oauthUseCase, identityUseCase, samlUseCase, scimUseCase, accessTokenUseCase, serviceAccountUseCase, impersonationUseCase, passkeyUseCase, healthUseCase, testLogger)
+
+	srv := httptest.NewTLSServer(server.NewHTTPServer(c.Server, userService, testLogger))
+	t.Cleanup(srv.Close)
+	// 签发者在服务启动后才知道地址，用例层持有同一个配置对象
+	c.Oauth.Issuer = srv.URL
+	return &testServer{Server: srv, conf: c, data: d, redis: redisServer, mailer: mailer, users: userRepo}
+}
+
+// newClient 信任测试证书、带Cookie的客户端，不自动跟随跳转
+func (s *testServer) newClient(t *testing.T) *http.Client {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("fail to create cookie jar: %v", err)
+	}
+	return &http.Client{
+		Transport: s.Client().Transport,
+		Jar:       jar,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// call 以JSON请求接口，reply不为nil时解析响应，返回状态码和响应内容
+func (s *testServer) call(t *testing.T, client *http.Client, method, path string, header http.Header, body, reply interface{}) (int, []byte) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		marshal, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("json marshal error: %v", err)
+		}
+		reader = bytes.NewReader(marshal)
+	}
+	req, err := http.NewRequest(method, s.URL+path, reader)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	result, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	if reply != nil && resp.StatusCode == http.StatusOK {
+		err = json.Unmarshal(result, reply)
+		if err != nil {
+			t.Fatalf("json unmarshal error: %v, body(%s)", err, result)
+		}
+	}
+	return resp.StatusCode, result
+}
+
+// createUser 直接通过仓储创建可以登录的用户
+func (s *testServer) createUser(t *testing.T, user *biz.User) int32 {
+	t.Helper()
+	userId, err := data.NewAuthRepo(s.data, testLogger).CreateUser(context.Background(), user)
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return userId
+}
+
+// login 为用户创建登录态
+func (s *testServer) login(t *testing.T, userId int32) {
+	t.Helper()
+	user, err := s.users.GetCurrentUser(context.Background(), userId)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	err = data.NewAuthRepo(s.data, testLogger).SetLoginSession(context.Background(), user)
+	if err != nil {
+		t.Fatalf("set login session: %v", err)
+	}
+}
+
+// errorReason 错误响应中的reason
+func errorReason(body []byte) string {
+	var reply struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.Unmarshal(body, &reply)
+	return reply.Reason
+}
+
+// userHeader 网关转发请求时携带的当前用户
+func userHeader(userId int32) http.Header {
+	return http.Header{"Userid": []string{fmt.Sprint(userId)}}
+}
@@ -11,10 +11,16 @@ import (
 	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
 	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
 	"github.com/user-center/user-center-backend/app/user/service/internal/service"
+	"net"
+	nethttp "net/http"
 )
 
 // NewHTTPServer new a HTTP user.
 func NewHTTPServer(c *conf.Server, userService *service.UserService, logger log.Logger) *http.Server {
+	proxies, err := newTrustedProxies(c)
+	if err != nil {
+		log.NewHelper(logger).Fatalf("fail to load server config: %v", err)
+	}
 	var opts = []http.ServerOption{
 		http.Middleware(
 			recovery.Recovery(recovery.WithHandler(func(ctx context.Context, req, err interface{}) error {
@@ -24,7 +30,7 @@ func NewHTTPServer(c *conf.Server, userService *service.UserService, logger log.
 			})),
 			ratelimit.Server(),
 			responseServer(),
-			requestServer(),
+			requestServer(proxies, userService.CredentialContext),
 			logging.Server(log.NewFilter(logger, log.FilterLevel(log.LevelError))),
 			validate.Validator(),
 		),
@@ -40,5 +46,46 @@ func NewHTTPServer(c *conf.Server, userService *service.UserService, logger log.
 	}
 	srv := http.NewServer(opts...)
 	v1.RegisterUserServiceHTTPServer(srv, userService)
+	if len(proxies) == 0 {
+		log.NewHelper(logger).Warn("no trusted proxy configured, /oauth/authorize and /oauth/logout treat every request as not logged in")
+	}
+	srv.HandleFunc("/oauth/authorize", requestHandler(proxies, gatewayUserHandler(proxies, userService.OAuthAuthorize)))
+	srv.HandleFunc("/oauth/token", requestHandler(proxies, userService.OAuthToken))
+	srv.HandleFunc("/oauth/jwks", requestHandler(proxies, userService.OAuthJWKS))
+	srv.HandleFunc("/oauth/logout", requestHandler(proxies, gatewayUserHandler(proxies, userService.OIDCLogout)))
+	srv.HandleFunc("/userinfo", requestHandler(proxies, userService.OIDCUserInfo))
+	srv.HandleFunc("/.well-known/openid-configuration", requestHandler(proxies, userService.OIDCDiscovery))
+	srv.HandleFunc("/saml/metadata", requestHandler(proxies, userService.SAMLMetadata))
+	srv.HandlePrefix("/scim/v2/", requestHandler(proxies, userService.SCIM))
+	srv.HandleFunc("/health", requestHandler(proxies, userService.Health))
+	srv.HandleFunc("/metrics", requestHandler(proxies, userService.Metrics))
 	return srv
 }
+
+// requestHandler 直接注册的http接口不经过kratos中间件，需要补充请求上下文
+func requestHandler(proxies trustedProxies, handler nethttp.HandlerFunc) nethttp.HandlerFunc {
+	return func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		ctx, err := requestContext(r.Context(), proxies)
+		if err != nil {
+			nethttp.Error(w, err.Error(), nethttp.StatusBadRequest)
+			return
+		}
+		handler(w, r.WithContext(ctx))
+	}
+}
+
+// gatewayUserHandler 浏览器直接访问的OAuth端点只信任网关转发的用户id
+//1. 这些端点通常直接暴露给浏览器，请求不经过网关时userId请求头可以被伪造
+//2. 对端不是可信代理时忽略userId请求头，按未登录处理
+func gatewayUserHandler(proxies trustedProxies, handler nethttp.HandlerFunc) nethttp.HandlerFunc {
+	return func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if remote := net.ParseIP(host); remote == nil || !proxies.contains(remote) {
+			r = r.WithContext(context.WithValue(r.Context(), "userId", int32(0)))
+		}
+		handler(w, r)
+	}
+}
@@ -0,0 +1,63 @@
+package server_test
+
+import (
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"net/http"
+	"testing"
+)
+
+// startImpersonation 管理员模拟登录指定用户，返回模拟登录令牌
+func startImpersonation(t *testing.T, s *testServer, adminId, userId int32) string {
+	t.Helper()
+	var reply struct {
+		Token string `json:"token"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/impersonate/start", userHeader(adminId),
+		map[string]interface{}{"userId": userId, "reason": "reproduce ticket"}, &reply)
+	if status != http.StatusOK || reply.Token == "" {
+		t.Fatalf("start impersonation: status(%v), body(%s)", status, body)
+	}
+	return reply.Token
+}
+
+func TestImpersonationAllowedOperations(t *testing.T) {
+	s := newTestServer(t)
+	adminId := s.createUser(t, &biz.User{UserAccount: "admin", Role: 1})
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice"})
+	s.login(t, adminId)
+	s.login(t, aliceId)
+	header := http.Header{"Authorization": {"Bearer " + startImpersonation(t, s, adminId, aliceId)}}
+
+	// 只读接口以被模拟的用户身份调用
+	var current struct {
+		Data struct {
+			Id             int32 `json:"id"`
+			ImpersonatorId int32 `json:"impersonatorId"`
+		} `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", header, nil, &current)
+	if status != http.StatusOK || current.Data.Id != aliceId || current.Data.ImpersonatorId != adminId {
+		t.Fatalf("current user during impersonation: status(%v), body(%s)", status, body)
+	}
+	for _, path := range []string{"/api/user/session/list", "/api/user/identity/list", "/api/user/token/list", "/api/user/passkey/list"} {
+		if status, body = s.call(t, s.newClient(t), http.MethodGet, path, header, nil, nil); status != http.StatusOK {
+			t.Fatalf("%s during impersonation: status(%v), body(%s)", path, status, body)
+		}
+	}
+
+	// 不在允许列表中的接口一律拒绝，包括修改凭证、会话和数据的接口
+	for _, path := range []string{"/api/user/logout", "/api/user/token/create", "/api/user/delete", "/api/user/impersonate/start", "/api/webhook/create"} {
+		status, body = s.call(t, s.newClient(t), http.MethodPost, path, header, map[string]interface{}{}, nil)
+		if errorReason(body) != "PERMISSION_DENY" {
+			t.Fatalf("%s during impersonation: status(%v), body(%s)", path, status, body)
+		}
+	}
+
+	// 结束模拟登录后令牌失效
+	if status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/impersonate/stop", header, map[string]interface{}{}, nil); status != http.StatusOK {
+		t.Fatalf("stop impersonation: status(%v), body(%s)", status, body)
+	}
+	if status, _ = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", header, nil, nil); status == http.StatusOK {
+		t.Fatalf("impersonation token still valid after stop")
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"sync"
+	"time"
+)
+
+var _ transport.Server = (*JobServer)(nil)
+
+// JobServer 后台定时任务服务，随应用启动和停止
+type JobServer struct {
+	jobs   []*biz.Job
+	log    *log.Helper
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewJobServer new a job server.
+func NewJobServer(history *biz.LoginHistoryUseCase, webhook *biz.WebhookUseCase, outbox *biz.OutboxRelay, changes *biz.UserChangeFeed, logger log.Logger) *JobServer {
+	return &JobServer{
+		jobs: []*biz.Job{
+			history.PruneJob(),
+			webhook.DeliverJob(),
+			outbox.RelayJob(),
+			outbox.CleanupJob(),
+			changes.PruneJob(),
+		},
+		log: log.NewHelper(log.With(logger, "module", "user/server/job")),
+	}
+}
+
+func (s *JobServer) Start(ctx context.Context) error {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.run(ctx, job)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (s *JobServer) Stop(_ context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return nil
+}
+
+func (s *JobServer) run(ctx context.Context, job *biz.Job) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		s.execute(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *JobServer) execute(ctx context.Context, job *biz.Job) {
+	defer func() {
+		if rerr := recover(); rerr != nil {
+			s.log.Errorf("job(%s) panic: %v", job.Name, rerr)
+		}
+	}()
+	err := job.Run(ctx)
+	if err != nil {
+		s.log.Errorf("job(%s) failed: %v", job.Name, err)
+	}
+}
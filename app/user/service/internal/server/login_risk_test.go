@@ -0,0 +1,166 @@
+package server_test
+
+import (
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var verifyCodeRegexp = regexp.MustCompile(`验证码：(\d{6})`)
+
+func newLoginRiskServer(t *testing.T, setup ...func(c *conf.Config)) *testServer {
+	return newTestServer(t, append([]func(c *conf.Config){func(c *conf.Config) {
+		c.Constant.LoginStepUp = true
+	}}, setup...)...)
+}
+
+type sessionReply struct {
+	Ip           string `json:"ip"`
+	Method       string `json:"method"`
+	Unrecognized bool   `json:"unrecognized"`
+}
+
+// listMySessions 当前用户的登录会话和最近的登录历史
+func listMySessions(t *testing.T, s *testServer, userId int32) ([]*sessionReply, []*sessionReply) {
+	t.Helper()
+	var sessions, history struct {
+		Data []*sessionReply `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodGet, "/api/user/session/list", userHeader(userId), nil, &sessions)
+	if status != http.StatusOK {
+		t.Fatalf("list sessions: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/login/history", userHeader(userId), map[string]interface{}{}, &history)
+	if status != http.StatusOK {
+		t.Fatalf("list login history: status(%v), body(%s)", status, body)
+	}
+	return sessions.Data, history.Data
+}
+
+// deviceLogin 从指定设备以账号密码登录
+func deviceLogin(t *testing.T, s *testServer, client *http.Client, device, userAccount, userPassword, verifyCode string) (int, []byte) {
+	t.Helper()
+	return s.call(t, client, http.MethodPost, "/api/user/login", http.Header{"X-Device-Id": {device}},
+		map[string]string{"userAccount": userAccount, "userPassword": userPassword, "verifyCode": verifyCode}, nil)
+}
+
+func TestLoginStepUpEmail(t *testing.T) {
+	s := newLoginRiskServer(t)
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice", UserPassword: biz.PasswordHash("alice-password"), Email: "alice@example.com"})
+	client := s.newClient(t)
+	if status, body := deviceLogin(t, s, client, "laptop", "alice", "alice-password", ""); status != http.StatusOK {
+		t.Fatalf("first login: status(%v), body(%s)", status, body)
+	}
+	sessions, history := listMySessions(t, s, aliceId)
+	if len(sessions) != 1 || sessions[0].Unrecognized || len(history) != 1 || history[0].Unrecognized {
+		t.Fatalf("first login marked unrecognized: sessions(%+v), history(%+v)", sessions, history)
+	}
+
+	// 新设备登录需要邮箱验证码
+	status, body := deviceLogin(t, s, client, "phone", "alice", "alice-password", "")
+	if status == http.StatusOK || errorReason(body) != "LOGIN_VERIFY_REQUIRED" {
+		t.Fatalf("login from new device: status(%v), body(%s)", status, body)
+	}
+	mail, ok := s.mailer.last("alice@example.com")
+	match := verifyCodeRegexp.FindStringSubmatch(mail.Body)
+	if !ok || match == nil {
+		t.Fatalf("verify code not sent: %+v", mail)
+	}
+	if status, body = deviceLogin(t, s, client, "phone", "alice", "alice-password", match[1]); status != http.StatusOK {
+		t.Fatalf("login with verify code: status(%v), body(%s)", status, body)
+	}
+
+	// 经过二次验证的登录仍然标记为未识别，便于用户在会话和登录历史中核对
+	sessions, history = listMySessions(t, s, aliceId)
+	if len(sessions) != 1 || !sessions[0].Unrecognized || sessions[0].Method != biz.LoginMethodPassword {
+		t.Fatalf("unrecognized session: %+v", sessions)
+	}
+	if len(history) != 3 || !history[0].Unrecognized || history[2].Unrecognized {
+		t.Fatalf("unrecognized login history: %+v", history)
+	}
+}
+
+func TestLoginStepUpWithoutEmail(t *testing.T) {
+	s := newLoginRiskServer(t)
+	s.createUser(t, &biz.User{UserAccount: "bobby", UserPassword: biz.PasswordHash("bobby-password")})
+	client := s.newClient(t)
+	if status, body := deviceLogin(t, s, client, "laptop", "bobby", "bobby-password", ""); status != http.StatusOK {
+		t.Fatalf("first login: status(%v), body(%s)", status, body)
+	}
+
+	// 没有邮箱也没有通行密钥时无法二次验证，不能跳过验证直接登录
+	status, body := deviceLogin(t, s, client, "phone", "bobby", "bobby-password", "")
+	if status == http.StatusOK || errorReason(body) != "LOGIN_VERIFY_UNAVAILABLE" {
+		t.Fatalf("login from new device without email: status(%v), body(%s)", status, body)
+	}
+	// 已使用过的设备不受影响
+	if status, body = deviceLogin(t, s, client, "laptop", "bobby", "bobby-password", ""); status != http.StatusOK {
+		t.Fatalf("login from known device: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestLoginStepUpPasskey(t *testing.T) {
+	s := newLoginRiskServer(t, func(c *conf.Config) {
+		c.Webauthn = &conf.WebAuthn{RpId: testRpId, RpDisplayName: "User Center", RpOrigins: []string{testOrigin}}
+	})
+	authenticator := &softAuthenticator{origin: testOrigin}
+	carolId := s.createUser(t, &biz.User{UserAccount: "carol", UserPassword: biz.PasswordHash("carol-password")})
+	client := s.newClient(t)
+	if status, body := deviceLogin(t, s, client, "laptop", "carol", "carol-password", ""); status != http.StatusOK {
+		t.Fatalf("first login: status(%v), body(%s)", status, body)
+	}
+	_, credential := registerPasskey(t, s, authenticator, carolId)
+
+	// 没有邮箱时使用已注册的通行密钥二次验证
+	status, body := deviceLogin(t, s, client, "phone", "carol", "carol-password", "")
+	if status == http.StatusOK || errorReason(body) != "PASSKEY_REQUIRED" {
+		t.Fatalf("login from new device with passkey: status(%v), body(%s)", status, body)
+	}
+	challenge := &passkeyChallenge{}
+	status, body = s.call(t, client, http.MethodPost, "/api/user/passkey/login/begin", http.Header{"X-Device-Id": {"phone"}},
+		map[string]string{"userAccount": "carol"}, challenge)
+	if status != http.StatusOK {
+		t.Fatalf("begin passkey login: status(%v), body(%s)", status, body)
+	}
+	reply := &externalLoginReply{}
+	status, body = s.call(t, client, http.MethodPost, "/api/user/passkey/login/finish", http.Header{"X-Device-Id": {"phone"}},
+		map[string]string{"challengeId": challenge.ChallengeId, "credential": authenticator.get(t, challenge.Options, credential)}, reply)
+	if status != http.StatusOK || reply.Data.Id != carolId {
+		t.Fatalf("finish passkey login: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestLoginRiskRulesGeo(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "geo.csv")
+	err := os.WriteFile(file, []byte("network,location\n198.51.100.0/24,US\n192.0.2.0/24,US\n203.0.113.0/24,CN\n"), 0600)
+	if err != nil {
+		t.Fatalf("write geo file: %v", err)
+	}
+	s := newLoginRiskServer(t, func(c *conf.Config) {
+		c.Server.TrustedProxies = []string{"127.0.0.1/32"}
+		c.Data.Geo = &conf.Data_Geo{Files: []string{file}}
+		c.Constant.LoginRiskRules = []string{"geo"}
+	})
+	s.createUser(t, &biz.User{UserAccount: "alice", UserPassword: biz.PasswordHash("alice-password"), Email: "alice@example.com"})
+	client := s.newClient(t)
+	login := func(ip string) (int, []byte) {
+		return s.call(t, client, http.MethodPost, "/api/user/login", http.Header{"X-Forwarded-For": {ip}, "X-Device-Id": {ip}},
+			map[string]string{"userAccount": "alice", "userPassword": "alice-password"}, nil)
+	}
+	if status, body := login("198.51.100.7"); status != http.StatusOK {
+		t.Fatalf("first login: status(%v), body(%s)", status, body)
+	}
+
+	// 只启用地理位置规则时，同一地区的新设备和新ip段不需要二次验证
+	if status, body := login("192.0.2.9"); status != http.StatusOK {
+		t.Fatalf("login from same location: status(%v), body(%s)", status, body)
+	}
+	status, body := login("203.0.113.7")
+	if status == http.StatusOK || errorReason(body) != "LOGIN_VERIFY_REQUIRED" {
+		t.Fatalf("login from new location: status(%v), body(%s)", status, body)
+	}
+}
@@ -0,0 +1,110 @@
+package server_test
+
+import (
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+var magicLinkRegexp = regexp.MustCompile(`https://front\.example/login/magic\?token=\S+`)
+
+func newMagicLinkServer(t *testing.T, setup func(c *conf.UserConstant)) *testServer {
+	s := newTestServer(t, func(c *conf.Config) {
+		c.Constant.MagicLinkLogin = true
+		c.Constant.MagicLinkUrl = "https://front.example/login/magic"
+		if setup != nil {
+			setup(c.Constant)
+		}
+	})
+	s.createUser(t, &biz.User{UserAccount: "magic", Email: "magic@example.com"})
+	return s
+}
+
+// requestMagicLink 请求登录链接，返回邮件中的令牌
+func requestMagicLink(t *testing.T, s *testServer, client *http.Client, email string) string {
+	t.Helper()
+	status, body := s.call(t, client, http.MethodPost, "/api/user/login/magic/request", nil, map[string]string{"email": email}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("request magic link: status(%v), body(%s)", status, body)
+	}
+	mail, ok := s.mailer.last(email)
+	if !ok {
+		t.Fatalf("magic link mail not sent")
+	}
+	link, err := url.Parse(magicLinkRegexp.FindString(mail.Body))
+	if err != nil {
+		t.Fatalf("parse magic link: %v, body(%s)", err, mail.Body)
+	}
+	return link.Query().Get("token")
+}
+
+func TestMagicLinkRequiresBrowserNonce(t *testing.T) {
+	s := newMagicLinkServer(t, nil)
+	browser := s.newClient(t)
+
+	token := requestMagicLink(t, s, browser, "magic@example.com")
+	cookies := browser.Jar.Cookies(&url.URL{Scheme: "https", Host: s.Listener.Addr().String(), Path: "/"})
+	if len(cookies) != 1 || cookies[0].Name != "magic_link_nonce" || cookies[0].Value == "" {
+		t.Fatalf("nonce cookie not set: %v", cookies)
+	}
+
+	// 只拿到邮件中的链接，即使伪造相同的设备信息也不能登录，链接随之失效
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/login/magic", nil, map[string]string{"token": token}, nil)
+	if status == http.StatusOK || errorReason(body) != "MAGIC_LINK_INVALID" {
+		t.Fatalf("login without nonce: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, browser, http.MethodPost, "/api/user/login/magic", nil, map[string]string{"token": token}, nil)
+	if status == http.StatusOK {
+		t.Fatalf("used magic link accepted: body(%s)", body)
+	}
+
+	token = requestMagicLink(t, s, browser, "magic@example.com")
+	var reply struct {
+		Data struct {
+			UserAccount string `json:"userAccount"`
+		} `json:"data"`
+	}
+	status, body = s.call(t, browser, http.MethodPost, "/api/user/login/magic", nil, map[string]string{"token": token}, &reply)
+	if status != http.StatusOK || reply.Data.UserAccount != "magic" {
+		t.Fatalf("login in requesting browser: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestMagicLinkEmailRateLimit(t *testing.T) {
+	s := newMagicLinkServer(t, func(c *conf.UserConstant) {
+		c.MagicLinkEmailLimit = 2
+	})
+	client := s.newClient(t)
+	requestMagicLink(t, s, client, "magic@example.com")
+	requestMagicLink(t, s, client, "magic@example.com")
+	status, body := s.call(t, client, http.MethodPost, "/api/user/login/magic/request", nil, map[string]string{"email": "magic@example.com"}, nil)
+	if status != http.StatusTooManyRequests || errorReason(body) != "MAGIC_LINK_RATE_LIMITED" {
+		t.Fatalf("request over email limit: status(%v), body(%s)", status, body)
+	}
+
+	// 其他邮箱不受影响
+	status, body = s.call(t, client, http.MethodPost, "/api/user/login/magic/request", nil, map[string]string{"email": "other@example.com"}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("request for another email: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestMagicLinkIpRateLimit(t *testing.T) {
+	s := newMagicLinkServer(t, func(c *conf.UserConstant) {
+		c.MagicLinkIpLimit = 2
+	})
+	client := s.newClient(t)
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		status, body := s.call(t, client, http.MethodPost, "/api/user/login/magic/request", nil, map[string]string{"email": email}, nil)
+		if status != http.StatusOK {
+			t.Fatalf("request magic link: status(%v), body(%s)", status, body)
+		}
+	}
+	status, body := s.call(t, client, http.MethodPost, "/api/user/login/magic/request", nil, map[string]string{"email": "c@example.com"}, nil)
+	if status != http.StatusTooManyRequests || errorReason(body) != "MAGIC_LINK_RATE_LIMITED" {
+		t.Fatalf("request over ip limit: status(%v), body(%s)", status, body)
+	}
+}
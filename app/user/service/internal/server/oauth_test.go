@@ -0,0 +1,482 @@
+package server_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"github.com/user-center/user-center-backend/app/user/service/internal/data"
+	"golang.org/x/oauth2"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	oauthRedirectUri = "https://app.example/callback"
+	oauthVerifier    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+type oauthConformance struct {
+	*testServer
+	browser      *http.Client
+	userId       int32
+	clientId     string
+	clientSecret string
+}
+
+// gatewayProxies 测试请求来自本机，模拟经过网关转发
+var gatewayProxies = []string{"127.0.0.1/32"}
+
+// newOAuthConformance 管理员创建注册了redirectUris的机密应用，普通用户已登录，proxies为可信代理
+func newOAuthConformance(t *testing.T, proxies []string, redirectUris ...string) *oauthConformance {
+	s := newTestServer(t, func(c *conf.Config) {
+		c.Server.TrustedProxies = proxies
+	})
+	adminId := s.createUser(t, &biz.User{UserAccount: "oauth_admin", Role: 1})
+	s.login(t, adminId)
+	userId := s.createUser(t, &biz.User{UserAccount: "oauth_user", UserName: "OAuth User"})
+	s.login(t, userId)
+
+	var created struct {
+		Data struct {
+			ClientId     string `json:"clientId"`
+			ClientSecret string `json:"clientSecret"`
+		} `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/oauth/client/create", userHeader(adminId), map[string]interface{}{
+		"name":         "conformance",
+		"redirectUris": redirectUris,
+		"grantTypes":   []string{"authorization_code", "refresh_token"},
+		"scopes":       []string{"openid", "profile"},
+	}, &created)
+	if status != http.StatusOK || created.Data.ClientId == "" || created.Data.ClientSecret == "" {
+		t.Fatalf("create oauth client: status(%v), body(%s)", status, body)
+	}
+	return &oauthConformance{
+		testServer:   s,
+		browser:      s.newClient(t),
+		userId:       userId,
+		clientId:     created.Data.ClientId,
+		clientSecret: created.Data.ClientSecret,
+	}
+}
+
+// consent 用户同意授权，之后的授权请求直接签发授权码
+func (o *oauthConformance) consent(t *testing.T, redirectUri string) *url.URL {
+	t.Helper()
+	var reply struct {
+		RedirectUri string `json:"redirectUri"`
+	}
+	request := map[string]interface{}{
+		"responseType": "code", "clientId": o.clientId, "redirectUri": redirectUri, "scope": "openid profile",
+		"state": "xyz", "nonce": "n-0S6_WzA2Mj", "codeChallenge": pkceChallenge(oauthVerifier), "codeChallengeMethod": "S256",
+	}
+	status, body := o.call(t, o.browser, http.MethodPost, "/api/oauth/consent/submit", userHeader(o.userId), map[string]interface{}{"request": request, "approve": true}, &reply)
+	if status != http.StatusOK {
+		t.Fatalf("submit consent: status(%v), body(%s)", status, body)
+	}
+	location, err := url.Parse(reply.RedirectUri)
+	if err != nil {
+		t.Fatalf("consent redirect: %s", reply.RedirectUri)
+	}
+	return location
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// authorize 请求授权端点，返回响应和跳转地址
+func (o *oauthConformance) authorize(t *testing.T, header http.Header, query url.Values) (*http.Response, *url.URL) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, o.URL+"/oauth/authorize?"+query.Encode(), nil)
+	if err != nil {
+		t.Fatalf("new authorize request: %v", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := o.browser.Do(req)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	location, _ := resp.Location()
+	return resp, location
+}
+
+// code 用户授权后签发授权码，redirectUri为空时授权请求不携带redirect_uri
+func (o *oauthConformance) code(t *testing.T, redirectUri string) string {
+	t.Helper()
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.clientId},
+		"scope":                 {"openid profile"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0S6_WzA2Mj"},
+		"code_challenge":        {pkceChallenge(oauthVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+	if redirectUri != "" {
+		query.Set("redirect_uri", redirectUri)
+	}
+	resp, location := o.authorize(t, userHeader(o.userId), query)
+	if resp.StatusCode != http.StatusFound || location == nil {
+		t.Fatalf("authorize after consent: status(%v)", resp.StatusCode)
+	}
+	if location.Query().Get("state") != "xyz" || location.Query().Get("code") == "" {
+		t.Fatalf("authorize redirect: %s", location)
+	}
+	return location.Query().Get("code")
+}
+
+// token 以client_secret_basic请求令牌端点，返回状态码和响应
+func (o *oauthConformance) token(t *testing.T, form url.Values) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, o.URL+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatalf("new token request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(o.clientId), url.QueryEscape(o.clientSecret))
+	resp, err := o.browser.Do(req)
+	if err != nil {
+		t.Fatalf("token: %v", err)
+	}
+	defer resp.Body.Close()
+	reply := make(map[string]interface{})
+	err = json.NewDecoder(resp.Body).Decode(&reply)
+	if err != nil {
+		t.Fatalf("decode token reply: %v", err)
+	}
+	if resp.Header.Get("Cache-Control") != "no-store" {
+		t.Errorf("token reply must not be cached: Cache-Control(%s)", resp.Header.Get("Cache-Control"))
+	}
+	return resp.StatusCode, reply
+}
+
+func codeForm(code, redirectUri string) url.Values {
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {code}, "code_verifier": {oauthVerifier}}
+	if redirectUri != "" {
+		form.Set("redirect_uri", redirectUri)
+	}
+	return form
+}
+
+func expectOAuthError(t *testing.T, status int, reply map[string]interface{}, code string) {
+	t.Helper()
+	if status != http.StatusBadRequest && status != http.StatusUnauthorized {
+		t.Fatalf("want error status, got %v: %v", status, reply)
+	}
+	if reply["error"] != code {
+		t.Fatalf("want error %s, got %v", code, reply)
+	}
+}
+
+// TestOAuthCodeFlowConformance 授权码模式（RFC 6749、RFC 7636、OpenID Connect Core）端到端检查，只依赖本地服务
+func TestOAuthCodeFlowConformance(t *testing.T) {
+	o := newOAuthConformance(t, gatewayProxies, oauthRedirectUri, "https://app.example/other")
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.clientId},
+		"redirect_uri":          {oauthRedirectUri},
+		"scope":                 {"openid profile"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0S6_WzA2Mj"},
+		"code_challenge":        {pkceChallenge(oauthVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	t.Run("unregistered redirect_uri is not redirected to", func(t *testing.T) {
+		bad := url.Values{}
+		for key, values := range query {
+			bad[key] = values
+		}
+		bad.Set("redirect_uri", "https://evil.example/callback")
+		resp, location := o.authorize(t, userHeader(o.userId), bad)
+		if resp.StatusCode != http.StatusBadRequest || location != nil {
+			t.Fatalf("unregistered redirect_uri: status(%v), location(%v)", resp.StatusCode, location)
+		}
+	})
+
+	t.Run("errors are returned to the client with state", func(t *testing.T) {
+		bad := url.Values{}
+		for key, values := range query {
+			bad[key] = values
+		}
+		bad.Set("response_type", "token")
+		resp, location := o.authorize(t, userHeader(o.userId), bad)
+		if resp.StatusCode != http.StatusFound || location == nil || !strings.HasPrefix(location.String(), oauthRedirectUri) {
+			t.Fatalf("unsupported response_type: status(%v), location(%v)", resp.StatusCode, location)
+		}
+		if location.Query().Get("error") != "unsupported_response_type" || location.Query().Get("state") != "xyz" {
+			t.Fatalf("error redirect: %s", location)
+		}
+	})
+
+	t.Run("login and consent are required", func(t *testing.T) {
+		resp, _ := o.authorize(t, nil, query)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("anonymous authorize: status(%v)", resp.StatusCode)
+		}
+		resp, _ = o.authorize(t, userHeader(o.userId), query)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("authorize before consent: status(%v)", resp.StatusCode)
+		}
+		location := o.consent(t, oauthRedirectUri)
+		if location.Query().Get("code") == "" || location.Query().Get("state") != "xyz" {
+			t.Fatalf("consent redirect: %s", location)
+		}
+	})
+
+	t.Run("code exchange issues verifiable tokens", func(t *testing.T) {
+		code := o.code(t, oauthRedirectUri)
+		status, reply := o.token(t, codeForm(code, oauthRedirectUri))
+		if status != http.StatusOK {
+			t.Fatalf("exchange code: status(%v), reply(%v)", status, reply)
+		}
+		if reply["token_type"] != "Bearer" || reply["access_token"] == nil || reply["refresh_token"] == nil {
+			t.Fatalf("token reply: %v", reply)
+		}
+
+		ctx := oidc.ClientContext(context.Background(), o.browser)
+		provider, err := oidc.NewProvider(ctx, o.URL)
+		if err != nil {
+			t.Fatalf("discovery: %v", err)
+		}
+		rawIdToken, _ := reply["id_token"].(string)
+		idToken, err := provider.Verifier(&oidc.Config{ClientID: o.clientId}).Verify(ctx, rawIdToken)
+		if err != nil {
+			t.Fatalf("verify id_token: %v", err)
+		}
+		if idToken.Nonce != "n-0S6_WzA2Mj" {
+			t.Fatalf("id_token nonce: %s", idToken.Nonce)
+		}
+
+		userInfo, err := provider.UserInfo(ctx, oauth2TokenSource(reply["access_token"].(string)))
+		if err != nil || userInfo.Subject != idToken.Subject {
+			t.Fatalf("userinfo: info(%v), error(%v)", userInfo, err)
+		}
+
+		// 授权码只能使用一次
+		refreshToken := reply["refresh_token"].(string)
+		status, reply = o.token(t, codeForm(code, oauthRedirectUri))
+		expectOAuthError(t, status, reply, "invalid_grant")
+
+		// 刷新令牌使用后轮换，旧令牌失效
+		refresh := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}}
+		status, reply = o.token(t, refresh)
+		if status != http.StatusOK || reply["refresh_token"] == nil || reply["refresh_token"] == refreshToken {
+			t.Fatalf("refresh: status(%v), reply(%v)", status, reply)
+		}
+		status, reply = o.token(t, refresh)
+		expectOAuthError(t, status, reply, "invalid_grant")
+	})
+
+	t.Run("redirect_uri must match the authorization request", func(t *testing.T) {
+		status, reply := o.token(t, codeForm(o.code(t, oauthRedirectUri), ""))
+		expectOAuthError(t, status, reply, "invalid_grant")
+
+		status, reply = o.token(t, codeForm(o.code(t, oauthRedirectUri), "https://app.example/other"))
+		expectOAuthError(t, status, reply, "invalid_grant")
+
+		status, reply = o.token(t, codeForm(o.code(t, oauthRedirectUri), oauthRedirectUri))
+		if status != http.StatusOK {
+			t.Fatalf("exchange with matching redirect_uri: status(%v), reply(%v)", status, reply)
+		}
+	})
+
+	t.Run("pkce verifier is required", func(t *testing.T) {
+		form := codeForm(o.code(t, oauthRedirectUri), oauthRedirectUri)
+		form.Set("code_verifier", "wrong-verifier-wrong-verifier-wrong-verifier")
+		status, reply := o.token(t, form)
+		expectOAuthError(t, status, reply, "invalid_grant")
+	})
+
+	t.Run("client authentication is required", func(t *testing.T) {
+		secret := o.clientSecret
+		o.clientSecret = "wrong"
+		status, reply := o.token(t, codeForm(o.code(t, oauthRedirectUri), oauthRedirectUri))
+		o.clientSecret = secret
+		expectOAuthError(t, status, reply, "invalid_client")
+	})
+}
+
+// TestOAuthOmittedRedirectUri 只注册了一个回调地址时授权请求可以不携带redirect_uri，此时兑换可以不携带，携带时必须一致
+func TestOAuthOmittedRedirectUri(t *testing.T) {
+	o := newOAuthConformance(t, gatewayProxies, oauthRedirectUri)
+	o.consent(t, "")
+
+	status, reply := o.token(t, codeForm(o.code(t, ""), ""))
+	if status != http.StatusOK {
+		t.Fatalf("exchange without redirect_uri: status(%v), reply(%v)", status, reply)
+	}
+	status, reply = o.token(t, codeForm(o.code(t, ""), oauthRedirectUri))
+	if status != http.StatusOK {
+		t.Fatalf("exchange with the registered redirect_uri: status(%v), reply(%v)", status, reply)
+	}
+	status, reply = o.token(t, codeForm(o.code(t, ""), "https://app.example/other"))
+	expectOAuthError(t, status, reply, "invalid_grant")
+}
+
+// TestOAuthAuthorizeRequiresGateway 请求不是由可信代理转发时忽略userId请求头，按未登录处理
+func TestOAuthAuthorizeRequiresGateway(t *testing.T) {
+	o := newOAuthConformance(t, []string{"10.0.0.1"}, oauthRedirectUri)
+	o.consent(t, oauthRedirectUri)
+
+	resp, location := o.authorize(t, userHeader(o.userId), url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.clientId},
+		"redirect_uri":          {oauthRedirectUri},
+		"scope":                 {"openid profile"},
+		"state":                 {"xyz"},
+		"code_challenge":        {pkceChallenge(oauthVerifier)},
+		"code_challenge_method": {"S256"},
+	})
+	if resp.StatusCode != http.StatusUnauthorized || location != nil {
+		t.Fatalf("authorize from untrusted peer: status(%v), location(%v)", resp.StatusCode, location)
+	}
+}
+
+// oauth2TokenSource 固定的访问令牌
+func oauth2TokenSource(accessToken string) oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken, TokenType: "Bearer"})
+}
+
+// TestOIDCLogout RP发起的退出登录只结束当前用户自己的登录态，id_token_hint不能用来退出其他用户
+func TestOIDCLogout(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("fail to generate signing key: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "signing_key.pem")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600)
+	if err != nil {
+		t.Fatalf("fail to write signing key: %v", err)
+	}
+	s := newTestServer(t, func(c *conf.Config) {
+		c.Oauth.SigningKeyFile = keyFile
+		c.Server.TrustedProxies = gatewayProxies
+	})
+	signer, err := data.NewTokenSigner(s.conf.Oauth, testLogger)
+	if err != nil {
+		t.Fatalf("fail to create token signer: %v", err)
+	}
+
+	adminId := s.createUser(t, &biz.User{UserAccount: "logout_admin", Role: 1})
+	s.login(t, adminId)
+	var created struct {
+		Data struct {
+			ClientId string `json:"clientId"`
+		} `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/oauth/client/create", userHeader(adminId), map[string]interface{}{
+		"name":                   "logout",
+		"redirectUris":           []string{oauthRedirectUri},
+		"postLogoutRedirectUris": []string{"https://app.example/logged-out"},
+		"grantTypes":             []string{"authorization_code"},
+		"scopes":                 []string{"openid"},
+	}, &created)
+	if status != http.StatusOK || created.Data.ClientId == "" {
+		t.Fatalf("create oauth client: status(%v), body(%s)", status, body)
+	}
+	aliceId := s.createUser(t, &biz.User{UserAccount: "logout_alice"})
+	bobId := s.createUser(t, &biz.User{UserAccount: "logout_bob"})
+
+	hint := func(userId int32, expiresAt time.Time) string {
+		token, err := signer.Sign(&jwt.RegisteredClaims{
+			Issuer:    s.conf.Oauth.Issuer,
+			Subject:   fmt.Sprint(userId),
+			Audience:  jwt.ClaimStrings{created.Data.ClientId},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		})
+		if err != nil {
+			t.Fatalf("sign id_token_hint: %v", err)
+		}
+		return token
+	}
+	logout := func(header http.Header, idTokenHint string) *http.Response {
+		query := url.Values{"id_token_hint": {idTokenHint}, "post_logout_redirect_uri": {"https://app.example/logged-out"}, "state": {"xyz"}}
+		req, err := http.NewRequest(http.MethodGet, s.URL+"/oauth/logout?"+query.Encode(), nil)
+		if err != nil {
+			t.Fatalf("new logout request: %v", err)
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		resp, err := s.newClient(t).Do(req)
+		if err != nil {
+			t.Fatalf("logout: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	isLogin := func(userId int32) bool {
+		_, err := s.users.GetUserSession(context.Background(), userId)
+		return err == nil
+	}
+
+	t.Run("request without a session ends no session", func(t *testing.T) {
+		s.login(t, aliceId)
+		resp := logout(nil, hint(aliceId, time.Now().Add(time.Hour)))
+		location, _ := resp.Location()
+		if resp.StatusCode != http.StatusFound || location == nil || location.Query().Get("state") != "xyz" {
+			t.Fatalf("anonymous logout: status(%v), location(%v)", resp.StatusCode, location)
+		}
+		if !isLogin(aliceId) {
+			t.Fatalf("anonymous logout must not end the session of the id_token_hint subject")
+		}
+	})
+
+	t.Run("id_token_hint of another user is rejected", func(t *testing.T) {
+		s.login(t, aliceId)
+		s.login(t, bobId)
+		resp := logout(userHeader(bobId), hint(aliceId, time.Now().Add(time.Hour)))
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("logout with another user's id_token_hint: status(%v)", resp.StatusCode)
+		}
+		if !isLogin(aliceId) || !isLogin(bobId) {
+			t.Fatalf("mismatched id_token_hint must not end any session")
+		}
+	})
+
+	t.Run("expired id_token_hint is rejected", func(t *testing.T) {
+		s.login(t, aliceId)
+		resp := logout(userHeader(aliceId), hint(aliceId, time.Now().Add(-10*time.Minute)))
+		if resp.StatusCode != http.StatusBadRequest || !isLogin(aliceId) {
+			t.Fatalf("logout with expired id_token_hint: status(%v)", resp.StatusCode)
+		}
+	})
+
+	t.Run("caller ends its own session", func(t *testing.T) {
+		s.login(t, aliceId)
+		s.login(t, bobId)
+		// 刚过期的令牌在容忍时间内仍然可用
+		resp := logout(userHeader(aliceId), hint(aliceId, time.Now().Add(-10*time.Second)))
+		if resp.StatusCode != http.StatusFound {
+			t.Fatalf("logout: status(%v)", resp.StatusCode)
+		}
+		if isLogin(aliceId) || !isLogin(bobId) {
+			t.Fatalf("logout must end only the caller's session")
+		}
+		logs, _, err := data.NewAuditRepo(s.data, testLogger).ListAuditLogs(context.Background(), &biz.AuditLogQuery{Action: biz.AuditActionUserLogout, Result: biz.AuditResultSuccess, Page: 1, PageSize: 10})
+		if err != nil || len(logs) != 1 || logs[0].ActorId != aliceId || logs[0].TargetId != aliceId {
+			t.Fatalf("logout audit logs: logs(%v), error(%v)", logs, err)
+		}
+	})
+}
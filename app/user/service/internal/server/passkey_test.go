@@ -0,0 +1,412 @@
+package server_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"testing"
+)
+
+const (
+	testRpId   = "example.com"
+	testOrigin = "https://example.com"
+)
+
+// 认证器标志位
+const (
+	authenticatorUserPresent    = 0x01
+	authenticatorUserVerified   = 0x04
+	authenticatorBackupEligible = 0x08
+	authenticatorBackupState    = 0x10
+	authenticatorAttestedData   = 0x40
+)
+
+var testAaguid = []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+
+// softAuthenticator 软件实现的平台认证器，使用P-256密钥和none证明，凭证可被发现
+type softAuthenticator struct {
+	origin string
+}
+
+// softCredential 认证器中保存的凭证，每次签名前计数加一
+type softCredential struct {
+	id         []byte
+	key        *ecdsa.PrivateKey
+	userHandle []byte
+	signCount  uint32
+}
+
+func (a *softAuthenticator) clientData(t *testing.T, ceremony string, challenge protocol.URLEncodedBase64) []byte {
+	clientData, err := json.Marshal(map[string]interface{}{
+		"type":        ceremony,
+		"challenge":   base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":      a.origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		t.Fatalf("json marshal error: %v", err)
+	}
+	return clientData
+}
+
+func authenticatorData(flags byte, signCount uint32) []byte {
+	rpIdHash := sha256.Sum256([]byte(testRpId))
+	data := bytes.NewBuffer(rpIdHash[:])
+	data.WriteByte(flags)
+	_ = binary.Write(data, binary.BigEndian, signCount)
+	return data.Bytes()
+}
+
+// create 按注册选项创建凭证，返回提交给服务的凭证
+func (a *softAuthenticator) create(t *testing.T, options string) (string, *softCredential) {
+	t.Helper()
+	creation := &protocol.CredentialCreation{}
+	err := json.Unmarshal([]byte(options), creation)
+	if err != nil {
+		t.Fatalf("parse creation options: %v, options(%s)", err, options)
+	}
+	if creation.Response.RelyingParty.ID != testRpId {
+		t.Fatalf("relying party id: %s", creation.Response.RelyingParty.ID)
+	}
+	userId, _ := creation.Response.User.ID.(string)
+	userHandle, err := base64.RawURLEncoding.DecodeString(userId)
+	if err != nil || len(userHandle) == 0 {
+		t.Fatalf("user handle: %v", creation.Response.User.ID)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate credential key: %v", err)
+	}
+	credential := &softCredential{id: make([]byte, 16), key: key, userHandle: userHandle, signCount: 1}
+	_, _ = rand.Read(credential.id)
+
+	publicKey, err := webauthncbor.Marshal(map[int]interface{}{
+		1:  2,
+		3:  -7,
+		-1: 1,
+		-2: key.PublicKey.X.FillBytes(make([]byte, 32)),
+		-3: key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatalf("cbor marshal public key: %v", err)
+	}
+	authData := bytes.NewBuffer(authenticatorData(authenticatorUserPresent|authenticatorUserVerified|authenticatorBackupEligible|authenticatorBackupState|authenticatorAttestedData, credential.signCount))
+	authData.Write(testAaguid)
+	_ = binary.Write(authData, binary.BigEndian, uint16(len(credential.id)))
+	authData.Write(credential.id)
+	authData.Write(publicKey)
+	attestation, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData.Bytes(),
+	})
+	if err != nil {
+		t.Fatalf("cbor marshal attestation: %v", err)
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(credential.id)
+	response, err := json.Marshal(map[string]interface{}{
+		"id":                      id,
+		"rawId":                   id,
+		"type":                    "public-key",
+		"authenticatorAttachment": "platform",
+		"response": map[string]interface{}{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(a.clientData(t, "webauthn.create", creation.Response.Challenge)),
+			"attestationObject": base64.RawURLEncoding.EncodeToString(attestation),
+			"transports":        []string{"internal"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("json marshal error: %v", err)
+	}
+	return string(response), credential
+}
+
+// get 按登录选项使用凭证签名，选项限定了凭证时必须在其中
+func (a *softAuthenticator) get(t *testing.T, options string, credential *softCredential) string {
+	t.Helper()
+	assertion := &protocol.CredentialAssertion{}
+	err := json.Unmarshal([]byte(options), assertion)
+	if err != nil {
+		t.Fatalf("parse assertion options: %v, options(%s)", err, options)
+	}
+	if allowed := assertion.Response.AllowedCredentials; len(allowed) > 0 {
+		found := false
+		for _, descriptor := range allowed {
+			found = found || bytes.Equal(descriptor.CredentialID, credential.id)
+		}
+		if !found {
+			t.Fatalf("credential not allowed: %s", options)
+		}
+	}
+
+	credential.signCount++
+	authData := authenticatorData(authenticatorUserPresent|authenticatorUserVerified|authenticatorBackupEligible|authenticatorBackupState, credential.signCount)
+	clientData := a.clientData(t, "webauthn.get", assertion.Response.Challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, credential.key, digest[:])
+	if err != nil {
+		t.Fatalf("sign assertion: %v", err)
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(credential.id)
+	response, err := json.Marshal(map[string]interface{}{
+		"id":    id,
+		"rawId": id,
+		"type":  "public-key",
+		"response": map[string]interface{}{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+			"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+			"signature":         base64.RawURLEncoding.EncodeToString(signature),
+			"userHandle":        base64.RawURLEncoding.EncodeToString(credential.userHandle),
+		},
+	})
+	if err != nil {
+		t.Fatalf("json marshal error: %v", err)
+	}
+	return string(response)
+}
+
+type passkeyChallenge struct {
+	ChallengeId string `json:"challengeId"`
+	Options     string `json:"options"`
+}
+
+type passkeyReply struct {
+	Id             int64  `json:"id,string"`
+	Name           string `json:"name"`
+	CredentialId   string `json:"credentialId"`
+	Aaguid         string `json:"aaguid"`
+	BackupEligible bool   `json:"backupEligible"`
+	BackupState    bool   `json:"backupState"`
+	SignCount      int64  `json:"signCount,string"`
+}
+
+func newPasskeyServer(t *testing.T, setup func(c *conf.WebAuthn)) *testServer {
+	return newTestServer(t, func(c *conf.Config) {
+		c.Webauthn = &conf.WebAuthn{RpId: testRpId, RpDisplayName: "User Center", RpOrigins: []string{testOrigin}}
+		if setup != nil {
+			setup(c.Webauthn)
+		}
+	})
+}
+
+// registerPasskey 已登录的用户注册通行密钥
+func registerPasskey(t *testing.T, s *testServer, authenticator *softAuthenticator, userId int32) (*passkeyReply, *softCredential) {
+	t.Helper()
+	client := s.newClient(t)
+	challenge := &passkeyChallenge{}
+	status, body := s.call(t, client, http.MethodPost, "/api/user/passkey/register/begin", userHeader(userId), map[string]string{}, challenge)
+	if status != http.StatusOK {
+		t.Fatalf("begin registration: status(%v), body(%s)", status, body)
+	}
+	credential, created := authenticator.create(t, challenge.Options)
+	var reply struct {
+		Data *passkeyReply `json:"data"`
+	}
+	status, body = s.call(t, client, http.MethodPost, "/api/user/passkey/register/finish", userHeader(userId),
+		map[string]string{"challengeId": challenge.ChallengeId, "name": "laptop", "credential": credential}, &reply)
+	if status != http.StatusOK {
+		t.Fatalf("finish registration: status(%v), body(%s)", status, body)
+	}
+	return reply.Data, created
+}
+
+// beginPasskeyLogin 开始通行密钥登录，userAccount为空时使用可发现凭证
+func beginPasskeyLogin(t *testing.T, s *testServer, client *http.Client, userAccount string) *passkeyChallenge {
+	t.Helper()
+	challenge := &passkeyChallenge{}
+	status, body := s.call(t, client, http.MethodPost, "/api/user/passkey/login/begin", nil, map[string]string{"userAccount": userAccount}, challenge)
+	if status != http.StatusOK {
+		t.Fatalf("begin login: status(%v), body(%s)", status, body)
+	}
+	return challenge
+}
+
+func finishPasskeyLogin(t *testing.T, s *testServer, client *http.Client, challengeId, credential string) (int, []byte, *externalLoginReply) {
+	t.Helper()
+	reply := &externalLoginReply{}
+	status, body := s.call(t, client, http.MethodPost, "/api/user/passkey/login/finish", nil,
+		map[string]string{"challengeId": challengeId, "credential": credential}, reply)
+	return status, body, reply
+}
+
+func listPasskeys(t *testing.T, s *testServer, userId int32) []*passkeyReply {
+	t.Helper()
+	var reply struct {
+		Data []*passkeyReply `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodGet, "/api/user/passkey/list", userHeader(userId), nil, &reply)
+	if status != http.StatusOK {
+		t.Fatalf("list passkeys: status(%v), body(%s)", status, body)
+	}
+	return reply.Data
+}
+
+func TestPasskeyRegistrationAndLogin(t *testing.T) {
+	s := newPasskeyServer(t, nil)
+	authenticator := &softAuthenticator{origin: testOrigin}
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice"})
+	s.login(t, aliceId)
+
+	passkey, credential := registerPasskey(t, s, authenticator, aliceId)
+	if passkey.Name != "laptop" || passkey.CredentialId != base64.RawURLEncoding.EncodeToString(credential.id) ||
+		passkey.Aaguid != "01234567-89ab-cdef-0123-456789abcdef" || !passkey.BackupEligible || !passkey.BackupState || passkey.SignCount != 1 {
+		t.Fatalf("registered passkey: %+v", passkey)
+	}
+
+	// 已注册的凭证不能重复注册
+	challenge := &passkeyChallenge{}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/passkey/register/begin", userHeader(aliceId), map[string]string{}, challenge)
+	creation := &protocol.CredentialCreation{}
+	if status != http.StatusOK || json.Unmarshal([]byte(challenge.Options), creation) != nil ||
+		len(creation.Response.CredentialExcludeList) != 1 || !bytes.Equal(creation.Response.CredentialExcludeList[0].CredentialID, credential.id) {
+		t.Fatalf("registration options should exclude registered credential: status(%v), body(%s)", status, body)
+	}
+
+	// 可发现凭证登录，由认证器返回的用户句柄确定用户
+	client := s.newClient(t)
+	challenge = beginPasskeyLogin(t, s, client, "")
+	status, body, login := finishPasskeyLogin(t, s, client, challenge.ChallengeId, authenticator.get(t, challenge.Options, credential))
+	if status != http.StatusOK || login.Data.Id != aliceId {
+		t.Fatalf("discoverable login: status(%v), body(%s)", status, body)
+	}
+
+	// 指定账号时只允许该账号的凭证，作为第一因素必须验证用户
+	challenge = beginPasskeyLogin(t, s, client, "alice")
+	assertion := &protocol.CredentialAssertion{}
+	_ = json.Unmarshal([]byte(challenge.Options), assertion)
+	if assertion.Response.UserVerification != protocol.VerificationRequired {
+		t.Fatalf("first factor login should require user verification: %s", challenge.Options)
+	}
+	status, body, login = finishPasskeyLogin(t, s, client, challenge.ChallengeId, authenticator.get(t, challenge.Options, credential))
+	if status != http.StatusOK || login.Data.Id != aliceId {
+		t.Fatalf("login with account: status(%v), body(%s)", status, body)
+	}
+
+	passkeys := listPasskeys(t, s, aliceId)
+	if len(passkeys) != 1 || passkeys[0].SignCount != 3 {
+		t.Fatalf("sign count not updated: %+v", passkeys)
+	}
+}
+
+func TestPasskeyLoginRejectsInvalidAssertion(t *testing.T) {
+	s := newPasskeyServer(t, nil)
+	authenticator := &softAuthenticator{origin: testOrigin}
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice"})
+	s.login(t, aliceId)
+	_, credential := registerPasskey(t, s, authenticator, aliceId)
+	client := s.newClient(t)
+
+	expectFailed := func(t *testing.T, status int, body []byte) {
+		t.Helper()
+		if status == http.StatusOK || errorReason(body) != "PASSKEY_FAILED" {
+			t.Fatalf("invalid assertion accepted: status(%v), body(%s)", status, body)
+		}
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		challenge := beginPasskeyLogin(t, s, client, "")
+		response := authenticator.get(t, challenge.Options, credential)
+		status, body, _ := finishPasskeyLogin(t, s, client, challenge.ChallengeId, response)
+		if status != http.StatusOK {
+			t.Fatalf("login: status(%v), body(%s)", status, body)
+		}
+		status, body, _ = finishPasskeyLogin(t, s, client, challenge.ChallengeId, response)
+		expectFailed(t, status, body)
+	})
+
+	t.Run("challenge", func(t *testing.T) {
+		// 对另一个挑战的签名
+		first, second := beginPasskeyLogin(t, s, client, ""), beginPasskeyLogin(t, s, client, "")
+		status, body, _ := finishPasskeyLogin(t, s, client, second.ChallengeId, authenticator.get(t, first.Options, credential))
+		expectFailed(t, status, body)
+	})
+
+	t.Run("origin", func(t *testing.T) {
+		phishing := &softAuthenticator{origin: "https://example.com.evil.example"}
+		challenge := beginPasskeyLogin(t, s, client, "")
+		status, body, _ := finishPasskeyLogin(t, s, client, challenge.ChallengeId, phishing.get(t, challenge.Options, credential))
+		expectFailed(t, status, body)
+	})
+
+	t.Run("signature", func(t *testing.T) {
+		forged := *credential
+		forged.key, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		challenge := beginPasskeyLogin(t, s, client, "")
+		status, body, _ := finishPasskeyLogin(t, s, client, challenge.ChallengeId, authenticator.get(t, challenge.Options, &forged))
+		expectFailed(t, status, body)
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		// 复制的认证器签名计数落后于服务端记录
+		cloned := *credential
+		cloned.signCount = 0
+		challenge := beginPasskeyLogin(t, s, client, "")
+		status, body, _ := finishPasskeyLogin(t, s, client, challenge.ChallengeId, authenticator.get(t, challenge.Options, &cloned))
+		expectFailed(t, status, body)
+	})
+}
+
+func TestPasskeyRegistrationChallengeBoundToUser(t *testing.T) {
+	s := newPasskeyServer(t, nil)
+	authenticator := &softAuthenticator{origin: testOrigin}
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice"})
+	bobId := s.createUser(t, &biz.User{UserAccount: "bob"})
+	s.login(t, aliceId)
+	s.login(t, bobId)
+
+	challenge := &passkeyChallenge{}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/passkey/register/begin", userHeader(aliceId), map[string]string{}, challenge)
+	if status != http.StatusOK {
+		t.Fatalf("begin registration: status(%v), body(%s)", status, body)
+	}
+	credential, _ := authenticator.create(t, challenge.Options)
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/passkey/register/finish", userHeader(bobId),
+		map[string]string{"challengeId": challenge.ChallengeId, "name": "stolen", "credential": credential}, nil)
+	if status == http.StatusOK || errorReason(body) != "PASSKEY_FAILED" {
+		t.Fatalf("registration finished by another user: status(%v), body(%s)", status, body)
+	}
+	if passkeys := listPasskeys(t, s, bobId); len(passkeys) != 0 {
+		t.Fatalf("passkey registered for another user: %+v", passkeys)
+	}
+}
+
+func TestPasskeySecondFactor(t *testing.T) {
+	s := newPasskeyServer(t, func(c *conf.WebAuthn) {
+		c.SecondFactor = true
+	})
+	authenticator := &softAuthenticator{origin: testOrigin}
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice", UserPassword: biz.PasswordHash("alice-password")})
+	s.login(t, aliceId)
+	_, credential := registerPasskey(t, s, authenticator, aliceId)
+
+	client := s.newClient(t)
+	status, body := s.call(t, client, http.MethodPost, "/api/user/login", nil, map[string]string{"userAccount": "alice", "userPassword": "alice-password"}, nil)
+	if status == http.StatusOK || errorReason(body) != "PASSKEY_REQUIRED" {
+		t.Fatalf("password login should require passkey: status(%v), body(%s)", status, body)
+	}
+
+	// 账号密码已验证，通行密钥作为第二因素不要求验证用户
+	challenge := beginPasskeyLogin(t, s, client, "alice")
+	assertion := &protocol.CredentialAssertion{}
+	_ = json.Unmarshal([]byte(challenge.Options), assertion)
+	if assertion.Response.UserVerification != protocol.VerificationDiscouraged {
+		t.Fatalf("second factor should not require user verification: %s", challenge.Options)
+	}
+	status, body, login := finishPasskeyLogin(t, s, client, challenge.ChallengeId, authenticator.get(t, challenge.Options, credential))
+	if status != http.StatusOK || login.Data.Id != aliceId {
+		t.Fatalf("second factor login: status(%v), body(%s)", status, body)
+	}
+}
@@ -0,0 +1,163 @@
+package server_test
+
+import (
+	"encoding/json"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBreakerOpen = 200 * time.Millisecond
+
+// newRedisOutageServer 熔断参数调小，连续失败2次后熔断，便于在测试中触发和恢复
+func newRedisOutageServer(t *testing.T, sessionFallback bool) *testServer {
+	return newTestServer(t, func(c *conf.Config) {
+		c.Data.Redis.BreakerFailures = 2
+		c.Data.Redis.BreakerOpen = durationpb.New(testBreakerOpen)
+		c.Data.Redis.SessionFallback = sessionFallback
+	})
+}
+
+// redisDown 关闭Redis，已建立的连接和新连接都会失败
+func (s *testServer) redisDown() {
+	s.redis.Close()
+}
+
+// redisUp 在原地址重启Redis（数据保留），等待熔断到期后放行探测请求
+func (s *testServer) redisUp(t *testing.T) {
+	t.Helper()
+	err := s.redis.Restart()
+	if err != nil {
+		t.Fatalf("restart redis: %v", err)
+	}
+	time.Sleep(testBreakerOpen)
+}
+
+func (s *testServer) passwordLogin(t *testing.T, client *http.Client, userAccount, userPassword string) (int, []byte) {
+	t.Helper()
+	return s.call(t, client, http.MethodPost, "/api/user/login", nil,
+		map[string]string{"userAccount": userAccount, "userPassword": userPassword}, nil)
+}
+
+func (s *testServer) health(t *testing.T) (int, *biz.Health) {
+	t.Helper()
+	health := &biz.Health{}
+	status, body := s.call(t, s.newClient(t), http.MethodGet, "/health", nil, nil, nil)
+	if status == http.StatusOK || status == http.StatusServiceUnavailable {
+		err := json.Unmarshal(body, health)
+		if err != nil {
+			t.Fatalf("json unmarshal error: %v, body(%s)", err, body)
+		}
+	}
+	return status, health
+}
+
+func componentHealth(health *biz.Health, name string) *biz.ComponentHealth {
+	for _, component := range health.Components {
+		if component.Name == name {
+			return component
+		}
+	}
+	return &biz.ComponentHealth{}
+}
+
+func TestRedisOutageLoginFailsWithoutFallback(t *testing.T) {
+	s := newRedisOutageServer(t, false)
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice", UserPassword: biz.PasswordHash("alice-password")})
+	s.redisDown()
+
+	// 登录态无法保存时登录明确失败，不能返回成功
+	status, body := s.passwordLogin(t, s.newClient(t), "alice", "alice-password")
+	if status != http.StatusServiceUnavailable || errorReason(body) != "SESSION_UNAVAILABLE" {
+		t.Fatalf("login should fail during redis outage: status(%v), body(%s)", status, body)
+	}
+	// 无法确认登录态时不能当作未登录，也不能放行
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", userHeader(aliceId), nil, nil)
+	if status != http.StatusServiceUnavailable || errorReason(body) != "SESSION_UNAVAILABLE" {
+		t.Fatalf("current user during redis outage: status(%v), body(%s)", status, body)
+	}
+
+	// 连续失败后熔断，健康检查报告服务不可用和熔断状态
+	status, health := s.health(t)
+	redis := componentHealth(health, "redis")
+	if status != http.StatusServiceUnavailable || health.Status != biz.HealthUnavailable ||
+		redis.Status != biz.HealthUnavailable || !strings.Contains(redis.Detail, "circuit breaker open") {
+		t.Fatalf("health during redis outage: status(%v), health(%+v), redis(%+v)", status, health, redis)
+	}
+
+	// Redis恢复、熔断到期后自动恢复
+	s.redisUp(t)
+	status, body = s.passwordLogin(t, s.newClient(t), "alice", "alice-password")
+	if status != http.StatusOK {
+		t.Fatalf("login after redis recovered: status(%v), body(%s)", status, body)
+	}
+	status, health = s.health(t)
+	if status != http.StatusOK || health.Status != biz.HealthOk {
+		t.Fatalf("health after redis recovered: status(%v), health(%+v)", status, health)
+	}
+}
+
+func TestRedisOutageSessionFallback(t *testing.T) {
+	s := newRedisOutageServer(t, true)
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice", UserPassword: biz.PasswordHash("alice-password")})
+	s.redisDown()
+
+	// 登录态保存到数据库，登录和鉴权都不受影响
+	status, body := s.passwordLogin(t, s.newClient(t), "alice", "alice-password")
+	if status != http.StatusOK {
+		t.Fatalf("login with session fallback: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", userHeader(aliceId), nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("current user with session fallback: status(%v), body(%s)", status, body)
+	}
+
+	// 降级运行
+	status, health := s.health(t)
+	if status != http.StatusOK || health.Status != biz.HealthDegraded || componentHealth(health, "redis").Status != biz.HealthDegraded {
+		t.Fatalf("health with session fallback: status(%v), health(%+v)", status, health)
+	}
+
+	// 兜底期间写入的登录态在Redis恢复后仍然有效，退出登录后失效
+	s.redisUp(t)
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", userHeader(aliceId), nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("fallback session after redis recovered: status(%v), body(%s)", status, body)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/logout", userHeader(aliceId), map[string]string{}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("logout: status(%v), body(%s)", status, body)
+	}
+	var current struct {
+		Data struct {
+			Id    int32 `json:"id"`
+			Empty bool  `json:"empty"`
+		} `json:"data"`
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", userHeader(aliceId), nil, &current)
+	if status != http.StatusOK || !current.Data.Empty || current.Data.Id != 0 {
+		t.Fatalf("session still valid after logout: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestRedisOutageLogoutFails(t *testing.T) {
+	s := newRedisOutageServer(t, false)
+	aliceId := s.createUser(t, &biz.User{UserAccount: "alice"})
+	s.login(t, aliceId)
+	s.redisDown()
+
+	// 无法删除登录态时退出登录明确失败，避免客户端以为已经退出
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/logout", userHeader(aliceId), map[string]string{}, nil)
+	if status != http.StatusServiceUnavailable || errorReason(body) != "SESSION_UNAVAILABLE" {
+		t.Fatalf("logout during redis outage: status(%v), body(%s)", status, body)
+	}
+	s.redisUp(t)
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", userHeader(aliceId), nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("session should survive failed logout: status(%v), body(%s)", status, body)
+	}
+}
@@ -0,0 +1,40 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestPasswordLength 注册和登录的密码长度限制一致，注册时设置的长密码可以登录
+func TestPasswordLength(t *testing.T) {
+	s := newTestServer(t)
+	register := func(account, password string) (int, []byte) {
+		return s.call(t, s.newClient(t), http.MethodPost, "/api/user/register", nil,
+			map[string]string{"userAccount": account, "userPassword": password, "checkPassword": password}, nil)
+	}
+	login := func(account, password string) (int, []byte) {
+		return s.call(t, s.newClient(t), http.MethodPost, "/api/user/login", nil,
+			map[string]string{"userAccount": account, "userPassword": password}, nil)
+	}
+
+	password := "correct horse battery staple"
+	status, body := register("quinn", password)
+	if status != http.StatusOK {
+		t.Fatalf("register with long password: status(%v), body(%s)", status, body)
+	}
+	status, body = login("quinn", password)
+	if status != http.StatusOK {
+		t.Fatalf("login with long password: status(%v), body(%s)", status, body)
+	}
+
+	tooLong := strings.Repeat("p", 129)
+	status, body = register("rosa", tooLong)
+	if status == http.StatusOK || errorReason(body) != "VALIDATE_ERROR" {
+		t.Fatalf("register with too long password: status(%v), body(%s)", status, body)
+	}
+	status, body = login("quinn", tooLong)
+	if status == http.StatusOK || errorReason(body) != "VALIDATE_ERROR" {
+		t.Fatalf("login with too long password: status(%v), body(%s)", status, body)
+	}
+}
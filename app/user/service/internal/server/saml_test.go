@@ -0,0 +1,357 @@
+package server_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/crewjam/saml"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testSAMLRootUrl  = "https://sp.example"
+	testIdPEntityId  = "https://idp.example/metadata"
+	testSAMLProvider = "corp"
+)
+
+// testIdP 使用本地密钥签名断言的SAML IdP，按SP发起的AuthnRequest或IdP发起登录生成HTTP-POST绑定的响应
+type testIdP struct {
+	idp *saml.IdentityProvider
+	sp  *saml.EntityDescriptor
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("fail to generate idp key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "test idp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("fail to create idp certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("fail to parse idp certificate: %v", err)
+	}
+	metadataUrl, _ := url.Parse(testIdPEntityId)
+	ssoUrl, _ := url.Parse("https://idp.example/sso")
+	p := &testIdP{}
+	p.idp = &saml.IdentityProvider{
+		Key:                     key,
+		Certificate:             certificate,
+		MetadataURL:             *metadataUrl,
+		SSOURL:                  *ssoUrl,
+		ServiceProviderProvider: p,
+	}
+	return p
+}
+
+func (p *testIdP) GetServiceProvider(_ *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	if p.sp == nil || p.sp.EntityID != serviceProviderID {
+		return nil, os.ErrNotExist
+	}
+	return p.sp, nil
+}
+
+// withoutEncryption 使用相同密钥但不加密断言的IdP
+func (p *testIdP) withoutEncryption() *testIdP {
+	plain := &testIdP{}
+	idp := *p.idp
+	idp.ServiceProviderProvider = plain
+	plain.idp = &idp
+	sp := *p.sp
+	sp.SPSSODescriptors = nil
+	for _, descriptor := range p.sp.SPSSODescriptors {
+		descriptor.KeyDescriptors = nil
+		sp.SPSSODescriptors = append(sp.SPSSODescriptors, descriptor)
+	}
+	plain.sp = &sp
+	return plain
+}
+
+// metadataFile 把IdP元数据写入临时文件
+func (p *testIdP) metadataFile(t *testing.T) string {
+	metadata, err := xml.Marshal(p.idp.Metadata())
+	if err != nil {
+		t.Fatalf("fail to marshal idp metadata: %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "idp.xml")
+	err = os.WriteFile(file, metadata, 0600)
+	if err != nil {
+		t.Fatalf("fail to write idp metadata: %v", err)
+	}
+	return file
+}
+
+// loadSP 从测试服务获取SP元数据，IdP只接受已登记的SP
+func (p *testIdP) loadSP(t *testing.T, s *testServer) {
+	resp, err := s.Client().Get(s.URL + "/saml/metadata?provider=" + testSAMLProvider)
+	if err != nil {
+		t.Fatalf("get sp metadata: %v", err)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("get sp metadata: status(%v), error(%v)", resp.StatusCode, err)
+	}
+	p.sp = &saml.EntityDescriptor{}
+	err = xml.Unmarshal(content, p.sp)
+	if err != nil {
+		t.Fatalf("parse sp metadata: %v", err)
+	}
+}
+
+// respond 用户在IdP登录后按AuthnRequest签发断言，tamper在签名前修改断言，返回提交到ACS的表单
+func (p *testIdP) respond(t *testing.T, loginUrl string, session *saml.Session, tamper func(assertion *saml.Assertion)) url.Values {
+	t.Helper()
+	if !strings.HasPrefix(loginUrl, p.idp.SSOURL.String()+"?") {
+		t.Fatalf("login url: %s", loginUrl)
+	}
+	r, err := http.NewRequest(http.MethodGet, loginUrl, nil)
+	if err != nil {
+		t.Fatalf("new sso request: %v", err)
+	}
+	req, err := saml.NewIdpAuthnRequest(p.idp, r)
+	if err != nil {
+		t.Fatalf("parse authn request: %v", err)
+	}
+	err = req.Validate()
+	if err != nil {
+		t.Fatalf("validate authn request: %v", err)
+	}
+	return p.post(t, req, session, tamper)
+}
+
+// respondUnsolicited IdP发起的登录，断言不对应任何AuthnRequest
+func (p *testIdP) respondUnsolicited(t *testing.T, session *saml.Session) url.Values {
+	t.Helper()
+	req := &saml.IdpAuthnRequest{IDP: p.idp, HTTPRequest: httptest.NewRequest(http.MethodGet, p.idp.SSOURL.String(), nil),
+		Now: saml.TimeNow(), ServiceProviderMetadata: p.sp}
+	req.SPSSODescriptor = &p.sp.SPSSODescriptors[0]
+	for i, endpoint := range req.SPSSODescriptor.AssertionConsumerServices {
+		if endpoint.Binding == saml.HTTPPostBinding {
+			req.ACSEndpoint = &req.SPSSODescriptor.AssertionConsumerServices[i]
+		}
+	}
+	return p.post(t, req, session, nil)
+}
+
+func (p *testIdP) post(t *testing.T, req *saml.IdpAuthnRequest, session *saml.Session, tamper func(assertion *saml.Assertion)) url.Values {
+	t.Helper()
+	err := saml.DefaultAssertionMaker{}.MakeAssertion(req, session)
+	if err != nil {
+		t.Fatalf("make assertion: %v", err)
+	}
+	if tamper != nil {
+		tamper(req.Assertion)
+	}
+	form, err := req.PostBinding()
+	if err != nil {
+		t.Fatalf("make response: %v", err)
+	}
+	if form.URL != testSAMLRootUrl+"/api/user/saml/"+testSAMLProvider+"/acs" {
+		t.Fatalf("acs url: %s", form.URL)
+	}
+	return url.Values{"SAMLResponse": {form.SAMLResponse}, "RelayState": {form.RelayState}}
+}
+
+func newSAMLServer(t *testing.T, setup func(c *conf.SAMLProvider)) (*testServer, *testIdP) {
+	idp := newTestIdP(t)
+	provider := &conf.SAMLProvider{
+		Name:            testSAMLProvider,
+		IdpMetadataFile: idp.metadataFile(t),
+		Attributes:      map[string]string{biz.SAMLAttributeEmail: "eduPersonPrincipalName", biz.SAMLAttributeUserName: "cn"},
+		TrustEmail:      true,
+	}
+	if setup != nil {
+		setup(provider)
+	}
+	s := newTestServer(t, func(c *conf.Config) {
+		c.Saml = &conf.SAML{RootUrl: testSAMLRootUrl, Providers: []*conf.SAMLProvider{provider}}
+	})
+	idp.loadSP(t, s)
+	return s, idp
+}
+
+// samlLogin SP发起登录，返回IdP登录地址
+func samlLogin(t *testing.T, s *testServer) string {
+	t.Helper()
+	var reply struct {
+		AuthorizeUrl string `json:"authorizeUrl"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/saml/login", nil, map[string]string{"provider": testSAMLProvider}, &reply)
+	if status != http.StatusOK {
+		t.Fatalf("saml login: status(%v), body(%s)", status, body)
+	}
+	return reply.AuthorizeUrl
+}
+
+// acs 浏览器把IdP的响应表单提交到ACS
+func acs(t *testing.T, s *testServer, form url.Values, reply interface{}) (int, []byte) {
+	t.Helper()
+	resp, err := s.Client().PostForm(s.URL+"/api/user/saml/"+testSAMLProvider+"/acs", form)
+	if err != nil {
+		t.Fatalf("post acs: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read acs response: %v", err)
+	}
+	if reply != nil && resp.StatusCode == http.StatusOK {
+		err = json.Unmarshal(body, reply)
+		if err != nil {
+			t.Fatalf("decode acs response: %v, body(%s)", err, body)
+		}
+	}
+	return resp.StatusCode, body
+}
+
+func testSAMLSession(nameId, email string) *saml.Session {
+	return &saml.Session{
+		ID:             nameId,
+		CreateTime:     time.Now(),
+		ExpireTime:     time.Now().Add(time.Hour),
+		Index:          nameId,
+		NameID:         nameId,
+		UserEmail:      email,
+		UserCommonName: strings.ToUpper(nameId),
+	}
+}
+
+func TestSAMLLogin(t *testing.T) {
+	s, idp := newSAMLServer(t, nil)
+	bobId := s.createUser(t, &biz.User{UserAccount: "bob", Email: "bob@example.com"})
+
+	// 首次登录开通用户，属性按配置映射
+	reply := &externalLoginReply{}
+	status, body := acs(t, s, idp.respond(t, samlLogin(t, s), testSAMLSession("alice", "alice@example.com"), nil), reply)
+	if status != http.StatusOK || !strings.HasPrefix(reply.Data.UserAccount, "saml_corp_") || reply.Data.Email != "alice@example.com" {
+		t.Fatalf("saml login: status(%v), body(%s)", status, body)
+	}
+	aliceId := reply.Data.Id
+	status, body = acs(t, s, idp.respond(t, samlLogin(t, s), testSAMLSession("alice", "alice@example.com"), nil), reply)
+	if status != http.StatusOK || reply.Data.Id != aliceId {
+		t.Fatalf("login with linked identity: status(%v), body(%s)", status, body)
+	}
+
+	// trust_email时按邮箱绑定已有用户
+	status, body = acs(t, s, idp.respond(t, samlLogin(t, s), testSAMLSession("bob", "bob@example.com"), nil), reply)
+	if status != http.StatusOK || reply.Data.Id != bobId {
+		t.Fatalf("link by trusted email: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestSAMLRejectsInvalidAssertion(t *testing.T) {
+	s, idp := newSAMLServer(t, nil)
+	rogue := newTestIdP(t)
+	rogue.sp = idp.sp
+
+	tests := []struct {
+		name    string
+		respond func(t *testing.T, loginUrl string) url.Values
+	}{
+		{name: "signature", respond: func(t *testing.T, loginUrl string) url.Values {
+			// 同一实体id但使用其他密钥签名
+			return rogue.respond(t, loginUrl, testSAMLSession("mallory", "mallory@example.com"), nil)
+		}},
+		{name: "modified", respond: func(t *testing.T, loginUrl string) url.Values {
+			// 签名后修改明文断言中的身份
+			form := idp.withoutEncryption().respond(t, loginUrl, testSAMLSession("mallory", "mallory@example.com"), nil)
+			decoded, _ := base64.StdEncoding.DecodeString(form.Get("SAMLResponse"))
+			if !strings.Contains(string(decoded), "mallory") {
+				t.Fatalf("assertion not in plain text")
+			}
+			modified := strings.ReplaceAll(string(decoded), "mallory", "admin")
+			form.Set("SAMLResponse", base64.StdEncoding.EncodeToString([]byte(modified)))
+			return form
+		}},
+		{name: "audience", respond: func(t *testing.T, loginUrl string) url.Values {
+			return idp.respond(t, loginUrl, testSAMLSession("mallory", "mallory@example.com"), func(assertion *saml.Assertion) {
+				assertion.Conditions.AudienceRestrictions[0].Audience.Value = "https://other.example/saml/metadata"
+			})
+		}},
+		{name: "expired", respond: func(t *testing.T, loginUrl string) url.Values {
+			return idp.respond(t, loginUrl, testSAMLSession("mallory", "mallory@example.com"), func(assertion *saml.Assertion) {
+				expired := time.Now().Add(-time.Hour)
+				assertion.Conditions.NotOnOrAfter = expired
+				assertion.Subject.SubjectConfirmations[0].SubjectConfirmationData.NotOnOrAfter = expired
+			})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := acs(t, s, tt.respond(t, samlLogin(t, s)), nil)
+			if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+				t.Fatalf("invalid assertion accepted: status(%v), body(%s)", status, body)
+			}
+		})
+	}
+}
+
+func TestSAMLReplayAndRequestBinding(t *testing.T) {
+	s, idp := newSAMLServer(t, func(c *conf.SAMLProvider) {
+		c.AllowIdpInitiated = true
+	})
+
+	form := idp.respond(t, samlLogin(t, s), testSAMLSession("carol", "carol@example.com"), nil)
+	status, body := acs(t, s, form, nil)
+	if status != http.StatusOK {
+		t.Fatalf("saml login: status(%v), body(%s)", status, body)
+	}
+	status, body = acs(t, s, form, nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("replayed response accepted: status(%v), body(%s)", status, body)
+	}
+	// 去掉RelayState按IdP发起登录重放，断言id已使用
+	form.Del("RelayState")
+	status, body = acs(t, s, form, nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("replayed assertion accepted: status(%v), body(%s)", status, body)
+	}
+
+	// 断言必须回应RelayState对应的AuthnRequest
+	first, second := samlLogin(t, s), samlLogin(t, s)
+	form = idp.respond(t, first, testSAMLSession("carol", "carol@example.com"), nil)
+	secondUrl, _ := url.Parse(second)
+	form.Set("RelayState", secondUrl.Query().Get("RelayState"))
+	status, body = acs(t, s, form, nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("response to another request accepted: status(%v), body(%s)", status, body)
+	}
+
+	status, body = acs(t, s, idp.respondUnsolicited(t, testSAMLSession("carol", "carol@example.com")), nil)
+	if status != http.StatusOK {
+		t.Fatalf("idp initiated login: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestSAMLUnsolicitedResponseDenied(t *testing.T) {
+	s, idp := newSAMLServer(t, nil)
+	status, body := acs(t, s, idp.respondUnsolicited(t, testSAMLSession("dave", "dave@example.com")), nil)
+	if status == http.StatusOK || errorReason(body) != "EXTERNAL_LOGIN_FAILED" {
+		t.Fatalf("unsolicited response accepted: status(%v), body(%s)", status, body)
+	}
+}
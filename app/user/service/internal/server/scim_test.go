@@ -0,0 +1,333 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+const testScimToken = "scim-test-token"
+
+type scimUserReply struct {
+	Id          string `json:"id"`
+	UserName    string `json:"userName"`
+	DisplayName string `json:"displayName"`
+	Active      bool   `json:"active"`
+	Emails      []struct {
+		Value string `json:"value"`
+	} `json:"emails"`
+	PhoneNumbers []struct {
+		Value string `json:"value"`
+	} `json:"phoneNumbers"`
+}
+
+type scimListReply struct {
+	TotalResults int64            `json:"totalResults"`
+	StartIndex   int32            `json:"startIndex"`
+	ItemsPerPage int              `json:"itemsPerPage"`
+	Resources    []*scimUserReply `json:"Resources"`
+}
+
+func newScimServer(t *testing.T) *testServer {
+	return newTestServer(t, func(c *conf.Config) {
+		c.Constant.ScimTokens = []string{testScimToken}
+	})
+}
+
+// scimCall 携带SCIM凭证请求接口
+func (s *testServer) scimCall(t *testing.T, method, path string, body, reply interface{}) (int, []byte) {
+	t.Helper()
+	return s.call(t, s.newClient(t), method, path, http.Header{"Authorization": {"Bearer " + testScimToken}}, body, reply)
+}
+
+// scimCreateUser 通过SCIM开通用户，返回用户资源
+func (s *testServer) scimCreateUser(t *testing.T, userName, email string) *scimUserReply {
+	t.Helper()
+	status, body := s.scimCall(t, http.MethodPost, "/scim/v2/Users", map[string]interface{}{
+		"schemas":  []string{biz.ScimSchemaUser},
+		"userName": userName,
+		"emails":   []map[string]interface{}{{"value": email, "primary": true}},
+	}, nil)
+	reply := &scimUserReply{}
+	if status != http.StatusCreated || json.Unmarshal(body, reply) != nil || reply.Id == "" {
+		t.Fatalf("create scim user: status(%v), body(%s)", status, body)
+	}
+	return reply
+}
+
+// scimList 按过滤条件和分页参数查询用户
+func (s *testServer) scimList(t *testing.T, query url.Values) (int, []byte, *scimListReply) {
+	t.Helper()
+	reply := &scimListReply{}
+	status, body := s.scimCall(t, http.MethodGet, "/scim/v2/Users?"+query.Encode(), nil, reply)
+	return status, body, reply
+}
+
+func scimType(body []byte) string {
+	var reply struct {
+		ScimType string `json:"scimType"`
+	}
+	_ = json.Unmarshal(body, &reply)
+	return reply.ScimType
+}
+
+func TestScimBearerAuth(t *testing.T) {
+	s := newScimServer(t)
+	for _, authorization := range []string{"", "Bearer", "Bearer wrong-token", "Basic " + testScimToken, testScimToken} {
+		header := http.Header{}
+		if authorization != "" {
+			header.Set("Authorization", authorization)
+		}
+		status, body := s.call(t, s.newClient(t), http.MethodGet, "/scim/v2/Users", header, nil, nil)
+		if status != http.StatusUnauthorized {
+			t.Fatalf("authorization(%s): status(%v), body(%s)", authorization, status, body)
+		}
+	}
+	status, body := s.scimCall(t, http.MethodGet, "/scim/v2/Users", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("valid token: status(%v), body(%s)", status, body)
+	}
+
+	// 未配置凭证时关闭SCIM接口
+	closed := newTestServer(t)
+	status, body = closed.scimCall(t, http.MethodGet, "/scim/v2/Users", nil, nil)
+	if status != http.StatusUnauthorized {
+		t.Fatalf("scim without configured tokens: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestScimUserFilter(t *testing.T) {
+	s := newScimServer(t)
+	alice := s.scimCreateUser(t, "alice", "alice@example.com")
+	s.scimCreateUser(t, "albert", "albert@example.org")
+	s.scimCreateUser(t, "bob", "bob@example.com")
+
+	// 初始化迁移创建的管理员设置了昵称
+	filters := map[string][]string{
+		`userName eq "alice"`: {"alice"},
+		`USERNAME EQ "alice"`: {"alice"},
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bob"`: {"bob"},
+		`userName sw "al"`:  {"alice", "albert"},
+		`userName ew "ce"`:  {"alice"},
+		`userName co "b"`:   {"albert", "bob"},
+		`userName ne "bob"`: {"admin", "alice", "albert"},
+		`userName sw "al" and emails co "example.com"`: {"alice"},
+		`emails.value eq "bob@example.com"`:            {"bob"},
+		fmt.Sprintf(`id eq "%s"`, alice.Id):            {"alice"},
+		`displayName pr`:                               {"admin"},
+		`userName eq "al\"ice"`:                        {},
+	}
+	for filter, want := range filters {
+		status, body, reply := s.scimList(t, url.Values{"filter": {filter}})
+		if status != http.StatusOK || len(reply.Resources) != len(want) || reply.TotalResults != int64(len(want)) {
+			t.Fatalf("filter(%s): status(%v), body(%s)", filter, status, body)
+		}
+		for i, user := range reply.Resources {
+			if user.UserName != want[i] {
+				t.Fatalf("filter(%s): got %s, want %v", filter, user.UserName, want)
+			}
+		}
+	}
+
+	invalid := []string{
+		`userName eq`,
+		`userName gt "a"`,
+		`userName eq "alice" or userName eq "bob"`,
+		`(userName eq "alice")`,
+		`emails[type eq "work"] pr`,
+		`userName eq "alice`,
+		`title eq "engineer"`,
+		`active co "true"`,
+	}
+	for _, filter := range invalid {
+		status, body, _ := s.scimList(t, url.Values{"filter": {filter}})
+		if status != http.StatusBadRequest || scimType(body) != biz.ScimTypeInvalidFilter {
+			t.Fatalf("invalid filter(%s): status(%v), body(%s)", filter, status, body)
+		}
+	}
+}
+
+func TestScimUserPagination(t *testing.T) {
+	s := newScimServer(t)
+	// 初始化迁移创建了管理员，按userName过滤只统计测试用户
+	for i := 1; i <= 5; i++ {
+		s.scimCreateUser(t, fmt.Sprintf("page_%d", i), fmt.Sprintf("page_%d@example.com", i))
+	}
+	filter := `userName sw "page_"`
+
+	status, body, reply := s.scimList(t, url.Values{"filter": {filter}, "startIndex": {"2"}, "count": {"2"}})
+	if status != http.StatusOK || reply.TotalResults != 5 || reply.StartIndex != 2 || reply.ItemsPerPage != 2 {
+		t.Fatalf("second page: status(%v), body(%s)", status, body)
+	}
+	if reply.Resources[0].UserName != "page_2" || reply.Resources[1].UserName != "page_3" {
+		t.Fatalf("second page resources: body(%s)", body)
+	}
+
+	// count为0时只返回总数，startIndex小于1时按1处理
+	status, body, reply = s.scimList(t, url.Values{"filter": {filter}, "startIndex": {"0"}, "count": {"0"}})
+	if status != http.StatusOK || reply.TotalResults != 5 || reply.StartIndex != 1 || len(reply.Resources) != 0 {
+		t.Fatalf("count only: status(%v), body(%s)", status, body)
+	}
+	status, body, reply = s.scimList(t, url.Values{"filter": {filter}, "startIndex": {"5"}, "count": {"10"}})
+	if status != http.StatusOK || reply.ItemsPerPage != 1 || reply.Resources[0].UserName != "page_5" {
+		t.Fatalf("last page: status(%v), body(%s)", status, body)
+	}
+	status, body, _ = s.scimList(t, url.Values{"count": {"many"}})
+	if status != http.StatusBadRequest || scimType(body) != biz.ScimTypeInvalidValue {
+		t.Fatalf("invalid count: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestScimPatchUser(t *testing.T) {
+	s := newScimServer(t)
+	carol := s.scimCreateUser(t, "carol", "carol@example.com")
+	path := "/scim/v2/Users/" + carol.Id
+	patch := func(operations ...map[string]interface{}) (int, []byte, *scimUserReply) {
+		t.Helper()
+		reply := &scimUserReply{}
+		status, body := s.scimCall(t, http.MethodPatch, path, map[string]interface{}{
+			"schemas":    []string{biz.ScimSchemaPatchOp},
+			"Operations": operations,
+		}, reply)
+		return status, body, reply
+	}
+
+	status, body, reply := patch(
+		map[string]interface{}{"op": "replace", "path": "displayName", "value": "Carol C"},
+		map[string]interface{}{"op": "Add", "value": map[string]interface{}{"phoneNumbers": []map[string]interface{}{{"value": "+1 555 0100", "primary": true}}}},
+		map[string]interface{}{"op": "replace", "path": `emails[type eq "work"].value`, "value": "carol@example.org"},
+	)
+	if status != http.StatusOK || reply.DisplayName != "Carol C" || len(reply.PhoneNumbers) != 1 || reply.PhoneNumbers[0].Value != "+1 555 0100" {
+		t.Fatalf("patch attributes: status(%v), body(%s)", status, body)
+	}
+	if len(reply.Emails) != 1 || reply.Emails[0].Value != "carol@example.org" {
+		t.Fatalf("patch email: body(%s)", body)
+	}
+
+	status, body, reply = patch(map[string]interface{}{"op": "remove", "path": "phoneNumbers"})
+	if status != http.StatusOK || len(reply.PhoneNumbers) != 0 || reply.DisplayName != "Carol C" {
+		t.Fatalf("remove phone: status(%v), body(%s)", status, body)
+	}
+
+	// 停用用户后清除登录态，不能再登录
+	userId := s.userId(t, carol.Id)
+	s.login(t, userId)
+	status, body, reply = patch(map[string]interface{}{"op": "replace", "value": map[string]interface{}{"active": false}})
+	if status != http.StatusOK || reply.Active {
+		t.Fatalf("deactivate: status(%v), body(%s)", status, body)
+	}
+	_, err := s.users.GetUserSession(context.Background(), userId)
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("session after deactivate: want NotFound, got %v", err)
+	}
+	status, body, reply = patch(map[string]interface{}{"op": "replace", "path": "active", "value": "True"})
+	if status != http.StatusOK || !reply.Active {
+		t.Fatalf("reactivate: status(%v), body(%s)", status, body)
+	}
+
+	invalid := []struct {
+		operation map[string]interface{}
+		scimType  string
+	}{
+		{map[string]interface{}{"op": "move", "path": "displayName", "value": "x"}, biz.ScimTypeInvalidSyntax},
+		{map[string]interface{}{"op": "remove"}, biz.ScimTypeNoTarget},
+		{map[string]interface{}{"op": "remove", "path": "userName"}, biz.ScimTypeMutability},
+		{map[string]interface{}{"op": "replace", "path": "active", "value": "maybe"}, biz.ScimTypeInvalidValue},
+		{map[string]interface{}{"op": "add", "value": "not an object"}, biz.ScimTypeInvalidValue},
+	}
+	for _, c := range invalid {
+		status, body, _ = patch(c.operation)
+		if status != http.StatusBadRequest || scimType(body) != c.scimType {
+			t.Fatalf("patch(%v): status(%v), body(%s)", c.operation, status, body)
+		}
+	}
+
+	// 修改账号与已有账号冲突
+	s.scimCreateUser(t, "dave", "dave@example.com")
+	status, body, _ = patch(map[string]interface{}{"op": "replace", "path": "userName", "value": "dave"})
+	if status != http.StatusConflict || scimType(body) != biz.ScimTypeUniqueness {
+		t.Fatalf("patch to existing userName: status(%v), body(%s)", status, body)
+	}
+}
+
+func TestScimDeleteUser(t *testing.T) {
+	s := newScimServer(t)
+	erin := s.scimCreateUser(t, "erin", "erin@example.com")
+	userId := s.userId(t, erin.Id)
+	s.login(t, userId)
+	// 先读一次，写入用户信息缓存
+	_, err := s.users.GetCurrentUser(context.Background(), userId)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	var group struct {
+		Id string `json:"id"`
+	}
+	status, body := s.scimCall(t, http.MethodPost, "/scim/v2/Groups", map[string]interface{}{
+		"schemas":     []string{biz.ScimSchemaGroup},
+		"displayName": "engineering",
+		"members":     []map[string]string{{"value": erin.Id}},
+	}, nil)
+	if status != http.StatusCreated || json.Unmarshal(body, &group) != nil {
+		t.Fatalf("create group: status(%v), body(%s)", status, body)
+	}
+
+	status, body = s.scimCall(t, http.MethodDelete, "/scim/v2/Users/"+erin.Id, nil, nil)
+	if status != http.StatusNoContent {
+		t.Fatalf("delete user: status(%v), body(%s)", status, body)
+	}
+
+	// 删除后不能读取、查询、登录，登录态和组成员关系被清除
+	status, body = s.scimCall(t, http.MethodGet, "/scim/v2/Users/"+erin.Id, nil, nil)
+	if status != http.StatusNotFound {
+		t.Fatalf("get deleted user: status(%v), body(%s)", status, body)
+	}
+	status, body = s.scimCall(t, http.MethodDelete, "/scim/v2/Users/"+erin.Id, nil, nil)
+	if status != http.StatusNotFound {
+		t.Fatalf("delete deleted user: status(%v), body(%s)", status, body)
+	}
+	status, body, reply := s.scimList(t, url.Values{"filter": {`userName eq "erin"`}})
+	if status != http.StatusOK || reply.TotalResults != 0 {
+		t.Fatalf("list deleted user: status(%v), body(%s)", status, body)
+	}
+	_, err = s.users.GetCurrentUser(context.Background(), userId)
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("deleted user from profile cache: want NotFound, got %v", err)
+	}
+	_, err = s.users.GetUserSession(context.Background(), userId)
+	if !kerrors.IsNotFound(err) {
+		t.Fatalf("session after delete: want NotFound, got %v", err)
+	}
+	var members struct {
+		Members []struct {
+			Value string `json:"value"`
+		} `json:"members"`
+	}
+	status, body = s.scimCall(t, http.MethodGet, "/scim/v2/Groups/"+group.Id, nil, &members)
+	if status != http.StatusOK || len(members.Members) != 0 {
+		t.Fatalf("group after delete: status(%v), body(%s)", status, body)
+	}
+
+	// 逻辑删除的账号可以重新开通
+	again := s.scimCreateUser(t, "erin", "erin@example.com")
+	if again.Id == erin.Id {
+		t.Fatalf("recreate deleted user: reused id(%s)", again.Id)
+	}
+}
+
+// userId SCIM资源id转换为用户id
+func (s *testServer) userId(t *testing.T, id string) int32 {
+	t.Helper()
+	var userId int32
+	_, err := fmt.Sscan(id, &userId)
+	if err != nil {
+		t.Fatalf("scim id(%s): %v", id, err)
+	}
+	return userId
+}
@@ -2,26 +2,57 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/go-kratos/kratos/v2/transport/http"
 	"github.com/google/wire"
+	"github.com/user-center/user-center-backend/app/user/service/internal/conf"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"net"
 	"strconv"
+	"strings"
 )
 
 // ProviderSet is user providers.
-var ProviderSet = wire.NewSet(NewHTTPServer, NewGRPCServer)
+var ProviderSet = wire.NewSet(NewHTTPServer, NewGRPCServer, NewJobServer)
 var (
 	ErrorsMsgMap = map[string]string{
-		"UNKNOWN_ERROR":        "未知错误",
-		"ACCOUNT_EXIST":        "账号已存在",
-		"ACCOUNT_ILLEGAL":      "账号只能包含字母数字下划线",
-		"USER_REGISTER_FAILED": "用户注册失败",
-		"USER_LOGIN_FAILED":    "用户登录失败或账号不存在",
-		"USER_DELETE_FAILED":   "用户删除失败",
-		"PERMISSION_DENY":      "没有权限",
-		"LOGIN_STATE_TIMEOUT":  "登录已过期，请重新登录",
-		"USER_LOGOUT_FAILED":   "用户注销失败",
+		"UNKNOWN_ERROR":               "未知错误",
+		"ACCOUNT_EXIST":               "账号已存在",
+		"ACCOUNT_ILLEGAL":             "账号只能包含字母数字下划线",
+		"USER_REGISTER_FAILED":        "用户注册失败",
+		"USER_LOGIN_FAILED":           "用户登录失败或账号不存在",
+		"USER_DELETE_FAILED":          "用户删除失败",
+		"PERMISSION_DENY":             "没有权限",
+		"LOGIN_STATE_TIMEOUT":         "登录已过期，请重新登录",
+		"USER_LOGOUT_FAILED":          "用户注销失败",
+		"ACCOUNT_PENDING_APPROVAL":    "账号正在等待管理员审核",
+		"ACCOUNT_REJECTED":            "账号审核未通过",
+		"USER_APPROVE_FAILED":         "用户审核失败",
+		"AUDIT_LOG_SEARCH_FAILED":     "审计日志查询失败",
+		"LOGIN_HISTORY_SEARCH_FAILED": "登录历史查询失败",
+		"LOGIN_VERIFY_REQUIRED":       "检测到新设备登录，验证码已发送至邮箱",
+		"LOGIN_VERIFY_FAILED":         "验证码错误或已过期",
+		"WEBHOOK_FAILED":              "webhook操作失败",
+		"CHANGE_LOG_EXPIRED":          "变更日志已过期，请全量同步后重新订阅",
+		"OAUTH_FAILED":                "OAuth授权失败",
+		"EXTERNAL_LOGIN_FAILED":       "第三方登录失败",
+		"IDENTITY_UNLINK_DENIED":      "至少需要保留一种登录方式",
+		"ACCOUNT_DISABLED":            "账号已停用",
+		"ACCESS_TOKEN_INVALID":        "访问令牌无效或已过期",
+		"ACCESS_TOKEN_FAILED":         "访问令牌操作失败",
+		"SERVICE_ACCOUNT_FAILED":      "服务账号操作失败",
+		"IMPERSONATION_FAILED":        "模拟登录失败",
+		"MAGIC_LINK_INVALID":          "登录链接无效或已过期",
+		"PASSKEY_FAILED":              "通行密钥验证失败",
+		"PASSKEY_REQUIRED":            "请使用通行密钥完成二次验证",
+		"SESSION_UNAVAILABLE":         "登录服务暂时不可用，请稍后重试",
+		"MAGIC_LINK_RATE_LIMITED":     "登录链接请求过于频繁，请稍后重试",
+		"LOGIN_VERIFY_UNAVAILABLE":    "检测到新设备登录，账号未绑定邮箱或通行密钥，无法完成验证，请联系管理员",
 	}
 )
 
@@ -29,37 +60,157 @@ func responseServer() middleware.Middleware {
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
 			reply, err = handler(ctx, req)
-			if err != nil {
-				e, ok := err.(*errors.Error)
-				if ok {
-					if m, ok := ErrorsMsgMap[e.Reason]; ok {
-						e.Message = m
-					}
-					return
-				}
-				e.Message = ErrorsMsgMap["UNKNOWN_ERROR"]
+			return reply, responseError(err)
+		}
+	}
+}
+
+// responseError 将错误信息替换为对应的中文提示
+func responseError(err error) error {
+	if err != nil {
+		e, ok := err.(*errors.Error)
+		if ok {
+			if m, ok := ErrorsMsgMap[e.Reason]; ok {
+				e.Message = m
 			}
-			return
+			return err
 		}
+		e = errors.FromError(err)
+		e.Message = ErrorsMsgMap["UNKNOWN_ERROR"]
+		return e
 	}
+	return err
 }
 
-func requestServer() middleware.Middleware {
+// Authenticate 请求头中携带的凭证认证，通过后替换上下文中的当前用户
+type Authenticate func(ctx context.Context) (context.Context, error)
+
+func requestServer(proxies trustedProxies, authenticate Authenticate) middleware.Middleware {
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
-			if header, ok := transport.FromServerContext(ctx); ok {
-				var userId int
-				s := header.RequestHeader().Get("userId")
-				if s != "" {
-					userId, err = strconv.Atoi(s)
-					if err != nil {
-						return nil, err
-					}
-				}
-				ctx = context.WithValue(ctx, "userId", int32(userId))
+			ctx, err = requestContext(ctx, proxies)
+			if err != nil {
+				return nil, err
+			}
+			ctx, err = authenticate(ctx)
+			if err != nil {
+				return nil, err
 			}
 			reply, err = handler(ctx, req)
 			return
 		}
 	}
 }
+
+// streamServer grpc流式接口不经过kratos中间件，在拦截器中补充请求上下文和错误提示
+func streamServer(proxies trustedProxies, authenticate Authenticate) ggrpc.StreamServerInterceptor {
+	return func(srv interface{}, ss ggrpc.ServerStream, info *ggrpc.StreamServerInfo, handler ggrpc.StreamHandler) error {
+		ctx, err := requestContext(ss.Context(), proxies)
+		if err != nil {
+			return err
+		}
+		ctx, err = authenticate(ctx)
+		if err != nil {
+			return responseError(err)
+		}
+		return responseError(handler(srv, grpc.NewWrappedStream(ctx, ss)))
+	}
+}
+
+// requestContext 将请求头中的用户id、客户端ip等信息写入上下文
+func requestContext(ctx context.Context, proxies trustedProxies) (context.Context, error) {
+	if header, ok := transport.FromServerContext(ctx); ok {
+		var userId int
+		var err error
+		s := header.RequestHeader().Get("userId")
+		if s != "" {
+			userId, err = strconv.Atoi(s)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ctx = context.WithValue(ctx, "userId", int32(userId))
+		ctx = context.WithValue(ctx, "clientIp", clientIp(ctx, header, proxies))
+		ctx = context.WithValue(ctx, "userAgent", header.RequestHeader().Get("User-Agent"))
+		ctx = context.WithValue(ctx, "deviceId", header.RequestHeader().Get("X-Device-Id"))
+	}
+	return ctx, nil
+}
+
+// trustedProxies 可信反向代理的网段
+type trustedProxies []*net.IPNet
+
+// newTrustedProxies 解析配置的可信代理，支持单个ip和CIDR
+func newTrustedProxies(c *conf.Server) (trustedProxies, error) {
+	proxies := make(trustedProxies, 0, len(c.GetTrustedProxies()))
+	for _, proxy := range c.GetTrustedProxies() {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
+func (p trustedProxies) contains(ip net.IP) bool {
+	for _, ipNet := range p {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIp 获取客户端ip
+//1. 默认使用连接的对端地址，请求头可以被客户端伪造
+//2. 对端是可信代理时，从右向左跳过X-Forwarded-For中的可信代理，第一个不可信的地址即客户端ip，都可信时取最左边的地址
+//3. 没有X-Forwarded-For时使用可信代理设置的X-Real-IP
+func clientIp(ctx context.Context, header transport.Transporter, proxies trustedProxies) string {
+	var addr string
+	if r, ok := http.RequestFromServerContext(ctx); ok {
+		addr = r.RemoteAddr
+	} else if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	remote := net.ParseIP(host)
+	if remote == nil || !proxies.contains(remote) {
+		return host
+	}
+
+	if forwarded := header.RequestHeader().Get("X-Forwarded-For"); forwarded != "" {
+		client := host
+		hops := strings.Split(forwarded, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(hops[i]))
+			if ip == nil {
+				break
+			}
+			client = ip.String()
+			if !proxies.contains(ip) {
+				break
+			}
+		}
+		return client
+	}
+	if realIp := net.ParseIP(strings.TrimSpace(header.RequestHeader().Get("X-Real-IP"))); realIp != nil {
+		return realIp.String()
+	}
+	return host
+}
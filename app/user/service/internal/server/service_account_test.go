@@ -0,0 +1,84 @@
+package server_test
+
+import (
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"net/http"
+	"testing"
+)
+
+// TestServiceAccountLogin 服务账号不能交互式登录，只能使用API Key调用接口
+func TestServiceAccountLogin(t *testing.T) {
+	s := newTestServer(t)
+	adminId := s.createUser(t, &biz.User{UserAccount: "service_admin", Role: 1})
+	s.login(t, adminId)
+
+	var account struct {
+		Data struct {
+			Id int32 `json:"id"`
+		} `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/service/account/create", userHeader(adminId),
+		map[string]string{"userAccount": "billing_robot", "userName": "billing"}, &account)
+	if status != http.StatusOK || account.Data.Id == 0 {
+		t.Fatalf("create service account: status(%v), body(%s)", status, body)
+	}
+
+	// 即使服务账号被写入了密码也不能登录
+	s.createUser(t, &biz.User{UserAccount: "legacy_robot", UserPassword: biz.PasswordHash("legacy-password"), UserType: biz.UserTypeService})
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/user/login", nil,
+		map[string]string{"userAccount": "legacy_robot", "userPassword": "legacy-password"}, nil)
+	if status == http.StatusOK || errorReason(body) != "USER_LOGIN_FAILED" {
+		t.Fatalf("service account login: status(%v), body(%s)", status, body)
+	}
+
+	reply := &createAccessTokenReply{}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/service/account/token/create", userHeader(adminId),
+		map[string]interface{}{"id": account.Data.Id, "name": "billing key", "scopes": []string{biz.AccessTokenScopeRead}, "expireDays": 30}, reply)
+	if status != http.StatusOK || reply.Token == "" {
+		t.Fatalf("create service account token: status(%v), body(%s)", status, body)
+	}
+	var current struct {
+		Data struct {
+			Id int32 `json:"id"`
+		} `json:"data"`
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodGet, "/api/user/current", bearerHeader(reply.Token), nil, &current)
+	if status != http.StatusOK || current.Data.Id != account.Data.Id {
+		t.Fatalf("call with service account token: status(%v), body(%s)", status, body)
+	}
+}
+
+// TestServiceAccountSearch 用户搜索默认不返回服务账号
+func TestServiceAccountSearch(t *testing.T) {
+	s := newTestServer(t)
+	adminId := s.createUser(t, &biz.User{UserAccount: "search_admin", UserName: "ops", Role: 1})
+	s.login(t, adminId)
+	humanId := s.createUser(t, &biz.User{UserAccount: "search_human", UserName: "ops"})
+	robotId := s.createUser(t, &biz.User{UserAccount: "search_robot", UserName: "ops", UserType: biz.UserTypeService})
+
+	search := func(includeServiceAccounts bool) []int32 {
+		t.Helper()
+		var reply struct {
+			Data []struct {
+				Id int32 `json:"id"`
+			} `json:"data"`
+		}
+		status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/user/search", userHeader(adminId),
+			map[string]interface{}{"userName": "ops", "includeServiceAccounts": includeServiceAccounts}, &reply)
+		if status != http.StatusOK {
+			t.Fatalf("search users: status(%v), body(%s)", status, body)
+		}
+		ids := make([]int32, 0, len(reply.Data))
+		for _, user := range reply.Data {
+			ids = append(ids, user.Id)
+		}
+		return ids
+	}
+
+	if ids := search(false); len(ids) != 2 || ids[0] != adminId || ids[1] != humanId {
+		t.Fatalf("search without service accounts: %v", ids)
+	}
+	if ids := search(true); len(ids) != 3 || ids[2] != robotId {
+		t.Fatalf("search with service accounts: %v", ids)
+	}
+}
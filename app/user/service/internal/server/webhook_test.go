@@ -0,0 +1,104 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"github.com/user-center/user-center-backend/app/user/service/internal/data"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type auditLogReply struct {
+	ActorId int32  `json:"actorId"`
+	Action  string `json:"action"`
+	Ip      string `json:"ip"`
+	Result  string `json:"result"`
+	Detail  string `json:"detail"`
+}
+
+// listAuditLogs 按操作查询审计日志
+func (s *testServer) listAuditLogs(t *testing.T, adminId int32, action string) []*auditLogReply {
+	t.Helper()
+	var reply struct {
+		Data []*auditLogReply `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/audit/list", userHeader(adminId), map[string]interface{}{"action": action}, &reply)
+	if status != http.StatusOK {
+		t.Fatalf("list audit logs: status(%v), body(%s)", status, body)
+	}
+	return reply.Data
+}
+
+func TestWebhookAudit(t *testing.T) {
+	s := newTestServer(t)
+	adminId := s.createUser(t, &biz.User{UserAccount: "admin", Role: 1})
+	userId := s.createUser(t, &biz.User{UserAccount: "alice"})
+	s.login(t, adminId)
+	s.login(t, userId)
+
+	// 创建，审计日志不包含签名密钥
+	var created struct {
+		Data struct {
+			Id     int64  `json:"id,string"`
+			Secret string `json:"secret"`
+		} `json:"data"`
+	}
+	status, body := s.call(t, s.newClient(t), http.MethodPost, "/api/webhook/create", userHeader(adminId),
+		map[string]interface{}{"url": "https://hooks.example/user", "events": []string{"user.registered"}, "secret": "webhook-signing-secret"}, &created)
+	if status != http.StatusOK {
+		t.Fatalf("create webhook: status(%v), body(%s)", status, body)
+	}
+	logs := s.listAuditLogs(t, adminId, biz.AuditActionWebhookCreate)
+	if len(logs) != 1 || logs[0].ActorId != adminId || logs[0].Result != biz.AuditResultSuccess ||
+		!strings.Contains(logs[0].Detail, fmt.Sprintf("webhookId(%v)", created.Data.Id)) || !strings.Contains(logs[0].Detail, "https://hooks.example/user") ||
+		strings.Contains(logs[0].Detail, "webhook-signing-secret") {
+		t.Fatalf("create webhook audit: %+v", logs)
+	}
+
+	// 非管理员的操作同样记录
+	status, _ = s.call(t, s.newClient(t), http.MethodPost, "/api/webhook/create", userHeader(userId),
+		map[string]interface{}{"url": "https://evil.example/user", "events": []string{"user.registered"}}, nil)
+	if status == http.StatusOK {
+		t.Fatalf("webhook created by non-admin")
+	}
+	logs = s.listAuditLogs(t, adminId, biz.AuditActionWebhookCreate)
+	if len(logs) != 2 || logs[0].ActorId != userId || logs[0].Result != biz.AuditResultFailure {
+		t.Fatalf("denied create webhook audit: %+v", logs)
+	}
+
+	// 重新投递
+	deliveryRepo := data.NewWebhookRepo(s.data, testLogger)
+	delivery := &biz.WebhookDelivery{WebhookId: created.Data.Id, EventId: "event-1", EventType: "user.registered", Payload: "{}",
+		Status: biz.WebhookDeliveryFailed, NextAttemptTime: time.Now()}
+	err := deliveryRepo.CreateDeliveries(context.Background(), []*biz.WebhookDelivery{delivery})
+	if err != nil {
+		t.Fatalf("create delivery: %v", err)
+	}
+	deliveries, _, err := deliveryRepo.ListDeliveries(context.Background(), created.Data.Id, "", 1, 10)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/webhook/delivery/redeliver", userHeader(adminId),
+		map[string]interface{}{"deliveryId": deliveries[0].Id}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("redeliver: status(%v), body(%s)", status, body)
+	}
+	logs = s.listAuditLogs(t, adminId, biz.AuditActionWebhookRedeliver)
+	if len(logs) != 1 || logs[0].Result != biz.AuditResultSuccess || !strings.Contains(logs[0].Detail, "eventId(event-1)") {
+		t.Fatalf("redeliver audit: %+v", logs)
+	}
+
+	// 删除
+	status, body = s.call(t, s.newClient(t), http.MethodPost, "/api/webhook/delete", userHeader(adminId),
+		map[string]interface{}{"id": created.Data.Id}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("delete webhook: status(%v), body(%s)", status, body)
+	}
+	logs = s.listAuditLogs(t, adminId, biz.AuditActionWebhookDelete)
+	if len(logs) != 1 || logs[0].Result != biz.AuditResultSuccess || logs[0].Detail != fmt.Sprintf("webhookId(%v)", created.Data.Id) {
+		t.Fatalf("delete webhook audit: %+v", logs)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
+	"time"
+)
+
+func (s *UserService) CreateAccessToken(ctx context.Context, req *v1.CreateAccessTokenReq) (*v1.CreateAccessTokenReply, error) {
+	create := &biz.CreateAccessToken{
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+		ExpireDays: req.ExpireDays,
+	}
+	err := s.vc.ParamsValidate(create)
+	if err != nil {
+		return nil, err
+	}
+
+	token, secret, err := s.tc.CreateAccessToken(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.CreateAccessTokenReply{
+		Token: secret,
+		Data:  accessTokenReply(token),
+	}, nil
+}
+
+func (s *UserService) ListAccessTokens(ctx context.Context, _ *emptypb.Empty) (*v1.ListAccessTokensReply, error) {
+	tokens, err := s.tc.ListAccessTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListAccessTokensReply{
+		Data: make([]*v1.AccessToken, 0, len(tokens)),
+	}
+	for _, item := range tokens {
+		reply.Data = append(reply.Data, accessTokenReply(item))
+	}
+	return reply, nil
+}
+
+func (s *UserService) RevokeAccessToken(ctx context.Context, req *v1.RevokeAccessTokenReq) (*emptypb.Empty, error) {
+	revoke := &biz.RevokeAccessToken{
+		Id: req.Id,
+	}
+	err := s.vc.ParamsValidate(revoke)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tc.RevokeAccessToken(ctx, revoke.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) ListUserAccessTokens(ctx context.Context, req *v1.ListUserAccessTokensReq) (*v1.ListUserAccessTokensReply, error) {
+	list := &biz.ListUserAccessTokens{
+		UserId:   req.UserId,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	err := s.vc.ParamsValidate(list)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, total, err := s.tc.ListUserAccessTokens(ctx, list.UserId, list.Page, list.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListUserAccessTokensReply{
+		Data:  make([]*v1.AccessToken, 0, len(tokens)),
+		Total: total,
+	}
+	for _, item := range tokens {
+		reply.Data = append(reply.Data, accessTokenReply(item))
+	}
+	return reply, nil
+}
+
+// accessTokenReply 令牌摘要不返回，未使用或未吊销的时间返回空
+func accessTokenReply(token *biz.AccessToken) *v1.AccessToken {
+	return &v1.AccessToken{
+		Id:           token.Id,
+		UserId:       token.UserId,
+		Name:         token.Name,
+		TokenPrefix:  token.TokenPrefix,
+		Scopes:       strings.Fields(token.Scopes),
+		ExpireTime:   token.ExpireTime.Format(timeLayout),
+		LastUsedTime: formatTime(token.LastUsedTime),
+		LastUsedIp:   token.LastUsedIp,
+		RevokeTime:   formatTime(token.RevokeTime),
+		CreateTime:   token.CreateTime.Format(timeLayout),
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(timeLayout)
+}
@@ -2,28 +2,36 @@ package service
 
 import (
 	"context"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
 	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
 	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"net/http"
 )
 
+// magicLinkCookie 保存免密登录链接浏览器凭证的Cookie，前端脚本不能读取
+const magicLinkCookie = "magic_link_nonce"
+
 func (s *UserService) UserRegister(ctx context.Context, req *v1.UserRegisterReq) (*v1.UserRegisterReply, error) {
 	register := &biz.UserRegister{
 		UserAccount:   req.UserAccount,
 		UserPassword:  req.UserPassword,
 		CheckPassword: req.CheckPassword,
+		Email:         req.Email,
 	}
 	err := s.vc.ParamsValidate(register)
 	if err != nil {
 		return nil, err
 	}
-	id, err := s.ac.UserRegister(ctx, register.UserAccount, register.UserPassword, register.CheckPassword)
+	id, userStatus, err := s.ac.UserRegister(ctx, register.UserAccount, register.UserPassword, register.CheckPassword, register.Email)
 	if err != nil {
 		return nil, err
 	}
 	return &v1.UserRegisterReply{
 		Data: &v1.User{
-			Id: id,
+			Id:         id,
+			UserStatus: userStatus,
 		},
 	}, nil
 }
@@ -32,15 +40,67 @@ func (s *UserService) UserLogin(ctx context.Context, req *v1.UserLoginReq) (*v1.
 	login := &biz.UserLogin{
 		UserAccount:  req.UserAccount,
 		UserPassword: req.UserPassword,
+		VerifyCode:   req.VerifyCode,
+	}
+	err := s.vc.ParamsValidate(login)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.ac.UserLogin(ctx, login.UserAccount, login.UserPassword, login.VerifyCode)
+	if err != nil {
+		return nil, err
+	}
+	// 脱敏处理，只返回必要的字段
+	return &v1.UserLoginReply{
+		Data: &v1.User{
+			Id:          user.Id,
+			UserName:    user.UserName,
+			UserAccount: user.UserAccount,
+			AvatarUrl:   user.AvatarUrl,
+			Phone:       user.Phone,
+			Email:       user.Email,
+			UserStatus:  user.UserStatus,
+			Gender:      user.Gender,
+		},
+	}, nil
+}
+
+func (s *UserService) RequestMagicLink(ctx context.Context, req *v1.RequestMagicLinkReq) (*emptypb.Empty, error) {
+	request := &biz.RequestMagicLink{
+		Email: req.Email,
+	}
+	err := s.vc.ParamsValidate(request)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := s.ac.RequestMagicLink(ctx, request.Email)
+	if err != nil {
+		return nil, err
+	}
+	setMagicLinkCookie(ctx, nonce, 0)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) MagicLinkLogin(ctx context.Context, req *v1.MagicLinkLoginReq) (*v1.UserLoginReply, error) {
+	login := &biz.MagicLinkLogin{
+		Token: req.Token,
 	}
 	err := s.vc.ParamsValidate(login)
 	if err != nil {
 		return nil, err
 	}
-	user, err := s.ac.UserLogin(ctx, login.UserAccount, login.UserPassword)
+	var nonce string
+	if r, ok := khttp.RequestFromServerContext(ctx); ok {
+		if cookie, err := r.Cookie(magicLinkCookie); err == nil {
+			nonce = cookie.Value
+		}
+	}
+	user, err := s.ac.MagicLinkLogin(ctx, login.Token, nonce)
 	if err != nil {
 		return nil, err
 	}
+	setMagicLinkCookie(ctx, "", -1)
 	// 脱敏处理，只返回必要的字段
 	return &v1.UserLoginReply{
 		Data: &v1.User{
@@ -63,3 +123,21 @@ func (s *UserService) UserLogout(ctx context.Context, _ *emptypb.Empty) (*emptyp
 	}
 	return &emptypb.Empty{}, nil
 }
+
+// setMagicLinkCookie 写入或清除（maxAge小于0）免密登录链接的浏览器凭证，不设置过期时间，关闭浏览器后失效
+func setMagicLinkCookie(ctx context.Context, nonce string, maxAge int) {
+	header, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return
+	}
+	cookie := &http.Cookie{
+		Name:     magicLinkCookie,
+		Value:    nonce,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	header.ReplyHeader().Set("Set-Cookie", cookie.String())
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *UserService) ExternalLogin(ctx context.Context, req *v1.ExternalLoginReq) (*v1.ExternalLoginReply, error) {
+	login := &biz.ExternalLogin{
+		Provider:   req.Provider,
+		VerifyCode: req.VerifyCode,
+	}
+	err := s.vc.ParamsValidate(login)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizeUrl, err := s.ic.ExternalLogin(ctx, login.Provider, login.VerifyCode)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ExternalLoginReply{
+		AuthorizeUrl: authorizeUrl,
+	}, nil
+}
+
+func (s *UserService) ExternalLoginCallback(ctx context.Context, req *v1.ExternalLoginCallbackReq) (*v1.UserLoginReply, error) {
+	user, err := s.ic.ExternalLoginCallback(ctx, req.Provider, req.Code, req.State, req.Error)
+	if err != nil {
+		return nil, err
+	}
+	// 脱敏处理，只返回必要的字段
+	return &v1.UserLoginReply{
+		Data: &v1.User{
+			Id:          user.Id,
+			UserName:    user.UserName,
+			UserAccount: user.UserAccount,
+			AvatarUrl:   user.AvatarUrl,
+			Phone:       user.Phone,
+			Email:       user.Email,
+			UserStatus:  user.UserStatus,
+			Gender:      user.Gender,
+		},
+	}, nil
+}
+
+func (s *UserService) ListIdentities(ctx context.Context, _ *emptypb.Empty) (*v1.ListIdentitiesReply, error) {
+	identities, err := s.ic.ListIdentities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListIdentitiesReply{
+		Data: make([]*v1.Identity, 0, len(identities)),
+	}
+	for _, item := range identities {
+		reply.Data = append(reply.Data, &v1.Identity{
+			Id:            item.Id,
+			Provider:      item.Provider,
+			Subject:       item.Subject,
+			Email:         item.Email,
+			CreateTime:    item.CreateTime.Format(timeLayout),
+			LastLoginTime: item.LastLoginTime.Format(timeLayout),
+		})
+	}
+	return reply, nil
+}
+
+func (s *UserService) LinkIdentity(ctx context.Context, req *v1.ExternalLoginReq) (*v1.ExternalLoginReply, error) {
+	link := &biz.ExternalLogin{
+		Provider: req.Provider,
+	}
+	err := s.vc.ParamsValidate(link)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizeUrl, err := s.ic.LinkIdentity(ctx, link.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ExternalLoginReply{
+		AuthorizeUrl: authorizeUrl,
+	}, nil
+}
+
+func (s *UserService) UnlinkIdentity(ctx context.Context, req *v1.UnlinkIdentityReq) (*emptypb.Empty, error) {
+	unlink := &biz.UnlinkIdentity{
+		Id: req.Id,
+	}
+	err := s.vc.ParamsValidate(unlink)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.ic.UnlinkIdentity(ctx, unlink.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
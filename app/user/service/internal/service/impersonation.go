@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/transport"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
+)
+
+func (s *UserService) StartImpersonation(ctx context.Context, req *v1.StartImpersonationReq) (*v1.StartImpersonationReply, error) {
+	start := &biz.StartImpersonation{
+		UserId: req.UserId,
+		Reason: req.Reason,
+	}
+	err := s.vc.ParamsValidate(start)
+	if err != nil {
+		return nil, err
+	}
+
+	user, impersonation, secret, err := s.nc.StartImpersonation(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	// 脱敏处理，只返回必要的字段
+	return &v1.StartImpersonationReply{
+		Token:      secret,
+		ExpireTime: impersonation.ExpireTime.Format(timeLayout),
+		Data: &v1.User{
+			Id:             user.Id,
+			UserName:       user.UserName,
+			UserAccount:    user.UserAccount,
+			AvatarUrl:      user.AvatarUrl,
+			UserStatus:     user.UserStatus,
+			UserRole:       user.Role,
+			ImpersonatorId: impersonation.AdminId,
+		},
+	}, nil
+}
+
+func (s *UserService) StopImpersonation(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	var secret string
+	if header, ok := transport.FromServerContext(ctx); ok {
+		secret = bearerToken(header)
+	}
+	if !strings.HasPrefix(secret, biz.ImpersonationTokenPrefix) {
+		return nil, v1.ErrorImpersonationFailed("impersonation token required")
+	}
+
+	err := s.nc.StopImpersonation(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (s *UserService) CreateOAuthClient(ctx context.Context, req *v1.CreateOAuthClientReq) (*v1.CreateOAuthClientReply, error) {
+	create := &biz.CreateOAuthClient{
+		Name:                   req.Name,
+		RedirectUris:           req.RedirectUris,
+		PostLogoutRedirectUris: req.PostLogoutRedirectUris,
+		GrantTypes:             req.GrantTypes,
+		Scopes:                 req.Scopes,
+		Public:                 req.Public,
+	}
+	err := s.vc.ParamsValidate(create)
+	if err != nil {
+		return nil, err
+	}
+
+	client, secret, err := s.oc.CreateClient(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	// 应用密钥只在创建时返回
+	data := oauthClientReply(client)
+	data.ClientSecret = secret
+	return &v1.CreateOAuthClientReply{
+		Data: data,
+	}, nil
+}
+
+func (s *UserService) ListOAuthClients(ctx context.Context, _ *emptypb.Empty) (*v1.ListOAuthClientsReply, error) {
+	clients, err := s.oc.ListClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListOAuthClientsReply{
+		Data: make([]*v1.OAuthClient, 0, len(clients)),
+	}
+	for _, item := range clients {
+		reply.Data = append(reply.Data, oauthClientReply(item))
+	}
+	return reply, nil
+}
+
+func (s *UserService) DeleteOAuthClient(ctx context.Context, req *v1.DeleteOAuthClientReq) (*emptypb.Empty, error) {
+	del := &biz.DeleteOAuthClient{
+		ClientId: req.ClientId,
+	}
+	err := s.vc.ParamsValidate(del)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.oc.DeleteClient(ctx, del.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) GetOAuthConsent(ctx context.Context, req *v1.OAuthConsentReq) (*v1.GetOAuthConsentReply, error) {
+	authorize, err := s.oauthAuthorizeRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, scopes, granted, err := s.oc.GetConsent(ctx, authorize)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetOAuthConsentReply{
+		Client:  oauthClientReply(client),
+		Scopes:  scopes,
+		Granted: granted,
+	}, nil
+}
+
+func (s *UserService) SubmitOAuthConsent(ctx context.Context, req *v1.SubmitOAuthConsentReq) (*v1.SubmitOAuthConsentReply, error) {
+	authorize, err := s.oauthAuthorizeRequest(req.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := s.oc.Consent(ctx, authorize, req.Approve)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SubmitOAuthConsentReply{
+		RedirectUri: location,
+	}, nil
+}
+
+// OAuthAuthorize 授权端点，按RFC 6749通过302跳转返回结果
+func (s *UserService) OAuthAuthorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	err := r.ParseForm()
+	if err != nil {
+		writeOAuthError(w, &biz.OAuthError{Code: biz.OAuthErrInvalidRequest, Description: err.Error(), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	result, err := s.oc.Authorize(r.Context(), &biz.OAuthAuthorizeRequest{
+		ResponseType:        r.Form.Get("response_type"),
+		ClientId:            r.Form.Get("client_id"),
+		RedirectUri:         r.Form.Get("redirect_uri"),
+		Scope:               r.Form.Get("scope"),
+		State:               r.Form.Get("state"),
+		CodeChallenge:       r.Form.Get("code_challenge"),
+		CodeChallengeMethod: r.Form.Get("code_challenge_method"),
+		Nonce:               r.Form.Get("nonce"),
+	})
+	switch {
+	case err != nil:
+		writeOAuthError(w, err)
+	case result.RedirectUri != "":
+		http.Redirect(w, r, result.RedirectUri, http.StatusFound)
+	case result.LoginRequired:
+		writeOAuthError(w, &biz.OAuthError{Code: biz.OAuthErrLoginRequired, Description: "user is not logged in", StatusCode: http.StatusUnauthorized})
+	default:
+		writeOAuthError(w, &biz.OAuthError{Code: biz.OAuthErrConsentRequired, Description: "user consent is required", StatusCode: http.StatusForbidden})
+	}
+}
+
+// OAuthToken 令牌端点，支持client_secret_basic和client_secret_post两种客户端认证方式
+func (s *UserService) OAuthToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	err := r.ParseForm()
+	if err != nil {
+		writeOAuthError(w, &biz.OAuthError{Code: biz.OAuthErrInvalidRequest, Description: err.Error(), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	req := &biz.OAuthTokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientId:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		Code:         r.PostForm.Get("code"),
+		RedirectUri:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+	}
+	// client_secret_basic的用户名和密码需要先做表单解码
+	if clientId, clientSecret, ok := r.BasicAuth(); ok {
+		req.ClientId, _ = url.QueryUnescape(clientId)
+		req.ClientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+
+	token, err := s.oc.Token(r.Context(), req)
+	if err != nil {
+		writeOAuthError(w, err)
+		return
+	}
+	reply := map[string]interface{}{
+		"access_token": token.AccessToken,
+		"token_type":   token.TokenType,
+		"expires_in":   token.ExpiresIn,
+	}
+	if token.RefreshToken != "" {
+		reply["refresh_token"] = token.RefreshToken
+	}
+	if token.Scope != "" {
+		reply["scope"] = token.Scope
+	}
+	if token.IdToken != "" {
+		reply["id_token"] = token.IdToken
+	}
+	writeOAuthJSON(w, http.StatusOK, reply)
+}
+
+func (s *UserService) oauthAuthorizeRequest(req *v1.OAuthConsentReq) (*biz.OAuthAuthorizeRequest, error) {
+	authorize := &biz.OAuthAuthorize{
+		ClientId:            req.GetClientId(),
+		RedirectUri:         req.GetRedirectUri(),
+		Scope:               req.GetScope(),
+		State:               req.GetState(),
+		CodeChallenge:       req.GetCodeChallenge(),
+		CodeChallengeMethod: req.GetCodeChallengeMethod(),
+		Nonce:               req.GetNonce(),
+	}
+	err := s.vc.ParamsValidate(authorize)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.OAuthAuthorizeRequest{
+		ResponseType:        req.GetResponseType(),
+		ClientId:            authorize.ClientId,
+		RedirectUri:         authorize.RedirectUri,
+		Scope:               authorize.Scope,
+		State:               authorize.State,
+		CodeChallenge:       authorize.CodeChallenge,
+		CodeChallengeMethod: authorize.CodeChallengeMethod,
+		Nonce:               authorize.Nonce,
+	}, nil
+}
+
+func oauthClientReply(client *biz.OAuthClient) *v1.OAuthClient {
+	return &v1.OAuthClient{
+		ClientId:               client.ClientId,
+		Name:                   client.Name,
+		RedirectUris:           strings.Fields(client.RedirectUris),
+		PostLogoutRedirectUris: strings.Fields(client.PostLogoutRedirectUris),
+		GrantTypes:             strings.Fields(client.GrantTypes),
+		Scopes:                 strings.Fields(client.Scopes),
+		Public:                 client.Public,
+		CreatorId:              client.CreatorId,
+		CreateTime:             client.CreateTime.Format(timeLayout),
+	}
+}
+
+// writeOAuthError 按RFC 6749返回错误
+func writeOAuthError(w http.ResponseWriter, err error) {
+	var oerr *biz.OAuthError
+	if !errors.As(err, &oerr) {
+		oerr = &biz.OAuthError{Code: biz.OAuthErrServerError, Description: "internal error", StatusCode: http.StatusInternalServerError}
+	}
+	if oerr.Code == biz.OAuthErrInvalidClient {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	writeOAuthJSON(w, oerr.StatusCode, map[string]interface{}{
+		"error":             oerr.Code,
+		"error_description": oerr.Description,
+	})
+}
+
+func writeOAuthJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
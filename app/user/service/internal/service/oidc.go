@@ -0,0 +1,85 @@
+package service
+
+import (
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"net/http"
+	"strings"
+)
+
+// OIDCDiscovery OpenID Provider元数据
+func (s *UserService) OIDCDiscovery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeOAuthJSON(w, http.StatusOK, s.oc.Discovery())
+}
+
+// OAuthJWKS 签名公钥
+func (s *UserService) OAuthJWKS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeOAuthJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": s.oc.JWKS(),
+	})
+}
+
+// OIDCUserInfo 用户信息端点，访问令牌通过Authorization: Bearer请求头传递
+func (s *UserService) OIDCUserInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var accessToken string
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "Bearer ") {
+		accessToken = strings.TrimSpace(authorization[7:])
+	}
+	if accessToken == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="userinfo"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := s.oc.UserInfo(r.Context(), accessToken)
+	if err != nil {
+		if oerr, ok := err.(*biz.OAuthError); ok {
+			w.Header().Set("WWW-Authenticate", `Bearer error="`+oerr.Code+`", error_description="`+oerr.Description+`"`)
+		}
+		writeOAuthError(w, err)
+		return
+	}
+	writeOAuthJSON(w, http.StatusOK, claims)
+}
+
+// OIDCLogout RP发起的退出登录端点
+func (s *UserService) OIDCLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	err := r.ParseForm()
+	if err != nil {
+		writeOAuthError(w, &biz.OAuthError{Code: biz.OAuthErrInvalidRequest, Description: err.Error(), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	location, err := s.oc.EndSession(r.Context(), &biz.OIDCLogoutRequest{
+		IdTokenHint:           r.Form.Get("id_token_hint"),
+		ClientId:              r.Form.Get("client_id"),
+		PostLogoutRedirectUri: r.Form.Get("post_logout_redirect_uri"),
+		State:                 r.Form.Get("state"),
+	})
+	if err != nil {
+		writeOAuthError(w, err)
+		return
+	}
+	if location != "" {
+		http.Redirect(w, r, location, http.StatusFound)
+		return
+	}
+	writeOAuthJSON(w, http.StatusOK, map[string]interface{}{})
+}
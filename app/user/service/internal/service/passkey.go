@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
+)
+
+func (s *UserService) BeginPasskeyRegistration(ctx context.Context, _ *emptypb.Empty) (*v1.PasskeyChallengeReply, error) {
+	challengeId, options, err := s.kc.BeginPasskeyRegistration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PasskeyChallengeReply{
+		ChallengeId: challengeId,
+		Options:     string(options),
+	}, nil
+}
+
+func (s *UserService) FinishPasskeyRegistration(ctx context.Context, req *v1.FinishPasskeyRegistrationReq) (*v1.PasskeyReply, error) {
+	finish := &biz.FinishPasskeyRegistration{
+		ChallengeId: req.ChallengeId,
+		Name:        req.Name,
+		Credential:  req.Credential,
+	}
+	err := s.vc.ParamsValidate(finish)
+	if err != nil {
+		return nil, err
+	}
+
+	passkey, err := s.kc.FinishPasskeyRegistration(ctx, finish)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PasskeyReply{
+		Data: passkeyReply(passkey),
+	}, nil
+}
+
+func (s *UserService) ListPasskeys(ctx context.Context, _ *emptypb.Empty) (*v1.ListPasskeysReply, error) {
+	passkeys, err := s.kc.ListPasskeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListPasskeysReply{
+		Data: make([]*v1.Passkey, 0, len(passkeys)),
+	}
+	for _, item := range passkeys {
+		reply.Data = append(reply.Data, passkeyReply(item))
+	}
+	return reply, nil
+}
+
+func (s *UserService) DeletePasskey(ctx context.Context, req *v1.DeletePasskeyReq) (*emptypb.Empty, error) {
+	deletePasskey := &biz.DeletePasskey{
+		Id: req.Id,
+	}
+	err := s.vc.ParamsValidate(deletePasskey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.kc.DeletePasskey(ctx, deletePasskey.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) BeginPasskeyLogin(ctx context.Context, req *v1.BeginPasskeyLoginReq) (*v1.PasskeyChallengeReply, error) {
+	begin := &biz.BeginPasskeyLogin{
+		UserAccount: req.UserAccount,
+	}
+	err := s.vc.ParamsValidate(begin)
+	if err != nil {
+		return nil, err
+	}
+
+	challengeId, options, err := s.kc.BeginPasskeyLogin(ctx, begin.UserAccount)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PasskeyChallengeReply{
+		ChallengeId: challengeId,
+		Options:     string(options),
+	}, nil
+}
+
+func (s *UserService) FinishPasskeyLogin(ctx context.Context, req *v1.FinishPasskeyLoginReq) (*v1.UserLoginReply, error) {
+	finish := &biz.FinishPasskeyLogin{
+		ChallengeId: req.ChallengeId,
+		Credential:  req.Credential,
+	}
+	err := s.vc.ParamsValidate(finish)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.kc.FinishPasskeyLogin(ctx, finish)
+	if err != nil {
+		return nil, err
+	}
+	// 脱敏处理，只返回必要的字段
+	return &v1.UserLoginReply{
+		Data: &v1.User{
+			Id:          user.Id,
+			UserName:    user.UserName,
+			UserAccount: user.UserAccount,
+			AvatarUrl:   user.AvatarUrl,
+			Phone:       user.Phone,
+			Email:       user.Email,
+			UserStatus:  user.UserStatus,
+			Gender:      user.Gender,
+		},
+	}, nil
+}
+
+// passkeyReply 公钥不返回，未使用的时间返回空
+func passkeyReply(passkey *biz.Passkey) *v1.Passkey {
+	return &v1.Passkey{
+		Id:             passkey.Id,
+		Name:           passkey.Name,
+		CredentialId:   passkey.CredentialId,
+		Aaguid:         passkey.Aaguid,
+		Transports:     strings.Fields(passkey.Transports),
+		BackupEligible: passkey.BackupEligible,
+		BackupState:    passkey.BackupState,
+		SignCount:      passkey.SignCount,
+		CreateTime:     passkey.CreateTime.Format(timeLayout),
+		LastUsedTime:   formatTime(passkey.LastUsedTime),
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"net/http"
+)
+
+func (s *UserService) SAMLLogin(ctx context.Context, req *v1.ExternalLoginReq) (*v1.ExternalLoginReply, error) {
+	login := &biz.ExternalLogin{
+		Provider:   req.Provider,
+		VerifyCode: req.VerifyCode,
+	}
+	err := s.vc.ParamsValidate(login)
+	if err != nil {
+		return nil, err
+	}
+
+	loginUrl, err := s.sc.Login(ctx, login.Provider, login.VerifyCode)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ExternalLoginReply{
+		AuthorizeUrl: loginUrl,
+	}, nil
+}
+
+func (s *UserService) SAMLAssertionConsumer(ctx context.Context, req *v1.SAMLAssertionReq) (*v1.UserLoginReply, error) {
+	consume := &biz.SAMLAssertionConsume{
+		Provider:     req.Provider,
+		SAMLResponse: req.SAMLResponse,
+		RelayState:   req.RelayState,
+	}
+	err := s.vc.ParamsValidate(consume)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.sc.AssertionConsumer(ctx, consume.Provider, consume.SAMLResponse, consume.RelayState)
+	if err != nil {
+		return nil, err
+	}
+	// 脱敏处理，只返回必要的字段
+	return &v1.UserLoginReply{
+		Data: &v1.User{
+			Id:          user.Id,
+			UserName:    user.UserName,
+			UserAccount: user.UserAccount,
+			AvatarUrl:   user.AvatarUrl,
+			Phone:       user.Phone,
+			Email:       user.Email,
+			UserStatus:  user.UserStatus,
+			Gender:      user.Gender,
+		},
+	}, nil
+}
+
+// SAMLMetadata SP元数据，通过provider参数指定IdP
+func (s *UserService) SAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	metadata, err := s.sc.Metadata(r.Context(), r.URL.Query().Get("provider"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(metadata)
+}
@@ -0,0 +1,454 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	scimPathPrefix   = "/scim/v2/"
+	scimContentType  = "application/scim+json"
+	scimMaxBodySize  = 1 << 20
+	scimDefaultCount = 20
+)
+
+type scimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int32       `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+type scimErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// SCIM SCIM 2.0接口，凭证通过Authorization: Bearer请求头传递
+//1. /Users、/Groups支持创建、查询、替换、PATCH和删除，列表支持过滤和分页
+//2. /ServiceProviderConfig、/ResourceTypes、/Schemas返回服务能力和资源定义
+func (s *UserService) SCIM(w http.ResponseWriter, r *http.Request) {
+	var token string
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "Bearer ") {
+		token = strings.TrimSpace(authorization[7:])
+	}
+	err := s.pc.Authorize(r.Context(), token)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+		s.writeScimError(w, err)
+		return
+	}
+
+	segments := strings.SplitN(strings.Trim(strings.TrimPrefix(r.URL.Path, scimPathPrefix), "/"), "/", 2)
+	var id string
+	if len(segments) == 2 {
+		id = segments[1]
+	}
+	switch segments[0] {
+	case "Users":
+		s.scimUsers(w, r, id)
+	case "Groups":
+		s.scimGroups(w, r, id)
+	case "ServiceProviderConfig", "ResourceTypes", "Schemas":
+		if r.Method != http.MethodGet {
+			s.writeScimError(w, &biz.ScimError{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"})
+			return
+		}
+		s.scimDiscovery(w, r, segments[0], id)
+	default:
+		s.writeScimError(w, &biz.ScimError{Status: http.StatusNotFound, Detail: "resource not found"})
+	}
+}
+
+func (s *UserService) scimUsers(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		query, err := scimListQuery(r)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		users, total, err := s.pc.ListUsers(ctx, query)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		for _, user := range users {
+			user.Meta.Location = scimLocation(r, "Users", user.Id)
+		}
+		writeScimJSON(w, http.StatusOK, &scimListResponse{
+			Schemas:      []string{biz.ScimSchemaListResponse},
+			TotalResults: total,
+			StartIndex:   query.StartIndex,
+			ItemsPerPage: len(users),
+			Resources:    users,
+		})
+	case id == "" && r.Method == http.MethodPost:
+		resource := &biz.ScimUser{}
+		err := decodeScimBody(w, r, resource)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		user, err := s.pc.CreateUser(ctx, resource)
+		s.writeScimUser(w, r, http.StatusCreated, user, err)
+	case id != "" && r.Method == http.MethodGet:
+		user, err := s.pc.GetUser(ctx, id)
+		s.writeScimUser(w, r, http.StatusOK, user, err)
+	case id != "" && r.Method == http.MethodPut:
+		resource := &biz.ScimUser{}
+		err := decodeScimBody(w, r, resource)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		user, err := s.pc.ReplaceUser(ctx, id, resource)
+		s.writeScimUser(w, r, http.StatusOK, user, err)
+	case id != "" && r.Method == http.MethodPatch:
+		patch := &biz.ScimPatch{}
+		err := decodeScimBody(w, r, patch)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		user, err := s.pc.PatchUser(ctx, id, patch)
+		s.writeScimUser(w, r, http.StatusOK, user, err)
+	case id != "" && r.Method == http.MethodDelete:
+		err := s.pc.DeleteUser(ctx, id)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.writeScimError(w, &biz.ScimError{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"})
+	}
+}
+
+func (s *UserService) scimGroups(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		query, err := scimListQuery(r)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		groups, total, err := s.pc.ListGroups(ctx, query)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		for _, group := range groups {
+			group.Meta.Location = scimLocation(r, "Groups", group.Id)
+		}
+		writeScimJSON(w, http.StatusOK, &scimListResponse{
+			Schemas:      []string{biz.ScimSchemaListResponse},
+			TotalResults: total,
+			StartIndex:   query.StartIndex,
+			ItemsPerPage: len(groups),
+			Resources:    groups,
+		})
+	case id == "" && r.Method == http.MethodPost:
+		resource := &biz.ScimGroup{}
+		err := decodeScimBody(w, r, resource)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		group, err := s.pc.CreateGroup(ctx, resource)
+		s.writeScimGroup(w, r, http.StatusCreated, group, err)
+	case id != "" && r.Method == http.MethodGet:
+		group, err := s.pc.GetGroup(ctx, id, scimExcludeMembers(r))
+		s.writeScimGroup(w, r, http.StatusOK, group, err)
+	case id != "" && r.Method == http.MethodPut:
+		resource := &biz.ScimGroup{}
+		err := decodeScimBody(w, r, resource)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		group, err := s.pc.ReplaceGroup(ctx, id, resource)
+		s.writeScimGroup(w, r, http.StatusOK, group, err)
+	case id != "" && r.Method == http.MethodPatch:
+		patch := &biz.ScimPatch{}
+		err := decodeScimBody(w, r, patch)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		group, err := s.pc.PatchGroup(ctx, id, patch)
+		s.writeScimGroup(w, r, http.StatusOK, group, err)
+	case id != "" && r.Method == http.MethodDelete:
+		err := s.pc.DeleteGroup(ctx, id)
+		if err != nil {
+			s.writeScimError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.writeScimError(w, &biz.ScimError{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"})
+	}
+}
+
+func (s *UserService) writeScimUser(w http.ResponseWriter, r *http.Request, statusCode int, user *biz.ScimUser, err error) {
+	if err != nil {
+		s.writeScimError(w, err)
+		return
+	}
+	user.Meta.Location = scimLocation(r, "Users", user.Id)
+	if statusCode == http.StatusCreated {
+		w.Header().Set("Location", user.Meta.Location)
+	}
+	writeScimJSON(w, statusCode, user)
+}
+
+func (s *UserService) writeScimGroup(w http.ResponseWriter, r *http.Request, statusCode int, group *biz.ScimGroup, err error) {
+	if err != nil {
+		s.writeScimError(w, err)
+		return
+	}
+	group.Meta.Location = scimLocation(r, "Groups", group.Id)
+	if statusCode == http.StatusCreated {
+		w.Header().Set("Location", group.Meta.Location)
+	}
+	writeScimJSON(w, statusCode, group)
+}
+
+// scimDiscovery 服务能力和资源定义，不随请求变化
+func (s *UserService) scimDiscovery(w http.ResponseWriter, r *http.Request, endpoint, id string) {
+	var resources []map[string]interface{}
+	switch endpoint {
+	case "ServiceProviderConfig":
+		config := scimServiceProviderConfig()
+		config["meta"] = map[string]interface{}{"resourceType": "ServiceProviderConfig", "location": scimLocation(r, endpoint, "")}
+		writeScimJSON(w, http.StatusOK, config)
+		return
+	case "ResourceTypes":
+		resources = scimResourceTypes()
+	case "Schemas":
+		resources = scimSchemas()
+	}
+
+	if id == "" {
+		writeScimJSON(w, http.StatusOK, &scimListResponse{
+			Schemas:      []string{biz.ScimSchemaListResponse},
+			TotalResults: int64(len(resources)),
+			StartIndex:   1,
+			ItemsPerPage: len(resources),
+			Resources:    resources,
+		})
+		return
+	}
+	for _, resource := range resources {
+		if resource["id"] == id {
+			writeScimJSON(w, http.StatusOK, resource)
+			return
+		}
+	}
+	s.writeScimError(w, &biz.ScimError{Status: http.StatusNotFound, Detail: "resource not found"})
+}
+
+// writeScimError 非SCIM协议错误按服务端错误返回，不暴露内部错误信息
+func (s *UserService) writeScimError(w http.ResponseWriter, err error) {
+	var serr *biz.ScimError
+	if !errors.As(err, &serr) {
+		s.log.Errorf("scim request failed: error(%v)", err)
+		serr = &biz.ScimError{Status: http.StatusInternalServerError, Detail: "internal error"}
+	}
+	writeScimJSON(w, serr.Status, &scimErrorResponse{
+		Schemas:  []string{biz.ScimSchemaError},
+		Status:   strconv.Itoa(serr.Status),
+		ScimType: serr.ScimType,
+		Detail:   serr.Detail,
+	})
+}
+
+func writeScimJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func decodeScimBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, scimMaxBodySize)).Decode(v)
+	if err != nil {
+		return &biz.ScimError{Status: http.StatusBadRequest, ScimType: biz.ScimTypeInvalidSyntax, Detail: err.Error()}
+	}
+	return nil
+}
+
+// scimListQuery 解析列表查询参数，count未传时默认20
+func scimListQuery(r *http.Request) (*biz.ScimListQuery, error) {
+	values := r.URL.Query()
+	query := &biz.ScimListQuery{
+		Filter:         values.Get("filter"),
+		StartIndex:     1,
+		Count:          scimDefaultCount,
+		ExcludeMembers: scimExcludeMembers(r),
+	}
+	for name, target := range map[string]*int32{"startIndex": &query.StartIndex, "count": &query.Count} {
+		if value := values.Get(name); value != "" {
+			n, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return nil, &biz.ScimError{Status: http.StatusBadRequest, ScimType: biz.ScimTypeInvalidValue, Detail: "invalid " + name}
+			}
+			*target = int32(n)
+		}
+	}
+	return query, nil
+}
+
+// scimExcludeMembers 身份提供方通过excludedAttributes=members避免返回大量组成员
+func scimExcludeMembers(r *http.Request) bool {
+	for _, attribute := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attribute), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+// scimLocation 资源地址，优先使用代理转发的协议和域名
+func scimLocation(r *http.Request, endpoint, id string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	host := r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+	location := scheme + "://" + host + scimPathPrefix + endpoint
+	if id != "" {
+		location += "/" + id
+	}
+	return location
+}
+
+func scimServiceProviderConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":        []string{biz.ScimSchemaServiceProviderConfig},
+		"patch":          map[string]interface{}{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": 100},
+		"changePassword": map[string]interface{}{"supported": true},
+		"sort":           map[string]interface{}{"supported": false},
+		"etag":           map[string]interface{}{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Authentication using a SCIM token in the Authorization header",
+			"primary":     true,
+		}},
+	}
+}
+
+func scimResourceTypes() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"schemas":     []string{biz.ScimSchemaResourceType},
+			"id":          "User",
+			"name":        "User",
+			"endpoint":    "/Users",
+			"description": "User Account",
+			"schema":      biz.ScimSchemaUser,
+			"meta":        map[string]interface{}{"resourceType": "ResourceType"},
+		},
+		{
+			"schemas":     []string{biz.ScimSchemaResourceType},
+			"id":          "Group",
+			"name":        "Group",
+			"endpoint":    "/Groups",
+			"description": "Group",
+			"schema":      biz.ScimSchemaGroup,
+			"meta":        map[string]interface{}{"resourceType": "ResourceType"},
+		},
+	}
+}
+
+// scimSchemas 只列出实际保存的属性
+func scimSchemas() []map[string]interface{} {
+	multiValue := func(name, description string) map[string]interface{} {
+		return scimAttribute(name, "complex", description, true, false, "readWrite",
+			scimAttribute("value", "string", "Value", false, false, "readWrite"),
+			scimAttribute("type", "string", "Label", false, false, "readWrite"),
+			scimAttribute("primary", "boolean", "Primary value", false, false, "readWrite"),
+		)
+	}
+	userName := scimAttribute("userName", "string", "Unique identifier for the user, used as the account", false, true, "readWrite")
+	userName["uniqueness"] = "server"
+	password := scimAttribute("password", "string", "Password, never returned", false, false, "writeOnly")
+	password["returned"] = "never"
+	displayName := scimAttribute("displayName", "string", "Human-readable name of the group", false, true, "readWrite")
+	displayName["uniqueness"] = "server"
+
+	return []map[string]interface{}{
+		{
+			"schemas":     []string{biz.ScimSchemaSchema},
+			"id":          biz.ScimSchemaUser,
+			"name":        "User",
+			"description": "User Account",
+			"attributes": []map[string]interface{}{
+				userName,
+				scimAttribute("name", "complex", "Name of the user", false, false, "readWrite",
+					scimAttribute("formatted", "string", "Full name", false, false, "readWrite"),
+					scimAttribute("familyName", "string", "Family name, combined into formatted", false, false, "writeOnly"),
+					scimAttribute("givenName", "string", "Given name, combined into formatted", false, false, "writeOnly"),
+				),
+				scimAttribute("displayName", "string", "Name displayed to end-users", false, false, "readWrite"),
+				multiValue("emails", "Email address, only the primary value is stored"),
+				multiValue("phoneNumbers", "Phone number, only the primary value is stored"),
+				multiValue("photos", "Avatar url, only the primary value is stored"),
+				scimAttribute("active", "boolean", "Whether the user is allowed to log in", false, false, "readWrite"),
+				password,
+			},
+			"meta": map[string]interface{}{"resourceType": "Schema"},
+		},
+		{
+			"schemas":     []string{biz.ScimSchemaSchema},
+			"id":          biz.ScimSchemaGroup,
+			"name":        "Group",
+			"description": "Group",
+			"attributes": []map[string]interface{}{
+				displayName,
+				scimAttribute("members", "complex", "Members of the group", true, false, "readWrite",
+					scimAttribute("value", "string", "Identifier of the member user", false, false, "immutable"),
+					scimAttribute("display", "string", "Name of the member user", false, false, "readOnly"),
+					scimAttribute("type", "string", "Type of the member, always User", false, false, "readOnly"),
+				),
+			},
+			"meta": map[string]interface{}{"resourceType": "Schema"},
+		},
+	}
+}
+
+func scimAttribute(name, attributeType, description string, multiValued, required bool, mutability string, subAttributes ...map[string]interface{}) map[string]interface{} {
+	attribute := map[string]interface{}{
+		"name":        name,
+		"type":        attributeType,
+		"description": description,
+		"multiValued": multiValued,
+		"required":    required,
+		"caseExact":   false,
+		"mutability":  mutability,
+		"returned":    "default",
+		"uniqueness":  "none",
+	}
+	if len(subAttributes) > 0 {
+		attribute["subAttributes"] = subAttributes
+	}
+	return attribute
+}
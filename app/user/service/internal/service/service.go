@@ -2,32 +2,128 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/google/wire"
 	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
 	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"net/http"
+	"strings"
 )
 
 var ProviderSet = wire.NewSet(NewUserService)
 
+const timeLayout = "2006-01-02 15:04:05"
+
 type UserService struct {
 	v1.UnimplementedUserServiceServer
 	uc  *biz.UserUseCase
 	ac  *biz.AuthRepoUseCase
 	vc  *biz.ValidateUseCase
+	wc  *biz.WebhookUseCase
+	cf  *biz.UserChangeFeed
+	oc  *biz.OAuthUseCase
+	ic  *biz.IdentityUseCase
+	sc  *biz.SAMLUseCase
+	pc  *biz.ScimUseCase
+	tc  *biz.AccessTokenUseCase
+	mc  *biz.ServiceAccountUseCase
+	nc  *biz.ImpersonationUseCase
+	kc  *biz.PasskeyUseCase
+	hc  *biz.HealthUseCase
 	log *log.Helper
 }
 
-func NewUserService(uc *biz.UserUseCase, ac *biz.AuthRepoUseCase, vc *biz.ValidateUseCase, logger log.Logger) *UserService {
+func NewUserService(uc *biz.UserUseCase, ac *biz.AuthRepoUseCase, vc *biz.ValidateUseCase, wc *biz.WebhookUseCase, cf *biz.UserChangeFeed, oc *biz.OAuthUseCase, ic *biz.IdentityUseCase, sc *biz.SAMLUseCase, pc *biz.ScimUseCase, tc *biz.AccessTokenUseCase, mc *biz.ServiceAccountUseCase, nc *biz.ImpersonationUseCase, kc *biz.PasskeyUseCase, hc *biz.HealthUseCase, logger log.Logger) *UserService {
 	return &UserService{
 		log: log.NewHelper(log.With(logger, "module", "user/service")),
 		uc:  uc,
 		ac:  ac,
 		vc:  vc,
+		wc:  wc,
+		cf:  cf,
+		oc:  oc,
+		ic:  ic,
+		sc:  sc,
+		pc:  pc,
+		tc:  tc,
+		mc:  mc,
+		nc:  nc,
+		kc:  kc,
+		hc:  hc,
 	}
 }
 
 func (s *UserService) GetHealth(_ context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
+
+// Health 健康检查，返回数据库和Redis的状态，服务不可用时返回503，降级运行时仍返回200
+func (s *UserService) Health(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	health := s.hc.Check(r.Context())
+	statusCode := http.StatusOK
+	if health.Status == biz.HealthUnavailable {
+		statusCode = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(health)
+}
+
+// Metrics 缓存命中统计，使用Prometheus文本格式
+func (s *UserService) Metrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var b strings.Builder
+	b.WriteString("# HELP user_center_cache_hits_total Cache hits by layer.\n# TYPE user_center_cache_hits_total counter\n")
+	stats := s.hc.CacheStats()
+	for _, item := range stats {
+		fmt.Fprintf(&b, "user_center_cache_hits_total{cache=%q,layer=\"local\"} %d\n", item.Name, item.LocalHits)
+		fmt.Fprintf(&b, "user_center_cache_hits_total{cache=%q,layer=\"redis\"} %d\n", item.Name, item.RedisHits)
+	}
+	b.WriteString("# HELP user_center_cache_misses_total Cache misses loaded from the database.\n# TYPE user_center_cache_misses_total counter\n")
+	for _, item := range stats {
+		fmt.Fprintf(&b, "user_center_cache_misses_total{cache=%q} %d\n", item.Name, item.Misses)
+	}
+	b.WriteString("# HELP user_center_cache_errors_total Cache errors, the request falls back to the database.\n# TYPE user_center_cache_errors_total counter\n")
+	for _, item := range stats {
+		fmt.Fprintf(&b, "user_center_cache_errors_total{cache=%q} %d\n", item.Name, item.Errors)
+	}
+	b.WriteString("# HELP user_center_cache_invalidations_total Cache invalidations after writes.\n# TYPE user_center_cache_invalidations_total counter\n")
+	for _, item := range stats {
+		fmt.Fprintf(&b, "user_center_cache_invalidations_total{cache=%q} %d\n", item.Name, item.Invalidations)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// CredentialContext 请求头中携带个人访问令牌或模拟登录令牌时进行认证，并以令牌对应的用户作为当前用户
+func (s *UserService) CredentialContext(ctx context.Context) (context.Context, error) {
+	header, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	secret := bearerToken(header)
+	switch {
+	case strings.HasPrefix(secret, biz.AccessTokenPrefix):
+		return s.tc.Authenticate(ctx, secret, header.Operation())
+	case strings.HasPrefix(secret, biz.ImpersonationTokenPrefix):
+		return s.nc.Authenticate(ctx, secret, header.Operation())
+	}
+	return ctx, nil
+}
+
+func bearerToken(header transport.Transporter) string {
+	return strings.TrimPrefix(header.RequestHeader().Get("Authorization"), "Bearer ")
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+)
+
+func (s *UserService) CreateServiceAccount(ctx context.Context, req *v1.CreateServiceAccountReq) (*v1.ServiceAccountReply, error) {
+	create := &biz.CreateServiceAccount{
+		UserAccount: req.UserAccount,
+		UserName:    req.UserName,
+		Role:        req.Role,
+		OwnerId:     req.OwnerId,
+	}
+	err := s.vc.ParamsValidate(create)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.mc.CreateServiceAccount(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ServiceAccountReply{
+		Data: serviceAccountReply(account),
+	}, nil
+}
+
+func (s *UserService) ListServiceAccounts(ctx context.Context, req *v1.ListServiceAccountsReq) (*v1.ListServiceAccountsReply, error) {
+	list := &biz.ListServiceAccounts{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	err := s.vc.ParamsValidate(list)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, total, err := s.mc.ListServiceAccounts(ctx, list.Page, list.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListServiceAccountsReply{
+		Data:  make([]*v1.ServiceAccount, 0, len(accounts)),
+		Total: total,
+	}
+	for _, item := range accounts {
+		reply.Data = append(reply.Data, serviceAccountReply(item))
+	}
+	return reply, nil
+}
+
+func (s *UserService) UpdateServiceAccount(ctx context.Context, req *v1.UpdateServiceAccountReq) (*v1.ServiceAccountReply, error) {
+	update := &biz.UpdateServiceAccount{
+		Id:       req.Id,
+		UserName: req.UserName,
+		Role:     req.Role,
+		OwnerId:  req.OwnerId,
+		Disabled: req.Disabled,
+	}
+	err := s.vc.ParamsValidate(update)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.mc.UpdateServiceAccount(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ServiceAccountReply{
+		Data: serviceAccountReply(account),
+	}, nil
+}
+
+func (s *UserService) CreateServiceAccountToken(ctx context.Context, req *v1.CreateServiceAccountTokenReq) (*v1.CreateAccessTokenReply, error) {
+	create := &biz.CreateServiceAccountToken{
+		Id: req.Id,
+		CreateAccessToken: biz.CreateAccessToken{
+			Name:       req.Name,
+			Scopes:     req.Scopes,
+			ExpireDays: req.ExpireDays,
+		},
+	}
+	err := s.vc.ParamsValidate(create)
+	if err != nil {
+		return nil, err
+	}
+
+	token, secret, err := s.mc.CreateServiceAccountToken(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.CreateAccessTokenReply{
+		Token: secret,
+		Data:  accessTokenReply(token),
+	}, nil
+}
+
+func serviceAccountReply(account *biz.User) *v1.ServiceAccount {
+	return &v1.ServiceAccount{
+		Id:          account.Id,
+		UserAccount: account.UserAccount,
+		UserName:    account.UserName,
+		Role:        account.Role,
+		OwnerId:     account.OwnerId,
+		UserStatus:  account.UserStatus,
+		CreateTime:  account.CreateTime.Format(timeLayout),
+	}
+}
@@ -5,10 +5,14 @@ import (
 	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
 	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
 )
 
 func (s *UserService) SearchUsers(ctx context.Context, req *v1.SearchUsersReq) (*v1.SearchUsersReply, error) {
-	usersList, err := s.uc.SearchUsers(ctx, req.UserName)
+	usersList, err := s.uc.SearchUsers(ctx, &biz.SearchUser{
+		UserName:               req.UserName,
+		IncludeServiceAccounts: req.IncludeServiceAccounts,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -28,6 +32,7 @@ func (s *UserService) SearchUsers(ctx context.Context, req *v1.SearchUsersReq) (
 			Gender:      item.Gender,
 			UserRole:    item.Role,
 			CreateTime:  item.CreateTime.String(),
+			UserType:    item.UserType,
 		})
 	}
 	return reply, nil
@@ -73,6 +78,210 @@ func (s *UserService) GetCurrentUser(ctx context.Context, _ *emptypb.Empty) (*v1
 			UserStatus:  user.UserStatus,
 			Gender:      user.Gender,
 			UserRole:    user.Role,
+			// 模拟登录时返回管理员id，前端据此展示模拟登录标识
+			ImpersonatorId: biz.Impersonator(ctx),
 		},
 	}, nil
 }
+
+func (s *UserService) ListPendingUsers(ctx context.Context, req *v1.ListPendingUsersReq) (*v1.ListPendingUsersReply, error) {
+	list := &biz.ListPendingUsers{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	err := s.vc.ParamsValidate(list)
+	if err != nil {
+		return nil, err
+	}
+
+	usersList, total, err := s.uc.ListPendingUsers(ctx, list.Page, list.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListPendingUsersReply{
+		Data:  make([]*v1.User, 0, len(usersList)),
+		Total: total,
+	}
+	for _, item := range usersList {
+		reply.Data = append(reply.Data, &v1.User{
+			Id:          item.Id,
+			UserName:    item.UserName,
+			UserAccount: item.UserAccount,
+			AvatarUrl:   item.AvatarUrl,
+			Phone:       item.Phone,
+			Email:       item.Email,
+			UserStatus:  item.UserStatus,
+			Gender:      item.Gender,
+			UserRole:    item.Role,
+			CreateTime:  item.CreateTime.String(),
+		})
+	}
+	return reply, nil
+}
+
+func (s *UserService) ApproveUser(ctx context.Context, req *v1.ApproveUserReq) (*emptypb.Empty, error) {
+	approve := &biz.ApproveUser{
+		Id: req.Id,
+	}
+	err := s.vc.ParamsValidate(approve)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uc.ApproveUser(ctx, approve.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) RejectUser(ctx context.Context, req *v1.RejectUserReq) (*emptypb.Empty, error) {
+	reject := &biz.RejectUser{
+		Id:     req.Id,
+		Reason: req.Reason,
+	}
+	err := s.vc.ParamsValidate(reject)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uc.RejectUser(ctx, reject.Id, reject.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) ListAuditLogs(ctx context.Context, req *v1.ListAuditLogsReq) (*v1.ListAuditLogsReply, error) {
+	list := &biz.ListAuditLogs{
+		ActorId:   req.ActorId,
+		TargetId:  req.TargetId,
+		Action:    req.Action,
+		Result:    req.Result,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}
+	err := s.vc.ParamsValidate(list)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &biz.AuditLogQuery{
+		ActorId:  list.ActorId,
+		TargetId: list.TargetId,
+		Action:   list.Action,
+		Result:   list.Result,
+		Page:     list.Page,
+		PageSize: list.PageSize,
+	}
+	if list.StartTime != "" {
+		query.StartTime, _ = time.ParseInLocation(timeLayout, list.StartTime, time.Local)
+	}
+	if list.EndTime != "" {
+		query.EndTime, _ = time.ParseInLocation(timeLayout, list.EndTime, time.Local)
+	}
+	logs, total, err := s.uc.ListAuditLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListAuditLogsReply{
+		Data:  make([]*v1.AuditLog, 0, len(logs)),
+		Total: total,
+	}
+	for _, item := range logs {
+		reply.Data = append(reply.Data, &v1.AuditLog{
+			Id:         item.Id,
+			ActorId:    item.ActorId,
+			TargetId:   item.TargetId,
+			Action:     item.Action,
+			Ip:         item.Ip,
+			UserAgent:  item.UserAgent,
+			Result:     item.Result,
+			Reason:     item.Reason,
+			Detail:     item.Detail,
+			CreateTime: item.CreateTime.Format(timeLayout),
+		})
+	}
+	return reply, nil
+}
+
+func (s *UserService) ListMyLoginHistory(ctx context.Context, req *v1.ListMyLoginHistoryReq) (*v1.ListLoginHistoryReply, error) {
+	list := &biz.ListLoginHistory{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	err := s.vc.ParamsValidate(list)
+	if err != nil {
+		return nil, err
+	}
+
+	history, total, err := s.uc.ListMyLoginHistory(ctx, list.Page, list.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return loginHistoryReply(history, total), nil
+}
+
+func (s *UserService) ListMySessions(ctx context.Context, _ *emptypb.Empty) (*v1.ListSessionsReply, error) {
+	sessions, err := s.uc.ListMySessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &v1.ListSessionsReply{
+		Data: make([]*v1.Session, 0, len(sessions)),
+	}
+	for _, item := range sessions {
+		reply.Data = append(reply.Data, &v1.Session{
+			Ip:           item.Ip,
+			UserAgent:    item.UserAgent,
+			Method:       item.Method,
+			LoginTime:    item.LoginTime.Format(timeLayout),
+			Unrecognized: item.Unrecognized,
+		})
+	}
+	return reply, nil
+}
+
+func (s *UserService) ListLoginHistory(ctx context.Context, req *v1.ListLoginHistoryReq) (*v1.ListLoginHistoryReply, error) {
+	list := &biz.ListLoginHistory{
+		UserId:   req.UserId,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	err := s.vc.ParamsValidate(list)
+	if err != nil {
+		return nil, err
+	}
+
+	history, total, err := s.uc.ListLoginHistory(ctx, list.UserId, list.Page, list.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return loginHistoryReply(history, total), nil
+}
+
+func loginHistoryReply(history []*biz.LoginHistory, total int64) *v1.ListLoginHistoryReply {
+	reply := &v1.ListLoginHistoryReply{
+		Data:  make([]*v1.LoginHistory, 0, len(history)),
+		Total: total,
+	}
+	for _, item := range history {
+		reply.Data = append(reply.Data, &v1.LoginHistory{
+			Id:           item.Id,
+			UserId:       item.UserId,
+			UserAccount:  item.UserAccount,
+			Ip:           item.Ip,
+			UserAgent:    item.UserAgent,
+			Method:       item.Method,
+			Success:      item.Success,
+			Unrecognized: item.Unrecognized,
+			Reason:       item.Reason,
+			CreateTime:   item.CreateTime.Format(timeLayout),
+		})
+	}
+	return reply
+}
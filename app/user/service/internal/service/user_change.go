@@ -0,0 +1,36 @@
+package service
+
+import (
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+)
+
+func (s *UserService) WatchUsers(req *v1.WatchUsersReq, stream v1.UserService_WatchUsersServer) error {
+	return s.cf.Watch(stream.Context(), req.FromSeq, func(change *biz.UserChange) error {
+		event, err := change.Event()
+		if err != nil {
+			return v1.ErrorUnknownError("%s", err.Error())
+		}
+
+		reply := &v1.UserChangeEvent{
+			Seq:        change.Seq,
+			EventId:    change.EventId,
+			Type:       change.ChangeType,
+			UserId:     change.UserId,
+			OccurredAt: event.OccurredAt.Format(timeLayout),
+		}
+		if event.Data != nil {
+			reply.Data = &v1.User{
+				Id:          event.Data.Id,
+				UserName:    event.Data.UserName,
+				UserAccount: event.Data.UserAccount,
+				AvatarUrl:   event.Data.AvatarUrl,
+				Email:       event.Data.Email,
+				UserStatus:  event.Data.UserStatus,
+				Gender:      event.Data.Gender,
+				UserRole:    event.Data.UserRole,
+			}
+		}
+		return stream.Send(reply)
+	})
+}
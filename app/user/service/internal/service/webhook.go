@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	v1 "github.com/user-center/user-center-backend/api/user/service/v1"
+	"github.com/user-center/user-center-backend/app/user/service/internal/biz"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
+)
+
+func (s *UserService) CreateWebhook(ctx context.Context, req *v1.CreateWebhookReq) (*v1.CreateWebhookReply, error) {
+	create := &biz.CreateWebhook{
+		Url:    req.Url,
+		Events: req.Events,
+		Secret: req.Secret,
+	}
+	err := s.vc.ParamsValidate(create)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.wc.CreateWebhook(ctx, create.Url, create.Events, create.Secret)
+	if err != nil {
+		return nil, err
+	}
+	// 签名密钥只在创建时返回
+	data := webhookReply(webhook)
+	data.Secret = webhook.Secret
+	return &v1.CreateWebhookReply{
+		Data: data,
+	}, nil
+}
+
+func (s *UserService) ListWebhooks(ctx context.Context, _ *emptypb.Empty) (*v1.ListWebhooksReply, error) {
+	webhooks, err := s.wc.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListWebhooksReply{
+		Data: make([]*v1.Webhook, 0, len(webhooks)),
+	}
+	for _, item := range webhooks {
+		reply.Data = append(reply.Data, webhookReply(item))
+	}
+	return reply, nil
+}
+
+func (s *UserService) DeleteWebhook(ctx context.Context, req *v1.DeleteWebhookReq) (*emptypb.Empty, error) {
+	del := &biz.DeleteWebhook{
+		Id: req.Id,
+	}
+	err := s.vc.ParamsValidate(del)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.wc.DeleteWebhook(ctx, del.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) ListWebhookDeliveries(ctx context.Context, req *v1.ListWebhookDeliveriesReq) (*v1.ListWebhookDeliveriesReply, error) {
+	list := &biz.ListWebhookDeliveries{
+		WebhookId: req.WebhookId,
+		Status:    req.Status,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}
+	err := s.vc.ParamsValidate(list)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, total, err := s.wc.ListDeliveries(ctx, list.WebhookId, list.Status, list.Page, list.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &v1.ListWebhookDeliveriesReply{
+		Data:  make([]*v1.WebhookDelivery, 0, len(deliveries)),
+		Total: total,
+	}
+	for _, item := range deliveries {
+		reply.Data = append(reply.Data, webhookDeliveryReply(item))
+	}
+	return reply, nil
+}
+
+func (s *UserService) RedeliverWebhook(ctx context.Context, req *v1.RedeliverWebhookReq) (*v1.RedeliverWebhookReply, error) {
+	redeliver := &biz.RedeliverWebhook{
+		DeliveryId: req.DeliveryId,
+	}
+	err := s.vc.ParamsValidate(redeliver)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.wc.Redeliver(ctx, redeliver.DeliveryId)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.RedeliverWebhookReply{
+		Data: webhookDeliveryReply(delivery),
+	}, nil
+}
+
+func webhookReply(webhook *biz.Webhook) *v1.Webhook {
+	return &v1.Webhook{
+		Id:         webhook.Id,
+		Url:        webhook.Url,
+		Events:     strings.Split(webhook.Events, ","),
+		CreatorId:  webhook.CreatorId,
+		CreateTime: webhook.CreateTime.Format(timeLayout),
+	}
+}
+
+func webhookDeliveryReply(delivery *biz.WebhookDelivery) *v1.WebhookDelivery {
+	return &v1.WebhookDelivery{
+		Id:              delivery.Id,
+		WebhookId:       delivery.WebhookId,
+		EventId:         delivery.EventId,
+		EventType:       delivery.EventType,
+		Payload:         delivery.Payload,
+		Status:          delivery.Status,
+		Attempts:        delivery.Attempts,
+		NextAttemptTime: delivery.NextAttemptTime.Format(timeLayout),
+		LastStatusCode:  delivery.LastStatusCode,
+		LastError:       delivery.LastError,
+		CreateTime:      delivery.CreateTime.Format(timeLayout),
+		UpdateTime:      delivery.UpdateTime.Format(timeLayout),
+	}
+}